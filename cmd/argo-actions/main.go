@@ -24,7 +24,10 @@ type Config struct {
 	UpdateConfig      argoactions.UpdateConfig     `envconfig:"optional"`
 	LocalHubEndpoint  string                       `envconfig:"default=http://capact-hub-local.capact-system/graphql"`
 	PublicHubEndpoint string                       `envconfig:"default=http://capact-hub-public.capact-system/graphql"`
-	Logger            logger.Config
+	// Gateway holds configuration for calling the Hubs through the Gateway.
+	// If the Gateway endpoint is set, the Local and Public Hub endpoints are ignored.
+	Gateway hubclient.GatewayConfig `envconfig:"optional"`
+	Logger  logger.Config
 }
 
 func main() {
@@ -40,24 +43,20 @@ func main() {
 	logger, err := logger.New(cfg.Logger)
 	exitOnError(err, "while creating zap logger")
 
-	// TODO: Consider using connection `hubclient.New` and route requests through Gateway
-	client := hubclient.Client{
-		Local:  local.NewDefaultClient(cfg.LocalHubEndpoint),
-		Public: public.NewDefaultClient(cfg.PublicHubEndpoint),
-	}
+	client := getHubClient(&cfg)
 
 	switch cfg.Action {
 	case argoactions.DownloadAction:
 		log := logger.With(zap.String("Action", argoactions.DownloadAction))
-		action = argoactions.NewDownloadAction(log, &client, cfg.DownloadConfig)
+		action = argoactions.NewDownloadAction(log, client, cfg.DownloadConfig)
 
 	case argoactions.UploadAction:
 		log := logger.With(zap.String("Action", argoactions.UploadAction))
-		action = argoactions.NewUploadAction(log, &client, cfg.UploadConfig)
+		action = argoactions.NewUploadAction(log, client, cfg.UploadConfig)
 
 	case argoactions.UpdateAction:
 		log := logger.With(zap.String("Action", argoactions.UpdateAction))
-		action = argoactions.NewUpdateAction(log, &client, cfg.UpdateConfig)
+		action = argoactions.NewUpdateAction(log, client, cfg.UpdateConfig)
 
 	default:
 		err := fmt.Errorf("Invalid action: %s", cfg.Action)
@@ -75,6 +74,17 @@ func main() {
 	}
 }
 
+func getHubClient(cfg *Config) *hubclient.Client {
+	if cfg.Gateway.Enabled() {
+		return hubclient.NewForGateway(cfg.Gateway)
+	}
+
+	return &hubclient.Client{
+		Local:  local.NewDefaultClient(cfg.LocalHubEndpoint),
+		Public: public.NewDefaultClient(cfg.PublicHubEndpoint),
+	}
+}
+
 func exitOnError(err error, context string) {
 	if err != nil {
 		log.Fatalf("%s: %v", context, err)
@@ -1,7 +1,10 @@
 package action
 
 import (
+	"os"
+
 	"capact.io/capact/internal/cli"
+	"capact.io/capact/internal/cli/action"
 	"capact.io/capact/internal/cli/heredoc"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands"
@@ -10,8 +13,10 @@ import (
 )
 
 // NewWatch returns a cobra.Command for watching of runnning Actions.
-// It uses the command from "argo watch".
+// It uses the command from "argo watch", unless the runner progress is requested.
 func NewWatch() *cobra.Command {
+	var showProgress bool
+
 	cmd := commands.NewWatchCommand()
 	cmd.Use = "watch ACTION"
 	cmd.Short = "Watch an Action until it has completed execution"
@@ -21,6 +26,7 @@ func NewWatch() *cobra.Command {
     NOTE:   An action needs to be created and run in order to run this command.
             This command calls the Kubernetes API directly. As a result, KUBECONFIG has to be configured
             with the same cluster as the one which the Gateway points to.
+            With the --progress flag, the Action progress reported by the runner is fetched from the Gateway instead.
     `
 	cmd.Example = heredoc.WithCLIName(`
         # Watch an Action:
@@ -28,9 +34,31 @@ func NewWatch() *cobra.Command {
 
         # Watch the Action which was created last:
         <cli> action watch @latest
+
+        # Watch the progress reported by the Action runner:
+        <cli> action watch ACTION --progress
     `, cli.Name)
 
+	argoRun := cmd.Run
+	cmd.Run = nil
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !showProgress {
+			argoRun(cmd, args)
+			return nil
+		}
+		if len(args) != 1 {
+			return cmd.Usage()
+		}
+
+		opts := action.WatchProgressOptions{
+			ActionName: args[0],
+			Namespace:  client.Namespace(),
+		}
+		return action.WatchProgress(cmd.Context(), opts, os.Stdout)
+	}
+
 	client.AddKubectlFlagsToCmd(cmd)
+	cmd.Flags().BoolVar(&showProgress, "progress", false, "Print the Action phase and the progress reported by the runner, using the Gateway.")
 
 	for _, hide := range argoHiddenFlags {
 		// set flags exits
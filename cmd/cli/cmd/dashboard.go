@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"time"
+
+	"capact.io/capact/internal/cli"
+	"capact.io/capact/internal/cli/client"
+	"capact.io/capact/internal/cli/heredoc"
+	"capact.io/capact/internal/cli/tui"
+
+	"github.com/spf13/cobra"
+)
+
+// NewDashboard returns a cobra.Command for the interactive terminal UI.
+func NewDashboard() *cobra.Command {
+	var opts tui.Options
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Interactive terminal UI for Hub and Actions",
+		Long: heredoc.Doc(`
+			Displays a full-screen terminal UI to browse InterfaceGroups, Interfaces, Implementations and Types from Public Hub,
+			TypeInstances with their relations from Local Hub, and to track Actions live.
+
+			Use arrow keys or j/k to move, enter to open the selected item, and esc to go back.
+			On an Interface, press "c" to create an Action. On an Action, press "r" to run it once it is ready,
+			or press "d" twice to delete it.`),
+		Example: heredoc.WithCLIName(`
+			# Open the terminal UI and track Actions in the default Namespace
+			<cli> dashboard
+
+			# Track Actions in the "gitlab" Namespace, refreshing them every 5 seconds
+			<cli> dashboard -n gitlab --refresh 5s`, cli.Name),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui.Run(cmd.Context(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Namespace, "namespace", "n", "default", "Kubernetes namespace where Actions are tracked")
+	flags.DurationVar(&opts.RefreshInterval, "refresh", 2*time.Second, "Interval in which the list of Actions is refreshed")
+	client.RegisterFlags(flags)
+
+	return cmd
+}
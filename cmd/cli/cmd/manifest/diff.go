@@ -0,0 +1,78 @@
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"capact.io/capact/internal/cli"
+	"capact.io/capact/internal/cli/heredoc"
+	"capact.io/capact/pkg/sdk/manifest"
+
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewDiff returns a cobra.Command for comparing two revisions of a Hub manifest.
+func NewDiff() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff OLD_MANIFEST NEW_MANIFEST",
+		Short: "Compare two revisions of a Type or Interface manifest",
+		Long: heredoc.Doc(`
+			Compares two revisions of a Type JSON Schema or an Interface input and output.
+			Each change is classified as documentation (e.g. an updated description), compatible (e.g. a new optional field) or breaking (e.g. a new required field, a removed output, or a narrowed enum).
+			The command fails if the revision bump is too small for the detected changes.`),
+		Example: heredoc.WithCLIName(`
+			# Compare two revisions of the PostgreSQL config Type
+			<cli> manifest diff ./old/type/database/postgresql/config.yaml ./manifests/type/database/postgresql/config.yaml
+
+			# Compare the Interface from the main branch with the local one
+			git show main:manifests/interface/database/postgresql/install.yaml > /tmp/install.yaml
+			<cli> manifest diff /tmp/install.yaml ./manifests/interface/database/postgresql/install.yaml`, cli.Name),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diff(os.Stdout, args[0], args[1])
+		},
+	}
+}
+
+func diff(w io.Writer, oldPath, newPath string) error {
+	oldYAML, err := ioutil.ReadFile(filepath.Clean(oldPath))
+	if err != nil {
+		return errors.Wrapf(err, "while reading file from path %s", oldPath)
+	}
+	newYAML, err := ioutil.ReadFile(filepath.Clean(newPath))
+	if err != nil {
+		return errors.Wrapf(err, "while reading file from path %s", newPath)
+	}
+
+	res, err := manifest.Diff(oldYAML, newYAML)
+	if err != nil {
+		return errors.Wrap(err, "while comparing manifests")
+	}
+
+	fmt.Fprintf(w, "Comparing %s revisions %s and %s\n", res.Kind, res.OldRevision, res.NewRevision)
+	if len(res.Changes) == 0 {
+		fmt.Fprintln(w, "No changes detected")
+	}
+	for _, change := range res.Changes {
+		mark := color.GreenString("+")
+		switch change.Impact {
+		case manifest.BreakingChange:
+			mark = color.RedString("!")
+		case manifest.DocumentationChange:
+			mark = "~"
+		}
+		fmt.Fprintf(w, "- %s %s\n", mark, change)
+	}
+
+	if err := res.CheckRevisionBump(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nRevision bump is sufficient, at least %s bump is required\n", res.RequiredBump())
+	return nil
+}
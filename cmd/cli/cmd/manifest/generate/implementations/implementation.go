@@ -14,6 +14,7 @@ type implGeneratorType string
 const (
 	helmTool      implGeneratorType = "Helm"
 	terraformTool implGeneratorType = "Terraform"
+	kustomizeTool implGeneratorType = "Kustomize"
 	emptyManifest implGeneratorType = "Empty"
 )
 
@@ -30,6 +31,7 @@ func NewCmd() *cobra.Command {
 
 	cmd.AddCommand(NewTerraform())
 	cmd.AddCommand(NewHelm())
+	cmd.AddCommand(NewKustomize())
 	cmd.AddCommand(NewEmpty())
 
 	return cmd
@@ -62,6 +64,7 @@ func GenerateImplementationManifest(opts common.ManifestGenOptions) (manifestgen
 	toolAction := map[implGeneratorType]generateFn{
 		helmTool:      generateHelmManifests,
 		terraformTool: generateTerraformManifests,
+		kustomizeTool: generateKustomizeManifests,
 		emptyManifest: generateEmptyManifests,
 	}
 
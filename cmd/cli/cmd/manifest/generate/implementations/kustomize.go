@@ -0,0 +1,118 @@
+package implementations
+
+import (
+	"strings"
+
+	"capact.io/capact/cmd/cli/cmd/manifest/generate/common"
+	"capact.io/capact/internal/cli/manifestgen"
+
+	"capact.io/capact/internal/cli"
+	"capact.io/capact/internal/cli/heredoc"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewKustomize returns a cobra.Command to bootstrap manifests based on Kubernetes manifests or a kustomization.
+func NewKustomize() *cobra.Command {
+	var kustomizeCfg manifestgen.KustomizeConfig
+
+	cmd := &cobra.Command{
+		Use:   "kustomize [MANIFEST_PATH] [MANIFESTS_DIR]",
+		Short: "Generate Kustomize based manifests",
+		Long: heredoc.Doc(`
+			Generate Implementation manifests based on a directory with plain Kubernetes manifests or a kustomization.
+
+			Files with the ".tpl" extension are Go templates. The input parameters Type is generated from the ".Values" fields used in the templates.`),
+		Example: heredoc.WithCLIName(`
+		# Generate Implementation manifests for a directory with Kubernetes manifests
+		<cli> manifest generate implementation kustomize cap.implementation.nginx.install ./manifests/nginx
+
+		# Generate Implementation manifests for manifests stored in a Git repository
+		<cli> manifest generate implementation kustomize cap.implementation.nginx.install ./manifests/nginx -s "git::https://github.com/example/manifests.git//nginx"`, cli.Name),
+
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("accepts two arguments: [MANIFEST_PATH] [MANIFESTS_DIR]")
+			}
+
+			path := args[0]
+			if !strings.HasPrefix(path, "cap.implementation.") || len(strings.Split(path, ".")) < 4 {
+				return errors.New(`manifest path must be in format "cap.implementation.[PREFIX].[NAME]"`)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kustomizeCfg.ManifestRef.Path = args[0]
+			kustomizeCfg.ManifestsPath = args[1]
+			kustomizeCfg.Metadata = common.GetDefaultImplementationMetadata()
+
+			manifests, err := manifestgen.GenerateKustomizeManifests(&kustomizeCfg)
+			if err != nil {
+				return errors.Wrap(err, "while generating content files")
+			}
+
+			outputDir, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return errors.Wrap(err, "while reading output flag")
+			}
+
+			overrideManifests, err := cmd.Flags().GetBool("overwrite")
+			if err != nil {
+				return errors.Wrap(err, "while reading overwrite flag")
+			}
+
+			if err := manifestgen.WriteManifestFiles(outputDir, manifests, overrideManifests); err != nil {
+				return errors.Wrap(err, "while writing manifest files")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&kustomizeCfg.InterfacePathWithRevision, "interface", "i", "", "Path with revision of the Interface, which is implemented by this Implementation")
+	cmd.Flags().StringVarP(&kustomizeCfg.ManifestRef.Revision, "revision", "r", "0.1.0", "Revision of the Implementation manifest")
+	cmd.Flags().StringVarP(&kustomizeCfg.SourceURL, "source", "s", "https://example.com/manifests.tgz", "Location of the manifests, such as URL to Tarball or Git repository")
+
+	return cmd
+}
+
+func generateKustomizeManifests(opts common.ManifestGenOptions) (manifestgen.ManifestCollection, error) {
+	manifestsDir, err := common.AskForDirectory("Path to the directory with Kubernetes manifests", "")
+	if err != nil {
+		return nil, errors.Wrap(err, "while asking for path to Kubernetes manifests")
+	}
+
+	source, err := askForManifestsSource()
+	if err != nil {
+		return nil, errors.Wrap(err, "while asking for source of Kubernetes manifests")
+	}
+
+	kustomizeCfg := manifestgen.KustomizeConfig{
+		ImplementationConfig: manifestgen.ImplementationConfig{
+			Config: manifestgen.Config{
+				ManifestRef: types.ManifestRef{
+					Path:     common.CreateManifestPath(types.ImplementationManifestKind, opts.ManifestPath),
+					Revision: opts.Revision,
+				},
+			},
+			Metadata: types.ImplementationMetadata{
+				DocumentationURL: opts.Metadata.DocumentationURL,
+				SupportURL:       opts.Metadata.SupportURL,
+				IconURL:          opts.Metadata.IconURL,
+				Maintainers:      opts.Metadata.Maintainers,
+				License:          opts.Metadata.License,
+			},
+			InterfacePathWithRevision: opts.InterfacePath,
+		},
+		ManifestsPath: manifestsDir,
+		SourceURL:     source,
+	}
+
+	files, err := manifestgen.GenerateKustomizeManifests(&kustomizeCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while generating Kustomize manifests")
+	}
+	return files, nil
+}
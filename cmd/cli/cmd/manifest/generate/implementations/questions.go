@@ -19,7 +19,7 @@ func askForImplementationTool() (string, error) {
 	var selectedTool string
 	var options []string
 
-	availableTool := []implGeneratorType{helmTool, terraformTool, emptyManifest}
+	availableTool := []implGeneratorType{helmTool, terraformTool, kustomizeTool, emptyManifest}
 	for _, tool := range availableTool {
 		options = append(options, string(tool))
 	}
@@ -80,6 +80,16 @@ func askForSource() (string, error) {
 	return source, err
 }
 
+func askForManifestsSource() (string, error) {
+	var source string
+	prompt := &survey.Input{
+		Message: "Location of the hosted Kubernetes manifests, such as URL to Tarball or Git repository",
+		Default: "",
+	}
+	err := survey.AskOne(prompt, &source)
+	return source, err
+}
+
 func askForHelmLocation() (string, error) {
 	var selectedLocation string
 	availableLocations := []string{string(localHelmChartLocation), string(remoteHelmChartLocation)}
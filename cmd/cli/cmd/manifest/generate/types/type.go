@@ -1,6 +1,8 @@
 package types
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"capact.io/capact/cmd/cli/cmd/manifest/generate/common"
@@ -14,7 +16,11 @@ import (
 
 // NewType returns a cobra.Command to bootstrap new Type manifests.
 func NewType() *cobra.Command {
-	var typeCfg manifestgen.InterfaceConfig
+	var (
+		typeCfg     manifestgen.InterfaceConfig
+		fromOpenAPI string
+		fromCRD     string
+	)
 
 	cmd := &cobra.Command{
 		Use:     "type [PATH]",
@@ -22,13 +28,31 @@ func NewType() *cobra.Command {
 		Short:   "Generate new Type manifests",
 		Example: heredoc.WithCLIName(`
 			# Generate manifests for the cap.type.database.postgresql.config Type
-			<cli> manifest generate type cap.type.database.postgresql.config`, cli.Name),
+			<cli> manifest generate type cap.type.database.postgresql.config
+
+			# Generate Type manifests with the cap.type.database.postgresql prefix for all schemas from OpenAPI document components
+			<cli> manifest generate type cap.type.database.postgresql --from-openapi ./openapi.yaml
+
+			# Generate Type manifests with the cap.type.database.postgresql prefix for all CustomResourceDefinitions in a file
+			<cli> manifest generate type cap.type.database.postgresql --from-crd ./crds.yaml`, cli.Name),
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 1 {
 				return errors.New("accepts one argument: [MANIFEST_PATH]")
 			}
 
+			if fromOpenAPI != "" && fromCRD != "" {
+				return errors.New("--from-openapi and --from-crd flags are mutually exclusive")
+			}
+
 			path := args[0]
+			if fromOpenAPI != "" || fromCRD != "" {
+				// Type names are taken from the schemas, so only the prefix is provided
+				if !strings.HasPrefix(path, "cap.type.") || len(strings.Split(path, ".")) < 3 {
+					return errors.New(`manifest path must be in format "cap.type.[PREFIX]"`)
+				}
+				return nil
+			}
+
 			if !strings.HasPrefix(path, "cap.type.") || len(strings.Split(path, ".")) < 4 {
 				return errors.New(`manifest path must be in format "cap.type.[PREFIX].[NAME]"`)
 			}
@@ -39,7 +63,7 @@ func NewType() *cobra.Command {
 			typeCfg.ManifestRef.Path = args[0]
 			typeCfg.Metadata = common.GetDefaultInterfaceMetadata()
 
-			manifests, err := manifestgen.GenerateTypeTemplatingConfig(&typeCfg)
+			manifests, err := generateTypes(&typeCfg, fromOpenAPI, fromCRD)
 			if err != nil {
 				return errors.Wrap(err, "while generating content files")
 			}
@@ -63,6 +87,34 @@ func NewType() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&typeCfg.ManifestRef.Revision, "revision", "r", "0.1.0", "Revision of the Type manifest")
+	cmd.Flags().StringVar(&fromOpenAPI, "from-openapi", "", "Path to the OpenAPI v3 document. One Type is generated for each schema under components.schemas")
+	cmd.Flags().StringVar(&fromCRD, "from-crd", "", "Path to the file with CustomResourceDefinitions. One Type is generated for each CustomResourceDefinition from its spec schema")
 
 	return cmd
 }
+
+func generateTypes(cfg *manifestgen.InterfaceConfig, fromOpenAPI, fromCRD string) (manifestgen.ManifestCollection, error) {
+	var (
+		generate   func(*manifestgen.OpenAPITypeConfig) (manifestgen.ManifestCollection, error)
+		schemaPath string
+	)
+	switch {
+	case fromOpenAPI != "":
+		generate, schemaPath = manifestgen.GenerateTypeManifestsFromOpenAPI, fromOpenAPI
+	case fromCRD != "":
+		generate, schemaPath = manifestgen.GenerateTypeManifestsFromCRD, fromCRD
+	default:
+		return manifestgen.GenerateTypeTemplatingConfig(cfg)
+	}
+
+	schema, err := ioutil.ReadFile(filepath.Clean(schemaPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading schema file")
+	}
+
+	return generate(&manifestgen.OpenAPITypeConfig{
+		Config:   cfg.Config,
+		Metadata: cfg.Metadata,
+		Schema:   schema,
+	})
+}
@@ -0,0 +1,74 @@
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"capact.io/capact/internal/cli"
+	"capact.io/capact/internal/cli/heredoc"
+	"capact.io/capact/pkg/sdk/manifest"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	privateKeyFileName = "private.pem"
+	publicKeyFileName  = "public.pem"
+)
+
+// NewKeygen returns a cobra.Command for generating the key pair used for signing Hub manifests.
+func NewKeygen() *cobra.Command {
+	var outputDir string
+
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generate key pair for signing OCF manifests",
+		Long: heredoc.Doc(`
+			Generates the ed25519 key pair for signing OCF manifests.
+			The private key is saved to the "private.pem" file and must be kept secret.
+			The public key is saved to the "public.pem" file. To trust it, add it to the Hub populator trusted keys directory
+			under the signer name, e.g. "capact-maintainers.pem".`),
+		Example: heredoc.WithCLIName(`
+			# Generate the key pair in the ./keys directory
+			<cli> manifest keygen --output-dir ./keys`, cli.Name),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return keygen(os.Stdout, outputDir)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&outputDir, "output-dir", "o", ".", "Path to the directory, where the key pair is saved.")
+
+	return cmd
+}
+
+func keygen(w io.Writer, outputDir string) error {
+	privPath := filepath.Join(outputDir, privateKeyFileName)
+	if _, err := os.Stat(privPath); err == nil {
+		return fmt.Errorf("private key %s already exists", privPath)
+	}
+
+	priv, pub, err := manifest.GenerateKeyPair()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, 0o750); err != nil {
+		return errors.Wrapf(err, "while creating directory %s", outputDir)
+	}
+	if err := ioutil.WriteFile(privPath, priv, 0o600); err != nil {
+		return errors.Wrap(err, "while saving private key")
+	}
+	pubPath := filepath.Join(outputDir, publicKeyFileName)
+	if err := ioutil.WriteFile(pubPath, pub, 0o600); err != nil {
+		return errors.Wrap(err, "while saving public key")
+	}
+
+	fmt.Fprintf(w, "Private key saved to %s\nPublic key saved to %s\n", privPath, pubPath)
+	return nil
+}
@@ -15,6 +15,9 @@ func NewCmd() *cobra.Command {
 
 	root.AddCommand(
 		NewValidate(),
+		NewDiff(),
+		NewSign(),
+		NewKeygen(),
 		generate.NewCmd(),
 	)
 	return root
@@ -0,0 +1,106 @@
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"capact.io/capact/internal/cli"
+	"capact.io/capact/internal/cli/heredoc"
+	capactio "capact.io/capact/internal/io"
+	"capact.io/capact/pkg/sdk/manifest"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewSign returns a cobra.Command for signing Hub manifests.
+func NewSign() *cobra.Command {
+	var keyPath, rootDir string
+
+	cmd := &cobra.Command{
+		Use:   "sign [MANIFEST_PATH...]",
+		Short: "Sign OCF manifests",
+		Long: heredoc.Doc(`
+			Signs OCF manifests with the ed25519 private key.
+			For each manifest, the detached signature is saved next to it, in the file with the ".sig" suffix.
+			The signature covers the manifest content and its path, such as "cap.interface.database.postgresql.install".
+			The path prefix is computed from the manifest location in the root directory, the same way as the Hub populator does.
+			Directories are searched for YAML files recursively.
+			Signatures are verified by the Hub populator against the trusted public keys.`),
+		Example: heredoc.WithCLIName(`
+			# Generate the key pair used for signing
+			<cli> manifest keygen --output-dir ./keys
+
+			# Sign all Hub manifests
+			<cli> manifest sign --key ./keys/private.pem ./manifests/
+
+			# Sign a single Implementation
+			<cli> manifest sign --key ./keys/private.pem ./manifests/implementation/postgresql/install.yaml
+
+			# Sign manifests stored in a custom root directory
+			<cli> manifest sign --key ./keys/private.pem --root-dir ./hub/manifests ./hub/manifests/type/`, cli.Name),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sign(os.Stdout, keyPath, rootDir, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&keyPath, "key", "k", "", "Path to the ed25519 private key in the PEM format.")
+	flags.StringVar(&rootDir, "root-dir", "manifests", "Path to the manifests root directory, used to compute the manifest path prefix.")
+	_ = cmd.MarkFlagRequired("key")
+
+	return cmd
+}
+
+func sign(w io.Writer, keyPath, rootDir string, paths []string) error {
+	keyBytes, err := ioutil.ReadFile(filepath.Clean(keyPath))
+	if err != nil {
+		return errors.Wrapf(err, "while reading file from path %s", keyPath)
+	}
+	key, err := manifest.ParsePrivateKey(keyBytes)
+	if err != nil {
+		return errors.Wrap(err, "while loading private key")
+	}
+
+	var files []string
+	for _, path := range paths {
+		found, err := capactio.ListYAMLs(path)
+		if err != nil {
+			return errors.Wrapf(err, "while listing manifests in %s", path)
+		}
+		files = append(files, found...)
+	}
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return errors.Wrapf(err, "while reading file from path %s", file)
+		}
+		if _, err := manifest.UnmarshalMetadata(content); err != nil {
+			return errors.Wrapf(err, "while loading manifest metadata from path %s", file)
+		}
+		prefix, err := manifest.PrefixFromPath(rootDir, file)
+		if err != nil {
+			return errors.Wrapf(err, "while getting prefix of %s", file)
+		}
+		manifestPath, err := manifest.CanonicalPath(prefix, content)
+		if err != nil {
+			return errors.Wrapf(err, "while getting canonical path of %s", file)
+		}
+
+		sig, err := manifest.Sign(manifestPath, content, key)
+		if err != nil {
+			return errors.Wrapf(err, "while signing %s", file)
+		}
+		if err := ioutil.WriteFile(manifest.SignatureFilePath(file), sig, 0o600); err != nil {
+			return errors.Wrapf(err, "while saving signature of %s", file)
+		}
+		fmt.Fprintf(w, "Signed %s as %s\n", file, manifestPath)
+	}
+
+	return nil
+}
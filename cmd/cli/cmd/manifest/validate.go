@@ -30,7 +30,13 @@ func NewValidate() *cobra.Command {
 			<cli> manifest validate --server-side ./manifests/ --recursive
 			
 			# Validate interface-group.yaml file with custom OCF specification location 
-			<cli> manifest validate -s my/ocf/spec/directory ocf-spec/0.0.1/examples/interface-group.yaml`, cli.Name),
+			<cli> manifest validate -s my/ocf/spec/directory ocf-spec/0.0.1/examples/interface-group.yaml
+
+			# Validate all Hub manifests and references between them without calling Capact Hub, e.g. in a pre-commit hook
+			<cli> manifest validate --graph ./manifests/
+
+			# Validate all Hub manifests with custom lint rules and print results in the SARIF format
+			<cli> manifest validate ./manifests/ --recursive --lint-config ./my-lint.yaml -o sarif`, cli.Name),
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			validation, err := validate.New(os.Stdout, opts)
@@ -46,6 +52,9 @@ func NewValidate() *cobra.Command {
 	flags.BoolVarP(&opts.RecursiveSearch, "recursive", "r", false, "Search files under each directory, recursively.")
 	flags.BoolVar(&opts.ServerSide, "server-side", false, "Executes additional manifests checks against Capact Hub.")
 	flags.IntVar(&opts.MaxConcurrency, "concurrency", defaultMaxConcurrency, "Maximum number of concurrent workers.")
+	flags.BoolVar(&opts.GraphCheck, "graph", false, "Validates references between all manifests from the given directories. Directories are searched recursively.")
+	flags.StringVar(&opts.LintConfigPath, "lint-config", "", "Path to the lint rules configuration file. If not provided, the .capact-lint.yaml file from the current directory is used, if it exists.")
+	flags.StringVarP((*string)(&opts.OutputFormat), "output", "o", string(validate.TextOutputFormat), "Output format. One of: text | sarif")
 
 	return cmd
 }
@@ -85,6 +85,7 @@ func NewRoot() *cobra.Command {
 		NewUpgrade(),
 		NewCompletion(),
 		NewVersion(),
+		NewDashboard(),
 		manifest.NewCmd(),
 		hub.NewCmd(),
 		configcmd.NewCmd(),
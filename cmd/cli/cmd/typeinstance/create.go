@@ -13,6 +13,7 @@ import (
 	"capact.io/capact/internal/cli/heredoc"
 	"capact.io/capact/internal/cli/printer"
 	gqllocalapi "capact.io/capact/pkg/hub/api/graphql/local"
+	storagebackend "capact.io/capact/pkg/hub/storage-backend"
 	"capact.io/capact/pkg/sdk/validation"
 
 	"github.com/pkg/errors"
@@ -23,6 +24,7 @@ import (
 type createOptions struct {
 	FilePath           string
 	TypeInstancesFiles []string
+	SkipBackendCheck   bool
 }
 
 // NewCreate returns a cobra.Command for creating a TypeInstance on a Local Hub.
@@ -67,6 +69,9 @@ func NewCreate() *cobra.Command {
 		Example: heredoc.WithCLIName(`
 			# Create TypeInstances defined in a given file
 			<cli> typeinstance create -f ./tmp/typeinstances.yaml
+
+			# Create TypeInstances without checking the storage backends, e.g. when they are not reachable from your machine
+			<cli> typeinstance create -f ./tmp/typeinstances.yaml --skip-backend-check
 		`, cli.Name),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -77,6 +82,7 @@ func NewCreate() *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringSliceVarP(&opts.TypeInstancesFiles, cli.FromFileFlagName, "f", []string{}, "The TypeInstances input in YAML format (can specify multiple)")
 	panicOnError(cmd.MarkFlagRequired(cli.FromFileFlagName)) // this cannot happen
+	flags.BoolVar(&opts.SkipBackendCheck, "skip-backend-check", false, "Skip checking if the storage backends of created TypeInstances are healthy and match their registration")
 
 	resourcePrinter.RegisterFlags(flags)
 	client.RegisterFlags(flags)
@@ -111,6 +117,12 @@ func createTI(ctx context.Context, opts createOptions, resourcePrinter *printer.
 		return r.ErrorOrNil()
 	}
 
+	if !opts.SkipBackendCheck {
+		if err := checkBackends(ctx, storagebackend.NewChecker(hubCli), typeInstanceToCreate); err != nil {
+			return err
+		}
+	}
+
 	// HACK: UsesRelations are required on GQL side so at least empty array needs to be send
 	if typeInstanceToCreate.UsesRelations == nil {
 		typeInstanceToCreate.UsesRelations = []*gqllocalapi.TypeInstanceUsesRelationInput{}
@@ -124,6 +136,25 @@ func createTI(ctx context.Context, opts createOptions, resourcePrinter *printer.
 	return resourcePrinter.Print(createdTI)
 }
 
+func checkBackends(ctx context.Context, checker *storagebackend.Checker, in *gqllocalapi.CreateTypeInstancesInput) error {
+	checked := map[string]struct{}{}
+	for _, ti := range in.TypeInstances {
+		if ti == nil || ti.Backend == nil {
+			continue
+		}
+		if _, ok := checked[ti.Backend.ID]; ok {
+			continue
+		}
+		checked[ti.Backend.ID] = struct{}{}
+
+		if err := checker.CheckBackend(ctx, ti.Backend.ID); err != nil {
+			return errors.Wrap(err, "while checking storage backend")
+		}
+	}
+
+	return nil
+}
+
 func loadCreateTypeInstanceFromFile(path string) (*gqllocalapi.CreateTypeInstancesInput, error) {
 	f, err := os.Open(filepath.Clean(path))
 	if err != nil {
@@ -0,0 +1,56 @@
+package typeinstance
+
+import (
+	"os"
+
+	"capact.io/capact/internal/cli"
+	"capact.io/capact/internal/cli/client"
+	"capact.io/capact/internal/cli/heredoc"
+	"capact.io/capact/internal/cli/typeinstance"
+
+	"github.com/spf13/cobra"
+)
+
+// NewMigrate returns a cobra.Command for migrating TypeInstances between storage backends.
+func NewMigrate() *cobra.Command {
+	var opts typeinstance.MigrateOptions
+
+	cmd := &cobra.Command{
+		Use:   "migrate [TYPE_INSTANCE_ID...]",
+		Short: "Migrate TypeInstances to a different storage backend",
+		Long: heredoc.Doc(`
+			Migrate TypeInstances with all their resource versions to a given storage backend.
+			Each TypeInstance is locked during the migration. If it is already locked by a different owner, the TypeInstance is not migrated.
+			TypeInstances which are already stored in the target backend are skipped, so the migration can be safely re-run.
+		`),
+		Example: heredoc.WithCLIName(`
+			# Migrate TypeInstances with IDs 'c49b' and '4793' to the storage backend with ID '9e8f'
+			<cli> typeinstance migrate c49b 4793 --to-backend 9e8f
+
+			# Check which TypeInstances stored in the built-in storage would be migrated
+			<cli> typeinstance migrate --from-backend aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa --to-backend 9e8f --dry-run
+
+			# Migrate TypeInstances stored in the built-in storage and record the progress to resume the migration in case of a failure
+			<cli> typeinstance migrate --from-backend aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa --to-backend 9e8f --progress-file /tmp/migration.json
+
+			# Migrate a TypeInstance and pass the context to the target storage backend
+			<cli> typeinstance migrate c49b --to-backend 9e8f --to-backend-context '{"provider":"aws_secretsmanager"}'
+		`, cli.Name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TypeInstanceIDs = args
+			return typeinstance.Migrate(cmd.Context(), opts, os.Stdout)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.ToBackend, "to-backend", "", "ID of the target storage backend TypeInstance")
+	flags.StringVar(&opts.ToBackendContext, "to-backend-context", "", "Context passed to the target storage backend, in JSON or YAML format")
+	flags.StringVar(&opts.FromBackend, "from-backend", "", "Migrates all TypeInstances stored in the storage backend with a given ID. Cannot be used together with TypeInstance IDs")
+	flags.StringVar(&opts.ProgressFile, "progress-file", "", "Path to the file where IDs of already migrated TypeInstances are stored. If the file exists, listed TypeInstances are skipped")
+	flags.BoolVar(&opts.DryRun, "dry-run", false, "Checks which TypeInstances would be migrated without modifying them")
+	client.RegisterFlags(flags)
+
+	panicOnError(cmd.MarkFlagRequired("to-backend")) // this cannot happen
+
+	return cmd
+}
@@ -24,6 +24,7 @@ func NewCmd() *cobra.Command {
 		NewGet(),
 		NewEdit(),
 		NewApply(),
+		NewMigrate(),
 	)
 	return root
 }
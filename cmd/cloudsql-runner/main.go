@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"capact.io/capact/pkg/runner"
@@ -16,8 +17,9 @@ import (
 
 // Config holds the input parameters for the CloudSQL runner binary.
 type Config struct {
-	GCP    cloudsql.GCPConfig
-	Output cloudsql.OutputConfig
+	GCP                          cloudsql.GCPConfig
+	InstanceTypeInstanceFilepath string `envconfig:"optional"`
+	Output                       cloudsql.OutputConfig
 }
 
 func main() {
@@ -25,13 +27,31 @@ func main() {
 	err := envconfig.InitWithPrefix(&cfg, "RUNNER")
 	exitOnError(err, "failed to load config")
 
-	gcpCreds, err := cloudsql.LoadGCPCredentials(cfg.GCP)
-	exitOnError(err, "failed to load GCP credentials")
+	projectID := cfg.GCP.ProjectID
+	var opts []option.ClientOption
+	if cfg.GCP.APIEndpoint != "" {
+		if projectID == "" {
+			exitOnError(errors.New("project ID is required when API endpoint is set"), "failed to load config")
+		}
+		opts = append(opts, option.WithEndpoint(cfg.GCP.APIEndpoint), option.WithoutAuthentication())
+	} else {
+		gcpCreds, err := cloudsql.LoadGCPCredentials(cfg.GCP)
+		exitOnError(err, "failed to load GCP credentials")
 
-	service, err := sqladmin.NewService(context.Background(), option.WithCredentials(gcpCreds))
+		opts = append(opts, option.WithCredentials(gcpCreds))
+		if projectID == "" {
+			projectID = gcpCreds.ProjectID
+		}
+	}
+
+	service, err := sqladmin.NewService(context.Background(), opts...)
 	exitOnError(err, "failed to create GCP service client")
 
-	cloudsqlRunner := cloudsql.NewRunner(cfg.Output, service, gcpCreds.ProjectID)
+	runnerCfg := cloudsql.Config{
+		InstanceTypeInstanceFilepath: cfg.InstanceTypeInstanceFilepath,
+		Output:                       cfg.Output,
+	}
+	cloudsqlRunner := cloudsql.NewRunner(runnerCfg, cloudsql.NewSQLAdmin(service), projectID)
 
 	statusReporter := statusreporter.NewNoop()
 
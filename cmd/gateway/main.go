@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"capact.io/capact/internal/gateway/header"
+	"capact.io/capact/internal/gateway/token"
 	"capact.io/capact/internal/healthz"
 	"capact.io/capact/internal/logger"
 	"capact.io/capact/pkg/httputil"
@@ -43,6 +44,10 @@ type Config struct {
 type BasicAuth struct {
 	Username string `envconfig:"default=graphql"`
 	Password string
+
+	// ActionTokenKey is the key used to verify the short-lived credentials issued by the Engine for Actions.
+	// If empty, only the above credentials are accepted.
+	ActionTokenKey string `envconfig:"optional"`
 }
 
 // IntrospectionConfig holds configuration parameters related to GraphQL schema introspection.
@@ -80,7 +85,7 @@ func main() {
 	parallelServers.Go(func() error { return healthzServer.Start(ctx) })
 
 	// graphql server
-	schemas, err := introspectGraphQLSchemas(logger, cfg.Introspection)
+	schemas, err := introspectGraphQLSchemas(logger, cfg.Introspection, cfg.Auth)
 	exitOnError(err, "while introspecting GraphQL schemas")
 
 	gqlServer, err := setupGatewayServerFromSchemas(logger, schemas, cfg.Auth, cfg.GraphQLAddr)
@@ -92,7 +97,7 @@ func main() {
 	exitOnError(err, "while waiting for servers to finish gracefully")
 }
 
-func introspectGraphQLSchemas(log *zap.Logger, cfg IntrospectionConfig) ([]*graphql.RemoteSchema, error) {
+func introspectGraphQLSchemas(log *zap.Logger, cfg IntrospectionConfig, authCfg BasicAuth) ([]*graphql.RemoteSchema, error) {
 	log.Info("Introspecting GraphQL schemas",
 		zap.Strings("URLs", cfg.GraphQLEndpoints),
 		zap.Uint("attempts", cfg.Attempts),
@@ -104,7 +109,7 @@ func introspectGraphQLSchemas(log *zap.Logger, cfg IntrospectionConfig) ([]*grap
 
 	err = retry.Do(
 		func() error {
-			schemas, err = graphql.IntrospectRemoteSchemas(cfg.GraphQLEndpoints...)
+			schemas, err = introspectRemoteSchemas(authCfg, cfg.GraphQLEndpoints...)
 			return errors.Wrap(err, "while introspecting schemas")
 		},
 		retry.OnRetry(func(n uint, err error) {
@@ -122,12 +127,42 @@ func introspectGraphQLSchemas(log *zap.Logger, cfg IntrospectionConfig) ([]*grap
 	return schemas, nil
 }
 
+// introspectRemoteSchemas introspects the remote GraphQL APIs using the Gateway credentials,
+// as the Hubs may reject requests without them.
+func introspectRemoteSchemas(authCfg BasicAuth, urls ...string) ([]*graphql.RemoteSchema, error) {
+	withBasicAuth := []graphql.NetworkMiddleware{
+		func(r *http.Request) error {
+			r.SetBasicAuth(authCfg.Username, authCfg.Password)
+			return nil
+		},
+	}
+
+	var schemas []*graphql.RemoteSchema
+	for _, url := range urls {
+		schema, err := graphql.IntrospectAPI(graphql.NewSingleRequestQueryer(url).WithMiddlewares(withBasicAuth))
+		if err != nil {
+			return nil, errors.Wrapf(err, "while introspecting %q", url)
+		}
+		schemas = append(schemas, &graphql.RemoteSchema{
+			URL:    url,
+			Schema: schema,
+		})
+	}
+
+	return schemas, nil
+}
+
 func setupGatewayServerFromSchemas(log *zap.Logger, schemas []*graphql.RemoteSchema, authCfg BasicAuth, addr string) (httputil.StartableServer, error) {
 	log.Info("Setting up gateway GraphQL server")
 
 	headerMiddleware := header.Middleware{}
 
 	middlewares := []gateway.Middleware{
+		// the Hubs accept only the Gateway credentials, so they replace the credentials of the caller
+		gateway.RequestMiddleware(func(r *http.Request) error {
+			r.SetBasicAuth(authCfg.Username, authCfg.Password)
+			return nil
+		}),
 		gateway.RequestMiddleware(
 			headerMiddleware.RestoreFromCtx(),
 		)}
@@ -157,6 +192,11 @@ func setupGatewayServerFromSchemas(log *zap.Logger, schemas []*graphql.RemoteSch
 }
 
 func withBasicAuth(log *zap.Logger, cfg BasicAuth, handler http.Handler) http.HandlerFunc {
+	var actionTokens *token.Issuer
+	if cfg.ActionTokenKey != "" {
+		actionTokens = token.NewIssuer([]byte(cfg.ActionTokenKey), 0)
+	}
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			handler.ServeHTTP(w, r)
@@ -172,6 +212,18 @@ func withBasicAuth(log *zap.Logger, cfg BasicAuth, handler http.Handler) http.Ha
 			return
 		}
 
+		if actionTokens != nil && token.IsActionUsername(username) {
+			if err := actionTokens.Verify(username, password); err != nil {
+				if err := writeJSONError(w, err.Error(), http.StatusOK); err != nil {
+					log.Info("failed to write response")
+				}
+				return
+			}
+
+			handler.ServeHTTP(w, r)
+			return
+		}
+
 		if username != cfg.Username || password != cfg.Password {
 			if err := writeJSONError(w, "wrong credentials", http.StatusOK); err != nil {
 				log.Info("failed to write response")
@@ -14,13 +14,16 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 
 	"capact.io/capact/internal/healthz"
 	"capact.io/capact/internal/logger"
 	"capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	hubclient "capact.io/capact/pkg/hub/client"
 	"capact.io/capact/pkg/hub/client/local"
+	storagebackend "capact.io/capact/pkg/hub/storage-backend"
 )
 
 // Mode describes the selected handler for the Helm storage backend gRPC server.
@@ -38,6 +41,10 @@ type Config struct {
 	// LocalHubEndpoint is an endpoint to the Local Hub.
 	LocalHubEndpoint string `envconfig:"default=http://capact-hub-local.capact-system/graphql"`
 
+	// Gateway holds configuration for calling the Local Hub through the Gateway.
+	// If the Gateway endpoint is set, the Local Hub endpoint is ignored.
+	Gateway hubclient.GatewayConfig `envconfig:"optional"`
+
 	// KubeconfigTypeinstanceID is the optional kubeconfig TypeInstance ID.
 	KubeconfigTypeinstanceID string `envconfig:"optional"`
 
@@ -70,6 +77,9 @@ func main() {
 
 	if cfg.KubeconfigTypeinstanceID != "" {
 		hubClient := local.NewDefaultClient(cfg.LocalHubEndpoint)
+		if cfg.Gateway.Enabled() {
+			hubClient = local.NewDefaultClient(cfg.Gateway.Endpoint, cfg.Gateway.ClientOptions()...)
+		}
 		kubeconfigFetcher := helm_storage_backend.NewKubeconfigFetcher(hubClient)
 		err := kubeconfigFetcher.SetKubeconfigBasedOnTypeInstanceID(ctx, logger, cfg.KubeconfigTypeinstanceID)
 		if err != nil {
@@ -88,13 +98,22 @@ func main() {
 	parallelServers := new(errgroup.Group)
 
 	// create handler
-	var handler storage_backend.ContextStorageBackendServer
+	var (
+		handler storage_backend.ContextStorageBackendServer
+		info    *storage_backend.GetInfoResponse
+	)
 	switch cfg.Mode {
 	case HelmReleaseMode:
-		handler, err = helm_storage_backend.NewReleaseHandler(logger, relFetcher)
+		k8sCli, err := client.New(k8sCfg, client.Options{})
+		exitOnError(err, "while creating K8s client")
+
+		states := helm_storage_backend.NewReleaseStateStore(k8sCli)
+		handler, err = helm_storage_backend.NewReleaseHandler(logger, relFetcher, states)
 		exitOnError(err, "while creating Helm Release backend storage")
+		info = helm_storage_backend.ReleaseInfo()
 	case HelmTemplateMode:
 		handler = helm_storage_backend.NewTemplateHandler(logger, relFetcher)
+		info = helm_storage_backend.TemplateInfo()
 	default:
 		exitOnError(fmt.Errorf("invalid mode %q", cfg.Mode), "while loading storage backend handler")
 	}
@@ -109,6 +128,7 @@ func main() {
 
 	srv := grpc.NewServer()
 	storage_backend.RegisterContextStorageBackendServer(srv, handler)
+	storage_backend.RegisterStorageBackendInfoServer(srv, storagebackend.NewInfoServer(info, relFetcher.Ping))
 
 	go func() {
 		<-ctx.Done()
@@ -2,9 +2,11 @@ package main
 
 import (
 	"log"
+	"time"
 
 	policyvalidation "capact.io/capact/pkg/sdk/validation/policy"
 
+	"capact.io/capact/internal/gateway/token"
 	"capact.io/capact/internal/graphqlutil"
 	"capact.io/capact/internal/k8s-engine/controller"
 	domaingraphql "capact.io/capact/internal/k8s-engine/graphql"
@@ -17,6 +19,7 @@ import (
 	policytypes "capact.io/capact/pkg/engine/k8s/policy"
 	"capact.io/capact/pkg/httputil"
 	hubclient "capact.io/capact/pkg/hub/client"
+	storagebackend "capact.io/capact/pkg/hub/storage-backend"
 	"capact.io/capact/pkg/sdk/renderer"
 	"capact.io/capact/pkg/sdk/renderer/argo"
 	actionvalidation "capact.io/capact/pkg/sdk/validation/interfaceio"
@@ -24,6 +27,7 @@ import (
 	gqlgen_graphql "github.com/99designs/gqlgen/graphql"
 	wfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	"github.com/go-logr/zapr"
+	"github.com/pkg/errors"
 	"github.com/vrischmann/envconfig"
 	uber_zap "go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -80,6 +84,16 @@ type Config struct {
 
 	Renderer        renderer.Config
 	HubActionsImage string
+	// HubActionsGatewayEndpoint is the Gateway endpoint used by the TypeInstance steps of the Action workflows.
+	// If set, the steps call the Hubs through the Gateway with short-lived credentials issued for the Action, instead of calling the Hubs directly.
+	HubActionsGatewayEndpoint string `envconfig:"optional"`
+	// HubActionsGatewayTokenKey is the key used to sign the Action credentials. The Gateway must be configured with the same key.
+	HubActionsGatewayTokenKey string `envconfig:"optional"`
+	// HubActionsGatewayTokenTTL defines how long the Action credentials are valid since the Action is run.
+	HubActionsGatewayTokenTTL time.Duration `envconfig:"default=24h"`
+
+	// CheckStorageBackends enables checking storage backends for output TypeInstances before an Action is run.
+	CheckStorageBackends bool `envconfig:"default=true"`
 }
 
 func main() {
@@ -111,7 +125,9 @@ func main() {
 	exitOnError(err, "while creating manager")
 
 	hubClient := getHubClient(&cfg)
-	typeInstanceHandler := argo.NewTypeInstanceHandler(cfg.HubActionsImage, cfg.LocalHubEndpoint, cfg.PublicHubEndpoint)
+	gatewayTokenIssuer, err := getHubActionsGatewayTokenIssuer(&cfg)
+	exitOnError(err, "while creating Gateway token issuer")
+	typeInstanceHandler := argo.NewTypeInstanceHandler(cfg.HubActionsImage, cfg.LocalHubEndpoint, cfg.PublicHubEndpoint, getTypeInstanceHandlerOpts(&cfg)...)
 	interfaceIOValidator := actionvalidation.NewValidator(hubClient)
 	policyIOValidator := policyvalidation.NewValidator(hubClient)
 	wfValidator := renderer.NewWorkflowInputValidator(interfaceIOValidator, policyIOValidator)
@@ -134,7 +150,9 @@ func main() {
 		hubClient,
 		hubClient,
 		controller.Config{
-			BuiltinRunner: cfg.BuiltinRunner,
+			BuiltinRunner:      cfg.BuiltinRunner,
+			BackendChecker:     getBackendChecker(&cfg, hubClient),
+			GatewayTokenIssuer: gatewayTokenIssuer,
 		},
 	)
 
@@ -172,6 +190,33 @@ func getHubClient(cfg *Config) *hubclient.Client {
 	return hubclient.New(cfg.GraphQLGateway.Endpoint, httpClient)
 }
 
+func getTypeInstanceHandlerOpts(cfg *Config) []argo.TypeInstanceHandlerOption {
+	if cfg.HubActionsGatewayEndpoint == "" {
+		return nil
+	}
+	return []argo.TypeInstanceHandlerOption{
+		argo.WithGatewayEndpoint(cfg.HubActionsGatewayEndpoint),
+	}
+}
+
+// getHubActionsGatewayTokenIssuer returns the issuer of the Action credentials. The Gateway credentials are never passed to the Actions.
+func getHubActionsGatewayTokenIssuer(cfg *Config) (controller.GatewayTokenIssuer, error) {
+	if cfg.HubActionsGatewayEndpoint == "" {
+		return nil, nil
+	}
+	if cfg.HubActionsGatewayTokenKey == "" {
+		return nil, errors.New("Gateway token key is required when the Hub actions Gateway endpoint is set")
+	}
+	return token.NewIssuer([]byte(cfg.HubActionsGatewayTokenKey), cfg.HubActionsGatewayTokenTTL), nil
+}
+
+func getBackendChecker(cfg *Config, hubClient *hubclient.Client) argo.BackendChecker {
+	if !cfg.CheckStorageBackends {
+		return nil
+	}
+	return storagebackend.NewChecker(hubClient)
+}
+
 func gqlServer(log *uber_zap.Logger, execSchema gqlgen_graphql.ExecutableSchema, addr, name string) httputil.StartableServer {
 	nsMiddleware := namespace.NewMiddleware()
 
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"log"
+
+	"capact.io/capact/pkg/runner"
+	"capact.io/capact/pkg/runner/kustomize"
+	statusreporter "capact.io/capact/pkg/runner/status-reporter"
+
+	"github.com/vrischmann/envconfig"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+func main() {
+	var cfg kustomize.Config
+	err := envconfig.InitWithPrefix(&cfg, "RUNNER")
+	exitOnError(err, "while loading configuration")
+
+	stop := signals.SetupSignalHandler()
+
+	kustomizeRunner := kustomize.NewRunner(cfg)
+
+	statusReporter := statusreporter.NewNoop()
+
+	// create and run manager
+	mgr, err := runner.NewManager(kustomizeRunner, statusReporter)
+	exitOnError(err, "while creating runner manager")
+
+	err = mgr.Execute(stop)
+	exitOnError(err, "while executing runner")
+}
+
+func exitOnError(err error, context string) {
+	if err != nil {
+		log.Fatalf("%s: %v", context, err)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"capact.io/capact/internal/logger"
 	"capact.io/capact/internal/multierror"
 	"capact.io/capact/pkg/sdk/dbpopulator"
+	"capact.io/capact/pkg/sdk/manifest"
 	"github.com/avast/retry-go"
 	"github.com/docker/cli/cli"
 	gogetter "github.com/hashicorp/go-getter"
@@ -33,8 +34,11 @@ import (
 type filterPath func(string) string
 
 var getters = map[string]gogetter.Getter{
-	"file": new(gogetter.FileGetter),
-	"git":  new(gogetter.GitGetter),
+	"file":  new(gogetter.FileGetter),
+	"git":   new(gogetter.GitGetter),
+	"http":  new(gogetter.HttpGetter),
+	"https": new(gogetter.HttpGetter),
+	"oci":   new(getter.OCIGetter),
 }
 
 // NewOCFManifests returns a cobra.Command for populating manifests into a Neo4j database.
@@ -46,6 +50,15 @@ func NewOCFManifests(cliName string) *cobra.Command {
 		Short: "Populates locally available manifests into Neo4j database",
 		Example: heredoc.WithCLIName(`
 			APP_JSON_PUBLISH_ADDR=http://{HOST_IP} <cli> .
+
+			# Populate manifests published as an OCI artifact. Use the insecure=true query parameter for registries served over HTTP.
+			APP_JSON_PUBLISH_ADDR=http://{HOST_IP} <cli> --source "oci://registry.local:5000/capact/hub-manifests@sha256:{DIGEST}"
+
+			# Populate manifests from an HTTP archive. The checksum query parameter is required.
+			APP_JSON_PUBLISH_ADDR=http://{HOST_IP} <cli> --source "https://example.com/hub-manifests.tar.gz?checksum=sha256:{DIGEST}"
+
+			# Populate only manifests signed with one of the trusted keys. Manifests without a valid signature are skipped.
+			APP_JSON_PUBLISH_ADDR=http://{HOST_IP} APP_TRUSTED_KEYS_DIR=./trusted-keys APP_UNSIGNED_MANIFESTS_POLICY=quarantine <cli> .
 		`, cliName),
 		Args: cli.RequiresMaxArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -58,62 +71,83 @@ func NewOCFManifests(cliName string) *cobra.Command {
 }
 
 func runDBPopulateWithSources(ctx context.Context, sources []string) (err error) {
+	cfg, log, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	sources = removeDuplicateSources(sources)
+	if len(sources) == 0 {
+		return fmt.Errorf("no source information provided")
+	}
+
+	jsonHandler := dbpopulator.NewJSONHandler(nil)
+	go dbpopulator.MustServeJSON(ctx, cfg.JSONPublishPort, jsonHandler)
+
+	driver, err := neo4j.NewDriver(cfg.Neo4jAddr, neo4j.BasicAuth(cfg.Neo4jUser, cfg.Neo4jPassword, ""))
+	if err != nil {
+		return errors.Wrap(err, "while connecting to Neo4j db")
+	}
+	defer func() {
+		if cErr := driver.Close(); cErr != nil {
+			err = multierror.Append(err, cErr)
+		}
+	}()
+
+	_, err = syncSources(ctx, cfg, log, driver, jsonHandler, sources, retry.Attempts(6), retry.Delay(30*time.Second))
+	return err
+}
+
+func loadConfig() (dbpopulator.Config, *zap.Logger, error) {
 	var cfg dbpopulator.Config
-	err = envconfig.InitWithPrefix(&cfg, "APP")
+	err := envconfig.InitWithPrefix(&cfg, "APP")
 	if err != nil {
-		return errors.Wrap(err, "while loading configuration")
+		return dbpopulator.Config{}, nil, errors.Wrap(err, "while loading configuration")
 	}
 
 	log, err := logger.New(cfg.Logger)
 	if err != nil {
-		return errors.Wrap(err, "while creating zap logger")
+		return dbpopulator.Config{}, nil, errors.Wrap(err, "while creating zap logger")
 	}
 
+	return cfg, log, nil
+}
+
+// syncSources downloads manifests from sources and populates them into the Neo4j database.
+// Downloaded manifests are served by the given JSON handler only during the population.
+func syncSources(ctx context.Context, cfg dbpopulator.Config, log *zap.Logger, driver neo4j.Driver, jsonHandler *dbpopulator.JSONHandler, sources []string, retryOpts ...retry.Option) (res dbpopulator.SyncResult, err error) {
 	parentDir, err := ioutil.TempDir("/tmp", "*-hubs-parent")
 	if err != nil {
-		return errors.Wrap(err, "while creating parent temporary directory")
+		return res, errors.Wrap(err, "while creating parent temporary directory")
 	}
 
 	defer func() {
+		jsonHandler.SetPaths(nil)
 		if rErr := os.RemoveAll(parentDir); rErr != nil {
 			err = multierror.Append(err, rErr)
 		}
 	}()
 
-	sources = removeDuplicateSources(sources)
-	if len(sources) == 0 {
-		return fmt.Errorf("no source information provided")
-	}
-
 	sourcesInfo, err := getSourcesInfo(ctx, cfg, log, sources, parentDir)
 	if err != nil {
-		return errors.Wrap(err, "while getting sources info")
+		return res, errors.Wrap(err, "while getting sources info")
 	}
 
-	// run server with merge file list from various sources
+	// serve merged file list from various sources
 	seenFiles := make(map[string]struct{})
 	var fileList []string
 	var commits []string
 	for _, src := range sourcesInfo {
 		err = filesAlreadyExists(seenFiles, src.Files, src.RootDir)
 		if err != nil {
-			return errors.Wrap(err, "while validating the source files")
+			return res, errors.Wrap(err, "while validating the source files")
 		}
 		fileList = append(fileList, src.Files...)
-		commits = append(commits, strings.TrimSpace(string(src.GitHash)))
+		commits = append(commits, strings.TrimSpace(string(src.Revision)))
 	}
-	go dbpopulator.MustServeJSON(ctx, cfg.JSONPublishPort, fileList)
+	jsonHandler.SetPaths(fileList)
 
 	// create neo4j session
-	driver, err := neo4j.NewDriver(cfg.Neo4jAddr, neo4j.BasicAuth(cfg.Neo4jUser, cfg.Neo4jPassword, ""))
-	if err != nil {
-		return errors.Wrap(err, "while connecting to Neo4j db")
-	}
-	defer func() {
-		if cErr := driver.Close(); cErr != nil {
-			err = multierror.Append(err, cErr)
-		}
-	}()
 	session := driver.NewSession(neo4j.SessionConfig{})
 	defer func() {
 		if sErr := session.Close(); sErr != nil {
@@ -122,31 +156,33 @@ func runDBPopulateWithSources(ctx context.Context, sources []string) (err error)
 	}()
 
 	if cfg.UpdateOnGitCommit {
+		res.Revisions = commits
+
 		log.Info("APP_UPDATE_ON_GIT_COMMIT set. Updating manifests only if git commit changed.")
 		dataInDB, err := dbpopulator.IsDataInDB(session, log, commits)
 		if err != nil {
-			return errors.Wrap(err, "while verifying commits in db")
+			return res, errors.Wrap(err, "while verifying commits in db")
 		}
 		if dataInDB {
-			return nil
+			return res, nil
 		}
 	} else {
 		log.Info("APP_UPDATE_ON_GIT_COMMIT not set. Ignoring git commit, always updating manifests.")
 	}
 
-	err = runDBPopulate(ctx, cfg, session, log, sourcesInfo)
+	res.Populated, err = runDBPopulate(ctx, cfg, driver, log, sourcesInfo, retryOpts...)
 	if err != nil {
-		return errors.Wrap(err, "while populating db")
+		return res, errors.Wrap(err, "while populating db")
 	}
 
 	if cfg.UpdateOnGitCommit {
 		err = dbpopulator.SaveCommitsMetadata(session, commits)
 		if err != nil {
-			return errors.Wrap(err, "while saving metadata into db")
+			return res, errors.Wrap(err, "while saving metadata into db")
 		}
 	}
 
-	return nil
+	return res, nil
 }
 
 func removeDuplicateSources(sources []string) []string {
@@ -163,8 +199,22 @@ func removeDuplicateSources(sources []string) []string {
 }
 
 func getSourcesInfo(ctx context.Context, cfg dbpopulator.Config, log *zap.Logger, sources []string, parent string) ([]dbpopulator.SourceInfo, error) {
+	var trustedKeys manifest.TrustedKeys
+	if cfg.TrustedKeysDir != "" {
+		keys, err := manifest.LoadTrustedKeys(cfg.TrustedKeysDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "while loading trusted keys")
+		}
+		trustedKeys = keys
+	}
+
 	var sourcesInfo []dbpopulator.SourceInfo
 	for _, source := range sources {
+		digest, hasDigest, err := sourceDigest(source)
+		if err != nil {
+			return nil, errors.Wrap(err, "while validating source")
+		}
+
 		dstDir, err := getDestDir(parent, source)
 		if err != nil {
 			return nil, errors.Wrap(err, "while getting a destination directory")
@@ -182,21 +232,32 @@ func getSourcesInfo(ctx context.Context, cfg dbpopulator.Config, log *zap.Logger
 			return nil, errors.Wrap(err, "while loading manifests")
 		}
 
-		if len(files) == 0 {
-			return nil, fmt.Errorf("empty list of files for source %s", source)
-		}
-
 		newSourceInfo := dbpopulator.SourceInfo{
 			Files:   files,
 			RootDir: rootDir,
 		}
 
-		if cfg.UpdateOnGitCommit {
-			gitHash, err := getGitHash(rootDir)
+		if trustedKeys != nil {
+			log.Info("Verifying manifest signatures...", zap.String("source", trimSource(source)), zap.String("policy", string(cfg.UnsignedManifestsPolicy)))
+			newSourceInfo, err = dbpopulator.VerifySignatures(log, newSourceInfo, trustedKeys, cfg.UnsignedManifestsPolicy)
 			if err != nil {
-				return nil, errors.Wrap(err, "while getting `git rev-parse HEAD`")
+				return nil, errors.Wrap(err, "while verifying manifest signatures")
+			}
+		}
+
+		if len(newSourceInfo.Files) == 0 {
+			return nil, fmt.Errorf("empty list of files for source %s", source)
+		}
+
+		if cfg.UpdateOnGitCommit {
+			revision := []byte(digest)
+			if !hasDigest {
+				revision, err = getGitHash(rootDir)
+				if err != nil {
+					return nil, errors.Wrap(err, "while getting `git rev-parse HEAD`")
+				}
 			}
-			newSourceInfo.GitHash = gitHash
+			newSourceInfo.Revision = revision
 		}
 
 		sourcesInfo = append(sourcesInfo, newSourceInfo)
@@ -226,13 +287,56 @@ func trimSource(source string) string {
 	toEscape := source
 	filterPaths := []filterPath{
 		trimSSHKey,
+		trimUserInfo,
 	}
 	for _, filterPath := range filterPaths {
-		toEscape = filterPath(source)
+		toEscape = filterPath(toEscape)
 	}
 	return toEscape
 }
 
+// sourceDigest returns the digest of a source, which is pulled by digest or verified with a checksum.
+// HTTP sources without checksum are rejected. For other sources, it returns false.
+func sourceDigest(source string) (string, bool, error) {
+	forcedGetter, src := splitForcedGetter(source)
+	u, err := url.Parse(src)
+	if err != nil {
+		// not a URL, e.g. a local path
+		return "", false, nil
+	}
+
+	scheme := u.Scheme
+	if forcedGetter != "" {
+		scheme = forcedGetter
+	}
+
+	switch scheme {
+	case "oci":
+		ref, err := getter.ParseOCIReference(u)
+		if err != nil {
+			return "", false, err
+		}
+		return ref.Digest, true, nil
+	case "http", "https":
+		checksum := u.Query().Get("checksum")
+		if checksum == "" {
+			return "", false, fmt.Errorf("HTTP source %q must have the checksum query parameter, e.g. ?checksum=sha256:{DIGEST}", u.Redacted())
+		}
+		return checksum, true, nil
+	default:
+		return "", false, nil
+	}
+}
+
+// splitForcedGetter splits the go-getter source with the forced getter, such as `git::https://github.com/capactio/hub-manifests`.
+func splitForcedGetter(source string) (string, string) {
+	idx := strings.Index(source, "::")
+	if idx == -1 {
+		return "", source
+	}
+	return source[:idx], source[idx+2:]
+}
+
 func createTempDirName(suffix string) (string, error) {
 	randBytes := make([]byte, 16)
 	_, err := rand.Read(randBytes)
@@ -258,15 +362,30 @@ func trimSSHKey(s string) string {
 	return reg.ReplaceAllString(s, "${1}")
 }
 
+// trimUserInfo trims credentials from URL sources, e.g. OCI registry basic auth.
+func trimUserInfo(s string) string {
+	forcedGetter, src := splitForcedGetter(s)
+	u, err := url.Parse(src)
+	if err != nil || u.User == nil {
+		return s
+	}
+
+	u.User = nil
+	if forcedGetter != "" {
+		return forcedGetter + "::" + u.String()
+	}
+	return u.String()
+}
+
 func trimRootDir(s string, rootDir string) string {
 	return strings.TrimPrefix(s, rootDir)[1:]
 }
 
-func runDBPopulate(ctx context.Context, cfg dbpopulator.Config, session neo4j.Session, log *zap.Logger, sources []dbpopulator.SourceInfo) (err error) {
+func runDBPopulate(ctx context.Context, cfg dbpopulator.Config, driver neo4j.Driver, log *zap.Logger, sources []dbpopulator.SourceInfo, retryOpts ...retry.Option) (populated bool, err error) {
 	start := time.Now()
 	err = retry.Do(func() error {
-		populated, err := dbpopulator.Populate(
-			ctx, log, session, sources, fmt.Sprintf("%s:%d", cfg.JSONPublishAddr, cfg.JSONPublishPort))
+		populated, err = dbpopulator.Populate(
+			ctx, log, driver, sources, fmt.Sprintf("%s:%d", cfg.JSONPublishAddr, cfg.JSONPublishPort))
 		if err != nil {
 			log.Error("Cannot populate a new data", zap.String("error", err.Error()))
 			return err
@@ -276,12 +395,12 @@ func runDBPopulate(ctx context.Context, cfg dbpopulator.Config, session neo4j.Se
 			log.Info("Populated new data", zap.Duration("duration (seconds)", end.Sub(start)))
 		}
 		return nil
-	}, retry.Attempts(6), retry.Delay(30*time.Second))
+	}, retryOpts...)
 	if err != nil {
-		return errors.Wrap(err, "while populating manifests")
+		return false, errors.Wrap(err, "while populating manifests")
 	}
 
-	return nil
+	return populated, nil
 }
 
 // git is used directly because it's already required by go-getter
@@ -0,0 +1,89 @@
+package register
+
+import (
+	"context"
+	"fmt"
+
+	"capact.io/capact/internal/cli/heredoc"
+	"capact.io/capact/internal/multierror"
+	"capact.io/capact/pkg/httputil"
+	"capact.io/capact/pkg/sdk/dbpopulator"
+	"github.com/avast/retry-go"
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+// NewServe returns a cobra.Command for populating manifests into a Neo4j database periodically.
+func NewServe(cliName string) *cobra.Command {
+	var sources []string
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Periodically populates manifests from sources into Neo4j database",
+		Long: heredoc.Doc(`
+			Runs populator as a long-running process, which synchronizes manifests from sources periodically.
+			Synchronization can be also triggered with the POST request to the /webhook endpoint, e.g. from a Git repository webhook.
+			The /webhook endpoint is enabled only if the APP_SYNC_WEBHOOK_SECRET environment variable is set.
+			Failed synchronizations are retried with an exponential backoff.
+
+			The synchronization status is exposed on the /status endpoint, and as Prometheus metrics on the /metrics endpoint.`),
+		Example: heredoc.WithCLIName(`
+			# Synchronize manifests every 5 minutes
+			APP_JSON_PUBLISH_ADDR=http://{HOST_IP} APP_SYNC_INTERVAL=5m <cli> serve --source github.com/capactio/hub-manifests?ref=main
+
+			# Trigger synchronization from a webhook signed with a secret
+			APP_JSON_PUBLISH_ADDR=http://{HOST_IP} APP_SYNC_WEBHOOK_SECRET={SECRET} <cli> serve --source github.com/capactio/hub-manifests?ref=main
+			curl -X POST -H "Authorization: Bearer {SECRET}" http://localhost:8082/webhook
+		`, cliName),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(signals.SetupSignalHandler(), sources)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringSliceVar(&sources, "source", []string{}, "Manifests location")
+	return cmd
+}
+
+func runServe(ctx context.Context, sources []string) (err error) {
+	cfg, log, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	sources = removeDuplicateSources(sources)
+	if len(sources) == 0 {
+		return fmt.Errorf("no source information provided")
+	}
+
+	driver, err := neo4j.NewDriver(cfg.Neo4jAddr, neo4j.BasicAuth(cfg.Neo4jUser, cfg.Neo4jPassword, ""))
+	if err != nil {
+		return errors.Wrap(err, "while connecting to Neo4j db")
+	}
+	defer func() {
+		if cErr := driver.Close(); cErr != nil {
+			err = multierror.Append(err, cErr)
+		}
+	}()
+
+	jsonHandler := dbpopulator.NewJSONHandler(nil)
+	syncer := dbpopulator.NewSyncer(log, cfg.Sync, func(ctx context.Context) (dbpopulator.SyncResult, error) {
+		// failures are retried by the syncer with backoff
+		return syncSources(ctx, cfg, log, driver, jsonHandler, sources, retry.Attempts(1))
+	})
+
+	jsonServer := httputil.NewStartableServer(log.With(zap.String("server", "json")), fmt.Sprintf(":%d", cfg.JSONPublishPort), jsonHandler)
+	statusServer := httputil.NewStartableServer(log.With(zap.String("server", "status")), cfg.Sync.StatusAddr, syncer.Handler())
+
+	log.Info("Starting populator in the serve mode", zap.Duration("interval", cfg.Sync.Interval), zap.String("status address", cfg.Sync.StatusAddr), zap.Bool("webhook enabled", syncer.WebhookEnabled()))
+
+	parallel, ctx := errgroup.WithContext(ctx)
+	parallel.Go(func() error { return jsonServer.Start(ctx) })
+	parallel.Go(func() error { return statusServer.Start(ctx) })
+	parallel.Go(func() error { return syncer.Run(ctx) })
+
+	return parallel.Wait()
+}
@@ -8,6 +8,7 @@ import (
 	"capact.io/capact/internal/logger"
 	secret_storage_backend "capact.io/capact/internal/secret-storage-backend"
 	"capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	storagebackend "capact.io/capact/pkg/hub/storage-backend"
 	"github.com/pkg/errors"
 	tellerpkg "github.com/spectralops/teller/pkg"
 	tellercore "github.com/spectralops/teller/pkg/core"
@@ -65,6 +66,7 @@ func main() {
 
 	srv := grpc.NewServer()
 	storage_backend.RegisterValueAndContextStorageBackendServer(srv, handler)
+	storage_backend.RegisterStorageBackendInfoServer(srv, storagebackend.NewInfoServer(secret_storage_backend.Info(), handler.Ping))
 
 	go func() {
 		<-ctx.Done()
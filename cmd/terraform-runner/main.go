@@ -3,11 +3,14 @@ package main
 import (
 	"log"
 
+	k8sstatusreporter "capact.io/capact/internal/k8s-engine/status-reporter"
 	"capact.io/capact/pkg/runner"
 	statusreporter "capact.io/capact/pkg/runner/status-reporter"
 	"capact.io/capact/pkg/runner/terraform"
 
 	"github.com/vrischmann/envconfig"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 )
 
@@ -20,7 +23,15 @@ func main() {
 
 	terraformRunner := terraform.NewTerraformRunner(cfg)
 
-	statusReporter := statusreporter.NewNoop()
+	var statusReporter runner.StatusReporter = statusreporter.NewNoop()
+
+	// the progress is reported to the Action only when running as the Action workflow step
+	if k8sCfg, err := rest.InClusterConfig(); err == nil {
+		k8sCli, err := client.New(k8sCfg, client.Options{})
+		exitOnError(err, "while creating K8s client")
+
+		statusReporter = k8sstatusreporter.NewK8sSecretProgress(k8sCli)
+	}
 
 	// create and run manager
 	mgr, err := runner.NewManager(terraformRunner, statusReporter)
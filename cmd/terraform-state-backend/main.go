@@ -0,0 +1,105 @@
+package main
+
+import (
+	"log"
+	"net"
+
+	"github.com/vrischmann/envconfig"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+
+	"capact.io/capact/internal/healthz"
+	"capact.io/capact/internal/logger"
+	tf_state_backend "capact.io/capact/internal/terraform-state-backend"
+	"capact.io/capact/pkg/httputil"
+	"capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	storagebackend "capact.io/capact/pkg/hub/storage-backend"
+)
+
+// Config holds application related configuration.
+type Config struct {
+	// GRPCAddr is the TCP address the gRPC server binds to.
+	GRPCAddr string `envconfig:"default=:50051"`
+
+	// HTTPAddr is the TCP address the Terraform HTTP backend server binds to.
+	HTTPAddr string `envconfig:"default=:8080"`
+
+	// HTTPBackendURL is the URL under which the Terraform HTTP backend server is accessible from runners.
+	HTTPBackendURL string `envconfig:"default=http://capact-terraform-state-backend.capact-system:8080"`
+
+	// HealthzAddr is the TCP address the health probes endpoint binds to.
+	HealthzAddr string `envconfig:"default=:8082"`
+
+	// Namespace is the Kubernetes Namespace where the Terraform states are stored.
+	Namespace string `envconfig:"default=capact-system"`
+
+	Logger logger.Config
+}
+
+const appName = "terraform-state-backend"
+
+func main() {
+	var cfg Config
+	err := envconfig.InitWithPrefix(&cfg, "APP")
+	exitOnError(err, "while loading configuration")
+
+	ctx := signals.SetupSignalHandler()
+
+	// setup logger
+	unnamedLogger, err := logger.New(cfg.Logger)
+	exitOnError(err, "while creating zap logger")
+
+	logger := unnamedLogger.Named(appName)
+
+	k8sCfg, err := config.GetConfig()
+	exitOnError(err, "while getting K8s config")
+
+	k8sCli, err := client.New(k8sCfg, client.Options{})
+	exitOnError(err, "while creating K8s client")
+
+	store := tf_state_backend.NewSecretStore(k8sCli, cfg.Namespace)
+
+	// setup servers
+	parallelServers := new(errgroup.Group)
+
+	healthzServer := healthz.NewHTTPServer(logger, cfg.HealthzAddr, appName)
+	parallelServers.Go(func() error { return healthzServer.Start(ctx) })
+
+	httpBackend := tf_state_backend.NewHTTPBackend(logger.Named("http-backend"), store)
+	httpServer := httputil.NewStartableServer(logger.With(zap.String("server", "http-backend")), cfg.HTTPAddr, httpBackend.Handler())
+	parallelServers.Go(func() error { return httpServer.Start(ctx) })
+
+	handler := tf_state_backend.NewHandler(logger, store, cfg.HTTPBackendURL)
+
+	listenCfg := net.ListenConfig{}
+	listener, err := listenCfg.Listen(ctx, "tcp", cfg.GRPCAddr)
+	exitOnError(err, "while listening")
+
+	srv := grpc.NewServer()
+	storage_backend.RegisterContextStorageBackendServer(srv, handler)
+	storage_backend.RegisterStorageBackendInfoServer(srv, storagebackend.NewInfoServer(tf_state_backend.Info(), store.Ping))
+
+	go func() {
+		<-ctx.Done()
+		logger.Info("Stopping server gracefully")
+		srv.GracefulStop()
+	}()
+
+	parallelServers.Go(func() error {
+		logger.Info("Starting TCP server", zap.String("addr", cfg.GRPCAddr))
+		return srv.Serve(listener)
+	})
+
+	err = parallelServers.Wait()
+	exitOnError(err, "while waiting for servers to finish gracefully")
+}
+
+func exitOnError(err error, context string) {
+	if err != nil {
+		log.Fatalf("%s: %v", context, err)
+	}
+}
@@ -81,9 +81,13 @@ Create the manifest source paths separated by the comma
 Create the manifest source path
 */}}
 {{- define "populator.manifestSource" -}}
+{{- if .source -}}
+{{- .source -}}
+{{- else -}}
 {{- .repository -}}
 ?ref={{ .branch -}}
 {{- if .sshKey -}}
 &sshkey={{ .sshKey -}}
 {{- end }}
 {{- end }}
+{{- end }}
@@ -49,3 +49,10 @@ Selector labels
 app.kubernetes.io/name: {{ include "helm-storage-backend.name" . }}
 app.kubernetes.io/instance: {{ .Release.Name }}
 {{- end }}
+
+{{/*
+Name of the Secret with the Gateway credentials
+*/}}
+{{- define "helm-storage-backend.gatewaySecretName" -}}
+{{- .Values.gateway.auth.existingSecret | default (printf "%s-gateway" (include "helm-storage-backend.fullname" .)) }}
+{{- end }}
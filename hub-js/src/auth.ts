@@ -0,0 +1,74 @@
+import { NextFunction, Request, RequestHandler, Response } from "express";
+import { timingSafeEqual } from "crypto";
+import { ServerError, ServerMiddleware, Status } from "nice-grpc";
+
+// Returns a middleware, which rejects requests without the expected
+// basic access authentication credentials.
+export function basicAuth(username: string, password: string): RequestHandler {
+  return (req: Request, res: Response, next: NextFunction) => {
+    const err = checkBasicAuth(req.headers.authorization, username, password);
+    if (err) {
+      reject(res, err);
+      return;
+    }
+
+    next();
+  };
+}
+
+// Returns a gRPC server middleware, which rejects calls without the expected
+// basic access authentication credentials in the "authorization" metadata.
+export function grpcBasicAuth(
+  username: string,
+  password: string
+): ServerMiddleware {
+  return async function* (call, context) {
+    const err = checkBasicAuth(
+      context.metadata.get("authorization"),
+      username,
+      password
+    );
+    if (err) {
+      throw new ServerError(Status.UNAUTHENTICATED, err);
+    }
+
+    return yield* call.next(call.request, context);
+  };
+}
+
+// Returns an error message if a given authorization header doesn't contain the expected credentials.
+function checkBasicAuth(
+  header: string | undefined,
+  username: string,
+  password: string
+): string | undefined {
+  const [scheme, encoded] = (header || "").split(" ", 2);
+  if (scheme !== "Basic" || !encoded) {
+    return "missing credentials";
+  }
+
+  const decoded = Buffer.from(encoded, "base64").toString();
+  const separatorIdx = decoded.indexOf(":");
+  if (
+    separatorIdx < 0 ||
+    !safeEqual(decoded.slice(0, separatorIdx), username) ||
+    !safeEqual(decoded.slice(separatorIdx + 1), password)
+  ) {
+    return "wrong credentials";
+  }
+
+  return undefined;
+}
+
+function safeEqual(actual: string, expected: string): boolean {
+  const actualBuf = Buffer.from(actual);
+  const expectedBuf = Buffer.from(expected);
+  if (actualBuf.length !== expectedBuf.length) {
+    return false;
+  }
+  return timingSafeEqual(actualBuf, expectedBuf);
+}
+
+function reject(res: Response, message: string) {
+  res.status(401).json({ errors: [{ message }] });
+}
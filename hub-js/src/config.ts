@@ -2,9 +2,17 @@ if (process.env.APP_NEO4J_PASSWORD === undefined) {
   throw new Error("APP_NEO4J_PASSWORD not defined");
 }
 
+const authRequired = process.env.APP_AUTH_REQUIRED === "true";
+if (authRequired && process.env.APP_AUTH_PASSWORD === undefined) {
+  throw new Error("APP_AUTH_PASSWORD not defined");
+}
+
 const graphqlBindAddress = process.env.APP_GRAPH_QL_ADDR || ":8080";
 const [graphQLAddr, graphQLPort] = graphqlBindAddress.split(":", 2);
 
+const grpcBindAddress = process.env.APP_GRPC_ADDR || ":50052";
+const [grpcAddr, grpcPort] = grpcBindAddress.split(":", 2);
+
 export const BUILTIN_STORAGE_BACKEND_ID =
   "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa";
 
@@ -18,6 +26,10 @@ export const config = {
     bindAddress: graphQLAddr,
     bindPort: Number(graphQLPort),
   },
+  grpc: {
+    bindAddress: grpcAddr || "0.0.0.0",
+    bindPort: Number(grpcPort),
+  },
   hubMode: process.env.APP_HUB_MODE || "public",
   logger: {
     level: process.env.APP_LOGGER_LEVEL || "info",
@@ -25,4 +37,9 @@ export const config = {
   express: {
     bodySizeLimit: process.env.APP_EXPRESS_BODY_SIZE_LIMIT || "32mb",
   },
+  auth: {
+    required: authRequired,
+    username: process.env.APP_AUTH_USERNAME || "graphql",
+    password: process.env.APP_AUTH_PASSWORD || "",
+  },
 };
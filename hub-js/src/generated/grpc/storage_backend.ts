@@ -114,6 +114,28 @@ export interface OnUnlockRequest {
 
 export interface OnUnlockResponse {}
 
+export interface GetInfoRequest {}
+
+export interface SupportedOperations {
+  lock: boolean;
+  revisions: boolean;
+  preCreateValue: boolean;
+}
+
+export interface GetInfoResponse {
+  version: string;
+  acceptValue: boolean;
+  contextSchema?: Uint8Array | undefined;
+  supportedOperations: SupportedOperations | undefined;
+}
+
+export interface CheckHealthRequest {}
+
+export interface CheckHealthResponse {
+  healthy: boolean;
+  message?: string | undefined;
+}
+
 function createBaseGetPreCreateValueRequest(): GetPreCreateValueRequest {
   return { context: new Uint8Array() };
 }
@@ -1718,6 +1740,332 @@ export const OnUnlockResponse = {
   },
 };
 
+function createBaseGetInfoRequest(): GetInfoRequest {
+  return {};
+}
+
+export const GetInfoRequest = {
+  encode(
+    _: GetInfoRequest,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetInfoRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetInfoRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(_: any): GetInfoRequest {
+    return {};
+  },
+
+  toJSON(_: GetInfoRequest): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  fromPartial(_: DeepPartial<GetInfoRequest>): GetInfoRequest {
+    const message = createBaseGetInfoRequest();
+    return message;
+  },
+};
+
+function createBaseSupportedOperations(): SupportedOperations {
+  return { lock: false, revisions: false, preCreateValue: false };
+}
+
+export const SupportedOperations = {
+  encode(
+    message: SupportedOperations,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    if (message.lock === true) {
+      writer.uint32(8).bool(message.lock);
+    }
+    if (message.revisions === true) {
+      writer.uint32(16).bool(message.revisions);
+    }
+    if (message.preCreateValue === true) {
+      writer.uint32(24).bool(message.preCreateValue);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SupportedOperations {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSupportedOperations();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.lock = reader.bool();
+          break;
+        case 2:
+          message.revisions = reader.bool();
+          break;
+        case 3:
+          message.preCreateValue = reader.bool();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SupportedOperations {
+    return {
+      lock: isSet(object.lock) ? Boolean(object.lock) : false,
+      revisions: isSet(object.revisions) ? Boolean(object.revisions) : false,
+      preCreateValue: isSet(object.preCreateValue)
+        ? Boolean(object.preCreateValue)
+        : false,
+    };
+  },
+
+  toJSON(message: SupportedOperations): unknown {
+    const obj: any = {};
+    message.lock !== undefined && (obj.lock = message.lock);
+    message.revisions !== undefined && (obj.revisions = message.revisions);
+    message.preCreateValue !== undefined &&
+      (obj.preCreateValue = message.preCreateValue);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<SupportedOperations>): SupportedOperations {
+    const message = createBaseSupportedOperations();
+    message.lock = object.lock ?? false;
+    message.revisions = object.revisions ?? false;
+    message.preCreateValue = object.preCreateValue ?? false;
+    return message;
+  },
+};
+
+function createBaseGetInfoResponse(): GetInfoResponse {
+  return {
+    version: "",
+    acceptValue: false,
+    contextSchema: undefined,
+    supportedOperations: undefined,
+  };
+}
+
+export const GetInfoResponse = {
+  encode(
+    message: GetInfoResponse,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    if (message.version !== "") {
+      writer.uint32(10).string(message.version);
+    }
+    if (message.acceptValue === true) {
+      writer.uint32(16).bool(message.acceptValue);
+    }
+    if (message.contextSchema !== undefined) {
+      writer.uint32(26).bytes(message.contextSchema);
+    }
+    if (message.supportedOperations !== undefined) {
+      SupportedOperations.encode(
+        message.supportedOperations,
+        writer.uint32(34).fork()
+      ).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetInfoResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetInfoResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.version = reader.string();
+          break;
+        case 2:
+          message.acceptValue = reader.bool();
+          break;
+        case 3:
+          message.contextSchema = reader.bytes();
+          break;
+        case 4:
+          message.supportedOperations = SupportedOperations.decode(
+            reader,
+            reader.uint32()
+          );
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetInfoResponse {
+    return {
+      version: isSet(object.version) ? String(object.version) : "",
+      acceptValue: isSet(object.acceptValue)
+        ? Boolean(object.acceptValue)
+        : false,
+      contextSchema: isSet(object.contextSchema)
+        ? bytesFromBase64(object.contextSchema)
+        : undefined,
+      supportedOperations: isSet(object.supportedOperations)
+        ? SupportedOperations.fromJSON(object.supportedOperations)
+        : undefined,
+    };
+  },
+
+  toJSON(message: GetInfoResponse): unknown {
+    const obj: any = {};
+    message.version !== undefined && (obj.version = message.version);
+    message.acceptValue !== undefined &&
+      (obj.acceptValue = message.acceptValue);
+    message.contextSchema !== undefined &&
+      (obj.contextSchema =
+        message.contextSchema !== undefined
+          ? base64FromBytes(message.contextSchema)
+          : undefined);
+    message.supportedOperations !== undefined &&
+      (obj.supportedOperations = message.supportedOperations
+        ? SupportedOperations.toJSON(message.supportedOperations)
+        : undefined);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<GetInfoResponse>): GetInfoResponse {
+    const message = createBaseGetInfoResponse();
+    message.version = object.version ?? "";
+    message.acceptValue = object.acceptValue ?? false;
+    message.contextSchema = object.contextSchema ?? undefined;
+    message.supportedOperations =
+      object.supportedOperations !== undefined &&
+      object.supportedOperations !== null
+        ? SupportedOperations.fromPartial(object.supportedOperations)
+        : undefined;
+    return message;
+  },
+};
+
+function createBaseCheckHealthRequest(): CheckHealthRequest {
+  return {};
+}
+
+export const CheckHealthRequest = {
+  encode(
+    _: CheckHealthRequest,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CheckHealthRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCheckHealthRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(_: any): CheckHealthRequest {
+    return {};
+  },
+
+  toJSON(_: CheckHealthRequest): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  fromPartial(_: DeepPartial<CheckHealthRequest>): CheckHealthRequest {
+    const message = createBaseCheckHealthRequest();
+    return message;
+  },
+};
+
+function createBaseCheckHealthResponse(): CheckHealthResponse {
+  return { healthy: false, message: undefined };
+}
+
+export const CheckHealthResponse = {
+  encode(
+    message: CheckHealthResponse,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    if (message.healthy === true) {
+      writer.uint32(8).bool(message.healthy);
+    }
+    if (message.message !== undefined) {
+      writer.uint32(18).string(message.message);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CheckHealthResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCheckHealthResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.healthy = reader.bool();
+          break;
+        case 2:
+          message.message = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CheckHealthResponse {
+    return {
+      healthy: isSet(object.healthy) ? Boolean(object.healthy) : false,
+      message: isSet(object.message) ? String(object.message) : undefined,
+    };
+  },
+
+  toJSON(message: CheckHealthResponse): unknown {
+    const obj: any = {};
+    message.healthy !== undefined && (obj.healthy = message.healthy);
+    message.message !== undefined && (obj.message = message.message);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<CheckHealthResponse>): CheckHealthResponse {
+    const message = createBaseCheckHealthResponse();
+    message.healthy = object.healthy ?? false;
+    message.message = object.message ?? undefined;
+    return message;
+  },
+};
+
 /**
  * ValueAndContextStorageBackend handles the full lifecycle of the TypeInstance.
  * TypeInstance value is always provided as a part of request. Context may be provided but it is not required.
@@ -1877,6 +2225,33 @@ export const ContextStorageBackendDefinition = {
   },
 } as const;
 
+/**
+ * StorageBackendInfo describes the storage backend capabilities and reports its health.
+ * It is optional for storage backends, but allows detecting misconfigured backends before they are used.
+ */
+export const StorageBackendInfoDefinition = {
+  name: "StorageBackendInfo",
+  fullName: "storage_backend.StorageBackendInfo",
+  methods: {
+    getInfo: {
+      name: "GetInfo",
+      requestType: GetInfoRequest,
+      requestStream: false,
+      responseType: GetInfoResponse,
+      responseStream: false,
+      options: {},
+    },
+    checkHealth: {
+      name: "CheckHealth",
+      requestType: CheckHealthRequest,
+      requestStream: false,
+      responseType: CheckHealthResponse,
+      responseStream: false,
+      options: {},
+    },
+  },
+} as const;
+
 declare var self: any | undefined;
 declare var window: any | undefined;
 declare var global: any | undefined;
@@ -0,0 +1,463 @@
+/* eslint-disable */
+import Long from "long";
+import _m0 from "protobufjs/minimal";
+
+export const protobufPackage = "type_instance_events";
+
+export enum EventType {
+  UNSPECIFIED = 0,
+  CREATED = 1,
+  UPDATED = 2,
+  LOCKED = 3,
+  UNLOCKED = 4,
+  DELETED = 5,
+  UNRECOGNIZED = -1,
+}
+
+export function eventTypeFromJSON(object: any): EventType {
+  switch (object) {
+    case 0:
+    case "UNSPECIFIED":
+      return EventType.UNSPECIFIED;
+    case 1:
+    case "CREATED":
+      return EventType.CREATED;
+    case 2:
+    case "UPDATED":
+      return EventType.UPDATED;
+    case 3:
+    case "LOCKED":
+      return EventType.LOCKED;
+    case 4:
+    case "UNLOCKED":
+      return EventType.UNLOCKED;
+    case 5:
+    case "DELETED":
+      return EventType.DELETED;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return EventType.UNRECOGNIZED;
+  }
+}
+
+export function eventTypeToJSON(object: EventType): string {
+  switch (object) {
+    case EventType.UNSPECIFIED:
+      return "UNSPECIFIED";
+    case EventType.CREATED:
+      return "CREATED";
+    case EventType.UPDATED:
+      return "UPDATED";
+    case EventType.LOCKED:
+      return "LOCKED";
+    case EventType.UNLOCKED:
+      return "UNLOCKED";
+    case EventType.DELETED:
+      return "DELETED";
+    case EventType.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export interface TypeInstanceEvent {
+  type: EventType;
+  typeInstanceId: string;
+  typeRefPath: string;
+  typeRefRevision: string;
+  resourceVersion: number;
+  lockedBy?: string | undefined;
+  /** Position of the event in the Hub event log. It is 0 for events emitted for the observed resource versions. */
+  sequence: number;
+}
+
+export interface ObservedResourceVersion {
+  typeInstanceId: string;
+  resourceVersion: number;
+}
+
+export interface WatchRequest {
+  /** Watch only TypeInstances with given IDs. If empty, all TypeInstances are watched. */
+  typeInstanceIds: string[];
+  /** Watch only TypeInstances of a given Type. */
+  typeRefPath?: string | undefined;
+  /**
+   * Resource versions already observed by the client. Newer resource versions and deletions
+   * of such TypeInstances are emitted before any live event, so the client can resume watching.
+   */
+  observed: ObservedResourceVersion[];
+  /**
+   * Sequence of the last event received by the client. If set, events stored after it are emitted
+   * before any live event, and the observed resource versions are ignored.
+   * If such events were already pruned, the call fails with the OUT_OF_RANGE status.
+   */
+  sinceSequence: number;
+}
+
+function createBaseTypeInstanceEvent(): TypeInstanceEvent {
+  return {
+    type: 0,
+    typeInstanceId: "",
+    typeRefPath: "",
+    typeRefRevision: "",
+    resourceVersion: 0,
+    lockedBy: undefined,
+    sequence: 0,
+  };
+}
+
+export const TypeInstanceEvent = {
+  encode(
+    message: TypeInstanceEvent,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    if (message.type !== 0) {
+      writer.uint32(8).int32(message.type);
+    }
+    if (message.typeInstanceId !== "") {
+      writer.uint32(18).string(message.typeInstanceId);
+    }
+    if (message.typeRefPath !== "") {
+      writer.uint32(26).string(message.typeRefPath);
+    }
+    if (message.typeRefRevision !== "") {
+      writer.uint32(34).string(message.typeRefRevision);
+    }
+    if (message.resourceVersion !== 0) {
+      writer.uint32(40).uint32(message.resourceVersion);
+    }
+    if (message.lockedBy !== undefined) {
+      writer.uint32(50).string(message.lockedBy);
+    }
+    if (message.sequence !== 0) {
+      writer.uint32(56).uint64(message.sequence);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): TypeInstanceEvent {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTypeInstanceEvent();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.type = reader.int32() as any;
+          break;
+        case 2:
+          message.typeInstanceId = reader.string();
+          break;
+        case 3:
+          message.typeRefPath = reader.string();
+          break;
+        case 4:
+          message.typeRefRevision = reader.string();
+          break;
+        case 5:
+          message.resourceVersion = reader.uint32();
+          break;
+        case 6:
+          message.lockedBy = reader.string();
+          break;
+        case 7:
+          message.sequence = longToNumber(reader.uint64() as Long);
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): TypeInstanceEvent {
+    return {
+      type: isSet(object.type) ? eventTypeFromJSON(object.type) : 0,
+      typeInstanceId: isSet(object.typeInstanceId)
+        ? String(object.typeInstanceId)
+        : "",
+      typeRefPath: isSet(object.typeRefPath) ? String(object.typeRefPath) : "",
+      typeRefRevision: isSet(object.typeRefRevision)
+        ? String(object.typeRefRevision)
+        : "",
+      resourceVersion: isSet(object.resourceVersion)
+        ? Number(object.resourceVersion)
+        : 0,
+      lockedBy: isSet(object.lockedBy) ? String(object.lockedBy) : undefined,
+      sequence: isSet(object.sequence) ? Number(object.sequence) : 0,
+    };
+  },
+
+  toJSON(message: TypeInstanceEvent): unknown {
+    const obj: any = {};
+    message.type !== undefined && (obj.type = eventTypeToJSON(message.type));
+    message.typeInstanceId !== undefined &&
+      (obj.typeInstanceId = message.typeInstanceId);
+    message.typeRefPath !== undefined &&
+      (obj.typeRefPath = message.typeRefPath);
+    message.typeRefRevision !== undefined &&
+      (obj.typeRefRevision = message.typeRefRevision);
+    message.resourceVersion !== undefined &&
+      (obj.resourceVersion = Math.round(message.resourceVersion));
+    message.lockedBy !== undefined && (obj.lockedBy = message.lockedBy);
+    message.sequence !== undefined &&
+      (obj.sequence = Math.round(message.sequence));
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<TypeInstanceEvent>): TypeInstanceEvent {
+    const message = createBaseTypeInstanceEvent();
+    message.type = object.type ?? 0;
+    message.typeInstanceId = object.typeInstanceId ?? "";
+    message.typeRefPath = object.typeRefPath ?? "";
+    message.typeRefRevision = object.typeRefRevision ?? "";
+    message.resourceVersion = object.resourceVersion ?? 0;
+    message.lockedBy = object.lockedBy ?? undefined;
+    message.sequence = object.sequence ?? 0;
+    return message;
+  },
+};
+
+function createBaseObservedResourceVersion(): ObservedResourceVersion {
+  return { typeInstanceId: "", resourceVersion: 0 };
+}
+
+export const ObservedResourceVersion = {
+  encode(
+    message: ObservedResourceVersion,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    if (message.typeInstanceId !== "") {
+      writer.uint32(10).string(message.typeInstanceId);
+    }
+    if (message.resourceVersion !== 0) {
+      writer.uint32(16).uint32(message.resourceVersion);
+    }
+    return writer;
+  },
+
+  decode(
+    input: _m0.Reader | Uint8Array,
+    length?: number
+  ): ObservedResourceVersion {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseObservedResourceVersion();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.typeInstanceId = reader.string();
+          break;
+        case 2:
+          message.resourceVersion = reader.uint32();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ObservedResourceVersion {
+    return {
+      typeInstanceId: isSet(object.typeInstanceId)
+        ? String(object.typeInstanceId)
+        : "",
+      resourceVersion: isSet(object.resourceVersion)
+        ? Number(object.resourceVersion)
+        : 0,
+    };
+  },
+
+  toJSON(message: ObservedResourceVersion): unknown {
+    const obj: any = {};
+    message.typeInstanceId !== undefined &&
+      (obj.typeInstanceId = message.typeInstanceId);
+    message.resourceVersion !== undefined &&
+      (obj.resourceVersion = Math.round(message.resourceVersion));
+    return obj;
+  },
+
+  fromPartial(
+    object: DeepPartial<ObservedResourceVersion>
+  ): ObservedResourceVersion {
+    const message = createBaseObservedResourceVersion();
+    message.typeInstanceId = object.typeInstanceId ?? "";
+    message.resourceVersion = object.resourceVersion ?? 0;
+    return message;
+  },
+};
+
+function createBaseWatchRequest(): WatchRequest {
+  return {
+    typeInstanceIds: [],
+    typeRefPath: undefined,
+    observed: [],
+    sinceSequence: 0,
+  };
+}
+
+export const WatchRequest = {
+  encode(
+    message: WatchRequest,
+    writer: _m0.Writer = _m0.Writer.create()
+  ): _m0.Writer {
+    for (const v of message.typeInstanceIds) {
+      writer.uint32(10).string(v!);
+    }
+    if (message.typeRefPath !== undefined) {
+      writer.uint32(18).string(message.typeRefPath);
+    }
+    for (const v of message.observed) {
+      ObservedResourceVersion.encode(v!, writer.uint32(26).fork()).ldelim();
+    }
+    if (message.sinceSequence !== 0) {
+      writer.uint32(32).uint64(message.sinceSequence);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WatchRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWatchRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.typeInstanceIds.push(reader.string());
+          break;
+        case 2:
+          message.typeRefPath = reader.string();
+          break;
+        case 3:
+          message.observed.push(
+            ObservedResourceVersion.decode(reader, reader.uint32())
+          );
+          break;
+        case 4:
+          message.sinceSequence = longToNumber(reader.uint64() as Long);
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): WatchRequest {
+    return {
+      typeInstanceIds: Array.isArray(object?.typeInstanceIds)
+        ? object.typeInstanceIds.map((e: any) => String(e))
+        : [],
+      typeRefPath: isSet(object.typeRefPath)
+        ? String(object.typeRefPath)
+        : undefined,
+      observed: Array.isArray(object?.observed)
+        ? object.observed.map((e: any) => ObservedResourceVersion.fromJSON(e))
+        : [],
+      sinceSequence: isSet(object.sinceSequence)
+        ? Number(object.sinceSequence)
+        : 0,
+    };
+  },
+
+  toJSON(message: WatchRequest): unknown {
+    const obj: any = {};
+    if (message.typeInstanceIds) {
+      obj.typeInstanceIds = message.typeInstanceIds.map((e) => e);
+    } else {
+      obj.typeInstanceIds = [];
+    }
+    message.typeRefPath !== undefined &&
+      (obj.typeRefPath = message.typeRefPath);
+    if (message.observed) {
+      obj.observed = message.observed.map((e) =>
+        e ? ObservedResourceVersion.toJSON(e) : undefined
+      );
+    } else {
+      obj.observed = [];
+    }
+    message.sinceSequence !== undefined &&
+      (obj.sinceSequence = Math.round(message.sinceSequence));
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<WatchRequest>): WatchRequest {
+    const message = createBaseWatchRequest();
+    message.typeInstanceIds = object.typeInstanceIds?.map((e) => e) || [];
+    message.typeRefPath = object.typeRefPath ?? undefined;
+    message.observed =
+      object.observed?.map((e) => ObservedResourceVersion.fromPartial(e)) ||
+      [];
+    message.sinceSequence = object.sinceSequence ?? 0;
+    return message;
+  },
+};
+
+/** TypeInstanceEvents streams changes of TypeInstances stored in the Local Hub. */
+export const TypeInstanceEventsDefinition = {
+  name: "TypeInstanceEvents",
+  fullName: "type_instance_events.TypeInstanceEvents",
+  methods: {
+    watch: {
+      name: "Watch",
+      requestType: WatchRequest,
+      requestStream: false,
+      responseType: TypeInstanceEvent,
+      responseStream: true,
+      options: {},
+    },
+  },
+} as const;
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== "undefined") return globalThis;
+  if (typeof self !== "undefined") return self;
+  if (typeof window !== "undefined") return window;
+  if (typeof global !== "undefined") return global;
+  throw "Unable to locate global object";
+})();
+
+type Builtin =
+  | Date
+  | Function
+  | Uint8Array
+  | string
+  | number
+  | boolean
+  | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+function longToNumber(long: Long): number {
+  if (long.gt(Number.MAX_SAFE_INTEGER)) {
+    throw new globalThis.Error("Value is larger than Number.MAX_SAFE_INTEGER");
+  }
+  return long.toNumber();
+}
+
+if (_m0.util.Long !== Long) {
+  _m0.util.Long = Long as any;
+  _m0.configure();
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -8,12 +8,19 @@ import {
 } from "@godaddy/terminus";
 import * as http from "http";
 import { GraphQLSchema } from "graphql";
+import { createServer } from "nice-grpc";
 
 import { assertSchemaOnDatabase, getSchemaForMode, HubMode } from "./schema";
 import { config } from "./config";
 import { logger } from "./logger";
 import { ensureCoreStorageTypeInstance } from "./local/resolver/mutation/register-built-in-storage";
 import DelegatedStorageService from "./local/storage/service";
+import TypeInstanceEventBus from "./local/events/bus";
+import TypeInstanceEventPoller from "./local/events/poller";
+import { ensureEventLog } from "./local/events/log";
+import { typeInstanceEventsService } from "./local/events/service";
+import { TypeInstanceEventsDefinition } from "./generated/grpc/type_instance_events";
+import { basicAuth, grpcBasicAuth } from "./auth";
 
 async function main() {
   logger.info("Using Neo4j database", { endpoint: config.neo4j.endpoint });
@@ -48,6 +55,9 @@ async function main() {
     logger.info(
       "Successfully registered TypeInstance for core backend storage"
     );
+
+    await ensureEventLog(driver);
+    await startTypeInstanceEventsServer(driver);
   }
 
   server.listen(bindPort, bindAddress, () => {
@@ -76,6 +86,13 @@ async function setupHttpServer(
     },
   });
   await apolloServer.start();
+  if (config.auth.required) {
+    // Reject direct traffic, which does not come through the Gateway.
+    app.use(
+      apolloServer.graphqlPath,
+      basicAuth(config.auth.username, config.auth.password)
+    );
+  }
   apolloServer.applyMiddleware({ app });
 
   const server = http.createServer(app);
@@ -87,6 +104,30 @@ async function setupHttpServer(
   return server;
 }
 
+async function startTypeInstanceEventsServer(driver: Driver) {
+  const { bindPort, bindAddress } = config.grpc;
+
+  const events = new TypeInstanceEventBus();
+  await new TypeInstanceEventPoller(driver, events).start();
+
+  let server = createServer();
+  if (config.auth.required) {
+    // The events API is not exposed via the Gateway, so it checks the same credentials as the GraphQL API.
+    server = server.use(
+      grpcBasicAuth(config.auth.username, config.auth.password)
+    );
+  }
+  server.add(
+    TypeInstanceEventsDefinition,
+    typeInstanceEventsService(driver, events)
+  );
+  await server.listen(`${bindAddress}:${bindPort}`);
+
+  logger.info("TypeInstance events gRPC API is listening", {
+    endpoint: `${bindAddress}:${bindPort}`,
+  });
+}
+
 (async () => {
   await main();
 })();
@@ -0,0 +1,106 @@
+import { EventEmitter } from "events";
+import { TypeInstanceEvent } from "../../generated/grpc/type_instance_events";
+import { logger } from "../../logger";
+
+const typeInstanceEventName = "typeInstance";
+
+// Maximum number of events buffered for a single subscriber. Slower subscribers are disconnected,
+// so they can resume watching from the last received event sequence.
+const maxBufferedEvents = 1000;
+
+export type EventFilter = (event: TypeInstanceEvent) => boolean;
+
+export class SubscriptionOverflowError extends Error {
+  constructor() {
+    super(
+      `subscriber did not consume more than ${maxBufferedEvents} buffered events`
+    );
+    this.name = "SubscriptionOverflowError";
+  }
+}
+
+// TypeInstanceEventBus delivers TypeInstance events to subscribers connected to a given Hub instance.
+// Events are published by the TypeInstanceEventPoller, which reads them from the event log shared by all Hub replicas.
+export default class TypeInstanceEventBus {
+  private readonly emitter = new EventEmitter();
+
+  constructor() {
+    // Each watch stream registers a dedicated listener
+    this.emitter.setMaxListeners(0);
+  }
+
+  publish(...events: TypeInstanceEvent[]) {
+    for (const event of events) {
+      logger.debug("Publishing TypeInstance event", event);
+      this.emitter.emit(typeInstanceEventName, event);
+    }
+  }
+
+  // Subscription starts buffering events immediately, so no event published by this Hub instance
+  // is lost between the subscription and the iteration start.
+  subscribe(filter: EventFilter): Subscription {
+    return new Subscription(this.emitter, filter);
+  }
+}
+
+export class Subscription implements AsyncIterable<TypeInstanceEvent> {
+  private readonly queue: TypeInstanceEvent[] = [];
+  private readonly listener: (event: TypeInstanceEvent) => void;
+  private wake?: () => void;
+  private closed = false;
+  private overflow = false;
+
+  constructor(
+    private readonly emitter: EventEmitter,
+    filter: EventFilter
+  ) {
+    this.listener = (event: TypeInstanceEvent) => {
+      if (!filter(event)) {
+        return;
+      }
+      if (this.queue.length >= maxBufferedEvents) {
+        this.overflow = true;
+        this.close();
+        return;
+      }
+      this.queue.push(event);
+      this.notify();
+    };
+    this.emitter.on(typeInstanceEventName, this.listener);
+  }
+
+  close() {
+    if (this.closed) {
+      return;
+    }
+    this.closed = true;
+    this.emitter.off(typeInstanceEventName, this.listener);
+    this.notify();
+  }
+
+  async *[Symbol.asyncIterator](): AsyncIterator<TypeInstanceEvent> {
+    for (;;) {
+      if (this.overflow) {
+        throw new SubscriptionOverflowError();
+      }
+
+      const event = this.queue.shift();
+      if (event) {
+        yield event;
+        continue;
+      }
+
+      if (this.closed) {
+        return;
+      }
+
+      await new Promise<void>((resolve) => (this.wake = resolve));
+    }
+  }
+
+  private notify() {
+    const wake = this.wake;
+    this.wake = undefined;
+    wake?.();
+  }
+}
@@ -0,0 +1,174 @@
+import neo4j, { Driver, Transaction } from "neo4j-driver";
+import {
+  EventType,
+  eventTypeFromJSON,
+  eventTypeToJSON,
+  TypeInstanceEvent,
+} from "../../generated/grpc/type_instance_events";
+import { getTypeInstanceEvents } from "./query";
+
+// TypeInstance events are stored in Neo4j, so they are available to all Hub replicas and can be replayed
+// to clients which were disconnected. Each event gets the next value of a single sequence counter.
+// Appending events takes the write lock on the counter until the mutation transaction is committed,
+// so events are committed in the sequence order and the sequence has no gaps.
+const sequenceName = "type-instance-events";
+
+export async function ensureEventLog(driver: Driver): Promise<void> {
+  const neo4jSession = driver.session();
+  try {
+    await neo4jSession.run(
+      `CREATE CONSTRAINT type_instance_event_sequence IF NOT EXISTS ON (e:TypeInstanceEvent) ASSERT e.sequence IS UNIQUE`
+    );
+    await neo4jSession.run(
+      `CREATE INDEX type_instance_event_created_at IF NOT EXISTS FOR (e:TypeInstanceEvent) ON (e.createdAt)`
+    );
+    await neo4jSession.run(
+      `CREATE CONSTRAINT type_instance_event_sequence_name IF NOT EXISTS ON (s:EventSequence) ASSERT s.name IS UNIQUE`
+    );
+    await neo4jSession.writeTransaction((tx: Transaction) =>
+      tx.run(
+        `MERGE (s:EventSequence {name: $name}) ON CREATE SET s.value = 0`,
+        { name: sequenceName }
+      )
+    );
+  } finally {
+    await neo4jSession.close();
+  }
+}
+
+// Stores events of a given type with the current state of given TypeInstances.
+// Call it at the end of the mutation transaction, as it locks the sequence counter
+// for other mutations until the transaction is committed.
+export async function recordTypeInstanceEvents(
+  tx: Transaction,
+  type: EventType,
+  ids: string[]
+): Promise<void> {
+  const events = await getTypeInstanceEvents(tx, type, ids);
+  await appendTypeInstanceEvents(tx, events);
+}
+
+// Stores given events. Call it at the end of the mutation transaction, as it locks the sequence counter
+// for other mutations until the transaction is committed.
+export async function appendTypeInstanceEvents(
+  tx: Transaction,
+  events: TypeInstanceEvent[]
+): Promise<void> {
+  if (!events.length) {
+    return;
+  }
+
+  await tx.run(
+    `
+      MERGE (s:EventSequence {name: $name})
+      ON CREATE SET s.value = 0
+      SET s.value = s.value + size($events)
+      WITH s.value - size($events) AS last
+      UNWIND range(0, size($events) - 1) AS idx
+      WITH last + idx + 1 AS sequence, $events[idx] AS event
+      CREATE (:TypeInstanceEvent {
+        sequence: sequence,
+        type: event.type,
+        typeInstanceId: event.typeInstanceId,
+        typeRefPath: event.typeRefPath,
+        typeRefRevision: event.typeRefRevision,
+        resourceVersion: toInteger(event.resourceVersion),
+        lockedBy: event.lockedBy,
+        createdAt: timestamp()
+      })
+    `,
+    {
+      name: sequenceName,
+      events: events.map((event) => ({
+        type: eventTypeToJSON(event.type),
+        typeInstanceId: event.typeInstanceId,
+        typeRefPath: event.typeRefPath,
+        typeRefRevision: event.typeRefRevision,
+        resourceVersion: event.resourceVersion,
+        lockedBy: event.lockedBy ?? null,
+      })),
+    }
+  );
+}
+
+// Returns the sequence of the last stored event.
+export async function getLastSequence(driver: Driver): Promise<number> {
+  const neo4jSession = driver.session();
+  try {
+    const result = await neo4jSession.readTransaction((tx: Transaction) =>
+      tx.run(`MATCH (s:EventSequence {name: $name}) RETURN s.value AS value`, {
+        name: sequenceName,
+      })
+    );
+    if (!result.records.length) {
+      return 0;
+    }
+    return Number(result.records[0].get("value"));
+  } finally {
+    await neo4jSession.close();
+  }
+}
+
+// Returns at most `limit` events stored after a given sequence, in the sequence order.
+export async function getEventsSince(
+  driver: Driver,
+  sequence: number,
+  limit: number
+): Promise<TypeInstanceEvent[]> {
+  const neo4jSession = driver.session();
+  try {
+    const result = await neo4jSession.readTransaction((tx: Transaction) =>
+      tx.run(
+        `
+          MATCH (e:TypeInstanceEvent)
+          WHERE e.sequence > $sequence
+          RETURN e {.*} AS event
+          ORDER BY e.sequence ASC
+          LIMIT $limit
+        `,
+        { sequence: neo4j.int(sequence), limit: neo4j.int(limit) }
+      )
+    );
+
+    return result.records.map((record) => {
+      const event = record.get("event");
+      return TypeInstanceEvent.fromPartial({
+        type: eventTypeFromJSON(event.type),
+        typeInstanceId: event.typeInstanceId,
+        typeRefPath: event.typeRefPath,
+        typeRefRevision: event.typeRefRevision,
+        resourceVersion: Number(event.resourceVersion),
+        lockedBy: event.lockedBy ?? undefined,
+        sequence: Number(event.sequence),
+      });
+    });
+  } finally {
+    await neo4jSession.close();
+  }
+}
+
+// Deletes events stored before a given time. It returns the number of deleted events.
+export async function pruneEvents(
+  driver: Driver,
+  before: Date,
+  limit: number
+): Promise<number> {
+  const neo4jSession = driver.session();
+  try {
+    const result = await neo4jSession.writeTransaction((tx: Transaction) =>
+      tx.run(
+        `
+          MATCH (e:TypeInstanceEvent)
+          WHERE e.createdAt < $before
+          WITH e LIMIT $limit
+          DELETE e
+          RETURN count(e) AS count
+        `,
+        { before: neo4j.int(before.getTime()), limit: neo4j.int(limit) }
+      )
+    );
+    return Number(result.records[0].get("count"));
+  } finally {
+    await neo4jSession.close();
+  }
+}
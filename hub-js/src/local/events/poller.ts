@@ -0,0 +1,87 @@
+import { Driver } from "neo4j-driver";
+import TypeInstanceEventBus from "./bus";
+import { getEventsSince, getLastSequence, pruneEvents } from "./log";
+import { logger } from "../../logger";
+
+const pollInterval = 1000;
+const pollBatchSize = 500;
+
+// Events older than the retention period are pruned, so clients disconnected for a longer time
+// must resume watching from the observed resource versions.
+const retentionPeriod = 24 * 60 * 60 * 1000;
+const pruneInterval = 10 * 60 * 1000;
+const pruneBatchSize = 10000;
+
+// TypeInstanceEventPoller publishes events stored by all Hub replicas to subscribers connected to this replica.
+export default class TypeInstanceEventPoller {
+  private lastSequence = 0;
+  private lastPrune = 0;
+
+  constructor(
+    private readonly driver: Driver,
+    private readonly bus: TypeInstanceEventBus
+  ) {}
+
+  // Starts polling events stored after the current last sequence.
+  async start() {
+    this.lastSequence = await getLastSequence(this.driver);
+    this.schedule();
+  }
+
+  private schedule() {
+    setTimeout(async () => {
+      try {
+        await this.poll();
+        await this.pruneIfDue();
+      } catch (e) {
+        const err = e as Error;
+        logger.error("Failed to poll TypeInstance events", {
+          error: err.message,
+        });
+      }
+      this.schedule();
+    }, pollInterval);
+  }
+
+  private async poll() {
+    for (;;) {
+      const events = await getEventsSince(
+        this.driver,
+        this.lastSequence,
+        pollBatchSize
+      );
+      if (!events.length) {
+        return;
+      }
+
+      this.bus.publish(...events);
+      this.lastSequence = events[events.length - 1].sequence;
+
+      if (events.length < pollBatchSize) {
+        return;
+      }
+    }
+  }
+
+  private async pruneIfDue() {
+    const now = Date.now();
+    if (now - this.lastPrune < pruneInterval) {
+      return;
+    }
+    this.lastPrune = now;
+
+    const before = new Date(now - retentionPeriod);
+    let pruned = 0;
+    for (;;) {
+      const count = await pruneEvents(this.driver, before, pruneBatchSize);
+      pruned += count;
+      if (count < pruneBatchSize) {
+        break;
+      }
+    }
+
+    if (pruned > 0) {
+      logger.debug("Pruned TypeInstance events", { count: pruned });
+    }
+  }
+}
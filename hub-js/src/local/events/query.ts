@@ -0,0 +1,112 @@
+import { Driver, Transaction } from "neo4j-driver";
+import {
+  EventType,
+  ObservedResourceVersion,
+  TypeInstanceEvent,
+} from "../../generated/grpc/type_instance_events";
+
+interface TypeInstanceEventDetails {
+  typeInstanceId: string;
+  typeRefPath: string;
+  typeRefRevision: string;
+  resourceVersion: number;
+  lockedBy?: string;
+}
+
+// Returns events of a given type with the current state of given TypeInstances.
+// Run it in the same transaction as the mutation, so the event reflects the mutated state.
+export async function getTypeInstanceEvents(
+  tx: Transaction,
+  type: EventType,
+  ids: string[]
+): Promise<TypeInstanceEvent[]> {
+  const details = await getTypeInstanceEventDetails(tx, ids);
+  return details.map((item) => TypeInstanceEvent.fromPartial({ type, ...item }));
+}
+
+// Returns events for TypeInstances which changed since the client observed them.
+export async function getChangedSinceObserved(
+  driver: Driver,
+  observed: ObservedResourceVersion[]
+): Promise<TypeInstanceEvent[]> {
+  if (!observed.length) {
+    return [];
+  }
+
+  const neo4jSession = driver.session();
+  try {
+    const details = await neo4jSession.readTransaction((tx: Transaction) =>
+      getTypeInstanceEventDetails(
+        tx,
+        observed.map((item) => item.typeInstanceId)
+      )
+    );
+    const current = new Map(
+      details.map((item) => [item.typeInstanceId, item])
+    );
+
+    const events: TypeInstanceEvent[] = [];
+    for (const item of observed) {
+      const ti = current.get(item.typeInstanceId);
+      if (!ti) {
+        events.push(
+          TypeInstanceEvent.fromPartial({
+            type: EventType.DELETED,
+            typeInstanceId: item.typeInstanceId,
+            resourceVersion: item.resourceVersion,
+          })
+        );
+        continue;
+      }
+
+      if (ti.resourceVersion > item.resourceVersion) {
+        events.push(
+          TypeInstanceEvent.fromPartial({ type: EventType.UPDATED, ...ti })
+        );
+      }
+    }
+
+    return events;
+  } finally {
+    await neo4jSession.close();
+  }
+}
+
+async function getTypeInstanceEventDetails(
+  tx: Transaction,
+  ids: string[]
+): Promise<TypeInstanceEventDetails[]> {
+  const result = await tx.run(
+    `
+      UNWIND $ids as id
+      MATCH (ti:TypeInstance {id: id})
+      MATCH (ti)-[:OF_TYPE]->(typeRef:TypeInstanceTypeReference)
+
+      // Get Latest Revision
+      CALL {
+        WITH ti
+        WITH ti
+        MATCH (ti)-[:CONTAINS]->(tir:TypeInstanceResourceVersion)
+        RETURN tir ORDER BY tir.resourceVersion DESC LIMIT 1
+      }
+
+      RETURN {
+        typeInstanceId: ti.id,
+        typeRefPath: typeRef.path,
+        typeRefRevision: typeRef.revision,
+        resourceVersion: tir.resourceVersion,
+        lockedBy: ti.lockedBy
+      } as value
+    `,
+    { ids }
+  );
+
+  return result.records.map((record) => {
+    const value = record.get("value");
+    return {
+      ...value,
+      resourceVersion: Number(value.resourceVersion),
+      lockedBy: value.lockedBy ?? undefined,
+    } as TypeInstanceEventDetails;
+  });
+}
@@ -0,0 +1,135 @@
+import { Driver } from "neo4j-driver";
+import {
+  CallContext,
+  ServerError,
+  ServiceImplementation,
+  Status,
+} from "nice-grpc";
+import {
+  TypeInstanceEvent,
+  TypeInstanceEventsDefinition,
+  WatchRequest,
+} from "../../generated/grpc/type_instance_events";
+import TypeInstanceEventBus, {
+  EventFilter,
+  SubscriptionOverflowError,
+} from "./bus";
+import { getChangedSinceObserved } from "./query";
+import { getEventsSince, getLastSequence } from "./log";
+import { logger } from "../../logger";
+
+export function typeInstanceEventsService(
+  driver: Driver,
+  bus: TypeInstanceEventBus
+): ServiceImplementation<typeof TypeInstanceEventsDefinition> {
+  return {
+    async *watch(
+      request: WatchRequest,
+      context: CallContext
+    ): AsyncIterable<TypeInstanceEvent> {
+      logger.debug("Starting TypeInstance events watch", request);
+
+      const filter = eventFilter(request);
+      const subscription = bus.subscribe(filter);
+      const close = () => subscription.close();
+      context.signal.addEventListener("abort", close);
+
+      try {
+        // NOTE: Events published in the meantime are buffered by the subscription.
+        // Events already emitted from the event log are skipped by their sequence.
+        let lastSequence = request.sinceSequence;
+        if (lastSequence > 0) {
+          for await (const event of readEventLog(driver, lastSequence)) {
+            lastSequence = event.sequence;
+            if (filter(event)) {
+              yield event;
+            }
+          }
+        } else {
+          lastSequence = await getLastSequence(driver);
+          // Changed TypeInstances may duplicate the buffered events, so clients ignore already observed resource versions.
+          const changed = await getChangedSinceObserved(
+            driver,
+            request.observed
+          );
+          for (const event of changed.filter(filter)) {
+            yield event;
+          }
+        }
+
+        for await (const event of subscription) {
+          if (event.sequence > lastSequence) {
+            yield event;
+          }
+        }
+      } catch (e) {
+        if (e instanceof SubscriptionOverflowError) {
+          throw new ServerError(Status.RESOURCE_EXHAUSTED, e.message);
+        }
+        if (e instanceof EventsPrunedError) {
+          throw new ServerError(Status.OUT_OF_RANGE, e.message);
+        }
+        throw e;
+      } finally {
+        context.signal.removeEventListener("abort", close);
+        subscription.close();
+      }
+    },
+  };
+}
+
+const replayBatchSize = 500;
+
+class EventsPrunedError extends Error {
+  constructor(sequence: number) {
+    super(
+      `events stored after sequence ${sequence} were pruned, watch again with the observed resource versions`
+    );
+    this.name = "EventsPrunedError";
+  }
+}
+
+// Yields events stored after a given sequence. As the sequence has no gaps,
+// a missing next sequence means that the events were pruned.
+async function* readEventLog(
+  driver: Driver,
+  sequence: number
+): AsyncIterable<TypeInstanceEvent> {
+  const lastSequence = await getLastSequence(driver);
+  if (sequence > lastSequence) {
+    throw new EventsPrunedError(sequence);
+  }
+
+  let last = sequence;
+  while (last < lastSequence) {
+    const events = await getEventsSince(driver, last, replayBatchSize);
+    if (!events.length || events[0].sequence !== last + 1) {
+      throw new EventsPrunedError(last);
+    }
+
+    for (const event of events) {
+      yield event;
+    }
+    last = events[events.length - 1].sequence;
+  }
+}
+
+function eventFilter(request: WatchRequest): EventFilter {
+  const ids = new Set(request.typeInstanceIds);
+  return (event: TypeInstanceEvent) => {
+    if (ids.size > 0 && !ids.has(event.typeInstanceId)) {
+      return false;
+    }
+
+    // Type is unknown for TypeInstances deleted while the client was disconnected
+    if (
+      request.typeRefPath &&
+      event.typeRefPath &&
+      event.typeRefPath !== request.typeRefPath
+    ) {
+      return false;
+    }
+
+    return true;
+  };
+}
@@ -6,6 +6,7 @@ import { deleteTypeInstance } from "./resolver/mutation/delete-type-instance";
 import { createTypeInstance } from "./resolver/mutation/create-type-instance";
 import { lockTypeInstances } from "./resolver/mutation/lock-type-instances";
 import { unlockTypeInstances } from "./resolver/mutation/unlock-type-instances";
+import { migrateTypeInstanceBackend } from "./resolver/mutation/migrate-type-instance-backend";
 import { typeInstanceResourceVersionSpecValueField } from "./resolver/field/spec-value-field";
 
 const typeDefs = readFileSync("./graphql/local/schema.graphql", "utf-8");
@@ -20,6 +21,7 @@ export const schema = makeAugmentedSchema({
       deleteTypeInstance,
       lockTypeInstances,
       unlockTypeInstances,
+      migrateTypeInstanceBackend,
     },
     TypeInstanceResourceVersionSpec: {
       value: typeInstanceResourceVersionSpecValueField,
@@ -28,6 +28,8 @@ import {
 import { logger } from "../../../logger";
 import { builtinStorageBackendDetails } from "./register-built-in-storage";
 import * as grpc from "@grpc/grpc-js";
+import { recordTypeInstanceEvents } from "../../events/log";
+import { EventType } from "../../../generated/grpc/type_instance_events";
 
 const genAdjsColorsAndAnimals: Config = {
   dictionaries: [adjectives, colors, animals],
@@ -65,34 +67,44 @@ export async function createTypeInstances(
 
   let externallyStored: DeleteInput[] = [];
   try {
-    return await neo4jSession.writeTransaction(async (tx: Transaction) => {
-      const createAliasMappingsResult = await createTypeInstancesInDB(
-        tx,
-        typeInstancesInput
-      );
-
-      const storeInput = getExternallyStoredValues(
-        createAliasMappingsResult,
-        typeInstancesInput
-      );
-      externallyStored = storeInput;
-      const updatedContexts = await context.delegatedStorage.Store(
-        ...storeInput
-      );
-
-      await updateTypeInstancesContextInDB(tx, updatedContexts);
-
-      await setTypeInstanceRelationsInDB(
-        tx,
-        createAliasMappingsResult,
-        usesRelations
-      );
+    const created = await neo4jSession.writeTransaction(
+      async (tx: Transaction) => {
+        const createAliasMappingsResult = await createTypeInstancesInDB(
+          tx,
+          typeInstancesInput
+        );
+
+        const storeInput = getExternallyStoredValues(
+          createAliasMappingsResult,
+          typeInstancesInput
+        );
+        externallyStored = storeInput;
+        const updatedContexts = await context.delegatedStorage.Store(
+          ...storeInput
+        );
+
+        await updateTypeInstancesContextInDB(tx, updatedContexts);
+
+        await setTypeInstanceRelationsInDB(
+          tx,
+          createAliasMappingsResult,
+          usesRelations
+        );
+
+        await recordTypeInstanceEvents(
+          tx,
+          EventType.CREATED,
+          Object.values(createAliasMappingsResult)
+        );
+
+        return Object.entries(createAliasMappingsResult).map((entry) => ({
+          alias: entry[0],
+          id: entry[1],
+        }));
+      }
+    );
 
-      return Object.entries(createAliasMappingsResult).map((entry) => ({
-        alias: entry[0],
-        id: entry[1],
-      }));
-    });
+    return created;
   } catch (e) {
     const rollbackErr = await rollbackExternalStoreAction(
       context.delegatedStorage,
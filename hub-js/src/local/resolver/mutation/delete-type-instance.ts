@@ -7,6 +7,9 @@ import {
 } from "./cypher-errors";
 import { logger } from "../../../logger";
 import { TypeInstanceBackendInput } from "../../types/type-instance";
+import { getTypeInstanceEvents } from "../../events/query";
+import { appendTypeInstanceEvents } from "../../events/log";
+import { EventType } from "../../../generated/grpc/type_instance_events";
 
 export async function deleteTypeInstance(
   _: unknown,
@@ -15,7 +18,12 @@ export async function deleteTypeInstance(
 ) {
   const neo4jSession = context.driver.session();
   try {
-    return await neo4jSession.writeTransaction(async (tx: Transaction) => {
+    const id = await neo4jSession.writeTransaction(async (tx: Transaction) => {
+      // NOTE: Collect event details before the TypeInstance is deleted.
+      const events = await getTypeInstanceEvents(tx, EventType.DELETED, [
+        args.id,
+      ]);
+
       logger.debug(
         "Executing query to delete TypeInstance from database",
         args
@@ -110,8 +118,11 @@ export async function deleteTypeInstance(
         });
       }
 
+      await appendTypeInstanceEvents(tx, events);
       return args.id;
     });
+
+    return id;
   } catch (e) {
     let err = e as Error;
     const customErr = tryToExtractCustomCypherError(err);
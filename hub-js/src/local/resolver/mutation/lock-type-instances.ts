@@ -3,6 +3,8 @@ import { Context } from "./context";
 import { logger } from "../../../logger";
 import { TypeInstanceBackendDetails } from "../../types/type-instance";
 import { LockInput } from "../../storage/service";
+import { recordTypeInstanceEvents } from "../../events/log";
+import { EventType } from "../../../generated/grpc/type_instance_events";
 
 export interface LockingTypeInstanceInput {
   in: {
@@ -35,7 +37,7 @@ export async function lockTypeInstances(
 ) {
   const neo4jSession = context.driver.session();
   try {
-    return await neo4jSession.writeTransaction(async (tx: Transaction) => {
+    const ids = await neo4jSession.writeTransaction(async (tx: Transaction) => {
       logger.debug("Executing query to lock TypeInstance(s)", args);
       await switchLocking(
         tx,
@@ -53,8 +55,11 @@ export async function lockTypeInstances(
       );
       await context.delegatedStorage.Lock(...lockExternals);
 
+      await recordTypeInstanceEvents(tx, EventType.LOCKED, args.in.ids);
       return args.in.ids;
     });
+
+    return ids;
   } catch (e) {
     const err = e as Error;
     throw new Error(`failed to lock TypeInstances: ${err.message}`);
@@ -0,0 +1,354 @@
+import { Transaction } from "neo4j-driver";
+import { Context } from "./context";
+import { logger } from "../../../logger";
+import {
+  TypeInstanceBackendDetails,
+  TypeInstanceBackendInput,
+} from "../../types/type-instance";
+import { BUILTIN_STORAGE_BACKEND_ID } from "../../../config";
+
+interface MigrateResourceVersionInput {
+  resourceVersion: number;
+  value?: unknown;
+}
+
+export interface MigrateTypeInstanceBackendInput {
+  in: {
+    id: string;
+    ownerID: string;
+    backend: TypeInstanceBackendInput;
+    resourceVersions: MigrateResourceVersionInput[];
+  };
+}
+
+interface MigratedResourceVersion {
+  resourceVersion: number;
+  value?: unknown;
+  context?: unknown;
+}
+
+interface MigrationSource {
+  lockedBy?: string;
+  targetRegistered: boolean;
+  backend: TypeInstanceBackendDetails;
+  resourceVersions: number[];
+}
+
+export async function migrateTypeInstanceBackend(
+  _: unknown,
+  args: MigrateTypeInstanceBackendInput,
+  context: Context
+) {
+  const { id, ownerID, backend } = args.in;
+  const target: TypeInstanceBackendDetails = {
+    id: backend.id,
+    context: backend.context,
+    abstract: backend.id === BUILTIN_STORAGE_BACKEND_ID,
+  };
+
+  // NOTE: Holds the context of the last value stored in the target backend, used for a rollback.
+  const rollback: { target?: TypeInstanceBackendInput } = {};
+
+  const neo4jSession = context.driver.session();
+  try {
+    const source = await neo4jSession.readTransaction((tx: Transaction) =>
+      getMigrationSource(tx, id, target.id)
+    );
+    validate(args, target, source);
+
+    const resourceVersions = [...args.in.resourceVersions].sort(
+      (a, b) => a.resourceVersion - b.resourceVersion
+    );
+
+    // NOTE: Storage backends are called outside the database transaction, so the transaction is not kept open
+    // while waiting for them. The TypeInstance is locked, so its resource versions cannot be changed in the meantime.
+    let migrated: MigratedResourceVersion[] = resourceVersions;
+    if (!target.abstract) {
+      migrated = await storeInTargetBackend(
+        context,
+        args,
+        resourceVersions,
+        (stored) => (rollback.target = stored)
+      );
+    }
+
+    await neo4jSession.writeTransaction(async (tx: Transaction) => {
+      const current = await getMigrationSource(tx, id, target.id);
+      ensureSourceNotChanged(source, current);
+
+      logger.debug(
+        "Executing query to switch TypeInstance backend in database",
+        { id, backendId: target.id }
+      );
+      await switchBackendInDB(tx, id, target, migrated);
+    });
+
+    if (!source.backend.abstract) {
+      await deleteFromSourceBackend(context, id, ownerID, source.backend);
+    }
+
+    return id;
+  } catch (e) {
+    if (rollback.target) {
+      await rollbackTargetBackend(context, id, ownerID, rollback.target);
+    }
+
+    const err = e as Error;
+    throw new Error(
+      `failed to migrate TypeInstance with ID "${id}" to backend "${backend.id}": ${err.message}`
+    );
+  } finally {
+    await neo4jSession.close();
+  }
+}
+
+async function switchBackendInDB(
+  tx: Transaction,
+  id: string,
+  target: TypeInstanceBackendDetails,
+  migrated: MigratedResourceVersion[]
+) {
+  await tx.run(
+    `
+      MATCH (ti:TypeInstance {id: $id})
+      MATCH (ti)-[storedIn:STORED_IN]->(oldRef:TypeInstanceBackendReference)
+      OPTIONAL MATCH (ti)-[uses:USES]->(oldBackendTI:TypeInstance {id: oldRef.id})
+      DELETE storedIn, uses
+
+      WITH ti, oldRef
+      MATCH (backendTI:TypeInstance {id: $backend.id})
+      CREATE (ti)-[:USES]->(backendTI)
+      MERGE (storageRef:TypeInstanceBackendReference {id: $backend.id, abstract: $backend.abstract})
+      CREATE (ti)-[:STORED_IN]->(storageRef)
+
+      WITH ti, oldRef
+      CALL {
+        WITH oldRef
+        WITH oldRef
+        WHERE NOT (oldRef)--()
+        DELETE oldRef
+        RETURN count([]) as _tmp0
+      }
+
+      WITH ti
+      UNWIND $resourceVersions AS item
+      MATCH (ti)-[:CONTAINS]->(tir:TypeInstanceResourceVersion)
+      WHERE tir.resourceVersion = item.resourceVersion
+      MATCH (tir)-[:SPECIFIED_BY]->(spec:TypeInstanceResourceVersionSpec)
+      MATCH (spec)-[:WITH_BACKEND]->(specBackend:TypeInstanceResourceVersionSpecBackend)
+      SET specBackend.context = apoc.convert.toJson(item.context)
+      // Setting a property to null removes it, so the value is kept only for the built-in storage
+      SET spec.value = CASE WHEN $backend.abstract THEN apoc.convert.toJson(item.value) ELSE null END
+
+      RETURN count(tir) as migrated
+    `,
+    {
+      id,
+      backend: target,
+      resourceVersions: migrated.map((item) => ({
+        resourceVersion: item.resourceVersion,
+        value: item.value ?? null,
+        context: item.context ?? null,
+      })),
+    }
+  );
+}
+
+// Ensures that the TypeInstance was not modified while its values were stored in the target backend.
+function ensureSourceNotChanged(
+  source: MigrationSource,
+  current: MigrationSource
+) {
+  const sameResourceVersions =
+    source.resourceVersions.length === current.resourceVersions.length &&
+    source.resourceVersions.every((rv) =>
+      current.resourceVersions.includes(rv)
+    );
+
+  if (
+    current.lockedBy !== source.lockedBy ||
+    current.backend.id !== source.backend.id ||
+    !sameResourceVersions
+  ) {
+    throw new Error(`TypeInstance was modified during the migration`);
+  }
+}
+
+async function getMigrationSource(
+  tx: Transaction,
+  id: string,
+  backendId: string
+): Promise<MigrationSource> {
+  const result = await tx.run(
+    `
+      MATCH (ti:TypeInstance {id: $id})
+      MATCH (ti)-[:STORED_IN]->(backendRef:TypeInstanceBackendReference)
+
+      CALL {
+        WITH ti
+        WITH ti
+        MATCH (ti)-[:CONTAINS]->(tir:TypeInstanceResourceVersion)
+        RETURN collect(tir.resourceVersion) as resourceVersions
+      }
+
+      // Get Latest Revision
+      CALL {
+        WITH ti
+        WITH ti
+        MATCH (ti)-[:CONTAINS]->(tir:TypeInstanceResourceVersion)
+        RETURN tir ORDER BY tir.resourceVersion DESC LIMIT 1
+      }
+      MATCH (tir)-[:SPECIFIED_BY]->(spec:TypeInstanceResourceVersionSpec)
+      MATCH (spec)-[:WITH_BACKEND]->(backendCtx:TypeInstanceResourceVersionSpecBackend)
+
+      OPTIONAL MATCH (targetTI:TypeInstance {id: $backendId})
+
+      RETURN {
+        lockedBy: ti.lockedBy,
+        targetRegistered: targetTI IS NOT NULL,
+        backend: { id: backendRef.id, abstract: backendRef.abstract, context: apoc.convert.fromJsonMap(backendCtx.context) },
+        resourceVersions: resourceVersions
+      } as value
+    `,
+    { id, backendId }
+  );
+
+  if (!result.records.length) {
+    throw new Error("TypeInstance was not found");
+  }
+
+  const value = result.records[0].get("value");
+  return {
+    ...value,
+    resourceVersions: value.resourceVersions.map(Number),
+  } as MigrationSource;
+}
+
+function validate(
+  args: MigrateTypeInstanceBackendInput,
+  target: TypeInstanceBackendDetails,
+  source: MigrationSource
+) {
+  if (source.lockedBy !== args.in.ownerID) {
+    throw new Error(
+      `TypeInstance must be locked by "${args.in.ownerID}" during the migration`
+    );
+  }
+
+  if (!source.targetRegistered) {
+    throw new Error(`Target backend was not found`);
+  }
+
+  if (source.backend.id === target.id) {
+    throw new Error(`TypeInstance is already stored in the target backend`);
+  }
+
+  if (target.abstract && target.context) {
+    throw new Error(
+      `Built-in storage backend with ID ${target.id} does not accept context`
+    );
+  }
+
+  const got = args.in.resourceVersions.map((item) => item.resourceVersion);
+  const missing = source.resourceVersions.filter((rv) => !got.includes(rv));
+  const unknown = got.filter((rv) => !source.resourceVersions.includes(rv));
+  if (
+    missing.length !== 0 ||
+    unknown.length !== 0 ||
+    new Set(got).size !== got.length
+  ) {
+    throw new Error(
+      `values must be provided exactly once for each TypeInstance resource version: ${source.resourceVersions.join(
+        ", "
+      )}`
+    );
+  }
+}
+
+async function storeInTargetBackend(
+  context: Context,
+  args: MigrateTypeInstanceBackendInput,
+  resourceVersions: MigrateResourceVersionInput[],
+  onStored: (stored: TypeInstanceBackendInput) => void
+): Promise<MigratedResourceVersion[]> {
+  const { id, ownerID, backend } = args.in;
+  const migrated: MigratedResourceVersion[] = [];
+
+  // The first resource version is created in the target backend, all the next ones are stored as updates.
+  let backendCtx = backend.context;
+  for (const [idx, item] of resourceVersions.entries()) {
+    const updatedCtx =
+      idx === 0
+        ? await context.delegatedStorage.Store({
+            backend: { id: backend.id, context: backendCtx },
+            typeInstance: { id, value: item.value },
+          })
+        : await context.delegatedStorage.Update({
+            backend: { id: backend.id, context: backendCtx },
+            typeInstance: {
+              id,
+              newResourceVersion: item.resourceVersion,
+              newValue: item.value,
+              ownerID,
+            },
+          });
+
+    backendCtx = updatedCtx[id] ?? backendCtx;
+    onStored({ id: backend.id, context: backendCtx });
+    migrated.push({
+      resourceVersion: item.resourceVersion,
+      context: backendCtx,
+    });
+  }
+
+  // Keep the lock consistent, as the TypeInstance is unlocked from the target backend afterwards.
+  await context.delegatedStorage.Lock({
+    backend: { id: backend.id, context: backendCtx },
+    typeInstance: { id, lockedBy: ownerID },
+  });
+
+  return migrated;
+}
+
+async function deleteFromSourceBackend(
+  context: Context,
+  id: string,
+  ownerID: string,
+  source: TypeInstanceBackendInput
+) {
+  try {
+    await context.delegatedStorage.Delete({
+      backend: source,
+      typeInstance: { id, ownerID },
+    });
+  } catch (e) {
+    // The TypeInstance is already migrated, so don't fail the whole operation.
+    const err = e as Error;
+    logger.warn("Cannot delete migrated TypeInstance from source backend", {
+      typeInstanceId: id,
+      backendId: source.id,
+      error: err.message,
+    });
+  }
+}
+
+async function rollbackTargetBackend(
+  context: Context,
+  id: string,
+  ownerID: string,
+  target: TypeInstanceBackendInput
+) {
+  try {
+    await context.delegatedStorage.Delete({
+      backend: target,
+      typeInstance: { id, ownerID },
+    });
+  } catch (e) {
+    const err = e as Error;
+    logger.error("Cannot rollback TypeInstance from target backend", {
+      typeInstanceId: id,
+      backendId: target.id,
+      error: err.message,
+    });
+  }
+}
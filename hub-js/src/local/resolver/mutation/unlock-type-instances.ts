@@ -6,6 +6,8 @@ import {
   switchLocking,
 } from "./lock-type-instances";
 import { logger } from "../../../logger";
+import { recordTypeInstanceEvents } from "../../events/log";
+import { EventType } from "../../../generated/grpc/type_instance_events";
 
 interface UnLockTypeInstanceInput extends LockingTypeInstanceInput {}
 
@@ -16,7 +18,7 @@ export async function unlockTypeInstances(
 ) {
   const neo4jSession = context.driver.session();
   try {
-    return await neo4jSession.writeTransaction(async (tx: Transaction) => {
+    const ids = await neo4jSession.writeTransaction(async (tx: Transaction) => {
       logger.debug("Executing query to unlock TypeInstance(s)", args);
       await switchLocking(
         tx,
@@ -34,8 +36,11 @@ export async function unlockTypeInstances(
       );
       await context.delegatedStorage.Unlock(...unlockExternals);
 
+      await recordTypeInstanceEvents(tx, EventType.UNLOCKED, args.in.ids);
       return args.in.ids;
     });
+
+    return ids;
   } catch (e) {
     const err = e as Error;
     throw new Error(`failed to unlock TypeInstances: ${err.message}`);
@@ -15,6 +15,8 @@ import DelegatedStorageService, {
 } from "../../storage/service";
 import * as grpc from "@grpc/grpc-js";
 import { aggregateError } from "./create-type-instances";
+import { recordTypeInstanceEvents } from "../../events/log";
+import { EventType } from "../../../generated/grpc/type_instance_events";
 
 interface UpdateTypeInstancesInput {
   in: [
@@ -66,48 +68,58 @@ export async function updateTypeInstances(
   const externallyStored: DeleteRevisionInput[] = [];
 
   try {
-    return await neo4jSession.writeTransaction(async (tx: Transaction) => {
-      const externalBackends = await extractInformationAboutValueStore(
-        tx,
-        args
-      );
-
-      for (const [index, item] of args.in.entries()) {
-        if (!externalBackends.has(item.id)) {
-          continue;
-        }
-
-        const out = await storeValueExternally(
-          {
-            id: item.id,
-            value: item.typeInstance.value,
-            backend: item.typeInstance.backend,
-            owner: item.ownerID,
-          },
-          context.delegatedStorage,
-          externalBackends.get(item.id) as GetInput
+    const updated = await neo4jSession.writeTransaction(
+      async (tx: Transaction) => {
+        const externalBackends = await extractInformationAboutValueStore(
+          tx,
+          args
         );
-        externallyStored.push(out.rollbackInput);
 
-        if (!out.newContext) {
-          continue;
+        for (const [index, item] of args.in.entries()) {
+          if (!externalBackends.has(item.id)) {
+            continue;
+          }
+
+          const out = await storeValueExternally(
+            {
+              id: item.id,
+              value: item.typeInstance.value,
+              backend: item.typeInstance.backend,
+              owner: item.ownerID,
+            },
+            context.delegatedStorage,
+            externalBackends.get(item.id) as GetInput
+          );
+          externallyStored.push(out.rollbackInput);
+
+          if (!out.newContext) {
+            continue;
+          }
+
+          logger.debug("Backend contexts was changed by external backend", {
+            id: item.id,
+            oldContext: item.typeInstance.backend?.context,
+            newContext: out.newContext,
+          });
+          args.in[index].typeInstance.backend = {
+            context: out.newContext,
+          };
         }
 
-        logger.debug("Backend contexts was changed by external backend", {
-          id: item.id,
-          oldContext: item.typeInstance.backend?.context,
-          newContext: out.newContext,
-        });
-        args.in[index].typeInstance.backend = {
-          context: out.newContext,
-        };
-      }
+        const [query, queryParams] = cypherMutation(args, context, resolveInfo);
+        const outputResult = await tx.run(query, queryParams);
 
-      const [query, queryParams] = cypherMutation(args, context, resolveInfo);
-      const outputResult = await tx.run(query, queryParams);
+        await recordTypeInstanceEvents(
+          tx,
+          EventType.UPDATED,
+          args.in.map((item) => item.id)
+        );
+
+        return extractUpdateMutationResult(outputResult);
+      }
+    );
 
-      return extractUpdateMutationResult(outputResult);
-    });
+    return updated;
   } catch (e) {
     let err = e as Error;
     const customErr = tryToExtractCustomCypherError(err);
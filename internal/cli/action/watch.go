@@ -0,0 +1,157 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"capact.io/capact/internal/cli/client"
+	"capact.io/capact/internal/cli/config"
+	"capact.io/capact/internal/k8s-engine/graphql/namespace"
+	gqlengine "capact.io/capact/pkg/engine/api/graphql"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	watchPollInterval = time.Second
+	// latestActionName refers to the Action which was created last, the same as in the "argo watch" command.
+	latestActionName = "@latest"
+)
+
+// WatchProgressOptions holds configuration for watching Action progress.
+type WatchProgressOptions struct {
+	// ActionName holds the name of the watched Action. Use "@latest" to watch the Action which was created last.
+	ActionName string
+	Namespace  string
+}
+
+type actionLister interface {
+	ListActions(ctx context.Context, filter *gqlengine.ActionFilter) ([]*gqlengine.Action, error)
+}
+
+// WatchProgress polls a given Action and prints its phase changes and runner progress events
+// until the Action reaches a final phase.
+func WatchProgress(ctx context.Context, opts WatchProgressOptions, w io.Writer) error {
+	server := config.GetDefaultContext()
+	actionCli, err := client.NewCluster(server)
+	if err != nil {
+		return err
+	}
+
+	ctxWithNs := namespace.NewContext(ctx, opts.Namespace)
+	actionName, err := resolveActionName(ctxWithNs, actionCli, opts.ActionName)
+	if err != nil {
+		return err
+	}
+
+	printer := newProgressPrinter(w)
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		act, err := actionCli.GetAction(ctxWithNs, actionName)
+		if err != nil {
+			return err
+		}
+		if act == nil {
+			return fmt.Errorf("Action %q not found in Namespace %q", actionName, opts.Namespace)
+		}
+
+		printer.Print(act)
+		if isFinalPhase(act.Status.Phase) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// resolveActionName returns the name of the Action which was created last for the "@latest" name.
+// Other names are returned unchanged.
+func resolveActionName(ctx context.Context, lister actionLister, name string) (string, error) {
+	if name != latestActionName {
+		return name, nil
+	}
+
+	acts, err := lister.ListActions(ctx, &gqlengine.ActionFilter{})
+	if err != nil {
+		return "", errors.Wrap(err, "while listing Actions")
+	}
+
+	var latest *gqlengine.Action
+	for _, act := range acts {
+		if act == nil {
+			continue
+		}
+		if latest == nil || act.CreatedAt.Time.After(latest.CreatedAt.Time) {
+			latest = act
+		}
+	}
+	if latest == nil {
+		return "", errors.New("no Actions found")
+	}
+
+	return latest.Name, nil
+}
+
+// progressPrinter prints only the Action phase changes and progress events which were not printed yet.
+type progressPrinter struct {
+	w         io.Writer
+	lastPhase gqlengine.ActionStatusPhase
+	printed   map[gqlengine.RunnerProgressEvent]struct{}
+}
+
+func newProgressPrinter(w io.Writer) *progressPrinter {
+	return &progressPrinter{
+		w:       w,
+		printed: map[gqlengine.RunnerProgressEvent]struct{}{},
+	}
+}
+
+func (p *progressPrinter) Print(act *gqlengine.Action) {
+	if act.Status == nil {
+		return
+	}
+
+	if act.Status.Phase != p.lastPhase {
+		p.lastPhase = act.Status.Phase
+		fmt.Fprintf(p.w, "Action %q phase: %s\n", act.Name, act.Status.Phase)
+	}
+
+	if act.Status.Runner == nil {
+		return
+	}
+
+	for _, event := range act.Status.Runner.Progress {
+		if event == nil {
+			continue
+		}
+		if _, found := p.printed[*event]; found {
+			continue
+		}
+		p.printed[*event] = struct{}{}
+
+		timestamp := event.Timestamp.Local()
+		if event.Total > 0 {
+			fmt.Fprintf(p.w, "[%s] %d/%d %s\n", timestamp.Format("15:04:05"), event.Current, event.Total, event.Message)
+			continue
+		}
+		fmt.Fprintf(p.w, "[%s] %s\n", timestamp.Format("15:04:05"), event.Message)
+	}
+}
+
+func isFinalPhase(phase gqlengine.ActionStatusPhase) bool {
+	switch phase {
+	case gqlengine.ActionStatusPhaseSucceeded, gqlengine.ActionStatusPhaseFailed, gqlengine.ActionStatusPhaseCanceled:
+		return true
+	default:
+		return false
+	}
+}
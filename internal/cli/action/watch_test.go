@@ -0,0 +1,89 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gqlengine "capact.io/capact/pkg/engine/api/graphql"
+)
+
+func TestResolveActionName(t *testing.T) {
+	now := time.Now()
+
+	tests := map[string]struct {
+		givenName    string
+		givenActions []*gqlengine.Action
+		givenErr     error
+		expName      string
+		expListed    bool
+		expErr       string
+	}{
+		"Should return a given name without listing Actions": {
+			givenName: "example-action",
+			expName:   "example-action",
+		},
+		"Should resolve @latest to the Action created last": {
+			givenName: "@latest",
+			givenActions: []*gqlengine.Action{
+				fixAction("first", now.Add(-2*time.Minute)),
+				fixAction("last", now),
+				fixAction("second", now.Add(-time.Minute)),
+			},
+			expName:   "last",
+			expListed: true,
+		},
+		"Should fail when there are no Actions": {
+			givenName: "@latest",
+			expListed: true,
+			expErr:    "no Actions found",
+		},
+		"Should fail when listing Actions fails": {
+			givenName: "@latest",
+			givenErr:  errors.New("connection refused"),
+			expListed: true,
+			expErr:    "while listing Actions: connection refused",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// given
+			lister := &fakeActionLister{actions: tc.givenActions, err: tc.givenErr}
+
+			// when
+			actionName, err := resolveActionName(context.Background(), lister, tc.givenName)
+
+			// then
+			assert.Equal(t, tc.expListed, lister.listed)
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expName, actionName)
+		})
+	}
+}
+
+type fakeActionLister struct {
+	actions []*gqlengine.Action
+	err     error
+	listed  bool
+}
+
+func (f *fakeActionLister) ListActions(context.Context, *gqlengine.ActionFilter) ([]*gqlengine.Action, error) {
+	f.listed = true
+	return f.actions, f.err
+}
+
+func fixAction(name string, createdAt time.Time) *gqlengine.Action {
+	return &gqlengine.Action{
+		Name:      name,
+		CreatedAt: gqlengine.Timestamp{Time: createdAt},
+	}
+}
@@ -26,11 +26,15 @@ type Hub interface {
 	ListInterfaces(ctx context.Context, opts ...public.InterfaceOption) ([]*gqlpublicapi.Interface, error)
 	ListTypeInstances(ctx context.Context, filter *gqllocalapi.TypeInstanceFilter, opts ...local.TypeInstancesOption) ([]gqllocalapi.TypeInstance, error)
 	ListImplementationRevisions(ctx context.Context, opts ...public.ListImplementationRevisionsOption) ([]*gqlpublicapi.ImplementationRevision, error)
+	ListImplementationRevisionsForInterface(ctx context.Context, ref gqlpublicapi.InterfaceReference, opts ...public.ListImplementationRevisionsForInterfaceOption) ([]gqlpublicapi.ImplementationRevision, error)
 	FindTypeInstance(ctx context.Context, id string, opts ...local.TypeInstancesOption) (*gqllocalapi.TypeInstance, error)
 	CreateTypeInstance(ctx context.Context, in *gqllocalapi.CreateTypeInstanceInput) (string, error)
 	CreateTypeInstances(ctx context.Context, in *gqllocalapi.CreateTypeInstancesInput) ([]gqllocalapi.CreateTypeInstanceOutput, error)
 	UpdateTypeInstances(ctx context.Context, in []gqllocalapi.UpdateTypeInstancesInput, opts ...local.TypeInstancesOption) ([]gqllocalapi.TypeInstance, error)
 	DeleteTypeInstance(ctx context.Context, id string) error
+	LockTypeInstances(ctx context.Context, in *gqllocalapi.LockTypeInstancesInput) error
+	UnlockTypeInstances(ctx context.Context, in *gqllocalapi.UnlockTypeInstancesInput) error
+	MigrateTypeInstanceBackend(ctx context.Context, in *gqllocalapi.MigrateTypeInstanceBackendInput) error
 	FindInterfaceRevision(ctx context.Context, ref gqlpublicapi.InterfaceReference, opts ...public.InterfaceRevisionOption) (*gqlpublicapi.InterfaceRevision, error)
 	FindTypeInstancesTypeRef(ctx context.Context, ids []string) (map[string]gqllocalapi.TypeInstanceTypeReference, error)
 	CheckManifestRevisionsExist(ctx context.Context, manifestRefs []gqlpublicapi.ManifestReference) (map[gqlpublicapi.ManifestReference]bool, error)
@@ -0,0 +1,246 @@
+package manifestgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"capact.io/capact/internal/ptr"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"github.com/Masterminds/sprig"
+	"github.com/iancoleman/strcase"
+	"github.com/pkg/errors"
+)
+
+// GenerateKustomizeManifests generates manifest files for an Implementation based on Kubernetes manifests or a kustomization.
+// The input Type JSON schema is generated from the `.Values` fields used in the manifest templates.
+func GenerateKustomizeManifests(cfg *KustomizeConfig) (ManifestCollection, error) {
+	values, err := getKustomizeTemplateValues(cfg.ManifestsPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting values used in manifest templates")
+	}
+
+	cfgs := make([]*templatingConfig, 0, 2)
+
+	inputTypeCfg, err := getKustomizeInputTypeTemplatingConfig(cfg, values)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting input Type templating config")
+	}
+	cfgs = append(cfgs, inputTypeCfg)
+
+	implCfg, err := getKustomizeImplementationTemplatingConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting Implementation templating config")
+	}
+	cfgs = append(cfgs, implCfg)
+
+	generated, err := generateManifests(cfgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "while generating Kustomize manifests")
+	}
+
+	return createManifestCollection(generated)
+}
+
+func getKustomizeInputTypeTemplatingConfig(cfg *KustomizeConfig, values valueTree) (*templatingConfig, error) {
+	prefix, name, err := splitPathToPrefixAndName(cfg.ManifestRef.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting prefix and path for manifests")
+	}
+
+	schema := values.jsonSchema([]string{"#"})
+	schema["$schema"] = jsonSchemaDraft07
+
+	jsonSchema, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshaling JSON schema")
+	}
+
+	typeMetadata := types.TypeMetadata{
+		DocumentationURL: cfg.Metadata.DocumentationURL,
+		IconURL:          cfg.Metadata.IconURL,
+		SupportURL:       cfg.Metadata.SupportURL,
+		Maintainers:      cfg.Metadata.Maintainers,
+		DisplayName:      ptr.String(fmt.Sprintf("Input for %s.%s", prefix, name)),
+		Description:      fmt.Sprintf("Input for the \"%s.%s Action\"", prefix, name),
+	}
+
+	return &templatingConfig{
+		Template: typeManifestTemplate,
+		Input: &typeTemplatingInput{
+			templatingInput: templatingInput{
+				Name:     getDefaultAdditionalImplTypeName(name),
+				Prefix:   prefix,
+				Revision: cfg.ManifestRef.Revision,
+			},
+			Metadata:   typeMetadata,
+			JSONSchema: string(jsonSchema),
+		},
+	}, nil
+}
+
+func getKustomizeImplementationTemplatingConfig(cfg *KustomizeConfig) (*templatingConfig, error) {
+	prefix, name, err := splitPathToPrefixAndName(cfg.ManifestRef.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting prefix and path for manifests")
+	}
+
+	var (
+		interfacePath     = cfg.InterfacePathWithRevision
+		interfaceRevision = "0.1.0"
+	)
+
+	pathSlice := strings.SplitN(cfg.InterfacePathWithRevision, ":", 2)
+	if len(pathSlice) == 2 {
+		interfacePath = pathSlice[0]
+		interfaceRevision = pathSlice[1]
+	}
+
+	return &templatingConfig{
+		Template: kustomizeImplementationManifestTemplate,
+		Input: &kustomizeImplementationTemplatingInput{
+			templatingInput: templatingInput{
+				Name:     name,
+				Prefix:   prefix,
+				Revision: cfg.ManifestRef.Revision,
+			},
+			Metadata: cfg.Metadata,
+			InterfaceRef: types.ManifestRef{
+				Path:     interfacePath,
+				Revision: interfaceRevision,
+			},
+			SourceURL: cfg.SourceURL,
+		},
+	}, nil
+}
+
+// kustomizeTemplateExt is the extension of manifest templates rendered by the Kustomize runner.
+// Other files are applied as they are, so they are not scanned for the `.Values` fields.
+const kustomizeTemplateExt = ".tpl"
+
+// valueTree holds the `.Values` fields used in the manifest templates.
+// Leaves are fields used directly, other nodes are objects.
+type valueTree map[string]valueTree
+
+func getKustomizeTemplateValues(dir string) (valueTree, error) {
+	values := valueTree{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != kustomizeTemplateExt {
+			return nil
+		}
+
+		raw, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return errors.Wrapf(err, "while reading %q", path)
+		}
+
+		tmpl, err := template.New(filepath.Base(path)).Funcs(sprig.TxtFuncMap()).Parse(string(raw))
+		if err != nil {
+			return errors.Wrapf(err, "while parsing template %q", path)
+		}
+
+		for _, t := range tmpl.Templates() {
+			if t.Tree != nil {
+				values.collect(t.Tree.Root)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (v valueTree) collect(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, item := range n.Nodes {
+			v.collect(item)
+		}
+	case *parse.ActionNode:
+		v.collect(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			v.collect(cmd)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			v.collect(arg)
+		}
+	case *parse.IfNode:
+		v.collectBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		v.collectBranch(&n.BranchNode)
+	case *parse.WithNode:
+		v.collectBranch(&n.BranchNode)
+	case *parse.TemplateNode:
+		v.collect(n.Pipe)
+	case *parse.FieldNode:
+		v.add(n.Ident)
+	case *parse.VariableNode:
+		// fields accessed from the root context, such as `$.Values.name`
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			v.add(n.Ident[1:])
+		}
+	}
+}
+
+func (v valueTree) collectBranch(n *parse.BranchNode) {
+	v.collect(n.Pipe)
+	v.collect(n.List)
+	v.collect(n.ElseList)
+}
+
+func (v valueTree) add(ident []string) {
+	if len(ident) < 2 || ident[0] != "Values" {
+		return
+	}
+
+	current := v
+	for _, key := range ident[1:] {
+		if current[key] == nil {
+			current[key] = valueTree{}
+		}
+		current = current[key]
+	}
+}
+
+func (v valueTree) jsonSchema(keyPath []string) map[string]interface{} {
+	schema := map[string]interface{}{
+		"$id": strings.Join(keyPath, "/properties/"),
+	}
+	if len(keyPath) > 1 {
+		schema["title"] = strcase.ToCamel(keyPath[len(keyPath)-1])
+	}
+
+	if len(v) == 0 && len(keyPath) > 1 {
+		// the type of a value cannot be detected from the template, so it is up to the ContentDeveloper
+		return schema
+	}
+
+	properties := map[string]interface{}{}
+	for key, child := range v {
+		properties[key] = child.jsonSchema(append(keyPath[:len(keyPath):len(keyPath)], key))
+	}
+
+	schema["type"] = "object"
+	schema["properties"] = properties
+	return schema
+}
@@ -8,6 +8,8 @@ package manifestgen_test
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"capact.io/capact/cmd/cli/cmd/manifest/generate/common"
@@ -442,3 +444,125 @@ func TestGenerateHelmImplementationManifests(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateKustomizeImplementationManifests(t *testing.T) {
+	cfg := &manifestgen.KustomizeConfig{
+		ImplementationConfig: manifestgen.ImplementationConfig{
+			Config: manifestgen.Config{
+				ManifestRef: types.ManifestRef{
+					Path:     "cap.implementation.kustomize.test",
+					Revision: "0.1.0",
+				},
+			},
+			Metadata: types.ImplementationMetadata{
+				DocumentationURL: ptr.String("https://example.com"),
+				SupportURL:       ptr.String("https://example.com"),
+				Maintainers: []types.Maintainer{
+					{
+						Email: "dev@example.com",
+						Name:  ptr.String("Example Dev"),
+						URL:   ptr.String("https://example.com"),
+					},
+				},
+				License: types.License{
+					Name: common.ApacheLicense,
+				},
+			},
+			InterfacePathWithRevision: "cap.interface.group.test:0.2.0",
+		},
+		ManifestsPath: "testdata/kustomize",
+		SourceURL:     "https://example.com/manifests.tgz",
+	}
+
+	manifests, err := manifestgen.GenerateKustomizeManifests(cfg)
+	require.NoError(t, err)
+
+	require.Len(t, manifests, 2)
+	for name, manifestData := range manifests {
+		filename := fmt.Sprintf("%s.yaml", name)
+		golden.Assert(t, string(manifestData), filename)
+	}
+}
+
+func TestGenerateTypeManifestsFromSchemas(t *testing.T) {
+	tests := []struct {
+		name          string
+		prefix        string
+		schemaFile    string
+		generate      func(*manifestgen.OpenAPITypeConfig) (manifestgen.ManifestCollection, error)
+		expectedTypes []string
+	}{
+		{
+			name:          "OpenAPI components with references",
+			prefix:        "cap.type.openapi.test",
+			schemaFile:    "openapi/petstore.yaml",
+			generate:      manifestgen.GenerateTypeManifestsFromOpenAPI,
+			expectedTypes: []string{"cap.type.openapi.test.category", "cap.type.openapi.test.pet"},
+		},
+		{
+			name:          "CustomResourceDefinition",
+			prefix:        "cap.type.crd.test",
+			schemaFile:    "crd/postgresql.yaml",
+			generate:      manifestgen.GenerateTypeManifestsFromCRD,
+			expectedTypes: []string{"cap.type.crd.test.postgres-cluster"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// given
+			cfg := fixOpenAPITypeConfig(t, test.prefix, test.schemaFile)
+
+			// when
+			manifests, err := test.generate(cfg)
+
+			// then
+			require.NoError(t, err)
+			require.Len(t, manifests, len(test.expectedTypes))
+			for _, path := range test.expectedTypes {
+				manifestData, ok := manifests[manifestgen.ManifestPath(path)]
+				require.True(t, ok, "missing manifest %s", path)
+				golden.Assert(t, string(manifestData), fmt.Sprintf("%s.yaml", path))
+			}
+		})
+	}
+}
+
+func TestGenerateTypeManifestsFromOpenAPIRecursiveReference(t *testing.T) {
+	// given
+	cfg := fixOpenAPITypeConfig(t, "cap.type.openapi.test", "openapi/recursive.yaml")
+
+	// when
+	_, err := manifestgen.GenerateTypeManifestsFromOpenAPI(cfg)
+
+	// then
+	require.EqualError(t, err, `while resolving schema "Node": recursive reference to "Node" is not supported: Node -> Node`)
+}
+
+func fixOpenAPITypeConfig(t *testing.T, prefix, schemaFile string) *manifestgen.OpenAPITypeConfig {
+	t.Helper()
+
+	schema, err := ioutil.ReadFile(filepath.Join("testdata", schemaFile))
+	require.NoError(t, err)
+
+	return &manifestgen.OpenAPITypeConfig{
+		Config: manifestgen.Config{
+			ManifestRef: types.ManifestRef{
+				Path:     prefix,
+				Revision: "0.2.0",
+			},
+		},
+		Metadata: types.InterfaceMetadata{
+			DocumentationURL: ptr.String("https://example.com"),
+			SupportURL:       ptr.String("https://example.com"),
+			Maintainers: []types.Maintainer{
+				{
+					Email: "dev@example.com",
+					Name:  ptr.String("Example Dev"),
+					URL:   ptr.String("https://example.com"),
+				},
+			},
+		},
+		Schema: schema,
+	}
+}
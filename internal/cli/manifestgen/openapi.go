@@ -0,0 +1,358 @@
+package manifestgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"capact.io/capact/internal/ptr"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"github.com/iancoleman/strcase"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	jsonSchemaDraft07    = "http://json-schema.org/draft-07/schema"
+	openAPIComponentsRef = "#/components/schemas/"
+)
+
+// OpenAPITypeConfig stores input parameters for generating Types from OpenAPI or CRD schemas.
+// ManifestRef.Path holds the prefix of the generated Types, e.g. cap.type.database.postgresql.
+type OpenAPITypeConfig struct {
+	Config
+	Metadata types.InterfaceMetadata
+	// Schema is the content of the OpenAPI v3 document or the CustomResourceDefinition manifests, in YAML or JSON format.
+	Schema []byte
+}
+
+// schemaObject is a generic JSON schema node.
+type schemaObject = map[string]interface{}
+
+// namedSchema is a schema, from which a single Type is generated.
+type namedSchema struct {
+	Name        string
+	Title       string
+	Description string
+	Schema      schemaObject
+}
+
+// GenerateTypeManifestsFromOpenAPI generates one Type manifest for each named schema component in the OpenAPI v3 document.
+// References to other components are inlined, as Type JSON schemas cannot refer to each other.
+func GenerateTypeManifestsFromOpenAPI(cfg *OpenAPITypeConfig) (ManifestCollection, error) {
+	var doc struct {
+		Components struct {
+			Schemas map[string]schemaObject `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := yaml.Unmarshal(cfg.Schema, &doc); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling OpenAPI document")
+	}
+
+	components := doc.Components.Schemas
+	if len(components) == 0 {
+		return nil, errors.New("OpenAPI document doesn't have any schemas under components.schemas")
+	}
+
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolver := &refResolver{components: components}
+	var schemas []namedSchema
+	for _, name := range names {
+		resolved, err := resolver.resolve(components[name], []string{name})
+		if err != nil {
+			return nil, errors.Wrapf(err, "while resolving schema %q", name)
+		}
+
+		schemas = append(schemas, newNamedSchema(name, resolved))
+	}
+
+	return generateTypesFromSchemas(cfg, schemas)
+}
+
+// GenerateTypeManifestsFromCRD generates one Type manifest for each CustomResourceDefinition.
+// The Type is generated from the schema of the `spec` property of the storage version,
+// as it holds the desired state of the custom resource. If there is no `spec` property, the whole schema is used.
+func GenerateTypeManifestsFromCRD(cfg *OpenAPITypeConfig) (ManifestCollection, error) {
+	var schemas []namedSchema
+
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(cfg.Schema), 4096)
+	for {
+		var crd struct {
+			Kind string `json:"kind"`
+			Spec struct {
+				Names struct {
+					Kind string `json:"kind"`
+				} `json:"names"`
+				// v1beta1 CRDs can specify the schema for all versions
+				Validation *struct {
+					OpenAPIV3Schema schemaObject `json:"openAPIV3Schema"`
+				} `json:"validation"`
+				Versions []struct {
+					Name    string `json:"name"`
+					Storage bool   `json:"storage"`
+					Schema  *struct {
+						OpenAPIV3Schema schemaObject `json:"openAPIV3Schema"`
+					} `json:"schema"`
+				} `json:"versions"`
+			} `json:"spec"`
+		}
+		err := decoder.Decode(&crd)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "while unmarshaling CustomResourceDefinition")
+		}
+		if crd.Kind != "CustomResourceDefinition" {
+			continue
+		}
+
+		var schema schemaObject
+		if crd.Spec.Validation != nil {
+			schema = crd.Spec.Validation.OpenAPIV3Schema
+		}
+		for _, ver := range crd.Spec.Versions {
+			if ver.Storage && ver.Schema != nil {
+				schema = ver.Schema.OpenAPIV3Schema
+			}
+		}
+
+		kind := crd.Spec.Names.Kind
+		if schema == nil {
+			return nil, fmt.Errorf("CustomResourceDefinition for kind %q doesn't have the openAPIV3Schema", kind)
+		}
+
+		if props, ok := schema["properties"].(schemaObject); ok {
+			if spec, ok := props["spec"].(schemaObject); ok {
+				schema = spec
+			}
+		}
+
+		converted, err := (&refResolver{}).resolve(schema, []string{kind})
+		if err != nil {
+			return nil, errors.Wrapf(err, "while converting schema for kind %q", kind)
+		}
+		schemas = append(schemas, newNamedSchema(kind, converted))
+	}
+
+	if len(schemas) == 0 {
+		return nil, errors.New("no CustomResourceDefinitions found")
+	}
+
+	return generateTypesFromSchemas(cfg, schemas)
+}
+
+func newNamedSchema(name string, schema schemaObject) namedSchema {
+	out := namedSchema{
+		Name:        strcase.ToKebab(name),
+		Title:       name,
+		Description: fmt.Sprintf("Type generated from the %s schema", name),
+		Schema:      schema,
+	}
+
+	if title, ok := schema["title"].(string); ok && title != "" {
+		out.Title = title
+	}
+	if desc, ok := schema["description"].(string); ok && desc != "" {
+		out.Description = desc
+	}
+
+	schema["$schema"] = jsonSchemaDraft07
+	return out
+}
+
+func generateTypesFromSchemas(cfg *OpenAPITypeConfig, schemas []namedSchema) (ManifestCollection, error) {
+	prefix := strings.TrimPrefix(cfg.ManifestRef.Path, "cap.type.")
+
+	cfgs := make([]*templatingConfig, 0, len(schemas))
+	for _, schema := range schemas {
+		jsonSchema, err := json.MarshalIndent(schema.Schema, "", "  ")
+		if err != nil {
+			return nil, errors.Wrapf(err, "while marshaling JSON schema for %q", schema.Title)
+		}
+
+		typeMetadata := types.TypeMetadata{
+			DocumentationURL: cfg.Metadata.DocumentationURL,
+			IconURL:          cfg.Metadata.IconURL,
+			SupportURL:       cfg.Metadata.SupportURL,
+			Maintainers:      cfg.Metadata.Maintainers,
+			// values are quoted, as they come from the schema and may contain characters with a special meaning in YAML
+			DisplayName: ptr.String(quoteYAML(schema.Title)),
+			Description: quoteYAML(firstLine(schema.Description)),
+		}
+
+		cfgs = append(cfgs, &templatingConfig{
+			Template: typeManifestTemplate,
+			Input: &typeTemplatingInput{
+				templatingInput: templatingInput{
+					Name:     schema.Name,
+					Prefix:   prefix,
+					Revision: cfg.ManifestRef.Revision,
+				},
+				Metadata:   typeMetadata,
+				JSONSchema: string(jsonSchema),
+			},
+		})
+	}
+
+	generated, err := generateManifests(cfgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "while generating manifests")
+	}
+
+	return createManifestCollection(generated)
+}
+
+// refResolver converts OpenAPI v3 schemas to JSON schemas, inlining references to named components.
+type refResolver struct {
+	components map[string]schemaObject
+}
+
+// resolve returns a converted copy of a given schema. The visited slice holds components on the current path
+// and is used to detect recursive schemas, which cannot be inlined.
+func (r *refResolver) resolve(schema schemaObject, visited []string) (schemaObject, error) {
+	if ref, ok := schema["$ref"].(string); ok {
+		return r.resolveRef(ref, visited)
+	}
+
+	out := schemaObject{}
+	for key, val := range schema {
+		var err error
+		switch key {
+		case "properties", "patternProperties":
+			out[key], err = r.resolveMap(val, visited)
+		case "allOf", "anyOf", "oneOf":
+			out[key], err = r.resolveList(val, visited)
+		case "items":
+			if _, isList := val.([]interface{}); isList {
+				out[key], err = r.resolveList(val, visited)
+			} else {
+				out[key], err = r.resolveAny(val, visited)
+			}
+		case "additionalProperties", "not":
+			out[key], err = r.resolveAny(val, visited)
+		default:
+			// other keywords, such as enum or default, hold plain values which are copied as they are
+			out[key] = val
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	convertOpenAPIKeywords(out)
+	return out, nil
+}
+
+// resolveAny resolves a value which is either a schema or a boolean.
+func (r *refResolver) resolveAny(val interface{}, visited []string) (interface{}, error) {
+	schema, ok := val.(schemaObject)
+	if !ok {
+		return val, nil
+	}
+	return r.resolve(schema, visited)
+}
+
+func (r *refResolver) resolveMap(val interface{}, visited []string) (interface{}, error) {
+	in, ok := val.(schemaObject)
+	if !ok {
+		return val, nil
+	}
+
+	out := schemaObject{}
+	for name, item := range in {
+		resolved, err := r.resolveAny(item, visited)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = resolved
+	}
+	return out, nil
+}
+
+func (r *refResolver) resolveList(val interface{}, visited []string) (interface{}, error) {
+	in, ok := val.([]interface{})
+	if !ok {
+		return val, nil
+	}
+
+	out := make([]interface{}, 0, len(in))
+	for _, item := range in {
+		resolved, err := r.resolveAny(item, visited)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resolved)
+	}
+	return out, nil
+}
+
+func (r *refResolver) resolveRef(ref string, visited []string) (schemaObject, error) {
+	if !strings.HasPrefix(ref, openAPIComponentsRef) {
+		return nil, fmt.Errorf("unsupported reference %q: only references to %s are supported", ref, openAPIComponentsRef)
+	}
+
+	name := strings.TrimPrefix(ref, openAPIComponentsRef)
+	for _, v := range visited {
+		if v == name {
+			return nil, fmt.Errorf("recursive reference to %q is not supported: %s", name, strings.Join(append(visited, name), " -> "))
+		}
+	}
+
+	component, ok := r.components[name]
+	if !ok {
+		return nil, fmt.Errorf("reference %q not found", ref)
+	}
+
+	return r.resolve(component, append(visited[:len(visited):len(visited)], name))
+}
+
+// convertOpenAPIKeywords replaces OpenAPI and Kubernetes specific keywords with their JSON schema equivalents.
+func convertOpenAPIKeywords(schema schemaObject) {
+	if nullable, ok := schema["nullable"].(bool); ok {
+		delete(schema, "nullable")
+		if typ, ok := schema["type"].(string); ok && nullable {
+			schema["type"] = []interface{}{typ, "null"}
+		}
+	}
+
+	if example, ok := schema["example"]; ok {
+		delete(schema, "example")
+		schema["examples"] = []interface{}{example}
+	}
+
+	if intOrString, ok := schema["x-kubernetes-int-or-string"].(bool); ok && intOrString {
+		delete(schema, "type")
+		schema["anyOf"] = []interface{}{
+			schemaObject{"type": "integer"},
+			schemaObject{"type": "string"},
+		}
+	}
+
+	for key := range schema {
+		switch {
+		case key == "discriminator", key == "xml", key == "externalDocs", key == "deprecated":
+			delete(schema, key)
+		case strings.HasPrefix(key, "x-"):
+			delete(schema, key)
+		}
+	}
+}
+
+func quoteYAML(in string) string {
+	// JSON strings are valid YAML double-quoted scalars
+	out, _ := json.Marshal(in)
+	return string(out)
+}
+
+func firstLine(in string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(in), "\n", 2)[0])
+}
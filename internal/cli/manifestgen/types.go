@@ -64,6 +64,14 @@ type HelmConfig struct {
 	ChartVersion string
 }
 
+// KustomizeConfig stores input parameters for Implementation content generation based on Kubernetes manifests or a kustomization.
+type KustomizeConfig struct {
+	ImplementationConfig
+
+	ManifestsPath string
+	SourceURL     string
+}
+
 // EmptyImplementationConfig stores input parameters for empty Implementation content generation.
 type EmptyImplementationConfig struct {
 	AdditionalInputTypeName string
@@ -123,6 +131,14 @@ type terraformImplementationTemplatingInput struct {
 	Variables       []*tfconfig.Variable
 }
 
+type kustomizeImplementationTemplatingInput struct {
+	templatingInput
+
+	Metadata     types.ImplementationMetadata
+	InterfaceRef types.ManifestRef
+	SourceURL    string
+}
+
 type helmImplementationTemplatingInput struct {
 	templatingInput
 
@@ -9,6 +9,7 @@ import (
 	"capact.io/capact/internal/logger"
 	"capact.io/capact/internal/ptr"
 	hublocalgraphql "capact.io/capact/pkg/hub/api/graphql/local"
+	hubclient "capact.io/capact/pkg/hub/client"
 	"capact.io/capact/pkg/hub/client/local"
 	"github.com/pkg/errors"
 	"github.com/vrischmann/envconfig"
@@ -20,6 +21,9 @@ type StorageBackendRegisterConfig struct {
 	Logger                logger.Config
 	LocalHubEndpoint      string `envconfig:"default=http://capact-hub-local.capact-system/graphql"`
 	TestStorageBackendURL string `envconfig:"default=capact-test-storage-backend.capact-system:50051"`
+	// Gateway holds configuration for calling the Local Hub through the Gateway.
+	// If the Gateway endpoint is set, the Local Hub endpoint is ignored.
+	Gateway hubclient.GatewayConfig `envconfig:"optional"`
 }
 
 const testStorageTypePath = "cap.type.capactio.capact.validation.storage"
@@ -66,6 +70,9 @@ func NewStorageBackendRegister() (*StorageBackendRegister, error) {
 	}
 
 	client := local.NewDefaultClient(cfg.LocalHubEndpoint)
+	if cfg.Gateway.Enabled() {
+		client = local.NewDefaultClient(cfg.Gateway.Endpoint, cfg.Gateway.ClientOptions()...)
+	}
 
 	return &StorageBackendRegister{
 		logger:      logger,
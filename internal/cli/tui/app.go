@@ -0,0 +1,362 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/fatih/color"
+)
+
+const (
+	tickInterval   = 500 * time.Millisecond
+	detailsHeight  = 8
+	minListHeight  = 3
+	keyCtrlRefresh = '\x12' // ctrl+r
+)
+
+// Screen renders the terminal UI and reads keys pressed by the user.
+type Screen interface {
+	Enter() error
+	Leave() error
+	Size() (width, height int)
+	Draw(lines []string) error
+	ReadKey() (rune, error)
+}
+
+// App is a full-screen terminal UI for navigating through nested Views.
+type App struct {
+	stack      []*View
+	status     string
+	confirming rune
+	width      int
+	height     int
+	screen     Screen
+	now        func() time.Time
+	// pending holds the interactive Shortcut, which is run after the terminal UI is closed.
+	pending *Shortcut
+}
+
+// NewApp returns a new App instance with a given root View.
+func NewApp(root *View) *App {
+	return &App{
+		stack:  []*View{root},
+		width:  80,
+		height: 24,
+		now:    time.Now,
+	}
+}
+
+// Run displays the terminal UI until the user quits or the context is canceled.
+// Interactive Shortcuts are run after the terminal UI is closed, and the terminal UI is displayed again afterwards.
+func (a *App) Run(ctx context.Context, screen Screen) error {
+	a.screen = screen
+	a.current().reload(ctx, a.now())
+
+	for {
+		if err := a.runScreen(ctx, screen); err != nil {
+			return err
+		}
+
+		shortcut := a.pending
+		if shortcut == nil {
+			return nil
+		}
+		a.pending = nil
+
+		// the terminal UI is closed and keys are not read anymore, so the Shortcut can use the terminal on its own
+		a.execute(ctx, *shortcut)
+	}
+}
+
+// runScreen displays the terminal UI until the user quits, selects an interactive Shortcut, or the context is canceled.
+func (a *App) runScreen(ctx context.Context, screen Screen) (err error) {
+	if err := screen.Enter(); err != nil {
+		return err
+	}
+	defer func() {
+		if leaveErr := screen.Leave(); err == nil {
+			err = leaveErr
+		}
+	}()
+
+	// Keys are read only on demand, so the reader is not blocked on the terminal after the UI is closed.
+	// The reader exits when the screen is closed, also if the context was canceled while a key read was pending.
+	readCtx, stopReading := context.WithCancel(ctx)
+	keys, next := make(chan rune), make(chan struct{}, 1)
+	errs := make(chan error)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-readCtx.Done():
+				return
+			case <-next:
+			}
+
+			key, err := screen.ReadKey()
+			if err != nil {
+				select {
+				case errs <- err:
+				case <-readCtx.Done():
+				}
+				return
+			}
+
+			select {
+			case keys <- key:
+			case <-readCtx.Done():
+				return
+			}
+		}
+	}()
+
+	reading := true
+	next <- struct{}{}
+	defer func() {
+		stopReading()
+		// a pending read cannot be interrupted, so the reader stops after the next key is pressed
+		if !reading {
+			<-stopped
+		}
+	}()
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		a.width, a.height = screen.Size()
+		if err := screen.Draw(a.Render()); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			reading = false
+			return err
+		case <-ticker.C:
+			a.Tick(ctx)
+		case key := <-keys:
+			reading = false
+			if quit := a.HandleKey(ctx, key); quit || a.pending != nil {
+				return nil
+			}
+			reading = true
+			next <- struct{}{}
+		}
+	}
+}
+
+// Tick reloads the current View if its refresh interval elapsed.
+func (a *App) Tick(ctx context.Context) {
+	if view := a.current(); view.needsRefresh(a.now()) {
+		view.reload(ctx, a.now())
+	}
+}
+
+// HandleKey handles a key pressed by the user. It returns true if the App should quit.
+func (a *App) HandleKey(ctx context.Context, key rune) bool {
+	confirming := a.confirming
+	a.confirming = 0
+
+	view := a.current()
+	switch key {
+	case 'q', terminal.KeyInterrupt:
+		return true
+	case terminal.KeyArrowUp, 'k':
+		view.move(-1)
+	case terminal.KeyArrowDown, 'j':
+		view.move(1)
+	case terminal.KeyEnter, terminal.KeyArrowRight, 'l':
+		a.open(ctx)
+	case terminal.KeyEscape, terminal.KeyArrowLeft, terminal.KeyBackspace, terminal.KeyDelete, 'h':
+		a.back()
+	case keyCtrlRefresh:
+		view.reload(ctx, a.now())
+	default:
+		a.runShortcut(ctx, key, confirming == key)
+	}
+
+	return false
+}
+
+// Render returns lines which fill the whole screen.
+func (a *App) Render() []string {
+	view := a.current()
+	bold := color.New(color.Bold).SprintFunc()
+	selected := color.New(color.ReverseVideo).SprintFunc()
+	faint := color.New(color.Faint).SprintFunc()
+
+	var titles []string
+	for _, v := range a.stack {
+		titles = append(titles, v.Title)
+	}
+
+	lines := []string{
+		bold(a.truncate(strings.Join(titles, " › "))),
+		strings.Repeat("─", a.width),
+	}
+
+	listHeight := a.height - len(lines) - detailsHeight - 3
+	if listHeight < minListHeight {
+		listHeight = minListHeight
+	}
+
+	switch {
+	case view.err != nil:
+		lines = append(lines, color.RedString(a.truncate(fmt.Sprintf("Error: %s", view.err))))
+	case view.loadedAt.IsZero():
+		lines = append(lines, faint("Loading..."))
+	case len(view.items) == 0:
+		lines = append(lines, faint("No items"))
+	}
+
+	a.scroll(view, listHeight)
+	for idx := view.offset; idx < len(view.items) && idx < view.offset+listHeight; idx++ {
+		line := a.truncate("  " + view.items[idx].Label)
+		if idx == view.cursor {
+			line = selected(a.truncate("> " + view.items[idx].Label))
+		}
+		lines = append(lines, line)
+	}
+	lines = a.pad(lines, 2+listHeight)
+
+	lines = append(lines, strings.Repeat("─", a.width))
+	if item := view.selected(); item != nil {
+		for idx, detail := range item.Details {
+			if idx == detailsHeight {
+				break
+			}
+			lines = append(lines, a.truncate(detail))
+		}
+	}
+	lines = a.pad(lines, 2+listHeight+1+detailsHeight)
+
+	lines = append(lines, a.truncate(a.status))
+	lines = append(lines, faint(a.truncate(a.help())))
+
+	return lines
+}
+
+func (a *App) current() *View {
+	return a.stack[len(a.stack)-1]
+}
+
+func (a *App) open(ctx context.Context) {
+	item := a.current().selected()
+	if item == nil || item.Open == nil {
+		return
+	}
+
+	view, err := item.Open(ctx)
+	if err != nil {
+		a.status = color.RedString("Error: %s", err)
+		return
+	}
+	a.push(ctx, view)
+}
+
+func (a *App) push(ctx context.Context, view *View) {
+	a.stack = append(a.stack, view)
+	a.status = ""
+	view.reload(ctx, a.now())
+}
+
+func (a *App) back() {
+	if len(a.stack) == 1 {
+		return
+	}
+	a.stack = a.stack[:len(a.stack)-1]
+	a.status = ""
+}
+
+func (a *App) runShortcut(ctx context.Context, key rune, confirmed bool) {
+	item := a.current().selected()
+	if item == nil {
+		return
+	}
+	shortcut, ok := item.Shortcuts[key]
+	if !ok {
+		return
+	}
+
+	if shortcut.Confirm && !confirmed {
+		a.confirming = key
+		a.status = color.YellowString("Press %q again to confirm: %s", key, strings.ToLower(shortcut.Name))
+		return
+	}
+
+	if shortcut.Interactive && a.screen != nil {
+		a.pending = &shortcut
+		return
+	}
+
+	a.execute(ctx, shortcut)
+}
+
+func (a *App) execute(ctx context.Context, shortcut Shortcut) {
+	msg, view, err := shortcut.Run(ctx)
+	if err != nil {
+		a.status = color.RedString("Error: %s", err)
+		return
+	}
+
+	if view != nil {
+		a.push(ctx, view)
+	} else {
+		a.current().reload(ctx, a.now())
+	}
+	a.status = color.GreenString("%s", msg)
+}
+
+func (a *App) help() string {
+	keys := []string{"↑/↓ move", "enter open", "esc back", "ctrl+r refresh"}
+
+	if item := a.current().selected(); item != nil {
+		var shortcuts []string
+		for key, shortcut := range item.Shortcuts {
+			shortcuts = append(shortcuts, fmt.Sprintf("%c %s", key, strings.ToLower(shortcut.Name)))
+		}
+		sort.Strings(shortcuts)
+		keys = append(keys, shortcuts...)
+	}
+
+	return strings.Join(append(keys, "q quit"), " • ")
+}
+
+// scroll keeps the cursor within the visible part of the list.
+func (a *App) scroll(view *View, height int) {
+	if view.cursor < view.offset {
+		view.offset = view.cursor
+	}
+	if view.cursor >= view.offset+height {
+		view.offset = view.cursor - height + 1
+	}
+	if view.offset < 0 {
+		view.offset = 0
+	}
+}
+
+func (a *App) truncate(line string) string {
+	runes := []rune(line)
+	if len(runes) <= a.width {
+		return line
+	}
+	if a.width <= 1 {
+		return string(runes[:a.width])
+	}
+	return string(runes[:a.width-1]) + "…"
+}
+
+func (a *App) pad(lines []string, height int) []string {
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return lines
+}
@@ -0,0 +1,291 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gqlengine "capact.io/capact/pkg/engine/api/graphql"
+)
+
+func TestApp_NavigatesThroughViews(t *testing.T) {
+	// given
+	ctx := context.Background()
+	child := NewStaticView("Child", []Item{{Label: "leaf", Details: []string{"Leaf details"}}})
+	root := NewStaticView("Root", []Item{
+		{Label: "first"},
+		{Label: "second", Open: func(context.Context) (*View, error) { return child, nil }},
+	})
+
+	app := NewApp(root)
+	root.reload(ctx, time.Now())
+
+	// when
+	app.HandleKey(ctx, terminal.KeyArrowDown)
+	app.HandleKey(ctx, terminal.KeyEnter)
+
+	// then
+	out := strings.Join(app.Render(), "\n")
+	assert.Contains(t, out, "Root › Child")
+	assert.Contains(t, out, "> leaf")
+	assert.Contains(t, out, "Leaf details")
+
+	// when
+	app.HandleKey(ctx, terminal.KeyArrowLeft)
+
+	// then
+	out = strings.Join(app.Render(), "\n")
+	assert.NotContains(t, out, "Child")
+	assert.Contains(t, out, "> second")
+}
+
+func TestApp_RenderFillsScreen(t *testing.T) {
+	// given
+	var items []Item
+	for i := 0; i < 50; i++ {
+		items = append(items, Item{Label: strings.Repeat("x", 100)})
+	}
+	view := NewStaticView("Root", items)
+	view.reload(context.Background(), time.Now())
+
+	app := NewApp(view)
+	app.width, app.height = 40, 20
+
+	// when
+	lines := app.Render()
+
+	// then
+	assert.Len(t, lines, 20)
+	for _, line := range lines {
+		assert.LessOrEqual(t, len([]rune(line)), 40)
+	}
+}
+
+func TestApp_DeleteActionRequiresConfirmation(t *testing.T) {
+	// given
+	ctx := context.Background()
+	actions := &fakeActions{
+		actions: []*gqlengine.Action{fixAction("ready", gqlengine.ActionStatusPhaseReadyToRun)},
+	}
+	views := NewViews(nil, actions, nil, "default", time.Second)
+
+	app := NewApp(views.Actions())
+	app.current().reload(ctx, time.Now())
+
+	// when
+	app.HandleKey(ctx, 'd')
+
+	// then
+	assert.Empty(t, actions.deleted)
+	assert.Contains(t, app.status, `Press 'd' again to confirm: delete`)
+
+	// when
+	app.HandleKey(ctx, 'd')
+
+	// then
+	assert.Equal(t, []string{"ready"}, actions.deleted)
+	assert.Contains(t, app.status, `Action "ready" scheduled for deletion`)
+}
+
+func TestApp_RunOnlyReadyToRunAction(t *testing.T) {
+	// given
+	ctx := context.Background()
+	actions := &fakeActions{
+		actions: []*gqlengine.Action{
+			fixAction("running", gqlengine.ActionStatusPhaseRunning),
+			fixAction("ready", gqlengine.ActionStatusPhaseReadyToRun),
+		},
+	}
+	views := NewViews(nil, actions, nil, "default", time.Second)
+
+	app := NewApp(views.Actions())
+	app.current().reload(ctx, time.Now())
+
+	// when
+	app.HandleKey(ctx, 'r')
+	app.HandleKey(ctx, terminal.KeyArrowDown)
+	app.HandleKey(ctx, 'r')
+
+	// then
+	assert.Equal(t, []string{"ready"}, actions.run)
+}
+
+func TestApp_TickRefreshesActions(t *testing.T) {
+	// given
+	ctx := context.Background()
+	now := time.Now()
+	actions := &fakeActions{
+		actions: []*gqlengine.Action{fixAction("act", gqlengine.ActionStatusPhaseBeingRendered)},
+	}
+	views := NewViews(nil, actions, nil, "default", time.Second)
+
+	app := NewApp(views.Actions())
+	app.now = func() time.Time { return now }
+	app.current().reload(ctx, now)
+
+	actions.actions[0].Status.Phase = gqlengine.ActionStatusPhaseSucceeded
+
+	// when
+	app.Tick(ctx)
+
+	// then
+	require.Len(t, app.current().items, 1)
+	assert.Contains(t, app.current().items[0].Label, string(gqlengine.ActionStatusPhaseBeingRendered))
+
+	// when
+	now = now.Add(time.Second)
+	app.Tick(ctx)
+
+	// then
+	assert.Contains(t, app.current().items[0].Label, string(gqlengine.ActionStatusPhaseSucceeded))
+}
+
+func TestApp_RunInteractiveShortcutOutsideScreen(t *testing.T) {
+	// given
+	screen := &fakeScreen{keys: []rune{'c', 'q'}}
+	var entered bool
+	root := NewStaticView("Root", []Item{{
+		Label: "interface",
+		Shortcuts: map[rune]Shortcut{
+			'c': {
+				Name:        "Create Action",
+				Interactive: true,
+				Run: func(context.Context) (string, *View, error) {
+					entered = screen.entered
+					return "Action created", nil, nil
+				},
+			},
+		},
+	}})
+
+	// when
+	err := NewApp(root).Run(context.Background(), screen)
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, entered)
+	assert.Equal(t, 2, screen.enterCount)
+	assert.False(t, screen.entered)
+}
+
+func TestApp_RunStopsReadingKeysOnContextCancel(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	screen := &blockingScreen{
+		reads:   make(chan struct{}, 2),
+		release: make(chan rune),
+	}
+	app := NewApp(NewStaticView("Root", nil))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(ctx, screen)
+	}()
+	<-screen.reads
+
+	// when
+	cancel()
+
+	// then
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the context was canceled")
+	}
+
+	// the pending read returns, and the reader exits without reading another key
+	select {
+	case screen.release <- 'q':
+	case <-time.After(time.Second):
+		t.Fatal("the pending key read was not waiting for a key")
+	}
+	select {
+	case <-screen.reads:
+		t.Fatal("the reader read another key after the context was canceled")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+type fakeScreen struct {
+	keys       []rune
+	entered    bool
+	enterCount int
+}
+
+func (f *fakeScreen) Enter() error {
+	f.entered = true
+	f.enterCount++
+	return nil
+}
+
+func (f *fakeScreen) Leave() error {
+	f.entered = false
+	return nil
+}
+
+func (f *fakeScreen) Size() (int, int) {
+	return 80, 24
+}
+
+func (f *fakeScreen) Draw([]string) error {
+	return nil
+}
+
+func (f *fakeScreen) ReadKey() (rune, error) {
+	key := f.keys[0]
+	f.keys = f.keys[1:]
+	return key, nil
+}
+
+// blockingScreen blocks on reading keys until a key is released by the test.
+type blockingScreen struct {
+	fakeScreen
+	reads   chan struct{}
+	release chan rune
+}
+
+func (b *blockingScreen) ReadKey() (rune, error) {
+	b.reads <- struct{}{}
+	return <-b.release, nil
+}
+
+type fakeActions struct {
+	actions []*gqlengine.Action
+	run     []string
+	deleted []string
+}
+
+func (f *fakeActions) ListActions(context.Context, *gqlengine.ActionFilter) ([]*gqlengine.Action, error) {
+	// return copies, so the test can modify Actions between refreshes
+	var out []*gqlengine.Action
+	for _, act := range f.actions {
+		cpy := *act
+		status := *act.Status
+		cpy.Status = &status
+		out = append(out, &cpy)
+	}
+	return out, nil
+}
+
+func (f *fakeActions) RunAction(_ context.Context, name string) error {
+	f.run = append(f.run, name)
+	return nil
+}
+
+func (f *fakeActions) DeleteAction(_ context.Context, name string) error {
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func fixAction(name string, phase gqlengine.ActionStatusPhase) *gqlengine.Action {
+	return &gqlengine.Action{
+		Name:   name,
+		Status: &gqlengine.ActionStatus{Phase: phase},
+	}
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"k8s.io/kubectl/pkg/util/term"
+)
+
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	cursorHome     = "\x1b[H"
+	eraseLineEnd   = "\x1b[K"
+	eraseScreenEnd = "\x1b[J"
+)
+
+// terminalScreen draws the terminal UI on the alternate screen of the terminal.
+type terminalScreen struct {
+	out    *os.File
+	reader *terminal.RuneReader
+}
+
+func newTerminalScreen(in, out *os.File) *terminalScreen {
+	return &terminalScreen{
+		out:    out,
+		reader: terminal.NewRuneReader(terminal.Stdio{In: in, Out: out, Err: os.Stderr}),
+	}
+}
+
+func (s *terminalScreen) Enter() error {
+	if err := s.reader.SetTermMode(); err != nil {
+		return err
+	}
+	_, err := s.out.WriteString(enterAltScreen)
+	return err
+}
+
+func (s *terminalScreen) Leave() error {
+	if _, err := s.out.WriteString(leaveAltScreen); err != nil {
+		return err
+	}
+	return s.reader.RestoreTermMode()
+}
+
+func (s *terminalScreen) Size() (int, int) {
+	size := term.GetSize(s.out.Fd())
+	if size == nil {
+		return 80, 24
+	}
+	return int(size.Width), int(size.Height)
+}
+
+func (s *terminalScreen) Draw(lines []string) error {
+	var buf bytes.Buffer
+	buf.WriteString(cursorHome)
+	for idx, line := range lines {
+		buf.WriteString(line)
+		buf.WriteString(eraseLineEnd)
+		// raw mode doesn't translate new lines, and the last line cannot be followed by one, as it would scroll the screen
+		if idx < len(lines)-1 {
+			buf.WriteString("\r\n")
+		}
+	}
+	buf.WriteString(eraseScreenEnd)
+
+	_, err := s.out.Write(buf.Bytes())
+	return err
+}
+
+func (s *terminalScreen) ReadKey() (rune, error) {
+	key, _, err := s.reader.ReadRune()
+	return key, err
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"capact.io/capact/internal/cli"
+	"capact.io/capact/internal/cli/action"
+	"capact.io/capact/internal/cli/client"
+	"capact.io/capact/internal/cli/config"
+	gqlengine "capact.io/capact/pkg/engine/api/graphql"
+)
+
+// ErrNotInteractiveTerminal defines error indicating that the terminal UI cannot be displayed.
+var ErrNotInteractiveTerminal = errors.New("terminal UI requires an interactive terminal")
+
+// Options holds configuration for the terminal UI.
+type Options struct {
+	Namespace       string
+	RefreshInterval time.Duration
+}
+
+// Run displays the terminal UI for the default Capact context.
+func Run(ctx context.Context, opts Options) error {
+	if !cli.IsSmartTerminal(os.Stdout) {
+		return ErrNotInteractiveTerminal
+	}
+
+	server := config.GetDefaultContext()
+
+	hubCli, err := client.NewHub(server)
+	if err != nil {
+		return err
+	}
+
+	actionCli, err := client.NewCluster(server)
+	if err != nil {
+		return err
+	}
+
+	createAction := func(ctx context.Context, interfacePath string) (*gqlengine.Action, error) {
+		out, err := action.Create(ctx, action.CreateOptions{
+			InterfacePath: interfacePath,
+			Namespace:     opts.Namespace,
+			Interactive:   true,
+			Validate:      true,
+		}, os.Stdout)
+		if err != nil {
+			return nil, err
+		}
+		return out.Action, nil
+	}
+
+	views := NewViews(hubCli, actionCli, createAction, opts.Namespace, opts.RefreshInterval)
+	return NewApp(views.Root()).Run(ctx, newTerminalScreen(os.Stdin, os.Stdout))
+}
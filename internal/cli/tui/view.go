@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"context"
+	"time"
+)
+
+// Item is a single row displayed in a View.
+type Item struct {
+	Label   string
+	Details []string
+	// Open returns a View displayed after selecting the Item. If nil, the Item cannot be opened.
+	Open func(ctx context.Context) (*View, error)
+	// Shortcuts holds operations which can be executed on the Item, indexed by the key.
+	Shortcuts map[rune]Shortcut
+}
+
+// Shortcut is an operation executed on a selected Item.
+type Shortcut struct {
+	Name string
+	// Confirm requires pressing the key twice before running the operation.
+	Confirm bool
+	// Interactive suspends the terminal UI while the operation is running, so it can prompt the user.
+	Interactive bool
+	// Run executes the operation and returns a message displayed in the status line.
+	// If it returns a View, the View is opened afterwards.
+	Run func(ctx context.Context) (string, *View, error)
+}
+
+// View is a list of Items loaded from a given source.
+type View struct {
+	Title string
+	Load  func(ctx context.Context) ([]Item, error)
+	// Refresh is the interval in which Items are reloaded while the View is displayed. Zero disables refreshing.
+	Refresh time.Duration
+
+	items    []Item
+	cursor   int
+	offset   int
+	err      error
+	loadedAt time.Time
+}
+
+// NewStaticView returns a View with a fixed list of Items.
+func NewStaticView(title string, items []Item) *View {
+	return &View{
+		Title: title,
+		Load: func(context.Context) ([]Item, error) {
+			return items, nil
+		},
+	}
+}
+
+func (v *View) reload(ctx context.Context, now time.Time) {
+	var selected string
+	if item := v.selected(); item != nil {
+		selected = item.Label
+	}
+
+	items, err := v.Load(ctx)
+	v.loadedAt = now
+	v.err = err
+	if err != nil {
+		return
+	}
+	v.items = items
+
+	// keep the cursor on the same Item, as the list may change between refreshes
+	for idx, item := range items {
+		if item.Label == selected {
+			v.cursor = idx
+			return
+		}
+	}
+	v.move(0)
+}
+
+func (v *View) selected() *Item {
+	if v.cursor < 0 || v.cursor >= len(v.items) {
+		return nil
+	}
+	return &v.items[v.cursor]
+}
+
+func (v *View) move(delta int) {
+	v.cursor += delta
+	if v.cursor >= len(v.items) {
+		v.cursor = len(v.items) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+func (v *View) needsRefresh(now time.Time) bool {
+	return v.Refresh > 0 && now.Sub(v.loadedAt) >= v.Refresh
+}
@@ -0,0 +1,435 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"capact.io/capact/internal/k8s-engine/graphql/namespace"
+	gqlengine "capact.io/capact/pkg/engine/api/graphql"
+	gqllocalapi "capact.io/capact/pkg/hub/api/graphql/local"
+	gqlpublicapi "capact.io/capact/pkg/hub/api/graphql/public"
+	"capact.io/capact/pkg/hub/client/local"
+	"capact.io/capact/pkg/hub/client/public"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// HubClient aggregates Hub operations used by the terminal UI.
+type HubClient interface {
+	ListInterfaces(ctx context.Context, opts ...public.InterfaceOption) ([]*gqlpublicapi.Interface, error)
+	ListImplementationRevisionsForInterface(ctx context.Context, ref gqlpublicapi.InterfaceReference, opts ...public.ListImplementationRevisionsForInterfaceOption) ([]gqlpublicapi.ImplementationRevision, error)
+	ListTypes(ctx context.Context, opts ...public.TypeOption) ([]*gqlpublicapi.Type, error)
+	ListTypeInstances(ctx context.Context, filter *gqllocalapi.TypeInstanceFilter, opts ...local.TypeInstancesOption) ([]gqllocalapi.TypeInstance, error)
+	FindTypeInstance(ctx context.Context, id string, opts ...local.TypeInstancesOption) (*gqllocalapi.TypeInstance, error)
+	FindTypeInstancesTypeRef(ctx context.Context, ids []string) (map[string]gqllocalapi.TypeInstanceTypeReference, error)
+}
+
+// ActionClient aggregates Engine operations used by the terminal UI.
+type ActionClient interface {
+	ListActions(ctx context.Context, filter *gqlengine.ActionFilter) ([]*gqlengine.Action, error)
+	RunAction(ctx context.Context, name string) error
+	DeleteAction(ctx context.Context, name string) error
+}
+
+// ActionCreator creates an Action for a given Interface path. It is allowed to prompt the user for input.
+type ActionCreator func(ctx context.Context, interfacePath string) (*gqlengine.Action, error)
+
+const typeInstanceListFields = local.TypeInstanceRootFields | local.TypeInstanceTypeRefFields | local.TypeInstanceBackendFields |
+	local.TypeInstanceLatestResourceVersionVersionField | local.TypeInstanceUsesIDField | local.TypeInstanceUsedByIDField
+
+// Views builds Views for Hub manifests, TypeInstances and Actions.
+type Views struct {
+	hub           HubClient
+	actions       ActionClient
+	createAction  ActionCreator
+	namespace     string
+	actionRefresh time.Duration
+	now           func() time.Time
+}
+
+// NewViews returns a new Views instance.
+func NewViews(hub HubClient, actions ActionClient, createAction ActionCreator, namespace string, actionRefresh time.Duration) *Views {
+	return &Views{
+		hub:           hub,
+		actions:       actions,
+		createAction:  createAction,
+		namespace:     namespace,
+		actionRefresh: actionRefresh,
+		now:           time.Now,
+	}
+}
+
+// Root returns the main menu.
+func (v *Views) Root() *View {
+	return NewStaticView("Capact", []Item{
+		{
+			Label:   "InterfaceGroups",
+			Details: []string{"Browse InterfaceGroups, Interfaces and Implementations available in Public Hub."},
+			Open:    v.static(v.InterfaceGroups),
+		},
+		{
+			Label:   "Types",
+			Details: []string{"Browse Types available in Public Hub."},
+			Open:    v.static(v.Types),
+		},
+		{
+			Label:   "TypeInstances",
+			Details: []string{"Browse TypeInstances stored in Local Hub with their relations."},
+			Open:    v.static(v.TypeInstances),
+		},
+		{
+			Label:   "Actions",
+			Details: []string{fmt.Sprintf("Track Actions in the %q Namespace.", v.namespace)},
+			Open:    v.static(v.Actions),
+		},
+	})
+}
+
+// InterfaceGroups returns a View with Interfaces grouped by their prefix.
+func (v *Views) InterfaceGroups() *View {
+	return &View{
+		Title: "InterfaceGroups",
+		Load: func(ctx context.Context) ([]Item, error) {
+			ifaces, err := v.hub.ListInterfaces(ctx, public.WithLatestInterfaceRevision(public.InterfaceRevisionRootFields|public.InterfaceRevisionMetadataFields))
+			if err != nil {
+				return nil, err
+			}
+
+			groups := map[string][]*gqlpublicapi.Interface{}
+			for _, iface := range ifaces {
+				if iface == nil {
+					continue
+				}
+				groups[iface.Prefix] = append(groups[iface.Prefix], iface)
+			}
+
+			var items []Item
+			for _, prefix := range sortedKeys(groups) {
+				prefix, group := prefix, groups[prefix]
+				items = append(items, Item{
+					Label:   prefix,
+					Details: []string{fmt.Sprintf("Interfaces: %d", len(group))},
+					Open: v.static(func() *View {
+						return v.interfaces(prefix, group)
+					}),
+				})
+			}
+			return items, nil
+		},
+	}
+}
+
+func (v *Views) interfaces(prefix string, ifaces []*gqlpublicapi.Interface) *View {
+	var items []Item
+	for _, iface := range ifaces {
+		iface := iface
+		item := Item{
+			Label: iface.Path,
+			Open: v.static(func() *View {
+				return v.implementations(iface.Path)
+			}),
+			Shortcuts: map[rune]Shortcut{
+				'c': {
+					Name:        "Create Action",
+					Interactive: true,
+					Run: func(ctx context.Context) (string, *View, error) {
+						act, err := v.createAction(ctx, iface.Path)
+						if err != nil {
+							return "", nil, err
+						}
+						return fmt.Sprintf("Action %q created", act.Name), v.Actions(), nil
+					},
+				},
+			},
+		}
+		if rev := iface.LatestRevision; rev != nil {
+			item.Details = append(item.Details, fmt.Sprintf("Revision: %s", rev.Revision))
+			item.Details = append(item.Details, genericMetadataDetails(rev.Metadata)...)
+		}
+		items = append(items, item)
+	}
+
+	return NewStaticView(prefix, items)
+}
+
+func (v *Views) implementations(interfacePath string) *View {
+	return &View{
+		Title: interfacePath,
+		Load: func(ctx context.Context) ([]Item, error) {
+			revs, err := v.hub.ListImplementationRevisionsForInterface(ctx, gqlpublicapi.InterfaceReference{Path: interfacePath})
+			if err != nil {
+				return nil, err
+			}
+
+			var items []Item
+			for _, rev := range revs {
+				if rev.Metadata == nil {
+					continue
+				}
+				item := Item{
+					Label:   fmt.Sprintf("%s:%s", rev.Metadata.Path, rev.Revision),
+					Details: []string{fmt.Sprintf("Description: %s", rev.Metadata.Description)},
+				}
+				if rev.Metadata.DisplayName != nil {
+					item.Details = append([]string{fmt.Sprintf("Display name: %s", *rev.Metadata.DisplayName)}, item.Details...)
+				}
+				items = append(items, item)
+			}
+			return items, nil
+		},
+	}
+}
+
+// Types returns a View with Types available in Public Hub.
+func (v *Views) Types() *View {
+	return &View{
+		Title: "Types",
+		Load: func(ctx context.Context) ([]Item, error) {
+			types, err := v.hub.ListTypes(ctx, public.WithTypeLatestRevision(public.TypeRevisionRootFields|public.TypeRevisionMetadataFields))
+			if err != nil {
+				return nil, err
+			}
+
+			var items []Item
+			for _, typ := range types {
+				if typ == nil {
+					continue
+				}
+				item := Item{Label: typ.Path}
+				if rev := typ.LatestRevision; rev != nil {
+					item.Details = append(item.Details, fmt.Sprintf("Revision: %s", rev.Revision))
+					if rev.Metadata != nil {
+						if rev.Metadata.DisplayName != nil {
+							item.Details = append(item.Details, fmt.Sprintf("Display name: %s", *rev.Metadata.DisplayName))
+						}
+						item.Details = append(item.Details, fmt.Sprintf("Description: %s", rev.Metadata.Description))
+					}
+				}
+				items = append(items, item)
+			}
+			return items, nil
+		},
+	}
+}
+
+// TypeInstances returns a View with TypeInstances stored in Local Hub.
+func (v *Views) TypeInstances() *View {
+	return &View{
+		Title: "TypeInstances",
+		Load: func(ctx context.Context) ([]Item, error) {
+			tis, err := v.hub.ListTypeInstances(ctx, &gqllocalapi.TypeInstanceFilter{}, local.WithFields(typeInstanceListFields))
+			if err != nil {
+				return nil, err
+			}
+
+			var items []Item
+			for idx := range tis {
+				items = append(items, v.typeInstanceItem(tis[idx]))
+			}
+			return items, nil
+		},
+	}
+}
+
+// TypeInstance returns a View with relations of a given TypeInstance.
+func (v *Views) TypeInstance(id string) *View {
+	return &View{
+		Title: id,
+		Load: func(ctx context.Context) ([]Item, error) {
+			ti, err := v.hub.FindTypeInstance(ctx, id, local.WithFields(typeInstanceListFields))
+			if err != nil {
+				return nil, err
+			}
+			if ti == nil {
+				return nil, fmt.Errorf("TypeInstance %s not found", id)
+			}
+
+			var ids []string
+			for _, rels := range [][]*gqllocalapi.TypeInstance{ti.Uses, ti.UsedBy} {
+				for _, rel := range rels {
+					if rel != nil {
+						ids = append(ids, rel.ID)
+					}
+				}
+			}
+			typeRefs, err := v.hub.FindTypeInstancesTypeRef(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			items := []Item{{Label: "self: " + typeInstanceLabel(ti.ID, ti.TypeRef), Details: typeInstanceDetails(*ti)}}
+			items = append(items, v.relationItems("uses", ti.Uses, typeRefs)...)
+			items = append(items, v.relationItems("usedBy", ti.UsedBy, typeRefs)...)
+			return items, nil
+		},
+	}
+}
+
+func (v *Views) relationItems(relation string, tis []*gqllocalapi.TypeInstance, typeRefs map[string]gqllocalapi.TypeInstanceTypeReference) []Item {
+	var items []Item
+	for _, ti := range tis {
+		if ti == nil {
+			continue
+		}
+		id := ti.ID
+
+		var typeRef *gqllocalapi.TypeInstanceTypeReference
+		if ref, ok := typeRefs[id]; ok {
+			typeRef = &ref
+		}
+
+		items = append(items, Item{
+			Label:   fmt.Sprintf("%s: %s", relation, typeInstanceLabel(id, typeRef)),
+			Details: []string{fmt.Sprintf("Press enter to see relations of TypeInstance %s", id)},
+			Open: v.static(func() *View {
+				return v.TypeInstance(id)
+			}),
+		})
+	}
+	return items
+}
+
+func (v *Views) typeInstanceItem(ti gqllocalapi.TypeInstance) Item {
+	id := ti.ID
+	return Item{
+		Label:   typeInstanceLabel(ti.ID, ti.TypeRef),
+		Details: typeInstanceDetails(ti),
+		Open: v.static(func() *View {
+			return v.TypeInstance(id)
+		}),
+	}
+}
+
+// Actions returns a View with Actions, which is refreshed periodically.
+func (v *Views) Actions() *View {
+	return &View{
+		Title:   fmt.Sprintf("Actions (%s)", v.namespace),
+		Refresh: v.actionRefresh,
+		Load: func(ctx context.Context) ([]Item, error) {
+			ctx = namespace.NewContext(ctx, v.namespace)
+			acts, err := v.actions.ListActions(ctx, &gqlengine.ActionFilter{})
+			if err != nil {
+				return nil, err
+			}
+
+			// newest Actions first, as they are the most likely to be tracked
+			sort.SliceStable(acts, func(i, j int) bool {
+				return acts[i].CreatedAt.Time.After(acts[j].CreatedAt.Time)
+			})
+
+			var items []Item
+			for _, act := range acts {
+				if act == nil {
+					continue
+				}
+				items = append(items, v.actionItem(act))
+			}
+			return items, nil
+		},
+	}
+}
+
+func (v *Views) actionItem(act *gqlengine.Action) Item {
+	name := act.Name
+	phase := gqlengine.ActionStatusPhase("")
+	if act.Status != nil {
+		phase = act.Status.Phase
+	}
+
+	details := []string{
+		fmt.Sprintf("Name: %s", name),
+		fmt.Sprintf("Phase: %s", phase),
+		fmt.Sprintf("Age: %s", duration.HumanDuration(v.now().Sub(act.CreatedAt.Time))),
+	}
+	if act.ActionRef != nil {
+		details = append(details, fmt.Sprintf("Interface: %s:%s", act.ActionRef.Path, act.ActionRef.Revision))
+	}
+	if act.Status != nil && act.Status.Message != nil {
+		details = append(details, fmt.Sprintf("Message: %s", *act.Status.Message))
+	}
+
+	item := Item{
+		Label:   fmt.Sprintf("%-40s %s", name, phase),
+		Details: details,
+		Shortcuts: map[rune]Shortcut{
+			'd': {
+				Name:    "Delete",
+				Confirm: true,
+				Run: func(ctx context.Context) (string, *View, error) {
+					if err := v.actions.DeleteAction(namespace.NewContext(ctx, v.namespace), name); err != nil {
+						return "", nil, err
+					}
+					return fmt.Sprintf("Action %q scheduled for deletion", name), nil, nil
+				},
+			},
+		},
+	}
+
+	if phase == gqlengine.ActionStatusPhaseReadyToRun {
+		item.Shortcuts['r'] = Shortcut{
+			Name: "Run",
+			Run: func(ctx context.Context) (string, *View, error) {
+				if err := v.actions.RunAction(namespace.NewContext(ctx, v.namespace), name); err != nil {
+					return "", nil, err
+				}
+				return fmt.Sprintf("Action %q run", name), nil, nil
+			},
+		}
+	}
+
+	return item
+}
+
+func (v *Views) static(fn func() *View) func(context.Context) (*View, error) {
+	return func(context.Context) (*View, error) {
+		return fn(), nil
+	}
+}
+
+func typeInstanceLabel(id string, typeRef *gqllocalapi.TypeInstanceTypeReference) string {
+	if typeRef == nil {
+		return id
+	}
+	return fmt.Sprintf("%s  %s:%s", id, typeRef.Path, typeRef.Revision)
+}
+
+func typeInstanceDetails(ti gqllocalapi.TypeInstance) []string {
+	details := []string{fmt.Sprintf("ID: %s", ti.ID)}
+	if ti.TypeRef != nil {
+		details = append(details, fmt.Sprintf("Type: %s:%s", ti.TypeRef.Path, ti.TypeRef.Revision))
+	}
+	if ti.LatestResourceVersion != nil {
+		details = append(details, fmt.Sprintf("Resource version: %d", ti.LatestResourceVersion.ResourceVersion))
+	}
+	if ti.Backend != nil {
+		details = append(details, fmt.Sprintf("Backend: %s", ti.Backend.ID))
+	}
+	if ti.LockedBy != nil {
+		details = append(details, fmt.Sprintf("Locked by: %s", *ti.LockedBy))
+	}
+	details = append(details, fmt.Sprintf("Uses: %d, used by: %d", len(ti.Uses), len(ti.UsedBy)))
+	return details
+}
+
+func genericMetadataDetails(metadata *gqlpublicapi.GenericMetadata) []string {
+	if metadata == nil {
+		return nil
+	}
+
+	var details []string
+	if metadata.DisplayName != nil {
+		details = append(details, fmt.Sprintf("Display name: %s", *metadata.DisplayName))
+	}
+	return append(details, fmt.Sprintf("Description: %s", metadata.Description))
+}
+
+func sortedKeys(in map[string][]*gqlpublicapi.Interface) []string {
+	var keys []string
+	for key := range in {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
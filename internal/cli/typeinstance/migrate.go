@@ -0,0 +1,400 @@
+package typeinstance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"capact.io/capact/internal/cli/client"
+	"capact.io/capact/internal/cli/config"
+	"capact.io/capact/internal/cli/printer"
+	gqllocalapi "capact.io/capact/pkg/hub/api/graphql/local"
+	"capact.io/capact/pkg/hub/client/local"
+	storagebackend "capact.io/capact/pkg/hub/storage-backend"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// MigrationOwnerID is the lock owner used during the TypeInstance backend migration.
+// It is constant, so an interrupted migration can be resumed even if the TypeInstance was left locked.
+const MigrationOwnerID = "capact-cli/typeinstance-migrate"
+
+// MigrateOptions holds configuration for TypeInstance backend migration.
+type MigrateOptions struct {
+	TypeInstanceIDs  []string
+	FromBackend      string
+	ToBackend        string
+	ToBackendContext string
+	ProgressFile     string
+	DryRun           bool
+}
+
+var (
+	// ErrMissingTypeInstancesToMigrateOpt defines error indicating that TypeInstance IDs or the source backend need to be provided.
+	ErrMissingTypeInstancesToMigrateOpt = errors.New("TypeInstance IDs or the source backend need to be specified")
+	// ErrMutuallyExclusiveMigrateOpts defines error indicating that TypeInstance IDs cannot be provided together with the source backend.
+	ErrMutuallyExclusiveMigrateOpts = errors.New("TypeInstance IDs cannot be provided when the source backend is specified")
+	// ErrMissingTargetBackendOpt defines error indicating that the target backend needs to be provided.
+	ErrMissingTargetBackendOpt = errors.New("target backend needs to be specified")
+)
+
+// Validate validates if provided migrate options are valid.
+func (o *MigrateOptions) Validate() error {
+	if o.ToBackend == "" {
+		return ErrMissingTargetBackendOpt
+	}
+
+	if len(o.TypeInstanceIDs) == 0 && o.FromBackend == "" {
+		return ErrMissingTypeInstancesToMigrateOpt
+	}
+
+	if len(o.TypeInstanceIDs) > 0 && o.FromBackend != "" {
+		return ErrMutuallyExclusiveMigrateOpts
+	}
+
+	return nil
+}
+
+// MigrationProgress holds IDs of TypeInstances which were already migrated to a given backend.
+type MigrationProgress struct {
+	ToBackend string   `json:"toBackend"`
+	Migrated  []string `json:"migrated"`
+}
+
+// Migrate moves TypeInstances with all their resource versions to a given backend.
+func Migrate(ctx context.Context, opts MigrateOptions, w io.Writer) error {
+	server := config.GetDefaultContext()
+
+	hubCli, err := client.NewHub(server)
+	if err != nil {
+		return err
+	}
+
+	return NewMigrator(hubCli, storagebackend.NewValueReader(hubCli)).Migrate(ctx, opts, w)
+}
+
+// ValueGetter reads TypeInstance values directly from storage backends.
+type ValueGetter interface {
+	GetValue(ctx context.Context, backendID, typeInstanceID string, resourceVersion int, backendCtx interface{}) (interface{}, error)
+}
+
+// Migrator migrates TypeInstances between storage backends.
+type Migrator struct {
+	hubCli client.Hub
+	values ValueGetter
+}
+
+// NewMigrator returns a new Migrator instance.
+func NewMigrator(hubCli client.Hub, values ValueGetter) *Migrator {
+	return &Migrator{hubCli: hubCli, values: values}
+}
+
+// Migrate moves TypeInstances with all their resource versions to a given backend.
+// Each TypeInstance is locked during the migration. Already migrated TypeInstances are skipped,
+// so the migration can be safely re-run after a failure.
+func (m *Migrator) Migrate(ctx context.Context, opts MigrateOptions, w io.Writer) (err error) {
+	status := printer.NewStatus(w, "")
+	defer func() {
+		status.End(err == nil)
+	}()
+
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	status.Step("Checking target backend %s", opts.ToBackend)
+	target, err := m.targetBackend(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	progress, err := loadMigrationProgress(opts.ProgressFile, opts.ToBackend)
+	if err != nil {
+		return err
+	}
+
+	status.Step("Resolving TypeInstances to migrate")
+	ids, err := m.typeInstancesToMigrate(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, id := range ids {
+		if progress.isMigrated(id) {
+			status.Infof("TypeInstance %s already migrated, skipping", id)
+			continue
+		}
+
+		status.Step("Migrating TypeInstance %s", id)
+		migrated, err := m.migrateTypeInstance(ctx, id, target, opts.DryRun)
+		if err != nil {
+			status.End(false)
+			errs = append(errs, errors.Wrapf(err, "while migrating TypeInstance %s", id))
+			continue
+		}
+
+		switch {
+		case !migrated:
+			status.Infof("TypeInstance %s is already stored in backend %s, skipping", id, opts.ToBackend)
+		case opts.DryRun:
+			status.Infof("TypeInstance %s would be migrated to backend %s (dry run)", id, opts.ToBackend)
+			continue
+		}
+
+		progress.Migrated = append(progress.Migrated, id)
+		if err := saveMigrationProgress(opts.ProgressFile, progress); err != nil {
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		printer.PrintErrors(errs)
+		return fmt.Errorf("failed to migrate %d out of %d TypeInstances", len(errs), len(ids))
+	}
+
+	return nil
+}
+
+func (m *Migrator) targetBackend(ctx context.Context, opts MigrateOptions) (*gqllocalapi.TypeInstanceBackendInput, error) {
+	backendTI, err := m.hubCli.FindTypeInstance(ctx, opts.ToBackend, local.WithFields(local.TypeInstanceRootFields|local.TypeInstanceTypeRefFields))
+	if err != nil {
+		return nil, errors.Wrap(err, "while finding target backend TypeInstance")
+	}
+	if backendTI == nil {
+		return nil, fmt.Errorf("target backend TypeInstance %s not found", opts.ToBackend)
+	}
+
+	target := &gqllocalapi.TypeInstanceBackendInput{ID: opts.ToBackend}
+	if opts.ToBackendContext != "" {
+		if err := yaml.Unmarshal([]byte(opts.ToBackendContext), &target.Context); err != nil {
+			return nil, errors.Wrap(err, "while unmarshaling target backend context")
+		}
+	}
+
+	builtin := backendTI.TypeRef != nil && strings.HasPrefix(backendTI.TypeRef.Path, types.BuiltinHubStorageTypePath)
+	if builtin {
+		if target.Context != nil {
+			return nil, errors.New("built-in storage backend does not accept context")
+		}
+		return target, nil
+	}
+
+	backendValue, err := storagebackend.NewTypeInstanceValue(ctx, m.hubCli, &gqllocalapi.TypeInstance{
+		Backend: &gqllocalapi.TypeInstanceBackendReference{ID: opts.ToBackend},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while fetching target storage backend data")
+	}
+	if backendValue == nil || !backendValue.AcceptValue {
+		return nil, fmt.Errorf("target backend %s does not accept TypeInstance values", opts.ToBackend)
+	}
+
+	return target, nil
+}
+
+func (m *Migrator) typeInstancesToMigrate(ctx context.Context, opts MigrateOptions) ([]string, error) {
+	if len(opts.TypeInstanceIDs) > 0 {
+		return opts.TypeInstanceIDs, nil
+	}
+
+	all, err := m.hubCli.ListTypeInstances(ctx, &gqllocalapi.TypeInstanceFilter{}, local.WithFields(local.TypeInstanceRootFields|local.TypeInstanceBackendFields))
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing TypeInstances")
+	}
+
+	// storage backends cannot be migrated as other TypeInstances depend on them
+	backends := map[string]struct{}{
+		opts.ToBackend: {},
+	}
+	for _, ti := range all {
+		if ti.Backend != nil {
+			backends[ti.Backend.ID] = struct{}{}
+		}
+	}
+
+	var ids []string
+	for _, ti := range all {
+		if ti.Backend == nil || ti.Backend.ID != opts.FromBackend {
+			continue
+		}
+		if _, isBackend := backends[ti.ID]; isBackend {
+			continue
+		}
+		ids = append(ids, ti.ID)
+	}
+
+	return ids, nil
+}
+
+// migrateTypeInstance migrates a given TypeInstance. It returns false if the TypeInstance is already stored in the target backend.
+// The TypeInstance is locked before its values are read, so they cannot be changed until the migration is finished.
+func (m *Migrator) migrateTypeInstance(ctx context.Context, id string, target *gqllocalapi.TypeInstanceBackendInput, dryRun bool) (bool, error) {
+	ti, err := m.hubCli.FindTypeInstance(ctx, id, local.WithFields(local.TypeInstanceRootFields|local.TypeInstanceBackendFields))
+	if err != nil {
+		return false, errors.Wrap(err, "while finding TypeInstance")
+	}
+	if ti == nil {
+		return false, fmt.Errorf("TypeInstance %s not found", id)
+	}
+
+	if ti.Backend != nil && ti.Backend.ID == target.ID {
+		return false, nil
+	}
+
+	if dryRun {
+		if _, err := m.resourceVersionValues(ctx, id); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	err = m.hubCli.LockTypeInstances(ctx, &gqllocalapi.LockTypeInstancesInput{
+		Ids:     []string{id},
+		OwnerID: MigrationOwnerID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	migrateErr := m.migrateLocked(ctx, id, target)
+
+	err = m.hubCli.UnlockTypeInstances(ctx, &gqllocalapi.UnlockTypeInstancesInput{
+		Ids:     []string{id},
+		OwnerID: MigrationOwnerID,
+	})
+	if migrateErr != nil {
+		return false, migrateErr
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// migrateLocked sends values of all resource versions to Hub, which stores them in the target backend.
+func (m *Migrator) migrateLocked(ctx context.Context, id string, target *gqllocalapi.TypeInstanceBackendInput) error {
+	values, err := m.resourceVersionValues(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return m.hubCli.MigrateTypeInstanceBackend(ctx, &gqllocalapi.MigrateTypeInstanceBackendInput{
+		ID:               id,
+		OwnerID:          MigrationOwnerID,
+		Backend:          target,
+		ResourceVersions: values,
+	})
+}
+
+// resourceVersionValues returns values of all TypeInstance resource versions.
+// Values stored in an external backend are read from it directly, so they are not fetched by Hub from a backend which is being replaced.
+func (m *Migrator) resourceVersionValues(ctx context.Context, id string) ([]*gqllocalapi.MigrateTypeInstanceResourceVersionInput, error) {
+	ti, err := m.hubCli.FindTypeInstance(ctx, id, local.WithFields(local.TypeInstanceRootFields|local.TypeInstanceBackendFields|local.TypeInstanceResourceVersionsBackendFields))
+	if err != nil {
+		return nil, errors.Wrap(err, "while finding TypeInstance")
+	}
+	if ti == nil {
+		return nil, fmt.Errorf("TypeInstance %s not found", id)
+	}
+
+	builtin := ti.Backend == nil || ti.Backend.Abstract
+	if builtin {
+		// Values stored in the built-in storage are returned by Hub without calling any storage backend.
+		ti, err = m.hubCli.FindTypeInstance(ctx, id, local.WithFields(local.TypeInstanceAllFields))
+		if err != nil {
+			return nil, errors.Wrap(err, "while finding TypeInstance")
+		}
+		if ti == nil {
+			return nil, fmt.Errorf("TypeInstance %s not found", id)
+		}
+	}
+
+	var out []*gqllocalapi.MigrateTypeInstanceResourceVersionInput
+	for _, rev := range ti.ResourceVersions {
+		if rev == nil {
+			continue
+		}
+		if rev.Spec == nil {
+			return nil, fmt.Errorf("missing spec for resource version %d", rev.ResourceVersion)
+		}
+
+		value := rev.Spec.Value
+		if !builtin {
+			var backendCtx interface{}
+			if rev.Spec.Backend != nil {
+				backendCtx = rev.Spec.Backend.Context
+			}
+			value, err = m.values.GetValue(ctx, ti.Backend.ID, id, rev.ResourceVersion, backendCtx)
+			if err != nil {
+				return nil, errors.Wrapf(err, "while getting value for resource version %d", rev.ResourceVersion)
+			}
+		}
+
+		out = append(out, &gqllocalapi.MigrateTypeInstanceResourceVersionInput{
+			ResourceVersion: rev.ResourceVersion,
+			Value:           value,
+		})
+	}
+
+	return out, nil
+}
+
+func loadMigrationProgress(path, toBackend string) (*MigrationProgress, error) {
+	progress := &MigrationProgress{ToBackend: toBackend}
+	if path == "" {
+		return progress, nil
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return progress, nil
+	default:
+		return nil, errors.Wrap(err, "while reading migration progress file")
+	}
+
+	if err := json.Unmarshal(raw, progress); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling migration progress file")
+	}
+
+	if progress.ToBackend != toBackend {
+		return nil, fmt.Errorf("migration progress file %s was created for a different target backend %s", path, progress.ToBackend)
+	}
+
+	return progress, nil
+}
+
+func saveMigrationProgress(path string, progress *MigrationProgress) error {
+	if path == "" {
+		return nil
+	}
+
+	raw, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "while marshaling migration progress")
+	}
+
+	if err := ioutil.WriteFile(path, raw, 0600); err != nil {
+		return errors.Wrap(err, "while writing migration progress file")
+	}
+
+	return nil
+}
+
+func (p *MigrationProgress) isMigrated(id string) bool {
+	for _, migrated := range p.Migrated {
+		if migrated == id {
+			return true
+		}
+	}
+	return false
+}
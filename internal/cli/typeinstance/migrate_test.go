@@ -0,0 +1,269 @@
+package typeinstance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"capact.io/capact/internal/cli/client"
+	gqllocalapi "capact.io/capact/pkg/hub/api/graphql/local"
+	"capact.io/capact/pkg/hub/client/local"
+)
+
+const (
+	builtinBackendID = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	targetBackendID  = "secret-backend"
+)
+
+func TestMigrator_Migrate(t *testing.T) {
+	// given
+	hub := fixHubWithTypeInstances(true)
+	progressFile := filepath.Join(t.TempDir(), "progress.json")
+
+	opts := MigrateOptions{
+		FromBackend:  builtinBackendID,
+		ToBackend:    targetBackendID,
+		ProgressFile: progressFile,
+	}
+
+	// when
+	err := NewMigrator(hub, hub).Migrate(context.Background(), opts, ioutil.Discard)
+
+	// then
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"ti-1", "ti-2"}, hub.migrated)
+	assert.Empty(t, hub.lockedBy)
+
+	migrated := hub.migrations["ti-1"]
+	assert.Equal(t, MigrationOwnerID, migrated.OwnerID)
+	assert.Equal(t, targetBackendID, migrated.Backend.ID)
+	require.Len(t, migrated.ResourceVersions, 2)
+	assert.Equal(t, 1, migrated.ResourceVersions[0].ResourceVersion)
+	assert.Equal(t, map[string]interface{}{"rev": 1}, migrated.ResourceVersions[0].Value)
+	assert.Equal(t, 2, migrated.ResourceVersions[1].ResourceVersion)
+
+	assert.Equal(t, []string{"ti-1", "ti-2"}, readProgress(t, progressFile).Migrated)
+}
+
+func TestMigrator_Migrate_Resume(t *testing.T) {
+	// given
+	hub := fixHubWithTypeInstances(true)
+	progressFile := filepath.Join(t.TempDir(), "progress.json")
+	writeProgress(t, progressFile, MigrationProgress{ToBackend: targetBackendID, Migrated: []string{"ti-1"}})
+
+	opts := MigrateOptions{
+		TypeInstanceIDs: []string{"ti-1", "ti-2"},
+		ToBackend:       targetBackendID,
+		ProgressFile:    progressFile,
+	}
+
+	// when
+	err := NewMigrator(hub, hub).Migrate(context.Background(), opts, ioutil.Discard)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ti-2"}, hub.migrated)
+	assert.Equal(t, []string{"ti-1", "ti-2"}, readProgress(t, progressFile).Migrated)
+}
+
+func TestMigrator_Migrate_DryRun(t *testing.T) {
+	// given
+	hub := fixHubWithTypeInstances(true)
+	progressFile := filepath.Join(t.TempDir(), "progress.json")
+
+	opts := MigrateOptions{
+		FromBackend:  builtinBackendID,
+		ToBackend:    targetBackendID,
+		ProgressFile: progressFile,
+		DryRun:       true,
+	}
+
+	// when
+	err := NewMigrator(hub, hub).Migrate(context.Background(), opts, ioutil.Discard)
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, hub.migrated)
+	assert.NoFileExists(t, progressFile)
+}
+
+func TestMigrator_Migrate_TargetNotAcceptingValue(t *testing.T) {
+	// given
+	hub := fixHubWithTypeInstances(false)
+
+	opts := MigrateOptions{
+		TypeInstanceIDs: []string{"ti-1"},
+		ToBackend:       targetBackendID,
+	}
+
+	// when
+	err := NewMigrator(hub, hub).Migrate(context.Background(), opts, ioutil.Discard)
+
+	// then
+	assert.EqualError(t, err, "target backend secret-backend does not accept TypeInstance values")
+	assert.Empty(t, hub.migrated)
+}
+
+func TestMigrator_Migrate_ExternalValues(t *testing.T) {
+	// given
+	hub := fixHubWithTypeInstances(true)
+	hub.typeInstances["ti-1"].Backend = &gqllocalapi.TypeInstanceBackendReference{ID: "helm-backend"}
+
+	opts := MigrateOptions{
+		TypeInstanceIDs: []string{"ti-1"},
+		ToBackend:       targetBackendID,
+	}
+
+	// when
+	err := NewMigrator(hub, hub).Migrate(context.Background(), opts, ioutil.Discard)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ti-1"}, hub.migrated)
+	assert.Empty(t, hub.lockedBy)
+
+	// values are read from the storage backend when the TypeInstance is locked
+	assert.Equal(t, []string{MigrationOwnerID, MigrationOwnerID}, hub.readLockedBy)
+	migrated := hub.migrations["ti-1"]
+	require.Len(t, migrated.ResourceVersions, 2)
+	assert.Equal(t, map[string]interface{}{"rev": 2}, migrated.ResourceVersions[1].Value)
+}
+
+func TestMigrator_Migrate_MissingExternalValue(t *testing.T) {
+	// given
+	hub := fixHubWithTypeInstances(true)
+	ti := hub.typeInstances["ti-1"]
+	ti.Backend = &gqllocalapi.TypeInstanceBackendReference{ID: "helm-backend"}
+	ti.ResourceVersions[1].Spec.Value = nil
+
+	target := &gqllocalapi.TypeInstanceBackendInput{ID: targetBackendID}
+
+	// when
+	_, err := NewMigrator(hub, hub).migrateTypeInstance(context.Background(), "ti-1", target, false)
+
+	// then
+	assert.EqualError(t, err, "while getting value for resource version 2: storage backend helm-backend returned empty value")
+	assert.Empty(t, hub.migrated)
+	assert.Empty(t, hub.lockedBy)
+}
+
+type fakeHub struct {
+	client.Hub
+
+	typeInstances map[string]*gqllocalapi.TypeInstance
+	lockedBy      map[string]string
+	migrations    map[string]*gqllocalapi.MigrateTypeInstanceBackendInput
+	migrated      []string
+	readLockedBy  []string
+}
+
+func (f *fakeHub) FindTypeInstance(_ context.Context, id string, _ ...local.TypeInstancesOption) (*gqllocalapi.TypeInstance, error) {
+	return f.typeInstances[id], nil
+}
+
+func (f *fakeHub) ListTypeInstances(_ context.Context, _ *gqllocalapi.TypeInstanceFilter, _ ...local.TypeInstancesOption) ([]gqllocalapi.TypeInstance, error) {
+	var out []gqllocalapi.TypeInstance
+	for _, id := range []string{builtinBackendID, targetBackendID, "ti-1", "ti-2"} {
+		out = append(out, *f.typeInstances[id])
+	}
+	return out, nil
+}
+
+// GetValue returns values of TypeInstances stored in external backends, the same as the storage backend client.
+func (f *fakeHub) GetValue(_ context.Context, backendID, id string, resourceVersion int, _ interface{}) (interface{}, error) {
+	f.readLockedBy = append(f.readLockedBy, f.lockedBy[id])
+	for _, rev := range f.typeInstances[id].ResourceVersions {
+		if rev.ResourceVersion == resourceVersion && rev.Spec.Value != nil {
+			return rev.Spec.Value, nil
+		}
+	}
+	return nil, fmt.Errorf("storage backend %s returned empty value", backendID)
+}
+
+func (f *fakeHub) LockTypeInstances(_ context.Context, in *gqllocalapi.LockTypeInstancesInput) error {
+	for _, id := range in.Ids {
+		f.lockedBy[id] = in.OwnerID
+	}
+	return nil
+}
+
+func (f *fakeHub) UnlockTypeInstances(_ context.Context, in *gqllocalapi.UnlockTypeInstancesInput) error {
+	for _, id := range in.Ids {
+		delete(f.lockedBy, id)
+	}
+	return nil
+}
+
+func (f *fakeHub) MigrateTypeInstanceBackend(_ context.Context, in *gqllocalapi.MigrateTypeInstanceBackendInput) error {
+	f.migrations[in.ID] = in
+	f.migrated = append(f.migrated, in.ID)
+	f.typeInstances[in.ID].Backend = &gqllocalapi.TypeInstanceBackendReference{ID: in.Backend.ID}
+	return nil
+}
+
+func fixHubWithTypeInstances(targetAcceptsValue bool) *fakeHub {
+	builtinRef := &gqllocalapi.TypeInstanceBackendReference{ID: builtinBackendID, Abstract: true}
+	targetValue := map[string]interface{}{
+		"url":         "secret-backend:50051",
+		"acceptValue": targetAcceptsValue,
+	}
+
+	return &fakeHub{
+		typeInstances: map[string]*gqllocalapi.TypeInstance{
+			builtinBackendID: {
+				ID:      builtinBackendID,
+				TypeRef: &gqllocalapi.TypeInstanceTypeReference{Path: "cap.core.type.hub.storage.neo4j"},
+				Backend: builtinRef,
+			},
+			targetBackendID: {
+				ID:      targetBackendID,
+				TypeRef: &gqllocalapi.TypeInstanceTypeReference{Path: "cap.type.capactio.capact.validation.storage"},
+				Backend: builtinRef,
+				LatestResourceVersion: &gqllocalapi.TypeInstanceResourceVersion{
+					Spec: &gqllocalapi.TypeInstanceResourceVersionSpec{Value: targetValue},
+				},
+			},
+			"ti-1": fixTypeInstance("ti-1", builtinRef),
+			"ti-2": fixTypeInstance("ti-2", builtinRef),
+		},
+		lockedBy:   map[string]string{},
+		migrations: map[string]*gqllocalapi.MigrateTypeInstanceBackendInput{},
+	}
+}
+
+func fixTypeInstance(id string, backend *gqllocalapi.TypeInstanceBackendReference) *gqllocalapi.TypeInstance {
+	return &gqllocalapi.TypeInstance{
+		ID:      id,
+		Backend: backend,
+		ResourceVersions: []*gqllocalapi.TypeInstanceResourceVersion{
+			{ResourceVersion: 1, Spec: &gqllocalapi.TypeInstanceResourceVersionSpec{Value: map[string]interface{}{"rev": 1}}},
+			{ResourceVersion: 2, Spec: &gqllocalapi.TypeInstanceResourceVersionSpec{Value: map[string]interface{}{"rev": 2}}},
+		},
+	}
+}
+
+func readProgress(t *testing.T, path string) MigrationProgress {
+	t.Helper()
+
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var progress MigrationProgress
+	require.NoError(t, json.Unmarshal(raw, &progress))
+	return progress
+}
+
+func writeProgress(t *testing.T, path string, progress MigrationProgress) {
+	t.Helper()
+
+	raw, err := json.Marshal(progress)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, raw, os.ModePerm))
+}
@@ -0,0 +1,147 @@
+package validate
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"capact.io/capact/internal/cli"
+	"capact.io/capact/pkg/sdk/validation/manifest"
+	"github.com/pkg/errors"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	sarifLevelError   = "error"
+	sarifLevelWarning = "warning"
+
+	// manifestValidationRuleID is used for all issues, which are not reported by lint rules.
+	manifestValidationRuleID = "manifest-validation"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+func (v *Validation) printSARIF(results []ValidationResult) error {
+	out := newSARIFLog(v.linter, results)
+
+	enc := json.NewEncoder(v.writer)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return errors.Wrap(err, "while encoding SARIF output")
+	}
+	return nil
+}
+
+func newSARIFLog(linter *manifest.LintValidator, results []ValidationResult) sarifLog {
+	rules := []sarifRule{
+		{
+			ID:               manifestValidationRuleID,
+			ShortDescription: sarifMessage{Text: "Manifest must be valid against the OCF specification."},
+		},
+	}
+	if linter != nil {
+		for _, rule := range linter.Rules() {
+			rules = append(rules, sarifRule{
+				ID:               rule.ID(),
+				ShortDescription: sarifMessage{Text: rule.Description()},
+			})
+		}
+	}
+
+	sarifResults := []sarifResult{}
+	for _, res := range results {
+		for _, err := range res.Errors {
+			sarifResults = append(sarifResults, newSARIFResult(res.Path, sarifLevelError, err))
+		}
+		for _, err := range res.Warnings {
+			sarifResults = append(sarifResults, newSARIFResult(res.Path, sarifLevelWarning, err))
+		}
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           cli.Name,
+						InformationURI: "https://capact.io",
+						Rules:          rules,
+					},
+				},
+				Results: sarifResults,
+			},
+		},
+	}
+}
+
+func newSARIFResult(path string, level string, err error) sarifResult {
+	ruleID, msg := manifestValidationRuleID, err.Error()
+
+	var issue manifest.LintIssue
+	if errors.As(err, &issue) {
+		ruleID, msg = issue.RuleID, issue.Message
+	}
+
+	return sarifResult{
+		RuleID:  ruleID,
+		Level:   level,
+		Message: sarifMessage{Text: msg},
+		Locations: []sarifLocation{
+			{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(path)},
+				},
+			},
+		},
+	}
+}
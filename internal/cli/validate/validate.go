@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -20,12 +21,30 @@ import (
 	"github.com/pkg/errors"
 )
 
+// OutputFormat defines the format of the validation results.
+type OutputFormat string
+
+const (
+	// TextOutputFormat prints human-readable validation results.
+	TextOutputFormat OutputFormat = "text"
+	// SARIFOutputFormat prints validation results in the SARIF format, which can be consumed by CI systems.
+	SARIFOutputFormat OutputFormat = "sarif"
+)
+
 // Options struct defines validation options for OCF manifest validation.
 type Options struct {
 	SchemaLocation  string
 	ServerSide      bool
 	RecursiveSearch bool
 	MaxConcurrency  int
+	// LintConfigPath is a path to the lint rules configuration file.
+	// If empty, the `.capact-lint.yaml` file from the current directory is used, if it exists.
+	// If no configuration is found, the lint rules are not checked.
+	LintConfigPath string
+	OutputFormat   OutputFormat
+	// GraphCheck enables validation of references between all manifests from the given directories.
+	// The directories are searched recursively.
+	GraphCheck bool
 }
 
 // Validate validates the Options struct fields.
@@ -34,13 +53,20 @@ func (o *Options) Validate() error {
 		return errors.New("concurrency parameter cannot be less than 1")
 	}
 
+	switch o.OutputFormat {
+	case "", TextOutputFormat, SARIFOutputFormat:
+	default:
+		return fmt.Errorf("unknown output format %q", o.OutputFormat)
+	}
+
 	return nil
 }
 
 // ValidationResult defines a validation error.
 type ValidationResult struct {
-	Path   string
-	Errors []error
+	Path     string
+	Errors   []error
+	Warnings []error
 }
 
 // IsSuccess returns if there were any validation errors.
@@ -50,16 +76,31 @@ func (r *ValidationResult) IsSuccess() bool {
 
 // Error returns error message based on the ValidationResult data.
 func (r *ValidationResult) Error() string {
-	if r == nil || len(r.Errors) == 0 {
+	if r == nil {
+		return ""
+	}
+	return formatIssues(r.Path, r.Errors)
+}
+
+// Warning returns warning message based on the ValidationResult data.
+func (r *ValidationResult) Warning() string {
+	if r == nil {
+		return ""
+	}
+	return formatIssues(r.Path, r.Warnings)
+}
+
+func formatIssues(path string, issues []error) string {
+	if len(issues) == 0 {
 		return ""
 	}
 
-	var errMsgs []string
-	for _, err := range r.Errors {
-		errMsgs = append(errMsgs, err.Error())
+	var msgs []string
+	for _, issue := range issues {
+		msgs = append(msgs, issue.Error())
 	}
 
-	return fmt.Sprintf("%q:\n    * %s\n", r.Path, strings.Join(errMsgs, "\n    * "))
+	return fmt.Sprintf("%q:\n    * %s\n", path, strings.Join(msgs, "\n    * "))
 }
 
 // respectedManifestsExt defines valid extensions for OCF manifest files.
@@ -75,6 +116,9 @@ type Validation struct {
 	writer          io.Writer
 	maxWorkers      int
 	recursiveSearch bool
+	graphCheck      bool
+	outputFormat    OutputFormat
+	linter          *manifest.LintValidator
 	validatorFn     func() manifest.FileSystemValidator
 }
 
@@ -102,6 +146,19 @@ func New(writer io.Writer, opts Options) (*Validation, error) {
 		validatorOpts = append(validatorOpts, manifest.WithRemoteChecks(hubCli))
 	}
 
+	linter, err := newLinter(opts.LintConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	if linter != nil {
+		validatorOpts = append(validatorOpts, manifest.WithLinter(linter))
+	}
+
+	outputFormat := opts.OutputFormat
+	if outputFormat == "" {
+		outputFormat = TextOutputFormat
+	}
+
 	return &Validation{
 		// TODO: To improve: Share a single validator for all workers.
 		//		Current implementation makes OCF JSON schemas caching separated per validationWorker.
@@ -111,11 +168,39 @@ func New(writer io.Writer, opts Options) (*Validation, error) {
 		},
 		hubCli:          hubCli,
 		writer:          writer,
-		recursiveSearch: opts.RecursiveSearch,
+		recursiveSearch: opts.RecursiveSearch || opts.GraphCheck,
+		graphCheck:      opts.GraphCheck,
 		maxWorkers:      opts.MaxConcurrency,
+		outputFormat:    outputFormat,
+		linter:          linter,
 	}, nil
 }
 
+// newLinter returns the lint validator configured from a given file.
+// If the path is empty, the default configuration file is used only if it exists.
+func newLinter(configPath string) (*manifest.LintValidator, error) {
+	if configPath == "" {
+		if _, err := os.Stat(manifest.DefaultLintConfigFileName); err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrap(err, "while checking lint configuration file")
+		}
+		configPath = manifest.DefaultLintConfigFileName
+	}
+
+	cfg, err := manifest.LoadLintConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	linter, err := manifest.NewLintValidator(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating linter")
+	}
+	return linter, nil
+}
+
 // Run runs validation across all JSON validators.
 func (v *Validation) Run(ctx context.Context, paths []string) error {
 	filePaths, err := v.getFilesToParse(paths)
@@ -123,12 +208,19 @@ func (v *Validation) Run(ctx context.Context, paths []string) error {
 		return errors.Wrap(err, "while collecting files for validation")
 	}
 
+	graphResults, err := v.runGraphValidation(paths)
+	if err != nil {
+		return errors.Wrap(err, "while validating references between manifests")
+	}
+
 	var workersCount = v.maxWorkers
 	if len(filePaths) < workersCount {
 		workersCount = len(filePaths)
 	}
 
-	v.printIntroMessage(filePaths, workersCount)
+	if v.outputFormat == TextOutputFormat {
+		v.printIntroMessage(filePaths, workersCount)
+	}
 
 	jobsCh := make(chan string, len(filePaths))
 	resultsCh := make(chan ValidationResult, len(filePaths))
@@ -150,14 +242,84 @@ func (v *Validation) Run(ctx context.Context, paths []string) error {
 		close(resultsCh)
 	}()
 
-	var processedFilesCount, errsCount int
-	for res := range resultsCh {
-		processedFilesCount++
+	var (
+		results                                       []ValidationResult
+		processedFilesCount, errsCount, warningsCount int
+	)
+	handleResult := func(res ValidationResult) {
 		errsCount += len(res.Errors)
+		warningsCount += len(res.Warnings)
+
+		if v.outputFormat == SARIFOutputFormat {
+			results = append(results, res)
+			return
+		}
 		v.printPartialResult(res)
 	}
 
-	return v.outputResultSummary(processedFilesCount, errsCount)
+	for res := range resultsCh {
+		processedFilesCount++
+		if graphRes, found := graphResults[res.Path]; found {
+			res.Errors = append(res.Errors, graphRes.Errors...)
+			delete(graphResults, res.Path)
+		}
+		handleResult(res)
+	}
+
+	// graph issues for files, which were not validated separately
+	var remainingPaths []string
+	for path := range graphResults {
+		remainingPaths = append(remainingPaths, path)
+	}
+	sort.Strings(remainingPaths)
+	for _, path := range remainingPaths {
+		handleResult(graphResults[path])
+	}
+
+	if v.outputFormat == SARIFOutputFormat {
+		if err := v.printSARIF(results); err != nil {
+			return err
+		}
+		return validationErrorFor(errsCount)
+	}
+
+	return v.outputResultSummary(processedFilesCount, errsCount, warningsCount)
+}
+
+// runGraphValidation validates references between manifests from all given directories.
+// It returns the results keyed by the file path.
+func (v *Validation) runGraphValidation(paths []string) (map[string]ValidationResult, error) {
+	out := map[string]ValidationResult{}
+	if !v.graphCheck {
+		return out, nil
+	}
+
+	validator := manifest.NewGraphValidator()
+	for _, path := range paths {
+		fileInfo, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !fileInfo.IsDir() {
+			continue
+		}
+
+		results, err := validator.Do(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for filePath, res := range results {
+			current := out[filePath]
+			current.Path = filePath
+			for _, err := range res.Errors {
+				current.Errors = append(current.Errors, errors.Wrap(err, validator.Name()))
+			}
+			out[filePath] = current
+		}
+	}
+
+	return out, nil
 }
 
 func (v *Validation) printIntroMessage(filePaths []string, workersCount int) {
@@ -165,25 +327,45 @@ func (v *Validation) printIntroMessage(filePaths []string, workersCount int) {
 	fmt.Fprintf(v.writer, "Validating %s in %d concurrent %s...\n", fileNoun, workersCount, properNounFor("job", workersCount))
 }
 
-func (v *Validation) outputResultSummary(processedFilesCount int, errsCount int) error {
+func (v *Validation) outputResultSummary(processedFilesCount int, errsCount int, warningsCount int) error {
 	fileNoun := properNounFor("file", processedFilesCount)
 	fmt.Fprintf(v.writer, "\nValidated %d %s in total.\n", processedFilesCount, fileNoun)
 
-	if errsCount > 0 {
-		errNoun := properNounFor("error", errsCount)
-		return fmt.Errorf("detected %d validation %s", errsCount, errNoun)
+	if warningsCount > 0 {
+		fmt.Fprintf(v.writer, "Detected %d lint %s.\n", warningsCount, properNounFor("warning", warningsCount))
+	}
+
+	if err := validationErrorFor(errsCount); err != nil {
+		return err
 	}
 
 	fmt.Fprintf(v.writer, "🚀 No errors detected.\n")
 	return nil
 }
 
+func validationErrorFor(errsCount int) error {
+	if errsCount == 0 {
+		return nil
+	}
+
+	errNoun := properNounFor("error", errsCount)
+	return fmt.Errorf("detected %d validation %s", errsCount, errNoun)
+}
+
 func (v *Validation) printPartialResult(res ValidationResult) {
+	if len(res.Warnings) > 0 {
+		fmt.Fprintf(v.writer, "- %s %s\n", color.YellowString("!"), res.Warning())
+	}
+
 	if !res.IsSuccess() {
 		fmt.Fprintf(v.writer, "- %s %s\n", color.RedString("✗"), res.Error())
 		return
 	}
 
+	if len(res.Warnings) > 0 {
+		return
+	}
+
 	// Print successes only in verbose mode
 	if !cli.VerboseMode.IsEnabled() {
 		return
@@ -300,17 +482,19 @@ func (w *validationWorker) Do(ctx context.Context, jobCh <-chan string, resultCh
 				return
 			}
 
-			var resultErrs []error
+			var resultErrs, resultWarnings []error
 			res, err := w.validator.Do(ctx, filePath)
 			if err != nil {
 				resultErrs = append(resultErrs, errors.Wrap(err, "internal:"))
 			} else {
 				resultErrs = append(resultErrs, res.Errors...)
+				resultWarnings = append(resultWarnings, res.Warnings...)
 			}
 
 			resultCh <- ValidationResult{
-				Path:   filePath,
-				Errors: resultErrs,
+				Path:     filePath,
+				Errors:   resultErrs,
+				Warnings: resultWarnings,
 			}
 		}
 	}
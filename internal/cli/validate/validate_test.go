@@ -3,6 +3,8 @@ package validate_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"testing"
 
@@ -71,3 +73,48 @@ func TestValidation_Recursive(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, buff.String(), "Validated 7 files in total")
 }
+
+func TestValidation_LintSARIFOutput(t *testing.T) {
+	// given
+	var buff = &bytes.Buffer{}
+	validation, err := validate.New(buff, validate.Options{
+		MaxConcurrency: 5,
+		LintConfigPath: "testdata/lint-config.yaml",
+		OutputFormat:   validate.SARIFOutputFormat,
+	})
+	require.NoError(t, err)
+
+	pathToExample := "../../../ocf-spec/0.0.1/examples/implementation.yaml"
+
+	// when
+	err = validation.Run(context.Background(), []string{pathToExample})
+
+	// then
+	require.NoError(t, err)
+
+	var out struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID  string `json:"ruleId"`
+				Level   string `json:"level"`
+				Message struct {
+					Text string `json:"text"`
+				} `json:"message"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(buff.Bytes(), &out))
+
+	assert.Equal(t, "2.1.0", out.Version)
+	require.Len(t, out.Runs, 1)
+
+	var got []string
+	for _, res := range out.Runs[0].Results {
+		got = append(got, fmt.Sprintf("%s %s: %s", res.Level, res.RuleID, res.Message.Text))
+	}
+	assert.ElementsMatch(t, []string{
+		`warning unused-imports: imported method "mysql.install" (cap.interface.database.mysql.install) is not used`,
+		`warning unused-imports: imported method "mysql.create-db" (cap.interface.database.mysql.create-db) is not used`,
+	}, got)
+}
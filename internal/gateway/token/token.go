@@ -0,0 +1,88 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ActionUsernamePrefix is the prefix of the usernames issued for Actions.
+const ActionUsernamePrefix = "action:"
+
+var (
+	// ErrInvalidToken indicates that the credentials were not issued with the same key.
+	ErrInvalidToken = errors.New("invalid Action token")
+	// ErrExpiredToken indicates that the credentials expired.
+	ErrExpiredToken = errors.New("expired Action token")
+)
+
+// Issuer issues and verifies short-lived basic access authentication credentials bound to a given Action.
+// The username identifies the Action and the password holds the expiration time signed with the key,
+// so the Gateway doesn't need to store the issued credentials.
+type Issuer struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewIssuer returns a new Issuer instance.
+func NewIssuer(key []byte, ttl time.Duration) *Issuer {
+	return &Issuer{
+		key: key,
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// Issue returns the credentials for the Action with a given namespace and name.
+func (i *Issuer) Issue(namespace, name string) (username string, password string) {
+	username = fmt.Sprintf("%s%s/%s", ActionUsernamePrefix, namespace, name)
+	expiresAt := strconv.FormatInt(i.now().Add(i.ttl).Unix(), 10)
+
+	return username, expiresAt + "." + i.sign(username, expiresAt)
+}
+
+// IsActionUsername returns true if a given username was issued for an Action.
+func IsActionUsername(username string) bool {
+	return strings.HasPrefix(username, ActionUsernamePrefix)
+}
+
+// Verify checks if the credentials were issued by the Issuer and didn't expire.
+func (i *Issuer) Verify(username, password string) error {
+	if !IsActionUsername(username) {
+		return ErrInvalidToken
+	}
+
+	parts := strings.SplitN(password, ".", 2)
+	if len(parts) != 2 {
+		return ErrInvalidToken
+	}
+	expiresAt, signature := parts[0], parts[1]
+
+	if !hmac.Equal([]byte(signature), []byte(i.sign(username, expiresAt))) {
+		return ErrInvalidToken
+	}
+
+	unix, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	if !i.now().Before(time.Unix(unix, 0)) {
+		return ErrExpiredToken
+	}
+
+	return nil
+}
+
+func (i *Issuer) sign(username, expiresAt string) string {
+	mac := hmac.New(sha256.New, i.key)
+	// the username cannot contain a new line, so the signed data is unambiguous
+	_, _ = mac.Write([]byte(username + "\n" + expiresAt))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,85 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuer_Verify(t *testing.T) {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	issuer := fixIssuer("key", now)
+	username, password := issuer.Issue("default", "action")
+
+	tests := map[string]struct {
+		givenIssuer   *Issuer
+		givenUsername string
+		givenPassword string
+		expErr        error
+	}{
+		"Should accept issued credentials": {
+			givenIssuer:   issuer,
+			givenUsername: username,
+			givenPassword: password,
+		},
+		"Should reject credentials for other Action": {
+			givenIssuer:   issuer,
+			givenUsername: "action:default/other",
+			givenPassword: password,
+			expErr:        ErrInvalidToken,
+		},
+		"Should reject credentials issued with other key": {
+			givenIssuer:   fixIssuer("other-key", now),
+			givenUsername: username,
+			givenPassword: password,
+			expErr:        ErrInvalidToken,
+		},
+		"Should reject expired credentials": {
+			givenIssuer:   fixIssuer("key", now.Add(time.Hour)),
+			givenUsername: username,
+			givenPassword: password,
+			expErr:        ErrExpiredToken,
+		},
+		"Should reject non-Action username": {
+			givenIssuer:   issuer,
+			givenUsername: "graphql",
+			givenPassword: password,
+			expErr:        ErrInvalidToken,
+		},
+		"Should reject malformed password": {
+			givenIssuer:   issuer,
+			givenUsername: username,
+			givenPassword: "t0p_s3cr3t",
+			expErr:        ErrInvalidToken,
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// when
+			err := tc.givenIssuer.Verify(tc.givenUsername, tc.givenPassword)
+
+			// then
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestIssuer_Issue(t *testing.T) {
+	// given
+	issuer := fixIssuer("key", time.Unix(1633089600, 0))
+
+	// when
+	username, password := issuer.Issue("default", "action")
+
+	// then
+	assert.Equal(t, "action:default/action", username)
+	assert.Regexp(t, `^1633091400\.[A-Za-z0-9_-]+$`, password)
+}
+
+func fixIssuer(key string, now time.Time) *Issuer {
+	issuer := NewIssuer([]byte(key), 30*time.Minute)
+	issuer.now = func() time.Time { return now }
+	return issuer
+}
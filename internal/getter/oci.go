@@ -0,0 +1,257 @@
+package getter
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/pkg/errors"
+)
+
+const (
+	ociManifestMediaType    = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// insecureQueryParam enables plain HTTP connection to the registry, e.g. for local registries in air-gapped clusters.
+	insecureQueryParam = "insecure"
+)
+
+var ociDigestRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// OCIGetter is a go-getter Getter, which downloads content published as an OCI artifact.
+// The artifact must be pulled by digest, for example `oci://registry.local:5000/capact/hub-manifests@sha256:<digest>`.
+// All artifact layers must be tar archives, optionally gzipped. They are extracted in order into the destination directory.
+// Registry credentials can be provided in the URL user info.
+type OCIGetter struct {
+	// HTTPClient is used to call the registry. If not provided, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	client *getter.Client
+}
+
+// OCIReference holds the parsed OCI artifact location.
+type OCIReference struct {
+	Registry   string
+	Repository string
+	Digest     string
+}
+
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+// ParseOCIReference parses the OCI artifact URL. It returns an error if the artifact is not referenced by digest.
+func ParseOCIReference(u *url.URL) (OCIReference, error) {
+	repoAndDigest := strings.TrimPrefix(u.Path, "/")
+	idx := strings.LastIndex(repoAndDigest, "@")
+	if idx == -1 {
+		return OCIReference{}, fmt.Errorf("OCI artifact %q must be referenced by digest, e.g. %s/<repository>@sha256:<digest>", u.Redacted(), u.Host)
+	}
+
+	ref := OCIReference{
+		Registry:   u.Host,
+		Repository: repoAndDigest[:idx],
+		Digest:     repoAndDigest[idx+1:],
+	}
+	if ref.Registry == "" || ref.Repository == "" {
+		return OCIReference{}, fmt.Errorf("OCI artifact %q must contain registry and repository", u.Redacted())
+	}
+	if !ociDigestRegex.MatchString(ref.Digest) {
+		return OCIReference{}, fmt.Errorf("invalid digest %q: only sha256 digests are supported", ref.Digest)
+	}
+
+	return ref, nil
+}
+
+// ClientMode returns the client mode for a given URL. OCI artifacts are always downloaded as directories.
+func (g *OCIGetter) ClientMode(_ *url.URL) (getter.ClientMode, error) {
+	return getter.ClientModeDir, nil
+}
+
+// SetClient sets the go-getter client, which is used to get the request context.
+func (g *OCIGetter) SetClient(c *getter.Client) {
+	g.client = c
+}
+
+// GetFile is not supported, as OCI artifacts are always downloaded as directories.
+func (g *OCIGetter) GetFile(_ string, u *url.URL) error {
+	return fmt.Errorf("downloading OCI artifact %q as a single file is not supported", u.Redacted())
+}
+
+// Get downloads the OCI artifact and extracts all its layers into the dst directory.
+func (g *OCIGetter) Get(dst string, u *url.URL) error {
+	ref, err := ParseOCIReference(u)
+	if err != nil {
+		return err
+	}
+
+	reg := &ociRegistry{
+		ctx:        g.context(),
+		httpClient: g.httpClient(),
+		baseURL:    fmt.Sprintf("%s://%s/v2/%s", registryScheme(u), ref.Registry, ref.Repository),
+		user:       u.User,
+	}
+
+	manifestBytes, err := reg.fetch("manifests/"+ref.Digest, ociManifestMediaType+", "+dockerManifestMediaType)
+	if err != nil {
+		return errors.Wrap(err, "while fetching OCI manifest")
+	}
+	if err := verifyDigest(ref.Digest, manifestBytes); err != nil {
+		return errors.Wrap(err, "while verifying OCI manifest")
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return errors.Wrap(err, "while unmarshalling OCI manifest")
+	}
+	if len(manifest.Layers) == 0 {
+		return fmt.Errorf("OCI artifact %s@%s doesn't have any layers", ref.Repository, ref.Digest)
+	}
+
+	if err := os.MkdirAll(dst, 0o750); err != nil {
+		return errors.Wrapf(err, "while creating destination directory %s", dst)
+	}
+
+	for _, layer := range manifest.Layers {
+		if err := g.extractLayer(reg, layer, dst); err != nil {
+			return errors.Wrapf(err, "while extracting layer %s", layer.Digest)
+		}
+	}
+
+	return nil
+}
+
+func (g *OCIGetter) extractLayer(reg *ociRegistry, layer ociDescriptor, dst string) error {
+	if !ociDigestRegex.MatchString(layer.Digest) {
+		return fmt.Errorf("invalid digest %q: only sha256 digests are supported", layer.Digest)
+	}
+
+	// the blob is stored in a temporary file, as it must be verified before extracting
+	tmp, err := ioutil.TempFile("", "oci-layer-*")
+	if err != nil {
+		return errors.Wrap(err, "while creating temporary file")
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+	if err := reg.download("blobs/"+layer.Digest, io.MultiWriter(tmp, hash)); err != nil {
+		return errors.Wrap(err, "while downloading blob")
+	}
+	if got := "sha256:" + hex.EncodeToString(hash.Sum(nil)); got != layer.Digest {
+		return fmt.Errorf("digest mismatch: expected %s, got %s", layer.Digest, got)
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "while reading downloaded blob")
+	}
+	return extractTar(tmp, dst)
+}
+
+func (g *OCIGetter) context() context.Context {
+	if g.client != nil && g.client.Ctx != nil {
+		return g.client.Ctx
+	}
+	return context.Background()
+}
+
+func (g *OCIGetter) httpClient() *http.Client {
+	if g.HTTPClient != nil {
+		return g.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func registryScheme(u *url.URL) string {
+	if u.Query().Get(insecureQueryParam) == "true" {
+		return "http"
+	}
+	return "https"
+}
+
+func verifyDigest(digest string, content []byte) error {
+	sum := sha256.Sum256(content)
+	if got := "sha256:" + hex.EncodeToString(sum[:]); got != digest {
+		return fmt.Errorf("digest mismatch: expected %s, got %s", digest, got)
+	}
+	return nil
+}
+
+// extractTar extracts a tar archive, optionally gzipped, into the dst directory.
+func extractTar(in io.Reader, dst string) error {
+	buffered := bufio.NewReader(in)
+	var reader io.Reader = buffered
+
+	// gzip magic number
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzr, err := gzip.NewReader(buffered)
+		if err != nil {
+			return errors.Wrap(err, "while creating gzip reader")
+		}
+		defer gzr.Close()
+		reader = gzr
+	}
+
+	tr := tar.NewReader(reader)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "while reading tar archive")
+		}
+
+		path := filepath.Join(dst, filepath.Clean("/"+hdr.Name))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0o750); err != nil {
+				return errors.Wrapf(err, "while creating directory %s", path)
+			}
+		case tar.TypeReg:
+			if err := writeFile(path, tr); err != nil {
+				return err
+			}
+		default:
+			// links and other special files are skipped, as they are not needed for manifests
+		}
+	}
+}
+
+func writeFile(path string, content io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return errors.Wrapf(err, "while creating directory for %s", path)
+	}
+
+	f, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "while creating file %s", path)
+	}
+	// #nosec G110: the archive is verified against its digest before extracting
+	if _, err := io.Copy(f, content); err != nil {
+		_ = f.Close()
+		return errors.Wrapf(err, "while writing file %s", path)
+	}
+	return errors.Wrapf(f.Close(), "while closing file %s", path)
+}
@@ -0,0 +1,183 @@
+package getter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ociRegistry calls the OCI Distribution API of a single repository.
+// It supports anonymous access, basic auth, and the token authentication, which is used by most registries.
+type ociRegistry struct {
+	ctx        context.Context
+	httpClient *http.Client
+	baseURL    string
+	user       *url.Userinfo
+
+	token string
+}
+
+type tokenResponse struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+}
+
+func (r *ociRegistry) fetch(path, accept string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := r.get(path, accept, buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *ociRegistry) download(path string, out io.Writer) error {
+	return r.get(path, "", out)
+}
+
+func (r *ociRegistry) get(path, accept string, out io.Writer) error {
+	res, err := r.do(path, accept)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusUnauthorized && r.token == "" {
+		challenge := res.Header.Get("WWW-Authenticate")
+		if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+			return fmt.Errorf("unauthorized to get %s", path)
+		}
+		if err := r.authenticate(challenge); err != nil {
+			return errors.Wrap(err, "while getting registry token")
+		}
+
+		res, err = r.do(path, accept)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get %s: unexpected status code %d", path, res.StatusCode)
+	}
+
+	_, err = io.Copy(out, res.Body)
+	return errors.Wrapf(err, "while reading %s", path)
+}
+
+func (r *ociRegistry) do(path, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, fmt.Sprintf("%s/%s", r.baseURL, path), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating request")
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	switch {
+	case r.token != "":
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	case r.user != nil:
+		password, _ := r.user.Password()
+		req.SetBasicAuth(r.user.Username(), password)
+	}
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while calling %s", path)
+	}
+	return res, nil
+}
+
+// authenticate gets the token from the realm provided in the Bearer challenge.
+func (r *ociRegistry) authenticate(challenge string) error {
+	params := parseChallengeParams(challenge[len("bearer "):])
+	realm := params["realm"]
+	if realm == "" {
+		return errors.New("missing realm in the authentication challenge")
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return errors.Wrap(err, "while parsing realm")
+	}
+	q := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			q.Set(key, params[key])
+		}
+	}
+	tokenURL.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "while creating request")
+	}
+	if r.user != nil {
+		password, _ := r.user.Password()
+		req.SetBasicAuth(r.user.Username(), password)
+	}
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "while calling token endpoint")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from token endpoint", res.StatusCode)
+	}
+
+	var token tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
+		return errors.Wrap(err, "while decoding token response")
+	}
+
+	r.token = token.Token
+	if r.token == "" {
+		r.token = token.AccessToken
+	}
+	if r.token == "" {
+		return errors.New("token endpoint returned empty token")
+	}
+	return nil
+}
+
+// parseChallengeParams parses parameters, such as `realm="https://auth.docker.io/token",service="registry.docker.io"`.
+func parseChallengeParams(in string) map[string]string {
+	out := map[string]string{}
+	for _, part := range splitChallenge(in) {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		out[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return out
+}
+
+// splitChallenge splits the challenge parameters by commas, which are not quoted. Scope can contain commas, e.g. `pull,push`.
+func splitChallenge(in string) []string {
+	var (
+		out    []string
+		quoted bool
+		start  int
+	)
+	for i, c := range in {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			out = append(out, in[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, in[start:])
+}
@@ -0,0 +1,174 @@
+package getter_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"capact.io/capact/internal/getter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCIGetter_Get(t *testing.T) {
+	// given
+	layer := fixTarGz(t, map[string]string{
+		"manifests/type/app/config.yaml": "kind: Type",
+	})
+	layerDigest := digestOf(layer)
+
+	manifest, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"layers": []map[string]interface{}{
+			{"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "digest": layerDigest},
+		},
+	})
+	require.NoError(t, err)
+	manifestDigest := digestOf(manifest)
+
+	const token = "test-token"
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			assert.Equal(t, "repository:capact/hub-manifests:pull", r.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"token": %q}`, token)))
+			return
+		case r.Header.Get("Authorization") != "Bearer "+token:
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:capact/hub-manifests:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/capact/hub-manifests/manifests/"+manifestDigest:
+			_, _ = w.Write(manifest)
+		case r.URL.Path == "/v2/capact/hub-manifests/blobs/"+layerDigest:
+			_, _ = w.Write(layer)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	u := fixOCIURL(t, server.URL, manifestDigest)
+	dst := t.TempDir()
+
+	// when
+	err = (&getter.OCIGetter{}).Get(dst, u)
+
+	// then
+	require.NoError(t, err)
+	content, err := ioutil.ReadFile(filepath.Join(dst, "manifests", "type", "app", "config.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Type", string(content))
+}
+
+func TestOCIGetter_GetDigestMismatch(t *testing.T) {
+	// given
+	manifestDigest := digestOf([]byte("expected"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"layers": []}`))
+	}))
+	defer server.Close()
+
+	u := fixOCIURL(t, server.URL, manifestDigest)
+
+	// when
+	err := (&getter.OCIGetter{}).Get(t.TempDir(), u)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "while verifying OCI manifest: digest mismatch")
+}
+
+func TestParseOCIReference(t *testing.T) {
+	const digest = "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+	tests := map[string]struct {
+		givenURL    string
+		expectedRef getter.OCIReference
+		expectedErr string
+	}{
+		"Reference with digest": {
+			givenURL: "oci://registry.local:5000/capact/hub-manifests@" + digest,
+			expectedRef: getter.OCIReference{
+				Registry:   "registry.local:5000",
+				Repository: "capact/hub-manifests",
+				Digest:     digest,
+			},
+		},
+		"Reference with tag": {
+			givenURL:    "oci://registry.local:5000/capact/hub-manifests:0.1.0",
+			expectedErr: `OCI artifact "oci://registry.local:5000/capact/hub-manifests:0.1.0" must be referenced by digest, e.g. registry.local:5000/<repository>@sha256:<digest>`,
+		},
+		"Reference with unsupported digest": {
+			givenURL:    "oci://registry.local:5000/capact/hub-manifests@md5:abc",
+			expectedErr: `invalid digest "md5:abc": only sha256 digests are supported`,
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// given
+			u, err := url.Parse(tc.givenURL)
+			require.NoError(t, err)
+
+			// when
+			ref, err := getter.ParseOCIReference(u)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRef, ref)
+		})
+	}
+}
+
+func fixOCIURL(t *testing.T, serverURL, digest string) *url.URL {
+	t.Helper()
+
+	srv, err := url.Parse(serverURL)
+	require.NoError(t, err)
+
+	u, err := url.Parse(fmt.Sprintf("oci://%s/capact/hub-manifests@%s?insecure=true", srv.Host, digest))
+	require.NoError(t, err)
+	return u
+}
+
+func fixTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o600,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+
+	return buf.Bytes()
+}
+
+func digestOf(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -1,6 +1,7 @@
 package helmstoragebackend
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -8,6 +9,7 @@ import (
 	"google.golang.org/grpc/status"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
 	"helm.sh/helm/v3/pkg/storage/driver"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
@@ -49,20 +51,13 @@ func (f *HelmReleaseFetcher) FetchHelmRelease(helmRelease HelmRelease, additiona
 	}
 
 	helmGet := action.NewGet(cfg)
-
-	// NOTE: req.resourceVersion is ignored on purpose.
-	// Based on our contract we always return the latest Helm release revision.
 	helmGet.Version = latestRevisionIndicator
 
 	rel, err := helmGet.Run(helmRelease.Name)
 	switch {
 	case err == nil:
 	case errors.Is(err, driver.ErrReleaseNotFound):
-		var additionalErrCtx string
-		if additionalErrMsg != nil {
-			additionalErrCtx = fmt.Sprintf(" (%s)", *additionalErrMsg)
-		}
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("Helm release '%s/%s'%s was not found", helmRelease.Namespace, helmRelease.Name, additionalErrCtx))
+		return nil, releaseNotFoundError(helmRelease, additionalErrMsg)
 	default:
 		return nil, gRPCInternalError(errors.Wrap(err, "while fetching Helm release"))
 	}
@@ -70,6 +65,73 @@ func (f *HelmReleaseFetcher) FetchHelmRelease(helmRelease HelmRelease, additiona
 	return rel, nil
 }
 
+// FetchHelmReleaseHistory returns all stored revisions of a given Helm release, sorted from the oldest to the latest one.
+// It already handles the gRPC errors properly.
+func (f *HelmReleaseFetcher) FetchHelmReleaseHistory(helmRelease HelmRelease, additionalErrMsg *string) ([]*release.Release, error) {
+	cfg, err := f.actionConfigurationProducer(f.helmCfgFlags, *helmRelease.Driver, helmRelease.Namespace)
+	if err != nil {
+		return nil, gRPCInternalError(errors.Wrap(err, "while creating Helm get release client"))
+	}
+
+	history, err := cfg.Releases.History(helmRelease.Name)
+	switch {
+	case err == nil:
+	case errors.Is(err, driver.ErrReleaseNotFound):
+		return nil, releaseNotFoundError(helmRelease, additionalErrMsg)
+	default:
+		return nil, gRPCInternalError(errors.Wrap(err, "while fetching Helm release history"))
+	}
+
+	if len(history) == 0 {
+		return nil, releaseNotFoundError(helmRelease, additionalErrMsg)
+	}
+
+	releaseutil.SortByRevision(history)
+	return history, nil
+}
+
+// UninstallHelmRelease uninstalls a given Helm release. Release which doesn't exist is treated as already uninstalled.
+// It already handles the gRPC errors properly.
+func (f *HelmReleaseFetcher) UninstallHelmRelease(helmRelease HelmRelease) error {
+	cfg, err := f.actionConfigurationProducer(f.helmCfgFlags, *helmRelease.Driver, helmRelease.Namespace)
+	if err != nil {
+		return gRPCInternalError(errors.Wrap(err, "while creating Helm uninstall client"))
+	}
+
+	helmUninstall := action.NewUninstall(cfg)
+	_, err = helmUninstall.Run(helmRelease.Name)
+	switch {
+	case err == nil:
+	case errors.Is(err, driver.ErrReleaseNotFound):
+	default:
+		return gRPCInternalError(errors.Wrapf(err, "while uninstalling Helm release '%s/%s'", helmRelease.Namespace, helmRelease.Name))
+	}
+
+	return nil
+}
+
+// Ping checks if the Kubernetes cluster, where Helm releases are stored, is reachable.
+func (f *HelmReleaseFetcher) Ping(_ context.Context) error {
+	cfg, err := f.actionConfigurationProducer(f.helmCfgFlags, defaultHelmDriver, "")
+	if err != nil {
+		return errors.Wrap(err, "while creating Helm client")
+	}
+
+	if err := cfg.KubeClient.IsReachable(); err != nil {
+		return errors.Wrap(err, "while checking Kubernetes cluster connection")
+	}
+
+	return nil
+}
+
+func releaseNotFoundError(helmRelease HelmRelease, additionalErrMsg *string) error {
+	var additionalErrCtx string
+	if additionalErrMsg != nil {
+		additionalErrCtx = fmt.Sprintf(" (%s)", *additionalErrMsg)
+	}
+	return status.Error(codes.NotFound, fmt.Sprintf("Helm release '%s/%s'%s was not found", helmRelease.Namespace, helmRelease.Name, additionalErrCtx))
+}
+
 // actionConfigurationProducer returns Configuration with a given input settings.
 func actionConfigurationProducer(flags *genericclioptions.ConfigFlags, driver, ns string) (*action.Configuration, error) {
 	actionConfig := new(action.Configuration)
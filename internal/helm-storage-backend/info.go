@@ -0,0 +1,98 @@
+package helmstoragebackend
+
+import (
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+)
+
+// ReleaseContextSchema is the JSON schema of the context used by the Helm release storage backend.
+const ReleaseContextSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema",
+  "type": "object",
+  "required": ["name", "namespace", "chartLocation"],
+  "properties": {
+    "name": {
+      "$id": "#/properties/context/properties/name",
+      "type": "string"
+    },
+    "namespace": {
+      "$id": "#/properties/context/properties/namespace",
+      "type": "string"
+    },
+    "chartLocation": {
+      "$id": "#/properties/context/properties/chartLocation",
+      "type": "string"
+    },
+    "driver": {
+      "$id": "#/properties/context/properties/driver",
+      "type": "string",
+      "default": "secrets",
+      "enum": ["secrets", "configmaps", "sql"]
+    },
+    "uninstallOnDelete": {
+      "$id": "#/properties/context/properties/uninstallOnDelete",
+      "type": "boolean",
+      "default": false
+    }
+  },
+  "additionalProperties": false
+}`
+
+// TemplateContextSchema is the JSON schema of the context used by the Helm template storage backend.
+const TemplateContextSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema",
+  "type": "object",
+  "required": ["goTemplate", "release"],
+  "properties": {
+    "goTemplate": {
+      "$id": "#/properties/context/properties/goTemplate",
+      "type": "string"
+    },
+    "release": {
+      "required": ["name", "namespace"],
+      "$id": "#/properties/context/properties/release",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "name": {
+          "$id": "#/properties/context/properties/release/properties/name",
+          "type": "string"
+        },
+        "namespace": {
+          "$id": "#/properties/context/properties/release/properties/namespace",
+          "type": "string"
+        },
+        "driver": {
+          "$id": "#/properties/context/properties/release/properties/driver",
+          "type": "string",
+          "default": "secrets",
+          "enum": ["secrets", "configmaps", "sql"]
+        }
+      }
+    }
+  },
+  "additionalProperties": false
+}`
+
+// ReleaseInfo returns the Helm release storage backend details reported by the StorageBackendInfo service.
+func ReleaseInfo() *pb.GetInfoResponse {
+	return &pb.GetInfoResponse{
+		AcceptValue:   false,
+		ContextSchema: []byte(ReleaseContextSchema),
+		SupportedOperations: &pb.SupportedOperations{
+			Lock:           true,
+			Revisions:      true,
+			PreCreateValue: true,
+		},
+	}
+}
+
+// TemplateInfo returns the Helm template storage backend details reported by the StorageBackendInfo service.
+func TemplateInfo() *pb.GetInfoResponse {
+	return &pb.GetInfoResponse{
+		AcceptValue:   false,
+		ContextSchema: []byte(TemplateContextSchema),
+		SupportedOperations: &pb.SupportedOperations{
+			PreCreateValue: true,
+		},
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"helm.sh/helm/v3/pkg/release"
 
 	"capact.io/capact/internal/ptr"
@@ -43,30 +45,50 @@ type (
 		HelmRelease
 		// ChartLocation specifies Helm Chart location.
 		ChartLocation string `json:"chartLocation"`
+		// UninstallOnDelete specifies whether the Helm release should be uninstalled when the TypeInstance is deleted.
+		UninstallOnDelete bool `json:"uninstallOnDelete,omitempty"`
 	}
 )
 
+// ReleaseHandler handles incoming requests to the Helm release storage backend gRPC server.
 // ReleaseHandler handles incoming requests to the Helm release storage backend gRPC server.
 type ReleaseHandler struct {
 	pb.UnimplementedContextStorageBackendServer
 
 	log     *zap.Logger
 	fetcher *HelmReleaseFetcher
+	states  *ReleaseStateStore
 }
 
 // NewReleaseHandler returns new ReleaseHandler.
-func NewReleaseHandler(log *zap.Logger, helmRelFetcher *HelmReleaseFetcher) (*ReleaseHandler, error) {
+func NewReleaseHandler(log *zap.Logger, helmRelFetcher *HelmReleaseFetcher, states *ReleaseStateStore) (*ReleaseHandler, error) {
 	return &ReleaseHandler{
 		log:     log,
 		fetcher: helmRelFetcher,
+		states:  states,
 	}, nil
 }
 
 // OnCreate checks whether a given Helm release is accessible this storage backend.
-func (h *ReleaseHandler) OnCreate(_ context.Context, req *pb.OnCreateRequest) (*pb.OnCreateResponse, error) {
-	if _, _, err := h.fetchHelmReleaseForTI(req.TypeInstanceId, req.Context); err != nil { // check if accessible
+// The latest Helm release revision is marked as the first TypeInstance resource version.
+func (h *ReleaseHandler) OnCreate(ctx context.Context, req *pb.OnCreateRequest) (*pb.OnCreateResponse, error) {
+	history, relCtx, err := h.fetchHelmReleaseHistoryForTI(req.TypeInstanceId, req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	// the state of a previously registered TypeInstance is not relevant for the new one
+	state, err := h.getReleaseState(ctx, relCtx)
+	if err != nil {
+		return nil, err
+	}
+	state.LockedBy = ""
+	state.ResourceVersions = map[uint32]resourceVersionRecord{}
+
+	if err := h.markResourceVersion(ctx, relCtx, history, state, 1); err != nil {
 		return nil, err
 	}
+
 	return &pb.OnCreateResponse{}, nil
 }
 
@@ -87,9 +109,23 @@ func (h *ReleaseHandler) GetPreCreateValue(ctx context.Context, req *pb.GetPreCr
 	}, nil
 }
 
-// GetValue returns a value for a given TypeInstance.
-func (h *ReleaseHandler) GetValue(_ context.Context, req *pb.GetValueRequest) (*pb.GetValueResponse, error) {
-	rel, relCtx, err := h.fetchHelmReleaseForTI(req.TypeInstanceId, req.Context)
+// GetValue returns a value for a given TypeInstance resource version.
+// The resource version is mapped to the Helm release revision which was the latest one when the TypeInstance resource version
+// was created or updated. If the resource version is not specified, the revision mapped to the latest resource version is returned,
+// so revisions created outside Capact are not returned until the TypeInstance is updated.
+// If none of the Helm release revisions is mapped, the latest Helm release revision is returned.
+func (h *ReleaseHandler) GetValue(ctx context.Context, req *pb.GetValueRequest) (*pb.GetValueResponse, error) {
+	history, relCtx, err := h.fetchHelmReleaseHistoryForTI(req.TypeInstanceId, req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getReleaseState(ctx, relCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := releaseForResourceVersion(history, state, req.TypeInstanceId, req.ResourceVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -105,31 +141,135 @@ func (h *ReleaseHandler) GetValue(_ context.Context, req *pb.GetValueRequest) (*
 }
 
 // OnUpdate checks whether a given Helm release is accessible this storage backend.
-func (h *ReleaseHandler) OnUpdate(_ context.Context, req *pb.OnUpdateRequest) (*pb.OnUpdateResponse, error) {
-	if _, _, err := h.fetchHelmReleaseForTI(req.TypeInstanceId, req.Context); err != nil { // check if accessible
+// The latest Helm release revision is marked as the new TypeInstance resource version.
+// If the Helm release is locked by a different owner than the one which performs the update, an error is returned.
+// While the TypeInstance is locked, new Helm release revisions are created by the lock owner, but the already mapped
+// revisions must not be replaced outside Capact, for example, by reinstalling the Helm release.
+func (h *ReleaseHandler) OnUpdate(ctx context.Context, req *pb.OnUpdateRequest) (*pb.OnUpdateResponse, error) {
+	history, relCtx, err := h.fetchHelmReleaseHistoryForTI(req.TypeInstanceId, req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getReleaseState(ctx, relCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.LockedBy != "" {
+		if state.LockedBy != ptr.StringPtrToString(req.OwnerId) {
+			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Helm release '%s/%s' is locked by %q", relCtx.Namespace, relCtx.Name, state.LockedBy))
+		}
+		if err := ensureNotModifiedOutOfBand(history, state, relCtx, req.TypeInstanceId, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := h.markResourceVersion(ctx, relCtx, history, state, req.NewResourceVersion); err != nil {
 		return nil, err
 	}
+
 	return &pb.OnUpdateResponse{}, nil
 }
 
-// OnDelete is NOP. Currently, we are not sure whether the release should be deleted or this should be more an information
-// that someone wants to deregister this TypeInstance.
-func (h *ReleaseHandler) OnDelete(_ context.Context, _ *pb.OnDeleteRequest) (*pb.OnDeleteResponse, error) {
+// OnDelete removes the TypeInstance details associated with a given Helm release.
+// The Helm release is uninstalled only if requested in the context. Otherwise, the TypeInstance is only deregistered.
+func (h *ReleaseHandler) OnDelete(ctx context.Context, req *pb.OnDeleteRequest) (*pb.OnDeleteResponse, error) {
+	relCtx, err := h.getReleaseContext(req.Context)
+	if err != nil {
+		return nil, gRPCInternalError(err)
+	}
+
+	if err := h.states.Delete(ctx, relCtx.HelmRelease); err != nil {
+		return nil, gRPCInternalError(err)
+	}
+
+	if !relCtx.UninstallOnDelete {
+		return &pb.OnDeleteResponse{}, nil
+	}
+
+	h.log.Info("Uninstalling Helm release", zap.String("id", req.TypeInstanceId), zap.String("name", relCtx.Name), zap.String("namespace", relCtx.Namespace))
+	if err := h.fetcher.UninstallHelmRelease(relCtx.HelmRelease); err != nil {
+		return nil, err
+	}
+
 	return &pb.OnDeleteResponse{}, nil
 }
 
-// GetLockedBy is NOP.
-func (h *ReleaseHandler) GetLockedBy(_ context.Context, _ *pb.GetLockedByRequest) (*pb.GetLockedByResponse, error) {
-	return &pb.GetLockedByResponse{}, nil
+// GetLockedBy returns the lock owner stored in the Helm release state.
+func (h *ReleaseHandler) GetLockedBy(ctx context.Context, req *pb.GetLockedByRequest) (*pb.GetLockedByResponse, error) {
+	_, relCtx, err := h.fetchHelmReleaseHistoryForTI(req.TypeInstanceId, req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getReleaseState(ctx, relCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.LockedBy == "" {
+		return &pb.GetLockedByResponse{}, nil
+	}
+
+	return &pb.GetLockedByResponse{
+		LockedBy: ptr.String(state.LockedBy),
+	}, nil
 }
 
-// OnLock is NOP.
-func (h *ReleaseHandler) OnLock(_ context.Context, _ *pb.OnLockRequest) (*pb.OnLockResponse, error) {
+// OnLock stores the lock owner in the Helm release state.
+// Locking fails if the Helm release was modified outside Capact, for example, with `helm upgrade` or `helm rollback`,
+// after the last TypeInstance update. In such case, the TypeInstance needs to be updated first.
+func (h *ReleaseHandler) OnLock(ctx context.Context, req *pb.OnLockRequest) (*pb.OnLockResponse, error) {
+	history, relCtx, err := h.fetchHelmReleaseHistoryForTI(req.TypeInstanceId, req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getReleaseState(ctx, relCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.LockedBy == req.LockedBy {
+		return &pb.OnLockResponse{}, nil
+	}
+	if state.LockedBy != "" {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Helm release '%s/%s' is already locked by %q", relCtx.Namespace, relCtx.Name, state.LockedBy))
+	}
+	if err := ensureNotModifiedOutOfBand(history, state, relCtx, req.TypeInstanceId, false); err != nil {
+		return nil, err
+	}
+
+	state.LockedBy = req.LockedBy
+	if err := h.saveReleaseState(ctx, relCtx, state); err != nil {
+		return nil, err
+	}
+
 	return &pb.OnLockResponse{}, nil
 }
 
-// OnUnlock is NOP.
-func (h *ReleaseHandler) OnUnlock(_ context.Context, _ *pb.OnUnlockRequest) (*pb.OnUnlockResponse, error) {
+// OnUnlock removes the lock owner from the Helm release state.
+func (h *ReleaseHandler) OnUnlock(ctx context.Context, req *pb.OnUnlockRequest) (*pb.OnUnlockResponse, error) {
+	_, relCtx, err := h.fetchHelmReleaseHistoryForTI(req.TypeInstanceId, req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getReleaseState(ctx, relCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.LockedBy == "" {
+		return &pb.OnUnlockResponse{}, nil
+	}
+
+	state.LockedBy = ""
+	if err := h.saveReleaseState(ctx, relCtx, state); err != nil {
+		return nil, err
+	}
+
 	return &pb.OnUnlockResponse{}, nil
 }
 
@@ -147,7 +287,9 @@ func (h *ReleaseHandler) getReleaseContext(contextBytes []byte) (*ReleaseContext
 	return &ctx, nil
 }
 
-// OnDeleteRevision is NOP.
+// OnDeleteRevision is NOP. The request doesn't contain the context, so the Helm release cannot be resolved.
+// Old Helm release revisions are pruned by Helm itself, based on the max history setting. The resource versions mapping
+// is kept in the Helm release state, so it is not affected by the pruning.
 func (*ReleaseHandler) OnDeleteRevision(context.Context, *pb.OnDeleteRevisionRequest) (*pb.OnDeleteRevisionResponse, error) {
 	return &pb.OnDeleteRevisionResponse{}, nil
 }
@@ -176,11 +318,6 @@ func (h *ReleaseHandler) fetchHelmReleaseForPrecreate(relCtx []byte) (*release.R
 	return h.fetchHelmRelease(relCtx, additionalCtxMsg)
 }
 
-func (h *ReleaseHandler) fetchHelmReleaseForTI(ti string, relCtx []byte) (*release.Release, *ReleaseContext, error) {
-	additionalCtxMsg := fmt.Sprintf("TypeInstance ID: '%s'", ti)
-	return h.fetchHelmRelease(relCtx, additionalCtxMsg)
-}
-
 func (h *ReleaseHandler) fetchHelmRelease(relCtx []byte, additionalCtxMsg string) (*release.Release, *ReleaseContext, error) {
 	resolvedRelCtx, err := h.getReleaseContext(relCtx)
 	if err != nil {
@@ -194,3 +331,115 @@ func (h *ReleaseHandler) fetchHelmRelease(relCtx []byte, additionalCtxMsg string
 
 	return rel, resolvedRelCtx, nil
 }
+
+func (h *ReleaseHandler) fetchHelmReleaseHistoryForTI(ti string, relCtx []byte) ([]*release.Release, *ReleaseContext, error) {
+	resolvedRelCtx, err := h.getReleaseContext(relCtx)
+	if err != nil {
+		return nil, nil, gRPCInternalError(err)
+	}
+
+	additionalCtxMsg := fmt.Sprintf("TypeInstance ID: '%s'", ti)
+	history, err := h.fetcher.FetchHelmReleaseHistory(resolvedRelCtx.HelmRelease, ptr.String(additionalCtxMsg))
+	if err != nil {
+		return nil, nil, err // it already handles grpc errors properly
+	}
+
+	return history, resolvedRelCtx, nil
+}
+
+func (h *ReleaseHandler) getReleaseState(ctx context.Context, relCtx *ReleaseContext) (*ReleaseState, error) {
+	state, err := h.states.Get(ctx, relCtx.HelmRelease)
+	if err != nil {
+		return nil, gRPCInternalError(err)
+	}
+	return state, nil
+}
+
+func (h *ReleaseHandler) saveReleaseState(ctx context.Context, relCtx *ReleaseContext, state *ReleaseState) error {
+	err := h.states.Save(ctx, relCtx.HelmRelease, state)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrReleaseStateConflict):
+		return status.Error(codes.Aborted, fmt.Sprintf("Helm release '%s/%s': %s", relCtx.Namespace, relCtx.Name, err))
+	default:
+		return gRPCInternalError(err)
+	}
+
+	return nil
+}
+
+// markResourceVersion maps a given TypeInstance resource version to the latest Helm release revision.
+func (h *ReleaseHandler) markResourceVersion(ctx context.Context, relCtx *ReleaseContext, history []*release.Release, state *ReleaseState, resourceVersion uint32) error {
+	if resourceVersion == latestRevisionIndicator {
+		return nil
+	}
+
+	record := recordForRelease(history[len(history)-1])
+	if current, found := state.ResourceVersions[resourceVersion]; found && current == record {
+		return nil
+	}
+
+	state.ResourceVersions[resourceVersion] = record
+	return h.saveReleaseState(ctx, relCtx, state)
+}
+
+// releaseForResourceVersion returns the Helm release revision mapped to a given TypeInstance resource version.
+// The history must be sorted from the oldest to the latest revision.
+func releaseForResourceVersion(history []*release.Release, state *ReleaseState, typeInstanceID string, resourceVersion uint32) (*release.Release, error) {
+	if len(state.ResourceVersions) == 0 {
+		// TypeInstance was registered before the revisions mapping was introduced
+		return history[len(history)-1], nil
+	}
+
+	if resourceVersion == latestRevisionIndicator {
+		resourceVersion, _, _ = state.latestResourceVersion()
+	}
+
+	record, found := state.ResourceVersions[resourceVersion]
+	if !found {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("while getting value for TypeInstance '%s': none of the Helm release revisions is associated with resource version %d", typeInstanceID, resourceVersion))
+	}
+
+	rel := findRevision(history, record.Revision)
+	if rel == nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("while getting value for TypeInstance '%s': Helm release revision %d associated with resource version %d was pruned", typeInstanceID, record.Revision, resourceVersion))
+	}
+	if recordForRelease(rel) != record {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("while getting value for TypeInstance '%s': Helm release revision %d associated with resource version %d was replaced outside Capact", typeInstanceID, record.Revision, resourceVersion))
+	}
+
+	return rel, nil
+}
+
+// ensureNotModifiedOutOfBand returns an error if the Helm release revision mapped to the latest TypeInstance resource version
+// was replaced outside Capact, for example, when the Helm release was reinstalled.
+// Unless newRevisionsAllowed is set, the latest Helm release revision must be mapped to the latest TypeInstance resource version.
+// The history must be sorted from the oldest to the latest revision.
+func ensureNotModifiedOutOfBand(history []*release.Release, state *ReleaseState, relCtx *ReleaseContext, typeInstanceID string, newRevisionsAllowed bool) error {
+	_, record, found := state.latestResourceVersion()
+	if !found {
+		return nil
+	}
+
+	latest := history[len(history)-1]
+	if rel := findRevision(history, record.Revision); latest.Version < record.Revision || (rel != nil && recordForRelease(rel) != record) {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("Helm release '%s/%s' revision %d associated with TypeInstance '%s' was replaced outside Capact",
+			relCtx.Namespace, relCtx.Name, record.Revision, typeInstanceID))
+	}
+
+	if !newRevisionsAllowed && latest.Version != record.Revision {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("Helm release '%s/%s' revision %d was created outside Capact and it is not associated with any TypeInstance '%s' resource version",
+			relCtx.Namespace, relCtx.Name, latest.Version, typeInstanceID))
+	}
+
+	return nil
+}
+
+func findRevision(history []*release.Release, revision int) *release.Release {
+	for _, rel := range history {
+		if rel.Version == revision {
+			return rel
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package helmstoragebackend
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/release"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	releaseStateNamePrefix = "capact-helm-release."
+	lockedByKey            = "locked-by"
+	resourceVersionsKey    = "resource-versions"
+	managedByLabelKey      = "app.kubernetes.io/managed-by"
+	managedByLabelValue    = "helm-storage-backend"
+	releaseNameLabelKey    = "capact.io/helm-release"
+)
+
+// ErrReleaseStateConflict is returned when a given release state was modified in the meantime.
+var ErrReleaseStateConflict = errors.New("Helm release state was modified in the meantime")
+
+// ReleaseState holds the TypeInstance details associated with a given Helm release.
+// It is stored outside the Helm release records, as `helm rollback` copies the record of the target revision.
+type ReleaseState struct {
+	// LockedBy holds the ID of the owner which locked the TypeInstance associated with the Helm release.
+	LockedBy string
+	// ResourceVersions maps the TypeInstance resource versions to the Helm release revisions.
+	ResourceVersions map[uint32]resourceVersionRecord
+
+	resourceVersion string
+}
+
+// resourceVersionRecord holds details of the Helm release revision mapped to a given TypeInstance resource version.
+// Chart details are used to detect revisions replaced outside Capact, for example, when the Helm release was reinstalled.
+type resourceVersionRecord struct {
+	Revision     int    `json:"revision"`
+	ChartName    string `json:"chartName"`
+	ChartVersion string `json:"chartVersion"`
+}
+
+// latestResourceVersion returns the latest mapped TypeInstance resource version and its record.
+func (s *ReleaseState) latestResourceVersion() (uint32, resourceVersionRecord, bool) {
+	var (
+		latest uint32
+		found  bool
+	)
+	for resourceVersion := range s.ResourceVersions {
+		if !found || resourceVersion > latest {
+			latest = resourceVersion
+			found = true
+		}
+	}
+
+	return latest, s.ResourceVersions[latest], found
+}
+
+func recordForRelease(rel *release.Release) resourceVersionRecord {
+	record := resourceVersionRecord{Revision: rel.Version}
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		record.ChartName = rel.Chart.Metadata.Name
+		record.ChartVersion = rel.Chart.Metadata.Version
+	}
+	return record
+}
+
+// ReleaseStateStore stores the Helm release states in Kubernetes ConfigMaps, next to the Helm releases.
+type ReleaseStateStore struct {
+	cli client.Client
+}
+
+// NewReleaseStateStore returns a new ReleaseStateStore instance.
+func NewReleaseStateStore(cli client.Client) *ReleaseStateStore {
+	return &ReleaseStateStore{cli: cli}
+}
+
+// Get returns the state of a given Helm release. If it doesn't exist, an empty state is returned.
+func (s *ReleaseStateStore) Get(ctx context.Context, helmRelease HelmRelease) (*ReleaseState, error) {
+	cm := &corev1.ConfigMap{}
+	err := s.cli.Get(ctx, releaseStateKey(helmRelease), cm)
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		return &ReleaseState{ResourceVersions: map[uint32]resourceVersionRecord{}}, nil
+	default:
+		return nil, errors.Wrapf(err, "while getting state for Helm release '%s/%s'", helmRelease.Namespace, helmRelease.Name)
+	}
+
+	state := &ReleaseState{
+		LockedBy:         cm.Data[lockedByKey],
+		ResourceVersions: map[uint32]resourceVersionRecord{},
+		resourceVersion:  cm.ResourceVersion,
+	}
+	if val, found := cm.Data[resourceVersionsKey]; found {
+		if err := json.Unmarshal([]byte(val), &state.ResourceVersions); err != nil {
+			return nil, errors.Wrapf(err, "while unmarshaling resource versions mapping for Helm release '%s/%s'", helmRelease.Namespace, helmRelease.Name)
+		}
+	}
+
+	return state, nil
+}
+
+// Save creates or updates the state of a given Helm release.
+// It returns ErrReleaseStateConflict if the state was modified after it was read.
+func (s *ReleaseStateStore) Save(ctx context.Context, helmRelease HelmRelease, state *ReleaseState) error {
+	mapping, err := json.Marshal(state.ResourceVersions)
+	if err != nil {
+		return errors.Wrap(err, "while marshaling resource versions mapping")
+	}
+
+	key := releaseStateKey(helmRelease)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				managedByLabelKey:   managedByLabelValue,
+				releaseNameLabelKey: helmRelease.Name,
+			},
+			ResourceVersion: state.resourceVersion,
+		},
+		Data: map[string]string{
+			resourceVersionsKey: string(mapping),
+		},
+	}
+	if state.LockedBy != "" {
+		cm.Data[lockedByKey] = state.LockedBy
+	}
+
+	if state.resourceVersion == "" {
+		err = s.cli.Create(ctx, cm)
+	} else {
+		err = s.cli.Update(ctx, cm)
+	}
+
+	switch {
+	case err == nil:
+	case apierrors.IsConflict(err), apierrors.IsAlreadyExists(err):
+		return ErrReleaseStateConflict
+	default:
+		return errors.Wrapf(err, "while saving state for Helm release '%s/%s'", helmRelease.Namespace, helmRelease.Name)
+	}
+	state.resourceVersion = cm.ResourceVersion
+
+	return nil
+}
+
+// Delete deletes the state of a given Helm release. Deleting a state which doesn't exist is not an error.
+func (s *ReleaseStateStore) Delete(ctx context.Context, helmRelease HelmRelease) error {
+	key := releaseStateKey(helmRelease)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+	}
+
+	if err := s.cli.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "while deleting state for Helm release '%s/%s'", helmRelease.Namespace, helmRelease.Name)
+	}
+
+	return nil
+}
+
+// releaseStateKey returns the key of the ConfigMap which holds the state of a given Helm release.
+func releaseStateKey(helmRelease HelmRelease) client.ObjectKey {
+	return client.ObjectKey{
+		Namespace: helmRelease.Namespace,
+		Name:      releaseStateNamePrefix + helmRelease.Name,
+	}
+}
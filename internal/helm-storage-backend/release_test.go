@@ -18,6 +18,7 @@ import (
 	"helm.sh/helm/v3/pkg/storage/driver"
 	"helm.sh/helm/v3/pkg/time"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"capact.io/capact/internal/logger"
 	"capact.io/capact/internal/ptr"
@@ -46,9 +47,9 @@ func TestRelease_CreateGetUpdate_Success(t *testing.T) {
 			expectedDriver:      "configmaps",
 		},
 		{
-			name:                 "should ignore resourceVersion and return the latest release",
+			name:                 "should return the latest release if revisions are not mapped to resource versions",
 			givenTypeInstanceID:  "123",
-			givenResourceVersion: 42, // should be ignored
+			givenResourceVersion: 42,
 			expectedDriver:       "secrets",
 		},
 	}
@@ -93,7 +94,7 @@ func TestRelease_CreateGetUpdate_Success(t *testing.T) {
 
 			fetcher := NewHelmReleaseFetcher(expFlags)
 			fetcher.actionConfigurationProducer = mockConfigurationProducer
-			svc, err := NewReleaseHandler(logger.Noop(), fetcher)
+			svc, err := NewReleaseHandler(logger.Noop(), fetcher, fixReleaseStateStore())
 			require.NoError(t, err)
 
 			// when
@@ -211,7 +212,7 @@ func TestRelease_CreateGetUpdate_Failures(t *testing.T) {
 			fetcher := NewHelmReleaseFetcher(expFlags)
 			fetcher.actionConfigurationProducer = mockConfigurationProducer
 
-			svc, err := NewReleaseHandler(logger.Noop(), fetcher)
+			svc, err := NewReleaseHandler(logger.Noop(), fetcher, fixReleaseStateStore())
 			require.NoError(t, err)
 
 			// when
@@ -317,7 +318,7 @@ func TestRelease_GetPreCreateValue_Failures(t *testing.T) {
 			fetcher := NewHelmReleaseFetcher(expFlags)
 			fetcher.actionConfigurationProducer = mockConfigurationProducer
 
-			svc, err := NewReleaseHandler(logger.Noop(), fetcher)
+			svc, err := NewReleaseHandler(logger.Noop(), fetcher, fixReleaseStateStore())
 			require.NoError(t, err)
 
 			// when
@@ -337,27 +338,14 @@ func TestRelease_NOP_Methods(t *testing.T) {
 		handler func(ctx context.Context, svc *ReleaseHandler) (interface{}, error)
 	}{
 		{
-			name: "no operation for OnDelete",
+			name: "no operation for OnDelete without uninstall",
 			handler: func(ctx context.Context, svc *ReleaseHandler) (interface{}, error) {
-				return svc.OnDelete(ctx, nil)
-			},
-		},
-		{
-			name: "no operation for GetLockedBy",
-			handler: func(ctx context.Context, svc *ReleaseHandler) (interface{}, error) {
-				return svc.GetLockedBy(ctx, nil)
-			},
-		},
-		{
-			name: "no operation for OnLock",
-			handler: func(ctx context.Context, svc *ReleaseHandler) (interface{}, error) {
-				return svc.OnLock(ctx, nil)
-			},
-		},
-		{
-			name: "no operation for OnUnlock",
-			handler: func(ctx context.Context, svc *ReleaseHandler) (interface{}, error) {
-				return svc.OnUnlock(ctx, nil)
+				return svc.OnDelete(ctx, &pb.OnDeleteRequest{
+					TypeInstanceId: "123",
+					Context: mustMarshal(t, ReleaseContext{
+						HelmRelease: HelmRelease{Name: "test-release", Namespace: "test-namespace"},
+					}),
+				})
 			},
 		},
 		{
@@ -379,7 +367,7 @@ func TestRelease_NOP_Methods(t *testing.T) {
 			}
 			fetcher := NewHelmReleaseFetcher(nil)
 			fetcher.actionConfigurationProducer = mockConfigurationProducer
-			svc, err := NewReleaseHandler(logger.Noop(), fetcher)
+			svc, err := NewReleaseHandler(logger.Noop(), fetcher, fixReleaseStateStore())
 			require.NoError(t, err)
 
 			// when
@@ -393,10 +381,233 @@ func TestRelease_NOP_Methods(t *testing.T) {
 	}
 }
 
+func TestRelease_GetValue_ResourceVersions(t *testing.T) {
+	// given
+	const (
+		releaseName      = "test-release"
+		releaseNamespace = "test-namespace"
+		tiID             = "123"
+	)
+	relCtx := mustMarshal(t, ReleaseContext{
+		HelmRelease: HelmRelease{
+			Name:      releaseName,
+			Namespace: releaseNamespace,
+		},
+	})
+
+	svc, releases := fixReleaseHandlerWithHistory(t, releaseName, releaseNamespace)
+
+	// when
+	_, err := svc.OnCreate(context.Background(), &pb.OnCreateRequest{TypeInstanceId: tiID, Context: relCtx})
+	require.NoError(t, err)
+
+	upgradeRelease(t, releases, releaseName, releaseNamespace, "0.2.0")
+
+	_, err = svc.OnUpdate(context.Background(), &pb.OnUpdateRequest{TypeInstanceId: tiID, NewResourceVersion: 2, Context: relCtx})
+	require.NoError(t, err)
+
+	// TypeInstance updated without Helm release upgrade
+	_, err = svc.OnUpdate(context.Background(), &pb.OnUpdateRequest{TypeInstanceId: tiID, NewResourceVersion: 3, Context: relCtx})
+	require.NoError(t, err)
+
+	// first revision pruned by Helm
+	_, err = releases.Delete(releaseName, 1)
+	require.NoError(t, err)
+
+	// out-of-band upgrade is not associated with any resource version
+	upgradeRelease(t, releases, releaseName, releaseNamespace, "0.3.0")
+
+	// then
+	for resourceVersion, expChartVersion := range map[uint32]string{
+		0: "0.2.0",
+		2: "0.2.0",
+		3: "0.2.0",
+	} {
+		out, err := svc.GetValue(context.Background(), &pb.GetValueRequest{TypeInstanceId: tiID, ResourceVersion: resourceVersion, Context: relCtx})
+		require.NoError(t, err)
+
+		var details ReleaseDetails
+		require.NoError(t, json.Unmarshal(out.Value, &details))
+		assert.Equal(t, expChartVersion, details.Chart.Version, "resource version %d", resourceVersion)
+	}
+
+	// when
+	_, err = svc.GetValue(context.Background(), &pb.GetValueRequest{TypeInstanceId: tiID, ResourceVersion: 1, Context: relCtx})
+
+	// then
+	assert.EqualError(t, err, "rpc error: code = NotFound desc = while getting value for TypeInstance '123': Helm release revision 1 associated with resource version 1 was pruned")
+
+	// when
+	_, err = svc.GetValue(context.Background(), &pb.GetValueRequest{TypeInstanceId: tiID, ResourceVersion: 4, Context: relCtx})
+
+	// then
+	assert.EqualError(t, err, "rpc error: code = NotFound desc = while getting value for TypeInstance '123': none of the Helm release revisions is associated with resource version 4")
+}
+
+func TestRelease_ReinstalledOutOfBand(t *testing.T) {
+	// given
+	const (
+		releaseName      = "test-release"
+		releaseNamespace = "test-namespace"
+		tiID             = "123"
+		owner            = "namespace/action"
+	)
+	relCtx := mustMarshal(t, ReleaseContext{
+		HelmRelease: HelmRelease{
+			Name:      releaseName,
+			Namespace: releaseNamespace,
+		},
+	})
+
+	svc, releases := fixReleaseHandlerWithHistory(t, releaseName, releaseNamespace)
+	_, err := svc.OnCreate(context.Background(), &pb.OnCreateRequest{TypeInstanceId: tiID, Context: relCtx})
+	require.NoError(t, err)
+	_, err = svc.OnLock(context.Background(), &pb.OnLockRequest{TypeInstanceId: tiID, Context: relCtx, LockedBy: owner})
+	require.NoError(t, err)
+
+	// when
+	_, err = releases.Delete(releaseName, 1)
+	require.NoError(t, err)
+	reinstalled := fixHelmRelease(releaseName, releaseNamespace)
+	reinstalled.Chart.Metadata.Version = "0.2.0"
+	require.NoError(t, releases.Create(reinstalled))
+
+	// then
+	_, err = svc.GetValue(context.Background(), &pb.GetValueRequest{TypeInstanceId: tiID, Context: relCtx})
+	assert.EqualError(t, err, "rpc error: code = FailedPrecondition desc = while getting value for TypeInstance '123': Helm release revision 1 associated with resource version 1 was replaced outside Capact")
+
+	_, err = svc.OnUpdate(context.Background(), &pb.OnUpdateRequest{TypeInstanceId: tiID, NewResourceVersion: 2, Context: relCtx, OwnerId: ptr.String(owner)})
+	assert.EqualError(t, err, "rpc error: code = FailedPrecondition desc = Helm release 'test-namespace/test-release' revision 1 associated with TypeInstance '123' was replaced outside Capact")
+}
+
+func TestRelease_Locking(t *testing.T) {
+	// given
+	const (
+		releaseName      = "test-release"
+		releaseNamespace = "test-namespace"
+		tiID             = "123"
+		owner            = "namespace/action"
+	)
+	relCtx := mustMarshal(t, ReleaseContext{
+		HelmRelease: HelmRelease{
+			Name:      releaseName,
+			Namespace: releaseNamespace,
+		},
+	})
+
+	svc, releases := fixReleaseHandlerWithHistory(t, releaseName, releaseNamespace)
+	_, err := svc.OnCreate(context.Background(), &pb.OnCreateRequest{TypeInstanceId: tiID, Context: relCtx})
+	require.NoError(t, err)
+
+	// when
+	_, err = svc.OnLock(context.Background(), &pb.OnLockRequest{TypeInstanceId: tiID, Context: relCtx, LockedBy: owner})
+	require.NoError(t, err)
+
+	// then
+	lockedBy, err := svc.GetLockedBy(context.Background(), &pb.GetLockedByRequest{TypeInstanceId: tiID, Context: relCtx})
+	require.NoError(t, err)
+	assert.Equal(t, ptr.String(owner), lockedBy.LockedBy)
+
+	// when
+	upgradeRelease(t, releases, releaseName, releaseNamespace, "0.2.0")
+	_, err = svc.OnUpdate(context.Background(), &pb.OnUpdateRequest{TypeInstanceId: tiID, NewResourceVersion: 2, Context: relCtx, OwnerId: ptr.String("other")})
+
+	// then
+	assert.EqualError(t, err, `rpc error: code = FailedPrecondition desc = Helm release 'test-namespace/test-release' is locked by "namespace/action"`)
+
+	// when
+	_, err = svc.OnUpdate(context.Background(), &pb.OnUpdateRequest{TypeInstanceId: tiID, NewResourceVersion: 2, Context: relCtx, OwnerId: ptr.String(owner)})
+	require.NoError(t, err)
+	_, err = svc.OnUnlock(context.Background(), &pb.OnUnlockRequest{TypeInstanceId: tiID, Context: relCtx})
+	require.NoError(t, err)
+
+	// then
+	lockedBy, err = svc.GetLockedBy(context.Background(), &pb.GetLockedByRequest{TypeInstanceId: tiID, Context: relCtx})
+	require.NoError(t, err)
+	assert.Nil(t, lockedBy.LockedBy)
+
+	// when
+	upgradeRelease(t, releases, releaseName, releaseNamespace, "0.3.0") // out-of-band upgrade
+	_, err = svc.OnLock(context.Background(), &pb.OnLockRequest{TypeInstanceId: tiID, Context: relCtx, LockedBy: owner})
+
+	// then
+	assert.EqualError(t, err, "rpc error: code = FailedPrecondition desc = Helm release 'test-namespace/test-release' revision 3 was created outside Capact and it is not associated with any TypeInstance '123' resource version")
+}
+
+func TestRelease_OnDelete_Uninstall(t *testing.T) {
+	// given
+	const (
+		releaseName      = "test-release"
+		releaseNamespace = "test-namespace"
+	)
+	relCtx := mustMarshal(t, ReleaseContext{
+		HelmRelease: HelmRelease{
+			Name:      releaseName,
+			Namespace: releaseNamespace,
+		},
+		UninstallOnDelete: true,
+	})
+
+	svc, releases := fixReleaseHandlerWithHistory(t, releaseName, releaseNamespace)
+
+	// when
+	_, err := svc.OnDelete(context.Background(), &pb.OnDeleteRequest{TypeInstanceId: "123", Context: relCtx})
+
+	// then
+	require.NoError(t, err)
+	_, err = releases.History(releaseName)
+	assert.True(t, errors.Is(err, driver.ErrReleaseNotFound))
+
+	// when
+	_, err = svc.OnDelete(context.Background(), &pb.OnDeleteRequest{TypeInstanceId: "123", Context: relCtx})
+
+	// then
+	assert.NoError(t, err)
+}
+
+func fixReleaseStateStore() *ReleaseStateStore {
+	return NewReleaseStateStore(fake.NewClientBuilder().Build())
+}
+
+func fixReleaseHandlerWithHistory(t *testing.T, name, ns string) (*ReleaseHandler, *storage.Storage) {
+	t.Helper()
+
+	inMemoryDriver := driver.NewMemory()
+	inMemoryDriver.SetNamespace(ns)
+	releases := storage.Init(inMemoryDriver)
+	require.NoError(t, releases.Create(fixHelmRelease(name, ns)))
+
+	fetcher := NewHelmReleaseFetcher(nil)
+	fetcher.actionConfigurationProducer = func(_ *genericclioptions.ConfigFlags, _, _ string) (*action.Configuration, error) {
+		return &action.Configuration{
+			Releases:   releases,
+			KubeClient: &kubefake.FailingKubeClient{PrintingKubeClient: kubefake.PrintingKubeClient{Out: ioutil.Discard}},
+			Log:        func(_ string, _ ...interface{}) {},
+		}, nil
+	}
+
+	svc, err := NewReleaseHandler(logger.Noop(), fetcher, fixReleaseStateStore())
+	require.NoError(t, err)
+
+	return svc, releases
+}
+
+func upgradeRelease(t *testing.T, releases *storage.Storage, name, ns, chartVersion string) {
+	t.Helper()
+
+	last, err := releases.Last(name)
+	require.NoError(t, err)
+
+	rel := fixHelmRelease(name, ns)
+	rel.Version = last.Version + 1
+	rel.Chart.Metadata.Version = chartVersion
+	require.NoError(t, releases.Create(rel))
+}
+
 func mockConfigurationProducer(t *testing.T, expHelmRelease *release.Release, expFlags *genericclioptions.ConfigFlags, expDriver string) actionConfigurationProducerFn {
 	t.Helper()
 	inMemoryDriver := driver.NewMemory()
-	err := inMemoryDriver.Create("1", expHelmRelease)
+	err := inMemoryDriver.Create(fmt.Sprintf("sh.helm.release.v1.%s.v%d", expHelmRelease.Name, expHelmRelease.Version), expHelmRelease)
 	require.NoError(t, err)
 
 	return func(inputFlags *genericclioptions.ConfigFlags, inputDriver, inputNs string) (*action.Configuration, error) {
@@ -425,6 +636,7 @@ func fixHelmRelease(name, ns string) *release.Release {
 	return &release.Release{
 		Name:      name,
 		Namespace: ns,
+		Version:   1,
 		Info: &release.Info{
 			FirstDeployed: now,
 			LastDeployed:  now,
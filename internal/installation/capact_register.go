@@ -66,6 +66,9 @@ func NewCapactRegister() (*CapactRegister, error) {
 	}
 
 	client := local.NewDefaultClient(cfg.LocalHubEndpoint)
+	if cfg.Gateway.Enabled() {
+		client = local.NewDefaultClient(cfg.Gateway.Endpoint, cfg.Gateway.ClientOptions()...)
+	}
 
 	return &CapactRegister{
 		k8sCfg:        k8sCfg,
@@ -179,7 +182,7 @@ func (i *CapactRegister) produceHelmReleaseTypeInstance(helmRelease *release.Rel
 	args := helm.ReleaseOutputArgs{
 		UseHelmReleaseStorage: false,
 	}
-	releaseOut, err := i.helmOutputter.ProduceHelmRelease(args, i.cfg.HelmRepositoryPath, i.helmDriver, helmRelease)
+	releaseOut, err := i.helmOutputter.ProduceHelmRelease(args, helm.Chart{Repo: i.cfg.HelmRepositoryPath}, i.helmDriver, helmRelease)
 	if err != nil {
 		return nil, errors.Wrap(err, "while producing Helm release definition")
 	}
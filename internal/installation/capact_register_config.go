@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"capact.io/capact/internal/logger"
+	hubclient "capact.io/capact/pkg/hub/client"
 	"github.com/vrischmann/envconfig"
 )
 
@@ -14,6 +15,9 @@ type TypeInstancesConfig struct {
 	HelmReleasesNSLookup LookupNS
 	CapactReleaseName    string `envconfig:"default=capact"`
 	HelmRepositoryPath   string `envconfig:"default=https://capactio-stable-charts.storage.googleapis.com"`
+	// Gateway holds configuration for calling the Local Hub through the Gateway.
+	// If the Gateway endpoint is set, the Local Hub endpoint is ignored.
+	Gateway hubclient.GatewayConfig `envconfig:"optional"`
 }
 
 // LookupNS holds Namespaces names in which Capact components were installed.
@@ -297,8 +297,13 @@ func (r *ActionReconciler) reportedRunnerStatus(ctx context.Context, action *v1a
 	if statusCpy.Runner == nil {
 		statusCpy.Runner = &v1alpha1.RunnerStatus{}
 	}
-	statusCpy.Runner.Status = &runtime.RawExtension{
-		Raw: reportedStatus.Status,
+	if reportedStatus.Status != nil {
+		statusCpy.Runner.Status = &runtime.RawExtension{
+			Raw: reportedStatus.Status,
+		}
+	}
+	if reportedStatus.Progress != nil {
+		statusCpy.Runner.Progress = reportedStatus.Progress
 	}
 
 	return statusCpy, nil
@@ -25,6 +25,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -81,6 +82,8 @@ type ActionService struct {
 	policyOrder        policy.MergeOrder
 	typeInstanceLocker TypeInstanceLocker
 	typeInstanceGetter TypeInstanceGetter
+	backendChecker     argo.BackendChecker
+	gatewayTokenIssuer GatewayTokenIssuer
 	log                *zap.Logger
 }
 
@@ -95,6 +98,8 @@ func NewActionService(log *zap.Logger, cli client.Client, argoRenderer ArgoRende
 		policyOrder:        policyOrder,
 		typeInstanceLocker: typeInstanceLocker,
 		typeInstanceGetter: typeInstanceGetter,
+		backendChecker:     cfg.BackendChecker,
+		gatewayTokenIssuer: cfg.GatewayTokenIssuer,
 		log:                log,
 	}
 }
@@ -269,6 +274,11 @@ func (a *ActionService) EnsureRunnerInputDataCreated(ctx context.Context, saName
 			runnerArgsSecretKey:    marshalledRunnerArgs,
 		},
 	}
+	if a.gatewayTokenIssuer != nil {
+		username, password := a.gatewayTokenIssuer.Issue(action.Namespace, action.Name)
+		secret.Data[argo.GatewayUsernameSecretKey] = []byte(username)
+		secret.Data[argo.GatewayPasswordSecretKey] = []byte(password)
+	}
 
 	err = a.k8sCli.Create(ctx, secret)
 	switch {
@@ -290,8 +300,9 @@ func (a *ActionService) EnsureRunnerInputDataCreated(ctx context.Context, saName
 			oldSecret.Data = map[string][]byte{}
 		}
 
-		oldSecret.Data[runnerContextSecretKey] = secret.Data[runnerContextSecretKey]
-		oldSecret.Data[runnerArgsSecretKey] = secret.Data[runnerArgsSecretKey]
+		for key, value := range secret.Data {
+			oldSecret.Data[key] = value
+		}
 		return a.k8sCli.Update(ctx, oldSecret)
 	default:
 		return err
@@ -408,6 +419,10 @@ func (a *ActionService) RenderAction(ctx context.Context, action *v1alpha1.Actio
 		options = append(options, argo.WithActionPolicy(*actionPolicy))
 	}
 
+	if a.backendChecker != nil {
+		options = append(options, argo.WithBackendChecker(a.backendChecker))
+	}
+
 	renderOutput, err := a.argoRenderer.Render(
 		ctx,
 		&argo.RenderInput{
@@ -524,11 +539,12 @@ func (a *ActionService) getPolicyWithFallbackToEmpty(ctx context.Context) (polic
 
 // GetReportedRunnerStatusOutput defines output for GetReportedRunnerStatus method.
 type GetReportedRunnerStatusOutput struct {
-	Changed bool
-	Status  []byte
+	Changed  bool
+	Status   []byte
+	Progress []v1alpha1.RunnerProgressEvent
 }
 
-// GetReportedRunnerStatus returns status reported by action runner.
+// GetReportedRunnerStatus returns status and progress events reported by action runner.
 func (a *ActionService) GetReportedRunnerStatus(ctx context.Context, action *v1alpha1.Action) (*GetReportedRunnerStatusOutput, error) {
 	// TODO: consider to move logic with fetching current status to status-reporter pkg
 	secret := &corev1.Secret{}
@@ -541,22 +557,60 @@ func (a *ActionService) GetReportedRunnerStatus(ctx context.Context, action *v1a
 		return &GetReportedRunnerStatusOutput{Changed: false}, nil
 	}
 
-	status, found := secret.Data[statusreporter.SecretStatusEntryKey]
-	if !found {
-		return &GetReportedRunnerStatusOutput{Changed: false}, nil
+	status := secret.Data[statusreporter.SecretStatusEntryKey]
+	progress, err := runnerProgressFromSecret(secret)
+	if err != nil {
+		return nil, err
 	}
 
-	if action.Status.Runner != nil && action.Status.Runner.Status != nil &&
-		bytes.Equal(action.Status.Runner.Status.Raw, status) {
+	var (
+		currentStatus   []byte
+		currentProgress []v1alpha1.RunnerProgressEvent
+	)
+	if action.Status.Runner != nil {
+		if action.Status.Runner.Status != nil {
+			currentStatus = action.Status.Runner.Status.Raw
+		}
+		currentProgress = action.Status.Runner.Progress
+	}
+
+	statusChanged := status != nil && !bytes.Equal(currentStatus, status)
+	progressChanged := len(progress) > 0 && !equality.Semantic.DeepEqual(currentProgress, progress)
+	if !statusChanged && !progressChanged {
 		return &GetReportedRunnerStatusOutput{Changed: false}, nil
 	}
 
 	return &GetReportedRunnerStatusOutput{
-		Changed: true,
-		Status:  status,
+		Changed:  true,
+		Status:   status,
+		Progress: progress,
 	}, nil
 }
 
+func runnerProgressFromSecret(secret *corev1.Secret) ([]v1alpha1.RunnerProgressEvent, error) {
+	raw, found := secret.Data[statusreporter.SecretProgressEntryKey]
+	if !found {
+		return nil, nil
+	}
+
+	var events []runner.ProgressEvent
+	if err := json.Unmarshal(raw, &events); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling runner progress events")
+	}
+
+	out := make([]v1alpha1.RunnerProgressEvent, 0, len(events))
+	for _, event := range events {
+		out = append(out, v1alpha1.RunnerProgressEvent{
+			// metav1.Time is serialized with the second precision, so truncate it to be able to detect changes.
+			Timestamp: metav1.NewTime(event.Timestamp.Truncate(time.Second)),
+			Message:   event.Message,
+			Current:   int32(event.Current),
+			Total:     int32(event.Total),
+		})
+	}
+	return out, nil
+}
+
 // GetRunnerJobStatusOutput defines output for GetRunnerJobStatus method.
 type GetRunnerJobStatusOutput struct {
 	Finished  bool
@@ -1,10 +1,24 @@
 package controller
 
-import "time"
+import (
+	"time"
+
+	"capact.io/capact/pkg/sdk/renderer/argo"
+)
 
 // Config holds Capact controller configuration.
 type Config struct {
 	BuiltinRunner BuiltinRunnerConfig
+	// BackendChecker is used to check storage backends for output TypeInstances. If nil, the check is skipped.
+	BackendChecker argo.BackendChecker
+	// GatewayTokenIssuer issues the credentials stored in the runner context Secret, so the TypeInstance steps can call the Hubs through the Gateway.
+	// If nil, the credentials are not stored.
+	GatewayTokenIssuer GatewayTokenIssuer
+}
+
+// GatewayTokenIssuer issues short-lived Gateway credentials bound to a given Action.
+type GatewayTokenIssuer interface {
+	Issue(namespace, name string) (username string, password string)
 }
 
 // BuiltinRunnerConfig holds configuration for built-in Action runner.
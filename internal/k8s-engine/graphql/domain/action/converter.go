@@ -420,7 +420,8 @@ func (c *Converter) statusToGraphQL(in *v1alpha1.ActionStatus) *graphql.ActionSt
 	var runnerStatus *graphql.RunnerStatus
 	if in.Runner != nil {
 		runnerStatus = &graphql.RunnerStatus{
-			Status: c.runtimeExtensionToJSONRawMessage(in.Runner.Status),
+			Status:   c.runtimeExtensionToJSONRawMessage(in.Runner.Status),
+			Progress: c.runnerProgressToGraphQL(in.Runner.Progress),
 		}
 	}
 
@@ -435,6 +436,19 @@ func (c *Converter) statusToGraphQL(in *v1alpha1.ActionStatus) *graphql.ActionSt
 	}
 }
 
+func (c *Converter) runnerProgressToGraphQL(in []v1alpha1.RunnerProgressEvent) []*graphql.RunnerProgressEvent {
+	out := make([]*graphql.RunnerProgressEvent, 0, len(in))
+	for _, event := range in {
+		out = append(out, &graphql.RunnerProgressEvent{
+			Timestamp: graphql.Timestamp{Time: event.Timestamp.Time},
+			Message:   event.Message,
+			Current:   int(event.Current),
+			Total:     int(event.Total),
+		})
+	}
+	return out
+}
+
 func (c *Converter) userInfoToGraphQL(in *authv1.UserInfo) *graphql.UserInfo {
 	if in == nil {
 		return nil
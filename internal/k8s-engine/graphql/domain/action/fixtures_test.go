@@ -106,6 +106,14 @@ func fixGQLAction(t *testing.T, name string) graphql.Action {
 			Message:   ptr.String("message"),
 			Runner: &graphql.RunnerStatus{
 				Status: ptrToJSONRawMessage(`{"runner":true}`),
+				Progress: []*graphql.RunnerProgressEvent{
+					{
+						Timestamp: graphql.Timestamp{Time: timestamp},
+						Message:   "Step 1/2",
+						Current:   1,
+						Total:     2,
+					},
+				},
 			},
 			CreatedBy:  &userInfo,
 			RunBy:      &userInfo,
@@ -167,6 +175,14 @@ func fixK8sAction(t *testing.T, name, namespace string) v1alpha1.Action {
 			Message: ptr.String("message"),
 			Runner: &v1alpha1.RunnerStatus{
 				Status: &runtime.RawExtension{Raw: []byte(`{"runner":true}`)},
+				Progress: []v1alpha1.RunnerProgressEvent{
+					{
+						Timestamp: metav1.NewTime(timestamp),
+						Message:   "Step 1/2",
+						Current:   1,
+						Total:     2,
+					},
+				},
 			},
 			Output: &v1alpha1.ActionOutput{
 				TypeInstances: &[]v1alpha1.OutputTypeInstanceDetails{
@@ -153,6 +153,7 @@ func (c *Converter) policyRulesToGraphQL(in []policy.Rule) []*graphql.PolicyRule
 				Requires:   c.manifestRefsToGraphQL(rule.ImplementationConstraints.Requires),
 				Attributes: c.manifestRefsToGraphQL(rule.ImplementationConstraints.Attributes),
 				Path:       rule.ImplementationConstraints.Path,
+				Signers:    c.signersToGraphQL(rule.ImplementationConstraints.Signers),
 			},
 			Inject: c.policyInjectDataToGraphQL(rule.Inject),
 		}
@@ -185,6 +186,7 @@ func (c *Converter) policyRulesFromGraphQLInput(in []*graphql.PolicyRuleInput) (
 				Requires:   c.manifestRefsFromGraphQLInput(gqlRule.ImplementationConstraints.Requires),
 				Attributes: c.manifestRefsFromGraphQLInput(gqlRule.ImplementationConstraints.Attributes),
 				Path:       gqlRule.ImplementationConstraints.Path,
+				Signers:    c.signersFromGraphQLInput(gqlRule.ImplementationConstraints.Signers),
 			}
 		}
 
@@ -241,6 +243,14 @@ func (c *Converter) manifestRefsToGraphQL(in *[]types.ManifestRefWithOptRevision
 	return out
 }
 
+func (c *Converter) signersToGraphQL(in *[]string) []string {
+	if in == nil {
+		return nil
+	}
+
+	return *in
+}
+
 func (c *Converter) requiredTypeInstancesToInjectToGraphQL(in []policy.RequiredTypeInstanceToInject) []*graphql.RequiredTypeInstanceReference {
 	var out []*graphql.RequiredTypeInstanceReference
 
@@ -291,6 +301,14 @@ func (c *Converter) manifestRefFromGraphQLInput(in *graphql.ManifestReferenceInp
 	}
 }
 
+func (c *Converter) signersFromGraphQLInput(in []string) *[]string {
+	if in == nil {
+		return nil
+	}
+
+	return &in
+}
+
 func (c *Converter) manifestRefsFromGraphQLInput(in []*graphql.ManifestReferenceInput) *[]types.ManifestRefWithOptRevision {
 	if in == nil {
 		return nil
@@ -7,13 +7,25 @@ import (
 	"capact.io/capact/pkg/runner"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// SecretStatusEntryKey defines key name under which Action status is saved.
-const SecretStatusEntryKey = "status"
+const (
+	// SecretStatusEntryKey defines key name under which Action status is saved.
+	SecretStatusEntryKey = "status"
+	// SecretProgressEntryKey defines key name under which Action progress events are saved.
+	SecretProgressEntryKey = "progress"
+	// MaxProgressEvents defines how many latest progress events are kept in the Secret.
+	MaxProgressEvents = 10
+)
 
-var _ runner.StatusReporter = &K8sSecretReporter{}
+var (
+	_ runner.StatusReporter   = &K8sSecretReporter{}
+	_ runner.ProgressReporter = &K8sSecretReporter{}
+	_ runner.StatusReporter   = &K8sSecretProgressReporter{}
+	_ runner.ProgressReporter = &K8sSecretProgressReporter{}
+)
 
 // K8sSecretReporter provides functionality to report status from Action Runner in a way that K8s Engine can
 // consume it later.
@@ -56,3 +68,67 @@ func (c *K8sSecretReporter) Report(ctx context.Context, runnerCtx runner.Context
 
 	return nil
 }
+
+// ReportProgress appends a given progress event to K8s Secret, so K8s engine can consume it later.
+// Only the MaxProgressEvents latest events are kept.
+func (c *K8sSecretReporter) ReportProgress(ctx context.Context, runnerCtx runner.Context, event runner.ProgressEvent) error {
+	key := client.ObjectKey{
+		Name:      runnerCtx.Name,
+		Namespace: runnerCtx.Platform.Namespace,
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &v1.Secret{}
+		if err := c.cli.Get(ctx, key, secret); err != nil {
+			return errors.Wrap(err, "while getting Secret")
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+
+		var events []runner.ProgressEvent
+		if raw, found := secret.Data[SecretProgressEntryKey]; found {
+			if err := json.Unmarshal(raw, &events); err != nil {
+				return errors.Wrap(err, "while unmarshaling progress events")
+			}
+		}
+
+		events = append(events, event)
+		if len(events) > MaxProgressEvents {
+			events = events[len(events)-MaxProgressEvents:]
+		}
+
+		jsonEvents, err := json.Marshal(events)
+		if err != nil {
+			return errors.Wrap(err, "while marshaling progress events")
+		}
+		secret.Data[SecretProgressEntryKey] = jsonEvents
+
+		// Do not wrap the error, so the conflict can be detected and retried.
+		return c.cli.Update(ctx, secret)
+	})
+}
+
+// K8sSecretProgressReporter provides functionality to report only the progress from runners executed as the Action workflow steps.
+// The Action status is reported by the Argo runner, so it is not overridden.
+type K8sSecretProgressReporter struct {
+	reporter *K8sSecretReporter
+}
+
+// NewK8sSecretProgress returns new K8sSecretProgressReporter instance.
+func NewK8sSecretProgress(cli client.Client) *K8sSecretProgressReporter {
+	return &K8sSecretProgressReporter{
+		reporter: NewK8sSecret(cli),
+	}
+}
+
+// Report does nothing and returns always nil, as the Action status is owned by the Argo runner.
+func (c *K8sSecretProgressReporter) Report(context.Context, runner.Context, interface{}) error {
+	return nil
+}
+
+// ReportProgress appends a given progress event to K8s Secret, the same as K8sSecretReporter does.
+func (c *K8sSecretProgressReporter) ReportProgress(ctx context.Context, runnerCtx runner.Context, event runner.ProgressEvent) error {
+	return c.reporter.ReportProgress(ctx, runnerCtx, event)
+}
@@ -0,0 +1,109 @@
+package statusreporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"capact.io/capact/pkg/runner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestK8sSecretReporterReportProgress(t *testing.T) {
+	// given
+	ctx := context.Background()
+	runnerCtx := runner.Context{
+		Name: "action",
+		Platform: runner.KubernetesPlatformConfig{
+			Namespace: "default",
+		},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      runnerCtx.Name,
+			Namespace: runnerCtx.Platform.Namespace,
+		},
+		Data: map[string][]byte{
+			SecretStatusEntryKey: []byte(`{"argoWorkflowRef":"action"}`),
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(secret).Build()
+	reporter := NewK8sSecret(cli)
+
+	timestamp := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	total := MaxProgressEvents + 2
+
+	// when
+	for i := 1; i <= total; i++ {
+		err := reporter.ReportProgress(ctx, runnerCtx, runner.ProgressEvent{
+			Timestamp: timestamp,
+			Message:   fmt.Sprintf("Step %d", i),
+			Current:   i,
+			Total:     total,
+		})
+		require.NoError(t, err)
+	}
+
+	// then
+	got := &v1.Secret{}
+	err := cli.Get(ctx, client.ObjectKeyFromObject(secret), got)
+	require.NoError(t, err)
+
+	assert.Equal(t, secret.Data[SecretStatusEntryKey], got.Data[SecretStatusEntryKey])
+
+	var events []runner.ProgressEvent
+	err = json.Unmarshal(got.Data[SecretProgressEntryKey], &events)
+	require.NoError(t, err)
+
+	require.Len(t, events, MaxProgressEvents)
+	assert.Equal(t, "Step 3", events[0].Message)
+	assert.Equal(t, runner.ProgressEvent{
+		Timestamp: timestamp,
+		Message:   fmt.Sprintf("Step %d", total),
+		Current:   total,
+		Total:     total,
+	}, events[MaxProgressEvents-1])
+}
+
+func TestK8sSecretProgressReporterKeepsStatus(t *testing.T) {
+	// given
+	ctx := context.Background()
+	runnerCtx := runner.Context{
+		Name: "action",
+		Platform: runner.KubernetesPlatformConfig{
+			Namespace: "default",
+		},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      runnerCtx.Name,
+			Namespace: runnerCtx.Platform.Namespace,
+		},
+		Data: map[string][]byte{
+			SecretStatusEntryKey: []byte(`{"argoWorkflowRef":"action"}`),
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(secret).Build()
+	reporter := NewK8sSecretProgress(cli)
+
+	// when
+	err := reporter.Report(ctx, runnerCtx, map[string]string{"other": "status"})
+	require.NoError(t, err)
+	err = reporter.ReportProgress(ctx, runnerCtx, runner.ProgressEvent{Message: "terraform apply 1/2 resources", Current: 1, Total: 2})
+	require.NoError(t, err)
+
+	// then
+	got := &v1.Secret{}
+	err = cli.Get(ctx, client.ObjectKeyFromObject(secret), got)
+	require.NoError(t, err)
+
+	assert.Equal(t, secret.Data[SecretStatusEntryKey], got.Data[SecretStatusEntryKey])
+	assert.JSONEq(t, `[{"timestamp":"0001-01-01T00:00:00Z","message":"terraform apply 1/2 resources","current":1,"total":2}]`, string(got.Data[SecretProgressEntryKey]))
+}
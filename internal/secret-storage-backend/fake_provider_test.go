@@ -10,8 +10,9 @@ import (
 var _ tellercore.Provider = &fakeProvider{}
 
 type fakeProvider struct {
-	secrets map[string]map[string]string
-	name    string
+	secrets       map[string]map[string]string
+	name          string
+	getMappingErr error
 }
 
 func newFakeProvider(secrets map[string]map[string]string) *fakeProvider {
@@ -23,6 +24,10 @@ func (f *fakeProvider) Name() string {
 }
 
 func (f *fakeProvider) GetMapping(kp tellercore.KeyPath) ([]tellercore.EnvEntry, error) {
+	if f.getMappingErr != nil {
+		return nil, f.getMappingErr
+	}
+
 	kvs := f.getSecret(kp)
 
 	var entries []tellercore.EnvEntry
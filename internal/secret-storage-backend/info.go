@@ -0,0 +1,17 @@
+package secretstoragebackend
+
+import (
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+)
+
+// Info returns the secret storage backend details reported by the StorageBackendInfo service.
+// The context schema is not reported, as it depends on the secret providers registered in a given Hub.
+func Info() *pb.GetInfoResponse {
+	return &pb.GetInfoResponse{
+		AcceptValue: true,
+		SupportedOperations: &pb.SupportedOperations{
+			Lock:      true,
+			Revisions: true,
+		},
+	}
+}
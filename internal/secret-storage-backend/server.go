@@ -61,6 +61,8 @@ type Handler struct {
 const (
 	lockedByField        = "locked_by"
 	firstResourceVersion = 1
+	// healthCheckTypeInstanceID is used to build the secret path read by the health check. The secret doesn't need to exist.
+	healthCheckTypeInstanceID = "healthz"
 )
 
 var (
@@ -76,6 +78,18 @@ func NewHandler(log *zap.Logger, providers Providers) *Handler {
 	}
 }
 
+// Ping checks if secrets can be read from all configured providers.
+func (h *Handler) Ping(_ context.Context) error {
+	for name, provider := range h.providers {
+		key := tellercore.KeyPath{Path: h.storagePathForTypeInstance(provider, healthCheckTypeInstanceID)}
+		if _, err := provider.GetMapping(key); err != nil {
+			return errors.Wrapf(err, "while reading secrets from provider %q", name)
+		}
+	}
+
+	return nil
+}
+
 // GetValue returns a value for a given TypeInstance. It returns nil as value if a given secret is not found.
 func (h *Handler) GetValue(_ context.Context, request *pb.GetValueRequest) (*pb.GetValueResponse, error) {
 	if request == nil {
@@ -6,6 +6,7 @@ import (
 	"net"
 	"testing"
 
+	"github.com/pkg/errors"
 	tellercore "github.com/spectralops/teller/pkg/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -797,6 +798,47 @@ func TestHandler_OnDeleteRevision(t *testing.T) {
 	}
 }
 
+func TestHandler_Ping(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name                 string
+		InputProviders       map[string]tellercore.Provider
+		ExpectedErrorMessage *string
+	}{
+		{
+			Name: "Healthy providers",
+			InputProviders: secret_storage_backend.Providers{
+				"one": &fakeProvider{name: "one"},
+			},
+		},
+		{
+			Name: "Provider not accessible",
+			InputProviders: secret_storage_backend.Providers{
+				"one": &fakeProvider{name: "one", getMappingErr: errors.New("access denied")},
+			},
+			ExpectedErrorMessage: ptr.String(`while reading secrets from provider "one": access denied`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			handler := secret_storage_backend.NewHandler(logger.Noop(), testCase.InputProviders)
+
+			// when
+			err := handler.Ping(context.Background())
+
+			// then
+			if testCase.ExpectedErrorMessage != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, *testCase.ExpectedErrorMessage)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestHandler_GetProviderFromContext(t *testing.T) {
 	// given
 	testCases := []struct {
@@ -0,0 +1,418 @@
+package terraformstatebackend
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"capact.io/capact/internal/ptr"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+)
+
+var _ pb.ContextStorageBackendServer = &Handler{}
+
+const (
+	firstResourceVersion = 1
+	accessTokenLength    = 32
+)
+
+type (
+	// StateContext holds context used by Terraform state storage backend.
+	StateContext struct {
+		// Name specifies the unique Terraform state name.
+		Name string `json:"name"`
+	}
+
+	// StateValue holds the TypeInstance value returned by the Terraform state storage backend.
+	StateValue struct {
+		// Address specifies the Terraform HTTP backend address for a given state.
+		Address string `json:"address"`
+		// State holds the Terraform state. It is empty if Terraform haven't written the state yet.
+		State json.RawMessage `json:"state,omitempty"`
+		// Username specifies the Terraform HTTP backend username. It is set only if the TypeInstance is locked.
+		Username string `json:"username,omitempty"`
+		// Password specifies the Terraform HTTP backend password. It is set only if the TypeInstance is locked.
+		Password string `json:"password,omitempty"`
+	}
+)
+
+// Handler handles incoming requests to the Terraform state storage backend gRPC server.
+type Handler struct {
+	pb.UnimplementedContextStorageBackendServer
+
+	log            *zap.Logger
+	store          *SecretStore
+	httpBackendURL string
+}
+
+// NewHandler returns new Handler.
+func NewHandler(log *zap.Logger, store *SecretStore, httpBackendURL string) *Handler {
+	return &Handler{
+		log:            log,
+		store:          store,
+		httpBackendURL: strings.TrimSuffix(httpBackendURL, "/"),
+	}
+}
+
+// GetPreCreateValue returns the current Terraform state for a given context.
+// It fails if the Terraform state is already associated with a TypeInstance.
+func (h *Handler) GetPreCreateValue(ctx context.Context, req *pb.GetPreCreateValueRequest) (*pb.GetPreCreateValueResponse, error) {
+	stateCtx, err := h.getStateContext(req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getOrEmptyState(ctx, stateCtx.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.TypeInstanceID != "" {
+		return nil, alreadyAssociatedError(state)
+	}
+
+	value, err := h.marshalValue(h.stateValue(stateCtx.Name, state.Current))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetPreCreateValueResponse{
+		Value: value,
+	}, nil
+}
+
+// GetValue returns the Terraform state snapshot for a given TypeInstance resource version.
+// If the resource version is not specified, the current Terraform state is returned.
+// If the TypeInstance is locked, the value contains the Terraform HTTP backend credentials for the lock owner.
+func (h *Handler) GetValue(ctx context.Context, req *pb.GetValueRequest) (*pb.GetValueResponse, error) {
+	stateCtx, err := h.getStateContext(req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getState(ctx, stateCtx.Name, req.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := state.Current
+	if req.ResourceVersion != 0 {
+		var found bool
+		snapshot, found = state.Revisions[req.ResourceVersion]
+		if !found {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("TypeInstance %q in revision %d was not found", req.TypeInstanceId, req.ResourceVersion))
+		}
+	}
+
+	stateValue := h.stateValue(stateCtx.Name, snapshot)
+	if state.LockedBy != "" {
+		stateValue.Username = state.LockedBy
+		stateValue.Password = state.AccessToken
+	}
+
+	value, err := h.marshalValue(stateValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetValueResponse{
+		Value: value,
+	}, nil
+}
+
+// OnCreate associates a given Terraform state with the TypeInstance and stores the current state as the first revision.
+// The Terraform state doesn't need to exist yet.
+func (h *Handler) OnCreate(ctx context.Context, req *pb.OnCreateRequest) (*pb.OnCreateResponse, error) {
+	h.log.Info("creating entry", zap.String("id", req.TypeInstanceId))
+	stateCtx, err := h.getStateContext(req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getOrEmptyState(ctx, stateCtx.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.TypeInstanceID != "" && state.TypeInstanceID != req.TypeInstanceId {
+		return nil, alreadyAssociatedError(state)
+	}
+
+	state.TypeInstanceID = req.TypeInstanceId
+	state.Revisions[firstResourceVersion] = state.Current
+
+	if err := h.saveState(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return &pb.OnCreateResponse{}, nil
+}
+
+// OnUpdate stores the current Terraform state as a new TypeInstance revision.
+// It fails if the TypeInstance is locked by a different owner or Terraform holds the state lock.
+func (h *Handler) OnUpdate(ctx context.Context, req *pb.OnUpdateRequest) (*pb.OnUpdateResponse, error) {
+	h.log.Info("updating entry", zap.String("id", req.TypeInstanceId))
+	stateCtx, err := h.getStateContext(req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getState(ctx, stateCtx.Name, req.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureOwner(state, req.OwnerId); err != nil {
+		return nil, err
+	}
+	if len(state.LockInfo) > 0 {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Terraform state %q is locked by Terraform: %s", state.Name, state.LockInfo))
+	}
+
+	state.Revisions[req.NewResourceVersion] = state.Current
+	if err := h.saveState(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return &pb.OnUpdateResponse{}, nil
+}
+
+// OnDelete deletes the Terraform state with all its revisions.
+func (h *Handler) OnDelete(ctx context.Context, req *pb.OnDeleteRequest) (*pb.OnDeleteResponse, error) {
+	h.log.Info("deleting entry", zap.String("id", req.TypeInstanceId))
+	stateCtx, err := h.getStateContext(req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getState(ctx, stateCtx.Name, req.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureOwner(state, req.OwnerId); err != nil {
+		return nil, err
+	}
+
+	if err := h.store.Delete(ctx, state.Name); err != nil {
+		return nil, internalError(err)
+	}
+
+	return &pb.OnDeleteResponse{}, nil
+}
+
+// OnDeleteRevision deletes the Terraform state snapshot for a given TypeInstance resource version.
+// As the request doesn't contain the context, the Terraform state is resolved based on the TypeInstance ID.
+func (h *Handler) OnDeleteRevision(ctx context.Context, req *pb.OnDeleteRevisionRequest) (*pb.OnDeleteRevisionResponse, error) {
+	h.log.Info("deleting entry revision", zap.String("id", req.TypeInstanceId), zap.Uint32("resourceVersion", req.ResourceVersion))
+	state, err := h.store.GetForTypeInstance(ctx, req.TypeInstanceId)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrStateNotFound):
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Terraform state for TypeInstance %q was not found", req.TypeInstanceId))
+	default:
+		return nil, internalError(err)
+	}
+
+	if _, found := state.Revisions[req.ResourceVersion]; !found {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("TypeInstance %q in revision %d was not found", req.TypeInstanceId, req.ResourceVersion))
+	}
+
+	if err := ensureOwner(state, req.OwnerId); err != nil {
+		return nil, err
+	}
+
+	delete(state.Revisions, req.ResourceVersion)
+	if err := h.saveState(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return &pb.OnDeleteRevisionResponse{}, nil
+}
+
+// GetLockedBy returns the owner which locked the TypeInstance.
+func (h *Handler) GetLockedBy(ctx context.Context, req *pb.GetLockedByRequest) (*pb.GetLockedByResponse, error) {
+	stateCtx, err := h.getStateContext(req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getState(ctx, stateCtx.Name, req.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.LockedBy == "" {
+		return &pb.GetLockedByResponse{}, nil
+	}
+
+	return &pb.GetLockedByResponse{
+		LockedBy: ptr.String(state.LockedBy),
+	}, nil
+}
+
+// OnLock locks the Terraform state for a given owner and generates a new access token for the lock.
+// Once locked, the Terraform HTTP backend accepts requests only from clients authenticated with the owner ID as the username
+// and the access token as the password.
+func (h *Handler) OnLock(ctx context.Context, req *pb.OnLockRequest) (*pb.OnLockResponse, error) {
+	stateCtx, err := h.getStateContext(req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getState(ctx, stateCtx.Name, req.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.LockedBy != "" && state.LockedBy != req.LockedBy {
+		return nil, typeInstanceLockedError(state)
+	}
+
+	if state.LockedBy == "" || state.AccessToken == "" {
+		token, err := newAccessToken()
+		if err != nil {
+			return nil, internalError(err)
+		}
+		state.AccessToken = token
+	}
+	state.LockedBy = req.LockedBy
+	if err := h.saveState(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return &pb.OnLockResponse{}, nil
+}
+
+// OnUnlock unlocks the Terraform state. The Terraform lock, if any, is kept untouched.
+func (h *Handler) OnUnlock(ctx context.Context, req *pb.OnUnlockRequest) (*pb.OnUnlockResponse, error) {
+	stateCtx, err := h.getStateContext(req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.getState(ctx, stateCtx.Name, req.TypeInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	state.LockedBy = ""
+	state.AccessToken = ""
+	if err := h.saveState(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return &pb.OnUnlockResponse{}, nil
+}
+
+func (h *Handler) getStateContext(contextBytes []byte) (*StateContext, error) {
+	var stateCtx StateContext
+	if err := json.Unmarshal(contextBytes, &stateCtx); err != nil {
+		return nil, internalError(errors.Wrap(err, "while unmarshaling context"))
+	}
+
+	if err := ValidateStateName(stateCtx.Name); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &stateCtx, nil
+}
+
+// getState returns the Terraform state associated with a given TypeInstance.
+func (h *Handler) getState(ctx context.Context, name, typeInstanceID string) (*State, error) {
+	state, err := h.store.Get(ctx, name)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrStateNotFound):
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Terraform state %q (TypeInstance ID: '%s') was not found", name, typeInstanceID))
+	default:
+		return nil, internalError(err)
+	}
+
+	if state.TypeInstanceID != typeInstanceID {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Terraform state %q is not associated with TypeInstance %q", name, typeInstanceID))
+	}
+
+	return state, nil
+}
+
+func (h *Handler) getOrEmptyState(ctx context.Context, name string) (*State, error) {
+	state, err := h.store.Get(ctx, name)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrStateNotFound):
+		return &State{Name: name, Revisions: map[uint32][]byte{}}, nil
+	default:
+		return nil, internalError(err)
+	}
+
+	return state, nil
+}
+
+func (h *Handler) saveState(ctx context.Context, state *State) error {
+	err := h.store.Save(ctx, state)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrStateConflict):
+		return status.Error(codes.Aborted, fmt.Sprintf("Terraform state %q: %s", state.Name, err))
+	default:
+		return internalError(err)
+	}
+
+	return nil
+}
+
+func (h *Handler) stateValue(name string, snapshot []byte) StateValue {
+	value := StateValue{
+		Address: fmt.Sprintf("%s/state/%s", h.httpBackendURL, name),
+	}
+	if len(snapshot) > 0 {
+		value.State = snapshot
+	}
+	return value
+}
+
+func (h *Handler) marshalValue(value StateValue) ([]byte, error) {
+	out, err := json.Marshal(value)
+	if err != nil {
+		return nil, internalError(errors.Wrap(err, "while marshaling response value"))
+	}
+
+	return out, nil
+}
+
+func ensureOwner(state *State, ownerID *string) error {
+	if state.LockedBy == "" || state.LockedBy == ptr.StringPtrToString(ownerID) {
+		return nil
+	}
+
+	return typeInstanceLockedError(state)
+}
+
+func newAccessToken() (string, error) {
+	token := make([]byte, accessTokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return "", errors.Wrap(err, "while generating access token")
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func internalError(err error) error {
+	return status.Error(codes.Internal, err.Error())
+}
+
+func alreadyAssociatedError(state *State) error {
+	return status.Error(codes.AlreadyExists, fmt.Sprintf("Terraform state %q is already associated with TypeInstance %q", state.Name, state.TypeInstanceID))
+}
+
+func typeInstanceLockedError(state *State) error {
+	return status.Error(codes.FailedPrecondition, fmt.Sprintf("Terraform state %q is locked by %q", state.Name, state.LockedBy))
+}
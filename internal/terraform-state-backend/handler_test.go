@@ -0,0 +1,178 @@
+package terraformstatebackend
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"capact.io/capact/internal/logger"
+	"capact.io/capact/internal/ptr"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+)
+
+const (
+	testNamespace  = "capact-system"
+	testBackendURL = "http://tf-state-backend:8080"
+)
+
+func TestHandler_Revisions(t *testing.T) {
+	// given
+	const tiID = "123"
+	ctx := context.Background()
+	store := NewSecretStore(fake.NewClientBuilder().Build(), testNamespace)
+	handler := NewHandler(logger.Noop(), store, testBackendURL)
+	stateCtx := mustMarshal(t, StateContext{Name: "db"})
+
+	// when
+	preCreate, err := handler.GetPreCreateValue(ctx, &pb.GetPreCreateValueRequest{Context: stateCtx})
+
+	// then
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"address":"http://tf-state-backend:8080/state/db"}`, string(preCreate.Value))
+
+	// when
+	_, err = handler.OnCreate(ctx, &pb.OnCreateRequest{TypeInstanceId: tiID, Context: stateCtx})
+	require.NoError(t, err)
+	saveCurrentState(t, store, "db", `{"serial":1}`)
+	_, err = handler.OnUpdate(ctx, &pb.OnUpdateRequest{TypeInstanceId: tiID, NewResourceVersion: 2, Context: stateCtx})
+	require.NoError(t, err)
+	saveCurrentState(t, store, "db", `{"serial":2}`)
+
+	// then
+	for resourceVersion, expValue := range map[uint32]string{
+		0: `{"address":"http://tf-state-backend:8080/state/db","state":{"serial":2}}`,
+		1: `{"address":"http://tf-state-backend:8080/state/db"}`,
+		2: `{"address":"http://tf-state-backend:8080/state/db","state":{"serial":1}}`,
+	} {
+		out, err := handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: tiID, ResourceVersion: resourceVersion, Context: stateCtx})
+		require.NoError(t, err)
+		assert.JSONEq(t, expValue, string(out.Value), "resource version %d", resourceVersion)
+	}
+
+	// when
+	_, err = handler.OnDeleteRevision(ctx, &pb.OnDeleteRevisionRequest{TypeInstanceId: tiID, ResourceVersion: 1})
+	require.NoError(t, err)
+	_, err = handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: tiID, ResourceVersion: 1, Context: stateCtx})
+
+	// then
+	assert.EqualError(t, err, `rpc error: code = NotFound desc = TypeInstance "123" in revision 1 was not found`)
+
+	// when
+	_, err = handler.OnDelete(ctx, &pb.OnDeleteRequest{TypeInstanceId: tiID, Context: stateCtx})
+	require.NoError(t, err)
+	_, err = handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: tiID, Context: stateCtx})
+
+	// then
+	assert.EqualError(t, err, `rpc error: code = NotFound desc = Terraform state "db" (TypeInstance ID: '123') was not found`)
+}
+
+func TestHandler_Locking(t *testing.T) {
+	// given
+	const (
+		tiID  = "123"
+		owner = "default/action"
+	)
+	ctx := context.Background()
+	store := NewSecretStore(fake.NewClientBuilder().Build(), testNamespace)
+	handler := NewHandler(logger.Noop(), store, testBackendURL)
+	stateCtx := mustMarshal(t, StateContext{Name: "db"})
+
+	_, err := handler.OnCreate(ctx, &pb.OnCreateRequest{TypeInstanceId: tiID, Context: stateCtx})
+	require.NoError(t, err)
+
+	// when
+	_, err = handler.OnLock(ctx, &pb.OnLockRequest{TypeInstanceId: tiID, Context: stateCtx, LockedBy: owner})
+	require.NoError(t, err)
+
+	// then
+	lockedBy, err := handler.GetLockedBy(ctx, &pb.GetLockedByRequest{TypeInstanceId: tiID, Context: stateCtx})
+	require.NoError(t, err)
+	assert.Equal(t, ptr.String(owner), lockedBy.LockedBy)
+
+	value, err := handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: tiID, Context: stateCtx})
+	require.NoError(t, err)
+	var lockedValue StateValue
+	require.NoError(t, json.Unmarshal(value.Value, &lockedValue))
+	assert.Equal(t, owner, lockedValue.Username)
+	assert.NotEmpty(t, lockedValue.Password)
+
+	_, err = handler.OnLock(ctx, &pb.OnLockRequest{TypeInstanceId: tiID, Context: stateCtx, LockedBy: "other"})
+	assert.EqualError(t, err, `rpc error: code = FailedPrecondition desc = Terraform state "db" is locked by "default/action"`)
+
+	_, err = handler.OnUpdate(ctx, &pb.OnUpdateRequest{TypeInstanceId: tiID, NewResourceVersion: 2, Context: stateCtx, OwnerId: ptr.String("other")})
+	assert.EqualError(t, err, `rpc error: code = FailedPrecondition desc = Terraform state "db" is locked by "default/action"`)
+
+	_, err = handler.OnUpdate(ctx, &pb.OnUpdateRequest{TypeInstanceId: tiID, NewResourceVersion: 2, Context: stateCtx, OwnerId: ptr.String(owner)})
+	assert.NoError(t, err)
+
+	// when
+	_, err = handler.OnUnlock(ctx, &pb.OnUnlockRequest{TypeInstanceId: tiID, Context: stateCtx})
+	require.NoError(t, err)
+
+	// then
+	lockedBy, err = handler.GetLockedBy(ctx, &pb.GetLockedByRequest{TypeInstanceId: tiID, Context: stateCtx})
+	require.NoError(t, err)
+	assert.Nil(t, lockedBy.LockedBy)
+
+	value, err = handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: tiID, Context: stateCtx})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"address":"http://tf-state-backend:8080/state/db"}`, string(value.Value))
+}
+
+func TestHandler_OtherTypeInstance(t *testing.T) {
+	// given
+	ctx := context.Background()
+	store := NewSecretStore(fake.NewClientBuilder().Build(), testNamespace)
+	handler := NewHandler(logger.Noop(), store, testBackendURL)
+	stateCtx := mustMarshal(t, StateContext{Name: "db"})
+
+	_, err := handler.OnCreate(ctx, &pb.OnCreateRequest{TypeInstanceId: "123", Context: stateCtx})
+	require.NoError(t, err)
+
+	// when
+	_, err = handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: "456", Context: stateCtx})
+
+	// then
+	assert.EqualError(t, err, `rpc error: code = FailedPrecondition desc = Terraform state "db" is not associated with TypeInstance "456"`)
+
+	// when
+	_, err = handler.GetPreCreateValue(ctx, &pb.GetPreCreateValueRequest{Context: stateCtx})
+
+	// then
+	assert.EqualError(t, err, `rpc error: code = AlreadyExists desc = Terraform state "db" is already associated with TypeInstance "123"`)
+}
+
+func TestHandler_InvalidContext(t *testing.T) {
+	// given
+	handler := NewHandler(logger.Noop(), NewSecretStore(fake.NewClientBuilder().Build(), testNamespace), testBackendURL)
+
+	// when
+	_, err := handler.GetValue(context.Background(), &pb.GetValueRequest{
+		TypeInstanceId: "123",
+		Context:        mustMarshal(t, StateContext{Name: "Invalid_Name"}),
+	})
+
+	// then
+	assert.EqualError(t, err, `rpc error: code = InvalidArgument desc = invalid Terraform state name "Invalid_Name": it must consist of at most 55 lower case alphanumeric characters or '-', and must start and end with an alphanumeric character`)
+}
+
+func saveCurrentState(t *testing.T, store *SecretStore, name, current string) {
+	t.Helper()
+
+	state, err := store.Get(context.Background(), name)
+	require.NoError(t, err)
+
+	state.Current = []byte(current)
+	require.NoError(t, store.Save(context.Background(), state))
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	return out
+}
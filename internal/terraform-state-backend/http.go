@@ -0,0 +1,280 @@
+package terraformstatebackend
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// lockMethod is the HTTP method used by Terraform to lock the state.
+	lockMethod = "LOCK"
+	// unlockMethod is the HTTP method used by Terraform to unlock the state.
+	unlockMethod = "UNLOCK"
+
+	stateNameVar = "name"
+)
+
+// lockInfo holds the subset of the Terraform lock info used by the HTTP backend.
+type lockInfo struct {
+	ID string `json:"ID"`
+}
+
+// HTTPBackend implements the Terraform HTTP backend protocol on top of the SecretStore.
+// See https://www.terraform.io/language/settings/backends/http.
+//
+// If the associated TypeInstance is locked, only clients authenticated with the lock owner ID as the username
+// and the access token generated for the lock as the password are allowed to read and modify the state. If Terraform holds the state lock, modifications must refer to its lock ID.
+type HTTPBackend struct {
+	log   *zap.Logger
+	store *SecretStore
+}
+
+// NewHTTPBackend returns a new HTTPBackend instance.
+func NewHTTPBackend(log *zap.Logger, store *SecretStore) *HTTPBackend {
+	return &HTTPBackend{
+		log:   log,
+		store: store,
+	}
+}
+
+// Handler returns the HTTP handler which serves the Terraform HTTP backend under the `/state/{name}` path.
+func (b *HTTPBackend) Handler() http.Handler {
+	router := mux.NewRouter()
+	route := fmt.Sprintf("/state/{%s}", stateNameVar)
+	router.HandleFunc(route, b.getState).Methods(http.MethodGet)
+	router.HandleFunc(route, b.updateState).Methods(http.MethodPost)
+	router.HandleFunc(route, b.deleteState).Methods(http.MethodDelete)
+	router.HandleFunc(route, b.lockState).Methods(lockMethod)
+	router.HandleFunc(route, b.unlockState).Methods(unlockMethod)
+	return router
+}
+
+func (b *HTTPBackend) getState(w http.ResponseWriter, r *http.Request) {
+	name, ok := b.stateName(w, r)
+	if !ok {
+		return
+	}
+
+	state, err := b.store.Get(r.Context(), name)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrStateNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		return
+	default:
+		b.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// state holds secrets, so it is protected in the same way as modifications
+	if !b.ensureOwner(w, r, state, nil) {
+		return
+	}
+
+	if len(state.Current) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(state.Current); err != nil {
+		b.log.Error("while writing response", zap.Error(err))
+	}
+}
+
+func (b *HTTPBackend) updateState(w http.ResponseWriter, r *http.Request) {
+	name, ok := b.stateName(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		b.writeError(w, http.StatusBadRequest, errors.Wrap(err, "while reading request body"))
+		return
+	}
+	if !json.Valid(body) {
+		b.writeError(w, http.StatusBadRequest, errors.New("Terraform state must be a valid JSON"))
+		return
+	}
+
+	lockID := r.URL.Query().Get("ID")
+	state, ok := b.getOrEmptyState(w, r, name)
+	if !ok || !b.ensureOwner(w, r, state, &lockID) {
+		return
+	}
+
+	state.Current = body
+	b.saveState(w, r, state)
+}
+
+func (b *HTTPBackend) deleteState(w http.ResponseWriter, r *http.Request) {
+	name, ok := b.stateName(w, r)
+	if !ok {
+		return
+	}
+
+	state, ok := b.getOrEmptyState(w, r, name)
+	if !ok || !b.ensureOwner(w, r, state, nil) {
+		return
+	}
+
+	// keep the TypeInstance revisions as they are managed via Hub
+	state.Current = nil
+	b.saveState(w, r, state)
+}
+
+func (b *HTTPBackend) lockState(w http.ResponseWriter, r *http.Request) {
+	name, ok := b.stateName(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		b.writeError(w, http.StatusBadRequest, errors.Wrap(err, "while reading request body"))
+		return
+	}
+	var requested lockInfo
+	if err := json.Unmarshal(body, &requested); err != nil {
+		b.writeError(w, http.StatusBadRequest, errors.Wrap(err, "while unmarshaling lock info"))
+		return
+	}
+
+	state, ok := b.getOrEmptyState(w, r, name)
+	if !ok || !b.ensureOwner(w, r, state, nil) {
+		return
+	}
+
+	if len(state.LockInfo) > 0 {
+		b.writeLocked(w, state.LockInfo)
+		return
+	}
+
+	state.LockInfo = body
+	b.saveState(w, r, state)
+}
+
+func (b *HTTPBackend) unlockState(w http.ResponseWriter, r *http.Request) {
+	name, ok := b.stateName(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		b.writeError(w, http.StatusBadRequest, errors.Wrap(err, "while reading request body"))
+		return
+	}
+
+	// `terraform force-unlock` sends an empty body
+	var lockID *string
+	if len(body) > 0 {
+		var requested lockInfo
+		if err := json.Unmarshal(body, &requested); err != nil {
+			b.writeError(w, http.StatusBadRequest, errors.Wrap(err, "while unmarshaling lock info"))
+			return
+		}
+		lockID = &requested.ID
+	}
+
+	state, ok := b.getOrEmptyState(w, r, name)
+	if !ok || !b.ensureOwner(w, r, state, lockID) {
+		return
+	}
+
+	if len(state.LockInfo) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	state.LockInfo = nil
+	b.saveState(w, r, state)
+}
+
+func (b *HTTPBackend) stateName(w http.ResponseWriter, r *http.Request) (string, bool) {
+	name := mux.Vars(r)[stateNameVar]
+	if err := ValidateStateName(name); err != nil {
+		b.writeError(w, http.StatusBadRequest, err)
+		return "", false
+	}
+	return name, true
+}
+
+func (b *HTTPBackend) getOrEmptyState(w http.ResponseWriter, r *http.Request, name string) (*State, bool) {
+	state, err := b.store.Get(r.Context(), name)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrStateNotFound):
+		return &State{Name: name, Revisions: map[uint32][]byte{}}, true
+	default:
+		b.writeError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	return state, true
+}
+
+// ensureOwner checks whether the client is allowed to access a state locked by a TypeInstance owner.
+// If the lock ID is given and Terraform holds the state lock, it must match the ID of the current Terraform lock.
+func (b *HTTPBackend) ensureOwner(w http.ResponseWriter, r *http.Request, state *State, lockID *string) bool {
+	if state.LockedBy != "" {
+		username, password, _ := r.BasicAuth()
+		if username != state.LockedBy || state.AccessToken == "" ||
+			subtle.ConstantTimeCompare([]byte(password), []byte(state.AccessToken)) != 1 {
+			b.writeError(w, http.StatusLocked, fmt.Errorf("Terraform state %q is locked by %q", state.Name, state.LockedBy))
+			return false
+		}
+	}
+
+	if lockID == nil || len(state.LockInfo) == 0 {
+		return true
+	}
+
+	var current lockInfo
+	if err := json.Unmarshal(state.LockInfo, &current); err != nil {
+		b.writeError(w, http.StatusInternalServerError, errors.Wrap(err, "while unmarshaling lock info"))
+		return false
+	}
+	if current.ID != *lockID {
+		b.writeLocked(w, state.LockInfo)
+		return false
+	}
+
+	return true
+}
+
+func (b *HTTPBackend) saveState(w http.ResponseWriter, r *http.Request, state *State) {
+	err := b.store.Save(r.Context(), state)
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusOK)
+	case errors.Is(err, ErrStateConflict):
+		b.writeError(w, http.StatusConflict, err)
+	default:
+		b.writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func (b *HTTPBackend) writeLocked(w http.ResponseWriter, currentLockInfo []byte) {
+	// Terraform reads the current lock info from the response body
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusLocked)
+	if _, err := w.Write(currentLockInfo); err != nil {
+		b.log.Error("while writing response", zap.Error(err))
+	}
+}
+
+func (b *HTTPBackend) writeError(w http.ResponseWriter, code int, err error) {
+	if code >= http.StatusInternalServerError {
+		b.log.Error("while handling Terraform HTTP backend request", zap.Error(err))
+	}
+	http.Error(w, err.Error(), code)
+}
@@ -0,0 +1,151 @@
+package terraformstatebackend
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"capact.io/capact/internal/logger"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+)
+
+func TestHTTPBackend_TerraformProtocol(t *testing.T) {
+	// given
+	store := NewSecretStore(fake.NewClientBuilder().Build(), testNamespace)
+	srv := httptest.NewServer(NewHTTPBackend(logger.Noop(), store).Handler())
+	defer srv.Close()
+
+	stateURL := srv.URL + "/state/db"
+
+	// when
+	resp := doRequest(t, http.MethodGet, stateURL, "", "", "")
+
+	// then
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// when
+	resp = doRequest(t, lockMethod, stateURL, `{"ID":"lock-1"}`, "", "")
+
+	// then
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// when
+	resp = doRequest(t, lockMethod, stateURL, `{"ID":"lock-2"}`, "", "")
+
+	// then
+	assert.Equal(t, http.StatusLocked, resp.StatusCode)
+	assert.Equal(t, `{"ID":"lock-1"}`, readBody(t, resp))
+
+	// when
+	resp = doRequest(t, http.MethodPost, stateURL+"?ID=lock-2", `{"serial":1}`, "", "")
+
+	// then
+	assert.Equal(t, http.StatusLocked, resp.StatusCode)
+
+	// when
+	resp = doRequest(t, http.MethodPost, stateURL+"?ID=lock-1", `{"serial":1}`, "", "")
+
+	// then
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// when
+	resp = doRequest(t, unlockMethod, stateURL, `{"ID":"lock-1"}`, "", "")
+
+	// then
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// when
+	resp = doRequest(t, http.MethodGet, stateURL, "", "", "")
+
+	// then
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"serial":1}`, readBody(t, resp))
+}
+
+func TestHTTPBackend_TypeInstanceLock(t *testing.T) {
+	// given
+	const owner = "default/action"
+	ctx := context.Background()
+	store := NewSecretStore(fake.NewClientBuilder().Build(), testNamespace)
+	handler := NewHandler(logger.Noop(), store, testBackendURL)
+	srv := httptest.NewServer(NewHTTPBackend(logger.Noop(), store).Handler())
+	defer srv.Close()
+
+	stateCtx := mustMarshal(t, StateContext{Name: "db"})
+	_, err := handler.OnCreate(ctx, &pb.OnCreateRequest{TypeInstanceId: "123", Context: stateCtx})
+	require.NoError(t, err)
+	_, err = handler.OnLock(ctx, &pb.OnLockRequest{TypeInstanceId: "123", Context: stateCtx, LockedBy: owner})
+	require.NoError(t, err)
+
+	out, err := handler.GetValue(ctx, &pb.GetValueRequest{TypeInstanceId: "123", Context: stateCtx})
+	require.NoError(t, err)
+	var value StateValue
+	require.NoError(t, json.Unmarshal(out.Value, &value))
+	require.Equal(t, owner, value.Username)
+	require.NotEmpty(t, value.Password)
+
+	stateURL := srv.URL + "/state/db"
+
+	// when
+	resp := doRequest(t, http.MethodPost, stateURL, `{"serial":1}`, "other", value.Password)
+
+	// then
+	assert.Equal(t, http.StatusLocked, resp.StatusCode)
+
+	// when
+	resp = doRequest(t, http.MethodPost, stateURL, `{"serial":1}`, owner, "")
+
+	// then
+	assert.Equal(t, http.StatusLocked, resp.StatusCode)
+
+	// when
+	resp = doRequest(t, http.MethodPost, stateURL, `{"serial":1}`, owner, value.Password)
+
+	// then
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// when
+	resp = doRequest(t, http.MethodGet, stateURL, "", owner, "wrong")
+
+	// then
+	assert.Equal(t, http.StatusLocked, resp.StatusCode)
+
+	// when
+	resp = doRequest(t, http.MethodGet, stateURL, "", owner, value.Password)
+
+	// then
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"serial":1}`, readBody(t, resp))
+}
+
+func doRequest(t *testing.T, method, url, body, username, password string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+
+	out, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(out)
+}
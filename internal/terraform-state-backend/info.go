@@ -0,0 +1,32 @@
+package terraformstatebackend
+
+import (
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+)
+
+// StateContextSchema is the JSON schema of the context used by the Terraform state storage backend.
+const StateContextSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema",
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {
+      "$id": "#/properties/context/properties/name",
+      "type": "string"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// Info returns the Terraform state storage backend details reported by the StorageBackendInfo service.
+func Info() *pb.GetInfoResponse {
+	return &pb.GetInfoResponse{
+		AcceptValue:   false,
+		ContextSchema: []byte(StateContextSchema),
+		SupportedOperations: &pb.SupportedOperations{
+			Lock:           true,
+			Revisions:      true,
+			PreCreateValue: true,
+		},
+	}
+}
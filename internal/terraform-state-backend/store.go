@@ -0,0 +1,388 @@
+package terraformstatebackend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	secretNamePrefix     = "tfstate-"
+	currentStateKey      = "state"
+	lockedByKey          = "locked-by"
+	accessTokenKey       = "access-token"
+	lockInfoKey          = "lock-info"
+	managedByLabelKey    = "app.kubernetes.io/managed-by"
+	managedByLabelValue  = "terraform-state-backend"
+	typeInstanceLabelKey = "capact.io/type-instance-id"
+	stateNameLabelKey    = "capact.io/terraform-state"
+	revisionLabelKey     = "capact.io/terraform-state-revision"
+)
+
+var (
+	// ErrStateNotFound is returned when a given Terraform state doesn't exist.
+	ErrStateNotFound = errors.New("Terraform state not found")
+	// ErrStateConflict is returned when a given Terraform state was modified in the meantime.
+	ErrStateConflict = errors.New("Terraform state was modified in the meantime")
+
+	stateNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+)
+
+// State holds a given Terraform state together with its TypeInstance revisions and lock details.
+type State struct {
+	// Name is the unique name of the Terraform state.
+	Name string
+	// TypeInstanceID is the ID of the TypeInstance associated with the Terraform state. Empty if not registered yet.
+	TypeInstanceID string
+	// Current holds the latest Terraform state written via the Terraform HTTP backend.
+	Current []byte
+	// Revisions holds the Terraform state snapshots indexed by the TypeInstance resource versions.
+	Revisions map[uint32][]byte
+	// LockedBy holds the ID of the owner which locked the associated TypeInstance.
+	LockedBy string
+	// AccessToken is the password required from the lock owner by the Terraform HTTP backend. Empty if the TypeInstance is not locked.
+	AccessToken string
+	// LockInfo holds the Terraform lock info if the state is locked by Terraform.
+	LockInfo []byte
+
+	resourceVersion string
+	// storedRevisions holds the revisions already stored in dedicated Secrets.
+	storedRevisions map[uint32]struct{}
+}
+
+// ValidateStateName returns an error if a given name cannot be used as the Terraform state name.
+func ValidateStateName(name string) error {
+	if len(name) > 63-len(secretNamePrefix) || !stateNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid Terraform state name %q: it must consist of at most %d lower case alphanumeric characters or '-', and must start and end with an alphanumeric character", name, 63-len(secretNamePrefix))
+	}
+	return nil
+}
+
+// SecretStore stores Terraform states in Kubernetes Secrets. Each Terraform state is stored in a dedicated Secret,
+// and each of its revisions is stored in a separate Secret. All state snapshots are compressed, so only the size
+// of a single compressed snapshot is limited by the Kubernetes Secret size limit.
+type SecretStore struct {
+	cli       client.Client
+	namespace string
+}
+
+// NewSecretStore returns a new SecretStore instance.
+func NewSecretStore(cli client.Client, namespace string) *SecretStore {
+	return &SecretStore{
+		cli:       cli,
+		namespace: namespace,
+	}
+}
+
+// Get returns the Terraform state with a given name.
+func (s *SecretStore) Get(ctx context.Context, name string) (*State, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: s.namespace, Name: secretNamePrefix + name}
+	err := s.cli.Get(ctx, key, secret)
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		return nil, ErrStateNotFound
+	default:
+		return nil, errors.Wrapf(err, "while getting Secret %q", key.String())
+	}
+
+	return s.stateFromSecrets(ctx, name, secret)
+}
+
+// GetForTypeInstance returns the Terraform state associated with a given TypeInstance.
+func (s *SecretStore) GetForTypeInstance(ctx context.Context, typeInstanceID string) (*State, error) {
+	secrets := &corev1.SecretList{}
+	err := s.cli.List(ctx, secrets, client.InNamespace(s.namespace), client.MatchingLabels{
+		managedByLabelKey:    managedByLabelValue,
+		typeInstanceLabelKey: typeInstanceID,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while listing Secrets for TypeInstance %q", typeInstanceID)
+	}
+
+	switch len(secrets.Items) {
+	case 0:
+		return nil, ErrStateNotFound
+	case 1:
+	default:
+		return nil, fmt.Errorf("found %d Terraform states for TypeInstance %q", len(secrets.Items), typeInstanceID)
+	}
+
+	secret := secrets.Items[0]
+	return s.stateFromSecrets(ctx, strings.TrimPrefix(secret.Name, secretNamePrefix), &secret)
+}
+
+// Save creates or updates a given Terraform state.
+// It returns ErrStateConflict if the state was modified after it was read.
+func (s *SecretStore) Save(ctx context.Context, state *State) error {
+	secret, err := stateToSecret(s.namespace, state)
+	if err != nil {
+		return err
+	}
+
+	if state.resourceVersion == "" {
+		err = s.cli.Create(ctx, secret)
+	} else {
+		err = s.cli.Update(ctx, secret)
+	}
+
+	switch {
+	case err == nil:
+	case apierrors.IsConflict(err), apierrors.IsAlreadyExists(err):
+		return ErrStateConflict
+	default:
+		return errors.Wrapf(err, "while saving Secret for Terraform state %q", state.Name)
+	}
+	state.resourceVersion = secret.ResourceVersion
+
+	return s.saveRevisions(ctx, state)
+}
+
+// Delete deletes the Terraform state with a given name. Deleting a state which doesn't exist is not an error.
+func (s *SecretStore) Delete(ctx context.Context, name string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretNamePrefix + name,
+			Namespace: s.namespace,
+		},
+	}
+
+	err := s.cli.Delete(ctx, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "while deleting Secret for Terraform state %q", name)
+	}
+
+	err = s.cli.DeleteAllOf(ctx, &corev1.Secret{}, client.InNamespace(s.namespace), client.MatchingLabels{
+		managedByLabelKey: managedByLabelValue,
+		stateNameLabelKey: name,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "while deleting revision Secrets for Terraform state %q", name)
+	}
+
+	return nil
+}
+
+// Ping checks if Terraform states can be read from the configured Namespace.
+func (s *SecretStore) Ping(ctx context.Context) error {
+	secrets := &corev1.SecretList{}
+	err := s.cli.List(ctx, secrets, client.InNamespace(s.namespace), client.Limit(1), client.MatchingLabels{
+		managedByLabelKey: managedByLabelValue,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "while listing Secrets in Namespace %q", s.namespace)
+	}
+
+	return nil
+}
+
+// stateFromSecrets returns the Terraform state stored in a given Secret, together with revisions stored in dedicated Secrets.
+func (s *SecretStore) stateFromSecrets(ctx context.Context, name string, secret *corev1.Secret) (*State, error) {
+	state, err := stateFromSecret(name, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := &corev1.SecretList{}
+	err = s.cli.List(ctx, revisions, client.InNamespace(s.namespace), client.MatchingLabels{
+		managedByLabelKey: managedByLabelValue,
+		stateNameLabelKey: name,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while listing revision Secrets for Terraform state %q", name)
+	}
+
+	for _, item := range revisions.Items {
+		rev, err := strconv.ParseUint(item.Labels[revisionLabelKey], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while parsing revision from Secret %q", item.Name)
+		}
+		snapshot, err := decompress(item.Data[currentStateKey])
+		if err != nil {
+			return nil, errors.Wrapf(err, "while decompressing state for revision %d", rev)
+		}
+		state.Revisions[uint32(rev)] = snapshot
+		state.storedRevisions[uint32(rev)] = struct{}{}
+	}
+
+	return state, nil
+}
+
+// saveRevisions creates Secrets for new revisions and deletes Secrets of removed ones.
+func (s *SecretStore) saveRevisions(ctx context.Context, state *State) error {
+	if state.storedRevisions == nil {
+		state.storedRevisions = map[uint32]struct{}{}
+	}
+
+	for rev, snapshot := range state.Revisions {
+		if _, stored := state.storedRevisions[rev]; stored {
+			continue
+		}
+
+		secret, err := revisionToSecret(s.namespace, state.Name, rev, snapshot)
+		if err != nil {
+			return err
+		}
+
+		err = s.cli.Create(ctx, secret)
+		if apierrors.IsAlreadyExists(err) {
+			// leftover of a removed revision
+			err = s.overrideSecret(ctx, secret)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "while saving Secret for Terraform state %q revision %d", state.Name, rev)
+		}
+		state.storedRevisions[rev] = struct{}{}
+	}
+
+	for rev := range state.storedRevisions {
+		if _, found := state.Revisions[rev]; found {
+			continue
+		}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      revisionSecretName(state.Name, rev),
+				Namespace: s.namespace,
+			},
+		}
+		if err := s.cli.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "while deleting Secret for Terraform state %q revision %d", state.Name, rev)
+		}
+		delete(state.storedRevisions, rev)
+	}
+
+	return nil
+}
+
+func (s *SecretStore) overrideSecret(ctx context.Context, secret *corev1.Secret) error {
+	existing := &corev1.Secret{}
+	if err := s.cli.Get(ctx, client.ObjectKeyFromObject(secret), existing); err != nil {
+		return err
+	}
+
+	secret.ResourceVersion = existing.ResourceVersion
+	return s.cli.Update(ctx, secret)
+}
+
+func stateFromSecret(name string, secret *corev1.Secret) (*State, error) {
+	state := &State{
+		Name:            name,
+		TypeInstanceID:  secret.Labels[typeInstanceLabelKey],
+		Revisions:       map[uint32][]byte{},
+		LockedBy:        string(secret.Data[lockedByKey]),
+		AccessToken:     string(secret.Data[accessTokenKey]),
+		LockInfo:        secret.Data[lockInfoKey],
+		resourceVersion: secret.ResourceVersion,
+		storedRevisions: map[uint32]struct{}{},
+	}
+
+	if data, ok := secret.Data[currentStateKey]; ok {
+		current, err := decompress(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "while decompressing current state")
+		}
+		state.Current = current
+	}
+
+	return state, nil
+}
+
+func stateToSecret(namespace string, state *State) (*corev1.Secret, error) {
+	labels := map[string]string{
+		managedByLabelKey: managedByLabelValue,
+	}
+	if state.TypeInstanceID != "" {
+		labels[typeInstanceLabelKey] = state.TypeInstanceID
+	}
+
+	data := map[string][]byte{}
+	if state.Current != nil {
+		current, err := compress(state.Current)
+		if err != nil {
+			return nil, errors.Wrap(err, "while compressing current state")
+		}
+		data[currentStateKey] = current
+	}
+	if state.LockedBy != "" {
+		data[lockedByKey] = []byte(state.LockedBy)
+	}
+	if state.AccessToken != "" {
+		data[accessTokenKey] = []byte(state.AccessToken)
+	}
+	if len(state.LockInfo) > 0 {
+		data[lockInfoKey] = state.LockInfo
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            secretNamePrefix + state.Name,
+			Namespace:       namespace,
+			Labels:          labels,
+			ResourceVersion: state.resourceVersion,
+		},
+		Data: data,
+	}, nil
+}
+
+func revisionToSecret(namespace, name string, rev uint32, snapshot []byte) (*corev1.Secret, error) {
+	compressed, err := compress(snapshot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while compressing state for revision %d", rev)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revisionSecretName(name, rev),
+			Namespace: namespace,
+			Labels: map[string]string{
+				managedByLabelKey: managedByLabelValue,
+				stateNameLabelKey: name,
+				revisionLabelKey:  strconv.FormatUint(uint64(rev), 10),
+			},
+		},
+		Data: map[string][]byte{
+			currentStateKey: compressed,
+		},
+	}, nil
+}
+
+// revisionSecretName returns the name of the Secret which holds a given revision.
+// State names cannot contain dots, so it never conflicts with the Secret of another state.
+func revisionSecretName(name string, rev uint32) string {
+	return fmt.Sprintf("%s%s.%d", secretNamePrefix, name, rev)
+}
+
+func compress(in []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(in); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(in []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(in))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,63 @@
+package terraformstatebackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSecretStore_Revisions(t *testing.T) {
+	// given
+	ctx := context.Background()
+	cli := fake.NewClientBuilder().Build()
+	store := NewSecretStore(cli, testNamespace)
+
+	// when
+	state := &State{
+		Name:    "db",
+		Current: []byte(`{"serial":2}`),
+		Revisions: map[uint32][]byte{
+			1: []byte(`{"serial":1}`),
+			2: []byte(`{"serial":2}`),
+		},
+	}
+	require.NoError(t, store.Save(ctx, state))
+
+	// then
+	secret := &corev1.Secret{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "tfstate-db"}, secret))
+	assert.Len(t, secret.Data, 1)
+	assert.Contains(t, secret.Data, currentStateKey)
+	assert.Len(t, listSecrets(t, cli), 3)
+
+	got, err := store.Get(ctx, "db")
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"serial":2}`), got.Current)
+	assert.Equal(t, state.Revisions, got.Revisions)
+
+	// when
+	delete(got.Revisions, 1)
+	require.NoError(t, store.Save(ctx, got))
+
+	// then
+	assert.Len(t, listSecrets(t, cli), 2)
+
+	// when
+	require.NoError(t, store.Delete(ctx, "db"))
+
+	// then
+	assert.Empty(t, listSecrets(t, cli))
+}
+
+func listSecrets(t *testing.T, cli client.Client) []corev1.Secret {
+	t.Helper()
+
+	secrets := &corev1.SecretList{}
+	require.NoError(t, cli.List(context.Background(), secrets, client.InNamespace(testNamespace)))
+	return secrets.Items
+}
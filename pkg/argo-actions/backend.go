@@ -3,6 +3,7 @@ package argoactions
 import (
 	"context"
 	"encoding/json"
+	"sync"
 
 	hublocalgraphql "capact.io/capact/pkg/hub/api/graphql/local"
 
@@ -10,6 +11,7 @@ import (
 
 	"capact.io/capact/pkg/hub/client/local"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 )
 
 type findTIClient interface {
@@ -46,3 +48,70 @@ func resolveBackendsValues(ctx context.Context, client findTIClient, ids []strin
 
 	return result, nil
 }
+
+// resolveBackendsValuesConcurrently splits the given IDs into batches and resolves them concurrently,
+// using at most `concurrency` parallel requests.
+func resolveBackendsValuesConcurrently(ctx context.Context, client findTIClient, ids []string, batchSize, concurrency int) (map[string]storagebackend.TypeInstanceValue, error) {
+	batches := splitIntoBatches(uniqueIDs(ids), batchSize)
+
+	var (
+		mu     sync.Mutex
+		result = make(map[string]storagebackend.TypeInstanceValue)
+		sem    = make(chan struct{}, concurrency)
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+	for _, batch := range batches {
+		batch := batch
+		g.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-gCtx.Done():
+				return gCtx.Err()
+			}
+			defer func() { <-sem }()
+
+			values, err := resolveBackendsValues(gCtx, client, batch)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for id, value := range values {
+				result[id] = value
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := map[string]struct{}{}
+	var out []string
+	for _, id := range ids {
+		if _, found := seen[id]; found {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
+
+func splitIntoBatches(ids []string, size int) [][]string {
+	var batches [][]string
+	for size < len(ids) {
+		ids, batches = ids[size:], append(batches, ids[0:size:size])
+	}
+	if len(ids) > 0 {
+		batches = append(batches, ids)
+	}
+	return batches
+}
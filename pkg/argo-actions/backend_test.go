@@ -2,6 +2,7 @@ package argoactions
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"capact.io/capact/internal/ptr"
@@ -86,6 +87,30 @@ func TestResolveBackendsValues(t *testing.T) {
 	}
 }
 
+func TestResolveBackendsValuesConcurrently(t *testing.T) {
+	// given
+	ids := []string{"foo", "bar", "baz", "foo"}
+	cli := &fakeFindTIClient{
+		result: map[string]gqllocalapi.TypeInstance{
+			"foo": fixTI(map[string]interface{}{"url": "foo.baz"}),
+			"bar": fixTI(map[string]interface{}{"url": "bar.baz"}),
+			"baz": fixTI(map[string]interface{}{"url": "baz.baz"}),
+		},
+	}
+
+	// when
+	res, err := resolveBackendsValuesConcurrently(context.Background(), cli, ids, 2, 2)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]storagebackend.TypeInstanceValue{
+		"foo": {URL: "foo.baz"},
+		"bar": {URL: "bar.baz"},
+		"baz": {URL: "baz.baz"},
+	}, res)
+	assert.ElementsMatch(t, [][]string{{"foo", "bar"}, {"baz"}}, cli.calls)
+}
+
 func fixTI(value interface{}) gqllocalapi.TypeInstance {
 	return gqllocalapi.TypeInstance{
 		LatestResourceVersion: &gqllocalapi.TypeInstanceResourceVersion{
@@ -98,8 +123,21 @@ func fixTI(value interface{}) gqllocalapi.TypeInstance {
 type fakeFindTIClient struct {
 	result map[string]gqllocalapi.TypeInstance
 	err    error
+
+	mu    sync.Mutex
+	calls [][]string
 }
 
-func (f *fakeFindTIClient) FindTypeInstances(ctx context.Context, _ []string, _ ...local.TypeInstancesOption) (map[string]gqllocalapi.TypeInstance, error) {
-	return f.result, f.err
+func (f *fakeFindTIClient) FindTypeInstances(ctx context.Context, ids []string, _ ...local.TypeInstancesOption) (map[string]gqllocalapi.TypeInstance, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, ids)
+
+	out := map[string]gqllocalapi.TypeInstance{}
+	for _, id := range ids {
+		if ti, found := f.result[id]; found {
+			out[id] = ti
+		}
+	}
+	return out, f.err
 }
@@ -13,6 +13,7 @@ import (
 	hubclient "capact.io/capact/pkg/hub/client"
 	"capact.io/capact/pkg/sdk/validation"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"sigs.k8s.io/yaml"
@@ -23,12 +24,26 @@ const (
 	UploadAction = "UploadAction"
 	valueKey     = "value"
 	backendKey   = "backend"
+
+	backendsFetchBatchSize          = 50
+	defaultBackendsFetchConcurrency = 5
 )
 
 // UploadConfig stores the configuration parameters for the upload TypeInstances action.
 type UploadConfig struct {
 	PayloadFilepath  string
 	TypeInstancesDir string
+	// BatchSize is the maximum number of TypeInstances created in a single Local Hub call.
+	// If not set, all TypeInstances are created atomically in a single call.
+	BatchSize int `envconfig:"optional"`
+	// BackendsFetchConcurrency is the maximum number of concurrent calls to fetch storage backends.
+	BackendsFetchConcurrency int `envconfig:"default=5"`
+}
+
+// createDeleteTIClient aggregates the Local Hub methods used to upload TypeInstances.
+type createDeleteTIClient interface {
+	CreateTypeInstances(ctx context.Context, in *graphqllocal.CreateTypeInstancesInput) ([]graphqllocal.CreateTypeInstanceOutput, error)
+	DeleteTypeInstance(ctx context.Context, id string) error
 }
 
 // Upload implements the Action interface.
@@ -47,6 +62,10 @@ type UploadTypeInstanceData struct {
 
 // NewUploadAction returns a new Upload instance.
 func NewUploadAction(log *zap.Logger, client *hubclient.Client, cfg UploadConfig) Action {
+	if cfg.BackendsFetchConcurrency <= 0 {
+		cfg.BackendsFetchConcurrency = defaultBackendsFetchConcurrency
+	}
+
 	return &Upload{
 		log:    log,
 		client: client,
@@ -55,6 +74,9 @@ func NewUploadAction(log *zap.Logger, client *hubclient.Client, cfg UploadConfig
 }
 
 // Do uploads TypeInstances to the Local Hub.
+// By default, all TypeInstances are created in a single call. If the batch size is set, TypeInstances are created in batches.
+// If the Local Hub rejects a batch, the TypeInstances created from the previous batches are deleted,
+// so their values are also removed from the storage backends.
 func (u *Upload) Do(ctx context.Context) error {
 	payloadBytes, err := ioutil.ReadFile(u.cfg.PayloadFilepath)
 	if err != nil {
@@ -116,7 +138,7 @@ func (u *Upload) Do(ctx context.Context) error {
 
 	u.log.Info("Uploading TypeInstances to Hub...", zap.Int("TypeInstance count", len(payload.TypeInstances)))
 
-	uploadOutput, err := u.uploadTypeInstances(ctx, payload)
+	uploadOutput, err := uploadTypeInstancesInBatches(ctx, u.log, u.client, payload, u.cfg.BatchSize)
 	if err != nil {
 		return errors.Wrap(err, "while uploading TypeInstances")
 	}
@@ -139,7 +161,7 @@ func (u *Upload) resolveBackendsValues(ctx context.Context, typeInstances []*gra
 		ids = append(ids, ti.Backend.ID)
 	}
 
-	return resolveBackendsValues(ctx, u.client, ids)
+	return resolveBackendsValuesConcurrently(ctx, u.client, ids, backendsFetchBatchSize, u.cfg.BackendsFetchConcurrency)
 }
 
 func (u *Upload) render(payload *graphqllocal.CreateTypeInstancesInput, values map[string]map[string]interface{}, shouldIncludeValue func(tiToUpload graphqllocal.CreateTypeInstanceInput) (bool, error)) error {
@@ -191,8 +213,122 @@ func (u *Upload) render(payload *graphqllocal.CreateTypeInstancesInput, values m
 	return nil
 }
 
-func (u *Upload) uploadTypeInstances(ctx context.Context, in *graphqllocal.CreateTypeInstancesInput) ([]graphqllocal.CreateTypeInstanceOutput, error) {
-	return u.client.Local.CreateTypeInstances(ctx, in)
+// uploadTypeInstancesInBatches creates TypeInstances in batches of a given size.
+// Each relation is created together with the batch which contains the last of its TypeInstances.
+// Aliases of TypeInstances created in the previous batches are replaced with their IDs.
+// If the batch size is not set or all TypeInstances fit in a single batch, they are created atomically in a single call.
+func uploadTypeInstancesInBatches(ctx context.Context, log *zap.Logger, client createDeleteTIClient, in *graphqllocal.CreateTypeInstancesInput, batchSize int) ([]graphqllocal.CreateTypeInstanceOutput, error) {
+	if batchSize <= 0 || batchSize >= len(in.TypeInstances) {
+		out, err := client.CreateTypeInstances(ctx, in)
+		if err != nil {
+			return nil, errors.Wrap(err, "while creating TypeInstances")
+		}
+		return out, nil
+	}
+
+	batches := splitPayloadIntoBatches(in, batchSize)
+
+	var created []graphqllocal.CreateTypeInstanceOutput
+	for idx, batch := range batches {
+		batch.UsesRelations = replaceCreatedAliases(batch.UsesRelations, created)
+
+		log.Info("Uploading TypeInstances batch", zap.Int("batch", idx+1), zap.Int("batches", len(batches)), zap.Int("TypeInstance count", len(batch.TypeInstances)))
+		out, err := client.CreateTypeInstances(ctx, batch)
+		if err == nil {
+			created = append(created, out...)
+			continue
+		}
+
+		err = errors.Wrapf(err, "while creating TypeInstances batch %d/%d", idx+1, len(batches))
+		if rollbackErr := deleteCreatedTypeInstances(ctx, log, client, created); rollbackErr != nil {
+			return nil, multierror.Append(err, errors.Wrap(rollbackErr, "while rolling back created TypeInstances"))
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func splitPayloadIntoBatches(in *graphqllocal.CreateTypeInstancesInput, batchSize int) []*graphqllocal.CreateTypeInstancesInput {
+	var (
+		batches      []*graphqllocal.CreateTypeInstancesInput
+		aliasToBatch = map[string]int{}
+	)
+
+	for i, ti := range in.TypeInstances {
+		if i%batchSize == 0 {
+			batches = append(batches, &graphqllocal.CreateTypeInstancesInput{
+				UsesRelations: []*graphqllocal.TypeInstanceUsesRelationInput{},
+			})
+		}
+		idx := len(batches) - 1
+		batches[idx].TypeInstances = append(batches[idx].TypeInstances, ti)
+		if ti.Alias != nil {
+			aliasToBatch[*ti.Alias] = idx
+		}
+	}
+
+	for _, relation := range in.UsesRelations {
+		// relations to already existing TypeInstances are referenced by ID, so they can be created with the first batch
+		idx := aliasToBatch[relation.From]
+		if toIdx := aliasToBatch[relation.To]; toIdx > idx {
+			idx = toIdx
+		}
+		batches[idx].UsesRelations = append(batches[idx].UsesRelations, relation)
+	}
+
+	return batches
+}
+
+func replaceCreatedAliases(relations []*graphqllocal.TypeInstanceUsesRelationInput, created []graphqllocal.CreateTypeInstanceOutput) []*graphqllocal.TypeInstanceUsesRelationInput {
+	if len(created) == 0 {
+		return relations
+	}
+
+	aliasToID := map[string]string{}
+	for _, ti := range created {
+		aliasToID[ti.Alias] = ti.ID
+	}
+
+	out := make([]*graphqllocal.TypeInstanceUsesRelationInput, 0, len(relations))
+	for _, relation := range relations {
+		resolved := *relation
+		if id, found := aliasToID[resolved.From]; found {
+			resolved.From = id
+		}
+		if id, found := aliasToID[resolved.To]; found {
+			resolved.To = id
+		}
+		out = append(out, &resolved)
+	}
+	return out
+}
+
+// deleteCreatedTypeInstances deletes the given TypeInstances from the Local Hub, which also calls OnDelete on their storage backends.
+// A TypeInstance used by other TypeInstances cannot be deleted, so the deletion is repeated as long as it makes progress.
+func deleteCreatedTypeInstances(ctx context.Context, log *zap.Logger, client createDeleteTIClient, created []graphqllocal.CreateTypeInstanceOutput) error {
+	toDelete := created
+	for len(toDelete) > 0 {
+		var (
+			remaining []graphqllocal.CreateTypeInstanceOutput
+			errs      error
+		)
+		for _, ti := range toDelete {
+			if err := client.DeleteTypeInstance(ctx, ti.ID); err != nil {
+				remaining = append(remaining, ti)
+				errs = multierror.Append(errs, errors.Wrapf(err, "while deleting TypeInstance %q (ID: %q)", ti.Alias, ti.ID))
+				continue
+			}
+			log.Info("TypeInstance rolled back", zap.String("alias", ti.Alias), zap.String("ID", ti.ID))
+		}
+
+		if len(remaining) == len(toDelete) {
+			return errs
+		}
+		toDelete = remaining
+	}
+
+	return nil
 }
 
 func (u *Upload) shouldIncludeTIValueFn(backends map[string]storage_backend.TypeInstanceValue) func(tiToCreate graphqllocal.CreateTypeInstanceInput) (bool, error) {
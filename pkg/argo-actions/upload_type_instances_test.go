@@ -0,0 +1,180 @@
+package argoactions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"capact.io/capact/internal/ptr"
+	gqllocalapi "capact.io/capact/pkg/hub/api/graphql/local"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUploadTypeInstancesInBatches(t *testing.T) {
+	t.Run("Should create all TypeInstances in a single call if batch size is not set", func(t *testing.T) {
+		// given
+		in := &gqllocalapi.CreateTypeInstancesInput{
+			TypeInstances: fixCreateTypeInstanceInputs("db", "config", "app"),
+			UsesRelations: []*gqllocalapi.TypeInstanceUsesRelationInput{
+				{From: "config", To: "db"},
+				{From: "app", To: "config"},
+			},
+		}
+		cli := &fakeCreateDeleteTIClient{}
+
+		// when
+		out, err := uploadTypeInstancesInBatches(context.Background(), zap.NewNop(), cli, in, 0)
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, out, 3)
+		require.Len(t, cli.createCalls, 1)
+		assert.Equal(t, in, cli.createCalls[0])
+	})
+
+	t.Run("Should not delete anything if the single call is rejected", func(t *testing.T) {
+		// given
+		in := &gqllocalapi.CreateTypeInstancesInput{
+			TypeInstances: fixCreateTypeInstanceInputs("db", "app"),
+		}
+		cli := &fakeCreateDeleteTIClient{failCreateOnCall: 1}
+
+		// when
+		_, err := uploadTypeInstancesInBatches(context.Background(), zap.NewNop(), cli, in, 0)
+
+		// then
+		assert.EqualError(t, err, "while creating TypeInstances: Local Hub rejected TypeInstances")
+		assert.Empty(t, cli.deleted)
+	})
+
+	t.Run("Should create relations with the batch containing the last TypeInstance", func(t *testing.T) {
+		// given
+		in := &gqllocalapi.CreateTypeInstancesInput{
+			TypeInstances: fixCreateTypeInstanceInputs("db", "config", "app"),
+			UsesRelations: []*gqllocalapi.TypeInstanceUsesRelationInput{
+				{From: "config", To: "db"},
+				{From: "app", To: "config"},
+				{From: "db", To: "existing-id"},
+			},
+		}
+		cli := &fakeCreateDeleteTIClient{}
+
+		// when
+		out, err := uploadTypeInstancesInBatches(context.Background(), zap.NewNop(), cli, in, 2)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []gqllocalapi.CreateTypeInstanceOutput{
+			{Alias: "db", ID: "db-id"},
+			{Alias: "config", ID: "config-id"},
+			{Alias: "app", ID: "app-id"},
+		}, out)
+
+		require.Len(t, cli.createCalls, 2)
+		assert.Equal(t, []*gqllocalapi.TypeInstanceUsesRelationInput{
+			{From: "config", To: "db"},
+			{From: "db", To: "existing-id"},
+		}, cli.createCalls[0].UsesRelations)
+		assert.Equal(t, []*gqllocalapi.TypeInstanceUsesRelationInput{
+			{From: "app", To: "config-id"},
+		}, cli.createCalls[1].UsesRelations)
+	})
+
+	t.Run("Should delete TypeInstances from previous batches if a batch is rejected", func(t *testing.T) {
+		// given
+		in := &gqllocalapi.CreateTypeInstancesInput{
+			TypeInstances: fixCreateTypeInstanceInputs("db", "config", "app"),
+			UsesRelations: []*gqllocalapi.TypeInstanceUsesRelationInput{
+				{From: "config", To: "db"},
+			},
+		}
+		cli := &fakeCreateDeleteTIClient{
+			failCreateOnCall: 2,
+			usedBy: map[string]string{
+				"db-id": "config-id",
+			},
+		}
+
+		// when
+		_, err := uploadTypeInstancesInBatches(context.Background(), zap.NewNop(), cli, in, 2)
+
+		// then
+		assert.EqualError(t, err, "while creating TypeInstances batch 2/2: Local Hub rejected TypeInstances")
+		assert.Equal(t, []string{"config-id", "db-id"}, cli.deleted)
+	})
+
+	t.Run("Should return rollback error if TypeInstances cannot be deleted", func(t *testing.T) {
+		// given
+		in := &gqllocalapi.CreateTypeInstancesInput{
+			TypeInstances: fixCreateTypeInstanceInputs("db", "app"),
+		}
+		cli := &fakeCreateDeleteTIClient{
+			failCreateOnCall: 2,
+			usedBy: map[string]string{
+				"db-id": "external-id",
+			},
+		}
+
+		// when
+		_, err := uploadTypeInstancesInBatches(context.Background(), zap.NewNop(), cli, in, 1)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while creating TypeInstances batch 2/2: Local Hub rejected TypeInstances")
+		assert.Contains(t, err.Error(), `while rolling back created TypeInstances: 1 error occurred:`)
+		assert.Contains(t, err.Error(), `while deleting TypeInstance "db" (ID: "db-id"): TypeInstance is used by "external-id"`)
+		assert.Empty(t, cli.deleted)
+	})
+}
+
+func fixCreateTypeInstanceInputs(aliases ...string) []*gqllocalapi.CreateTypeInstanceInput {
+	var out []*gqllocalapi.CreateTypeInstanceInput
+	for _, alias := range aliases {
+		out = append(out, &gqllocalapi.CreateTypeInstanceInput{
+			Alias: ptr.String(alias),
+		})
+	}
+	return out
+}
+
+type fakeCreateDeleteTIClient struct {
+	failCreateOnCall int
+	// usedBy maps the TypeInstance ID to the ID of TypeInstance which uses it.
+	usedBy map[string]string
+
+	createCalls []*gqllocalapi.CreateTypeInstancesInput
+	deleted     []string
+}
+
+func (f *fakeCreateDeleteTIClient) CreateTypeInstances(_ context.Context, in *gqllocalapi.CreateTypeInstancesInput) ([]gqllocalapi.CreateTypeInstanceOutput, error) {
+	f.createCalls = append(f.createCalls, in)
+	if len(f.createCalls) == f.failCreateOnCall {
+		return nil, errors.New("Local Hub rejected TypeInstances")
+	}
+
+	var out []gqllocalapi.CreateTypeInstanceOutput
+	for _, ti := range in.TypeInstances {
+		out = append(out, gqllocalapi.CreateTypeInstanceOutput{
+			Alias: *ti.Alias,
+			ID:    fmt.Sprintf("%s-id", *ti.Alias),
+		})
+	}
+	return out, nil
+}
+
+func (f *fakeCreateDeleteTIClient) DeleteTypeInstance(_ context.Context, id string) error {
+	if user, found := f.usedBy[id]; found {
+		return fmt.Errorf("TypeInstance is used by %q", user)
+	}
+
+	f.deleted = append(f.deleted, id)
+	for usedID, user := range f.usedBy {
+		if user == id {
+			delete(f.usedBy, usedID)
+		}
+	}
+	return nil
+}
@@ -194,6 +194,8 @@ type PolicyRuleImplementationConstraintsInput struct {
 	Attributes []*ManifestReferenceInput `json:"attributes"`
 	// Refers a specific Implementation with exact path.
 	Path *string `json:"path"`
+	// Refers trusted signers. The Implementation must be signed by one of them.
+	Signers []string `json:"signers"`
 }
 
 type PolicyRuleInjectDataInput struct {
@@ -232,10 +234,22 @@ type RulesForTypeInstanceInput struct {
 	Backend *TypeInstanceBackendRuleInput `json:"backend"`
 }
 
+// Incremental progress of the Runner operation
+type RunnerProgressEvent struct {
+	Timestamp Timestamp `json:"timestamp"`
+	Message   string    `json:"message"`
+	// Number of completed units of work, such as workflow steps or resources
+	Current int `json:"current"`
+	// Total number of units of work. It is 0 if unknown
+	Total int `json:"total"`
+}
+
 // Additional Action status from the Runner
 type RunnerStatus struct {
 	// Status of a given Runner e.g. Argo Workflow Runner status object with argoWorkflowRef field
 	Status interface{} `json:"status"`
+	// Latest progress events reported by the Runner, from the oldest to the newest
+	Progress []*RunnerProgressEvent `json:"progress"`
 }
 
 type TypeInstanceBackendDetails struct {
@@ -23,6 +23,8 @@ type PolicyRuleImplementationConstraints struct {
 	Attributes []*ManifestReferenceWithOptionalRevision `json:"attributes,omitempty"`
 	// Refers a specific Implementation with exact path.
 	Path *string `json:"path,omitempty"`
+	// Refers trusted signers. The Implementation must be signed by one of them.
+	Signers []string `json:"signers,omitempty"`
 }
 
 // ManifestReferenceWithOptionalRevision is used to represent a manifest reference with an optional revision property.
@@ -155,6 +155,7 @@ type ComplexityRoot struct {
 		Attributes func(childComplexity int) int
 		Path       func(childComplexity int) int
 		Requires   func(childComplexity int) int
+		Signers    func(childComplexity int) int
 	}
 
 	PolicyRuleInjectData struct {
@@ -184,8 +185,16 @@ type ComplexityRoot struct {
 		TypeRef func(childComplexity int) int
 	}
 
+	RunnerProgressEvent struct {
+		Current   func(childComplexity int) int
+		Message   func(childComplexity int) int
+		Timestamp func(childComplexity int) int
+		Total     func(childComplexity int) int
+	}
+
 	RunnerStatus struct {
-		Status func(childComplexity int) int
+		Progress func(childComplexity int) int
+		Status   func(childComplexity int) int
 	}
 
 	TypeInstanceBackendDetails struct {
@@ -680,6 +689,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PolicyRuleImplementationConstraints.Requires(childComplexity), true
 
+	case "PolicyRuleImplementationConstraints.signers":
+		if e.complexity.PolicyRuleImplementationConstraints.Signers == nil {
+			break
+		}
+
+		return e.complexity.PolicyRuleImplementationConstraints.Signers(childComplexity), true
+
 	case "PolicyRuleInjectData.additionalParameters":
 		if e.complexity.PolicyRuleInjectData.AdditionalParameters == nil {
 			break
@@ -774,6 +790,41 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.RulesForTypeInstance.TypeRef(childComplexity), true
 
+	case "RunnerProgressEvent.current":
+		if e.complexity.RunnerProgressEvent.Current == nil {
+			break
+		}
+
+		return e.complexity.RunnerProgressEvent.Current(childComplexity), true
+
+	case "RunnerProgressEvent.message":
+		if e.complexity.RunnerProgressEvent.Message == nil {
+			break
+		}
+
+		return e.complexity.RunnerProgressEvent.Message(childComplexity), true
+
+	case "RunnerProgressEvent.timestamp":
+		if e.complexity.RunnerProgressEvent.Timestamp == nil {
+			break
+		}
+
+		return e.complexity.RunnerProgressEvent.Timestamp(childComplexity), true
+
+	case "RunnerProgressEvent.total":
+		if e.complexity.RunnerProgressEvent.Total == nil {
+			break
+		}
+
+		return e.complexity.RunnerProgressEvent.Total(childComplexity), true
+
+	case "RunnerStatus.progress":
+		if e.complexity.RunnerStatus.Progress == nil {
+			break
+		}
+
+		return e.complexity.RunnerStatus.Progress(childComplexity), true
+
 	case "RunnerStatus.status":
 		if e.complexity.RunnerStatus.Status == nil {
 			break
@@ -1113,6 +1164,27 @@ type RunnerStatus {
   Status of a given Runner e.g. Argo Workflow Runner status object with argoWorkflowRef field
   """
   status: Any
+
+  """
+  Latest progress events reported by the Runner, from the oldest to the newest
+  """
+  progress: [RunnerProgressEvent!]!
+}
+
+"""
+Incremental progress of the Runner operation
+"""
+type RunnerProgressEvent {
+  timestamp: Timestamp!
+  message: String!
+  """
+  Number of completed units of work, such as workflow steps or resources
+  """
+  current: Int!
+  """
+  Total number of units of work. It is 0 if unknown
+  """
+  total: Int!
 }
 
 """
@@ -1281,6 +1353,11 @@ input PolicyRuleImplementationConstraintsInput {
   Refers a specific Implementation with exact path.
   """
   path: NodePath
+
+  """
+  Refers trusted signers. The Implementation must be signed by one of them.
+  """
+  signers: [String!]
 }
 
 type Policy {
@@ -1358,6 +1435,11 @@ type PolicyRuleImplementationConstraints {
   Refers a specific Implementation with exact path.
   """
   path: NodePath
+
+  """
+  Refers trusted signers. The Implementation must be signed by one of them.
+  """
+  signers: [String!]
 }
 
 type Query {
@@ -3605,6 +3687,38 @@ func (ec *executionContext) _PolicyRuleImplementationConstraints_path(ctx contex
 	return ec.marshalONodePath2ᚖstring(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _PolicyRuleImplementationConstraints_signers(ctx context.Context, field graphql.CollectedField, obj *PolicyRuleImplementationConstraints) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PolicyRuleImplementationConstraints",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Signers, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalOString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _PolicyRuleInjectData_requiredTypeInstances(ctx context.Context, field graphql.CollectedField, obj *PolicyRuleInjectData) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -4095,6 +4209,146 @@ func (ec *executionContext) _RulesForTypeInstance_backend(ctx context.Context, f
 	return ec.marshalNTypeInstanceBackendRule2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐTypeInstanceBackendRule(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _RunnerProgressEvent_timestamp(ctx context.Context, field graphql.CollectedField, obj *RunnerProgressEvent) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RunnerProgressEvent",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Timestamp, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(Timestamp)
+	fc.Result = res
+	return ec.marshalNTimestamp2capactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐTimestamp(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RunnerProgressEvent_message(ctx context.Context, field graphql.CollectedField, obj *RunnerProgressEvent) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RunnerProgressEvent",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Message, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RunnerProgressEvent_current(ctx context.Context, field graphql.CollectedField, obj *RunnerProgressEvent) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RunnerProgressEvent",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Current, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RunnerProgressEvent_total(ctx context.Context, field graphql.CollectedField, obj *RunnerProgressEvent) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RunnerProgressEvent",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Total, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _RunnerStatus_status(ctx context.Context, field graphql.CollectedField, obj *RunnerStatus) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -4127,6 +4381,41 @@ func (ec *executionContext) _RunnerStatus_status(ctx context.Context, field grap
 	return ec.marshalOAny2interface(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _RunnerStatus_progress(ctx context.Context, field graphql.CollectedField, obj *RunnerStatus) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "RunnerStatus",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Progress, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*RunnerProgressEvent)
+	fc.Result = res
+	return ec.marshalNRunnerProgressEvent2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRunnerProgressEventᚄ(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _TypeInstanceBackendDetails_id(ctx context.Context, field graphql.CollectedField, obj *TypeInstanceBackendDetails) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -5878,6 +6167,14 @@ func (ec *executionContext) unmarshalInputPolicyRuleImplementationConstraintsInp
 			if err != nil {
 				return it, err
 			}
+		case "signers":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("signers"))
+			it.Signers, err = ec.unmarshalOString2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -6717,6 +7014,8 @@ func (ec *executionContext) _PolicyRuleImplementationConstraints(ctx context.Con
 			out.Values[i] = ec._PolicyRuleImplementationConstraints_attributes(ctx, field, obj)
 		case "path":
 			out.Values[i] = ec._PolicyRuleImplementationConstraints_path(ctx, field, obj)
+		case "signers":
+			out.Values[i] = ec._PolicyRuleImplementationConstraints_signers(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -6918,6 +7217,48 @@ func (ec *executionContext) _RulesForTypeInstance(ctx context.Context, sel ast.S
 	return out
 }
 
+var runnerProgressEventImplementors = []string{"RunnerProgressEvent"}
+
+func (ec *executionContext) _RunnerProgressEvent(ctx context.Context, sel ast.SelectionSet, obj *RunnerProgressEvent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, runnerProgressEventImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RunnerProgressEvent")
+		case "timestamp":
+			out.Values[i] = ec._RunnerProgressEvent_timestamp(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "message":
+			out.Values[i] = ec._RunnerProgressEvent_message(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "current":
+			out.Values[i] = ec._RunnerProgressEvent_current(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "total":
+			out.Values[i] = ec._RunnerProgressEvent_total(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var runnerStatusImplementors = []string{"RunnerStatus"}
 
 func (ec *executionContext) _RunnerStatus(ctx context.Context, sel ast.SelectionSet, obj *RunnerStatus) graphql.Marshaler {
@@ -6931,6 +7272,11 @@ func (ec *executionContext) _RunnerStatus(ctx context.Context, sel ast.Selection
 			out.Values[i] = graphql.MarshalString("RunnerStatus")
 		case "status":
 			out.Values[i] = ec._RunnerStatus_status(ctx, field, obj)
+		case "progress":
+			out.Values[i] = ec._RunnerStatus_progress(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -7560,6 +7906,21 @@ func (ec *executionContext) marshalNInputTypeInstanceToProvide2ᚖcapactᚗioᚋ
 	return ec._InputTypeInstanceToProvide(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNInt2int(ctx context.Context, v interface{}) (int, error) {
+	res, err := graphql.UnmarshalInt(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.SelectionSet, v int) graphql.Marshaler {
+	res := graphql.MarshalInt(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) marshalNManifestReference2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐManifestReference(ctx context.Context, sel ast.SelectionSet, v *ManifestReference) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
@@ -7900,6 +8261,53 @@ func (ec *executionContext) unmarshalNRulesForTypeInstanceInput2ᚖcapactᚗio
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) marshalNRunnerProgressEvent2ᚕᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRunnerProgressEventᚄ(ctx context.Context, sel ast.SelectionSet, v []*RunnerProgressEvent) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNRunnerProgressEvent2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRunnerProgressEvent(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
+func (ec *executionContext) marshalNRunnerProgressEvent2ᚖcapactᚗioᚋcapactᚋpkgᚋengineᚋapiᚋgraphqlᚐRunnerProgressEvent(ctx context.Context, sel ast.SelectionSet, v *RunnerProgressEvent) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._RunnerProgressEvent(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNString2string(ctx context.Context, v interface{}) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -8778,6 +9186,42 @@ func (ec *executionContext) marshalOString2string(ctx context.Context, sel ast.S
 	return graphql.MarshalString(v)
 }
 
+func (ec *executionContext) unmarshalOString2ᚕstringᚄ(ctx context.Context, v interface{}) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNString2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOString2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNString2string(ctx, sel, v[i])
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v interface{}) (*string, error) {
 	if v == nil {
 		return nil, nil
@@ -53,6 +53,12 @@ var actionFields = fmt.Sprintf(`
 		message
 		runner {
 			status
+			progress {
+				timestamp
+				message
+				current
+				total
+			}
 		}
 		canceledBy {
 			username
@@ -98,6 +104,7 @@ const policyFields = `
 						revision
 					}
 					path
+					signers
 				}
 				inject {
 					requiredTypeInstances {
@@ -448,6 +448,27 @@ type RunnerStatus struct {
 	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Status *runtime.RawExtension `json:"status,omitempty"`
+
+	// Progress holds the latest progress events reported by the Runner, from the oldest to the newest.
+	// +optional
+	Progress []RunnerProgressEvent `json:"progress,omitempty"`
+}
+
+// RunnerProgressEvent describes incremental progress of the Runner operation.
+type RunnerProgressEvent struct {
+	// Timestamp is a time when the event was reported.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// Message is a human readable description of the progress.
+	Message string `json:"message"`
+
+	// Current is a number of completed units of work, such as workflow steps or resources.
+	// +optional
+	Current int32 `json:"current,omitempty"`
+
+	// Total is a number of all units of work. It is 0 if unknown.
+	// +optional
+	Total int32 `json:"total,omitempty"`
 }
 
 // NodePath defines full path for a given manifest, e.g. Implementation or Interface.
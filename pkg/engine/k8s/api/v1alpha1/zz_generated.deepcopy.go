@@ -484,6 +484,22 @@ func (in *ResolvedActionInput) DeepCopy() *ResolvedActionInput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerProgressEvent) DeepCopyInto(out *RunnerProgressEvent) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerProgressEvent.
+func (in *RunnerProgressEvent) DeepCopy() *RunnerProgressEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerProgressEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnerStatus) DeepCopyInto(out *RunnerStatus) {
 	*out = *in
@@ -492,6 +508,13 @@ func (in *RunnerStatus) DeepCopyInto(out *RunnerStatus) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = make([]RunnerProgressEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerStatus.
@@ -125,6 +125,9 @@ type ImplementationConstraints struct {
 
 	// Path refers a specific Implementation with exact path.
 	Path *string `json:"path,omitempty"`
+
+	// Signers refers trusted signers. The Implementation must be signed by one of them.
+	Signers *[]string `json:"signers,omitempty"`
 }
 
 // RequiredTypeInstanceToInject holds a RequiredTypeInstances to be injected to the Action.
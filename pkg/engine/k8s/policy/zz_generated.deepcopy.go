@@ -75,6 +75,15 @@ func (in *ImplementationConstraints) DeepCopyInto(out *ImplementationConstraints
 		*out = new(string)
 		**out = **in
 	}
+	if in.Signers != nil {
+		in, out := &in.Signers, &out.Signers
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImplementationConstraints.
@@ -51,6 +51,21 @@ type LockTypeInstancesInput struct {
 	OwnerID string   `json:"ownerID"`
 }
 
+type MigrateTypeInstanceBackendInput struct {
+	ID string `json:"id"`
+	// TypeInstance must be locked by a given owner during the migration.
+	OwnerID string `json:"ownerID"`
+	// The target backend. The context is passed to the target backend for the first resource version.
+	Backend *TypeInstanceBackendInput `json:"backend"`
+	// Values for all TypeInstance resource versions, read from the current backend.
+	ResourceVersions []*MigrateTypeInstanceResourceVersionInput `json:"resourceVersions"`
+}
+
+type MigrateTypeInstanceResourceVersionInput struct {
+	ResourceVersion int         `json:"resourceVersion"`
+	Value           interface{} `json:"value"`
+}
+
 type TypeInstance struct {
 	ID        string  `json:"id"`
 	CreatedAt *string `json:"createdAt"`
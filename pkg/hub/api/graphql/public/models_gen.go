@@ -139,7 +139,10 @@ type ImplementationRequirementItem struct {
 }
 
 type ImplementationRevision struct {
-	Revision   string                  `json:"revision"`
+	Revision string `json:"revision"`
+	// Identity of the trusted signer, which signed the Implementation manifest.
+	// Empty if signature verification is disabled in Hub.
+	Signer     *string                 `json:"signer"`
 	Metadata   *ImplementationMetadata `json:"metadata"`
 	Spec       *ImplementationSpec     `json:"spec"`
 	Interfaces []*InterfaceRevision    `json:"interfaces"`
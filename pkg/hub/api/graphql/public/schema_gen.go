@@ -155,6 +155,7 @@ type ComplexityRoot struct {
 		Interfaces func(childComplexity int) int
 		Metadata   func(childComplexity int) int
 		Revision   func(childComplexity int) int
+		Signer     func(childComplexity int) int
 		Spec       func(childComplexity int) int
 	}
 
@@ -810,6 +811,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ImplementationRevision.Revision(childComplexity), true
 
+	case "ImplementationRevision.signer":
+		if e.complexity.ImplementationRevision.Signer == nil {
+			break
+		}
+
+		return e.complexity.ImplementationRevision.Signer(childComplexity), true
+
 	case "ImplementationRevision.spec":
 		if e.complexity.ImplementationRevision.Spec == nil {
 			break
@@ -1933,6 +1941,11 @@ type Implementation @additionalLabels(labels: ["published"]) {
 
 type ImplementationRevision @additionalLabels(labels: ["published"]) {
   revision: Version! @index
+  """
+  Identity of the trusted signer, which signed the Implementation manifest.
+  Empty if signature verification is disabled in Hub.
+  """
+  signer: String
 
   metadata: ImplementationMetadata!
     @relation(name: "DESCRIBED_BY", direction: "OUT")
@@ -5615,6 +5628,38 @@ func (ec *executionContext) _ImplementationRevision_revision(ctx context.Context
 	return ec.marshalNVersion2string(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _ImplementationRevision_signer(ctx context.Context, field graphql.CollectedField, obj *ImplementationRevision) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ImplementationRevision",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Signer, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _ImplementationRevision_metadata(ctx context.Context, field graphql.CollectedField, obj *ImplementationRevision) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -13506,6 +13551,8 @@ func (ec *executionContext) _ImplementationRevision(ctx context.Context, sel ast
 			if out.Values[i] == graphql.Null {
 				atomic.AddUint32(&invalids, 1)
 			}
+		case "signer":
+			out.Values[i] = ec._ImplementationRevision_signer(ctx, field, obj)
 		case "metadata":
 			out.Values[i] = ec._ImplementationRevision_metadata(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -1273,6 +1273,271 @@ func (*OnUnlockResponse) Descriptor() ([]byte, []int) {
 	return file_storage_backend_proto_rawDescGZIP(), []int{22}
 }
 
+type GetInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetInfoRequest) Reset() {
+	*x = GetInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_backend_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoRequest) ProtoMessage() {}
+
+func (x *GetInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_backend_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetInfoRequest) Descriptor() ([]byte, []int) {
+	return file_storage_backend_proto_rawDescGZIP(), []int{23}
+}
+
+type SupportedOperations struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lock           bool `protobuf:"varint,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	Revisions      bool `protobuf:"varint,2,opt,name=revisions,proto3" json:"revisions,omitempty"`
+	PreCreateValue bool `protobuf:"varint,3,opt,name=pre_create_value,json=preCreateValue,proto3" json:"pre_create_value,omitempty"`
+}
+
+func (x *SupportedOperations) Reset() {
+	*x = SupportedOperations{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_backend_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SupportedOperations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupportedOperations) ProtoMessage() {}
+
+func (x *SupportedOperations) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_backend_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupportedOperations.ProtoReflect.Descriptor instead.
+func (*SupportedOperations) Descriptor() ([]byte, []int) {
+	return file_storage_backend_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SupportedOperations) GetLock() bool {
+	if x != nil {
+		return x.Lock
+	}
+	return false
+}
+
+func (x *SupportedOperations) GetRevisions() bool {
+	if x != nil {
+		return x.Revisions
+	}
+	return false
+}
+
+func (x *SupportedOperations) GetPreCreateValue() bool {
+	if x != nil {
+		return x.PreCreateValue
+	}
+	return false
+}
+
+type GetInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version             string               `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	AcceptValue         bool                 `protobuf:"varint,2,opt,name=accept_value,json=acceptValue,proto3" json:"accept_value,omitempty"`
+	ContextSchema       []byte               `protobuf:"bytes,3,opt,name=context_schema,json=contextSchema,proto3,oneof" json:"context_schema,omitempty"`
+	SupportedOperations *SupportedOperations `protobuf:"bytes,4,opt,name=supported_operations,json=supportedOperations,proto3" json:"supported_operations,omitempty"`
+}
+
+func (x *GetInfoResponse) Reset() {
+	*x = GetInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_backend_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoResponse) ProtoMessage() {}
+
+func (x *GetInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_backend_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetInfoResponse) Descriptor() ([]byte, []int) {
+	return file_storage_backend_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetInfoResponse) GetAcceptValue() bool {
+	if x != nil {
+		return x.AcceptValue
+	}
+	return false
+}
+
+func (x *GetInfoResponse) GetContextSchema() []byte {
+	if x != nil {
+		return x.ContextSchema
+	}
+	return nil
+}
+
+func (x *GetInfoResponse) GetSupportedOperations() *SupportedOperations {
+	if x != nil {
+		return x.SupportedOperations
+	}
+	return nil
+}
+
+type CheckHealthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CheckHealthRequest) Reset() {
+	*x = CheckHealthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_backend_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckHealthRequest) ProtoMessage() {}
+
+func (x *CheckHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_backend_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckHealthRequest.ProtoReflect.Descriptor instead.
+func (*CheckHealthRequest) Descriptor() ([]byte, []int) {
+	return file_storage_backend_proto_rawDescGZIP(), []int{26}
+}
+
+type CheckHealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Healthy bool    `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Message *string `protobuf:"bytes,2,opt,name=message,proto3,oneof" json:"message,omitempty"`
+}
+
+func (x *CheckHealthResponse) Reset() {
+	*x = CheckHealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_backend_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckHealthResponse) ProtoMessage() {}
+
+func (x *CheckHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_backend_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckHealthResponse.ProtoReflect.Descriptor instead.
+func (*CheckHealthResponse) Descriptor() ([]byte, []int) {
+	return file_storage_backend_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CheckHealthResponse) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *CheckHealthResponse) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
 var File_storage_backend_proto protoreflect.FileDescriptor
 
 var file_storage_backend_proto_rawDesc = []byte{
@@ -1422,7 +1687,37 @@ var file_storage_backend_proto_rawDesc = []byte{
 	0x70, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
 	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63,
 	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x12, 0x0a, 0x10, 0x4f, 0x6e, 0x55, 0x6e, 0x6c, 0x6f,
-	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xfe, 0x05, 0x0a, 0x1d, 0x56,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x71, 0x0a, 0x13,
+	0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x5f, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x70, 0x72, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0xe6, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a,
+	0x0c, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x2a, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x88, 0x01, 0x01, 0x12, 0x57, 0x0a, 0x14,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x53, 0x75, 0x70,
+	0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x13, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5a,
+	0x0a, 0x13, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12,
+	0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0a,
+	0x0a, 0x08, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xfe, 0x05, 0x0a, 0x1d, 0x56,
 	0x61, 0x6c, 0x75, 0x65, 0x41, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x53, 0x74,
 	0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x4f, 0x0a, 0x08,
 	0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x20, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61,
@@ -1521,9 +1816,20 @@ var file_storage_backend_proto_rawDesc = []byte{
 	0x64, 0x2e, 0x4f, 0x6e, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x1a, 0x21, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b,
 	0x65, 0x6e, 0x64, 0x2e, 0x4f, 0x6e, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
-	0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x6f, 0x6e, 0x73, 0x65, 0x32, 0xbc, 0x01, 0x0a, 0x12, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x4c, 0x0a, 0x07, 0x47,
+	0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x5f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0b, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x23, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x13, 0x5a, 0x11, 0x2e, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x5f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1538,7 +1844,7 @@ func file_storage_backend_proto_rawDescGZIP() []byte {
 	return file_storage_backend_proto_rawDescData
 }
 
-var file_storage_backend_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_storage_backend_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_storage_backend_proto_goTypes = []interface{}{
 	(*GetPreCreateValueRequest)(nil),               // 0: storage_backend.GetPreCreateValueRequest
 	(*GetPreCreateValueResponse)(nil),              // 1: storage_backend.GetPreCreateValueResponse
@@ -1563,47 +1869,57 @@ var file_storage_backend_proto_goTypes = []interface{}{
 	(*OnLockResponse)(nil),                         // 20: storage_backend.OnLockResponse
 	(*OnUnlockRequest)(nil),                        // 21: storage_backend.OnUnlockRequest
 	(*OnUnlockResponse)(nil),                       // 22: storage_backend.OnUnlockResponse
+	(*GetInfoRequest)(nil),                         // 23: storage_backend.GetInfoRequest
+	(*SupportedOperations)(nil),                    // 24: storage_backend.SupportedOperations
+	(*GetInfoResponse)(nil),                        // 25: storage_backend.GetInfoResponse
+	(*CheckHealthRequest)(nil),                     // 26: storage_backend.CheckHealthRequest
+	(*CheckHealthResponse)(nil),                    // 27: storage_backend.CheckHealthResponse
 }
 var file_storage_backend_proto_depIdxs = []int32{
-	15, // 0: storage_backend.ValueAndContextStorageBackend.GetValue:input_type -> storage_backend.GetValueRequest
-	3,  // 1: storage_backend.ValueAndContextStorageBackend.OnCreate:input_type -> storage_backend.OnCreateValueAndContextRequest
-	6,  // 2: storage_backend.ValueAndContextStorageBackend.OnUpdate:input_type -> storage_backend.OnUpdateValueAndContextRequest
-	9,  // 3: storage_backend.ValueAndContextStorageBackend.OnDelete:input_type -> storage_backend.OnDeleteValueAndContextRequest
-	13, // 4: storage_backend.ValueAndContextStorageBackend.OnDeleteRevision:input_type -> storage_backend.OnDeleteRevisionValueAndContextRequest
-	17, // 5: storage_backend.ValueAndContextStorageBackend.GetLockedBy:input_type -> storage_backend.GetLockedByRequest
-	19, // 6: storage_backend.ValueAndContextStorageBackend.OnLock:input_type -> storage_backend.OnLockRequest
-	21, // 7: storage_backend.ValueAndContextStorageBackend.OnUnlock:input_type -> storage_backend.OnUnlockRequest
-	0,  // 8: storage_backend.ContextStorageBackend.GetPreCreateValue:input_type -> storage_backend.GetPreCreateValueRequest
-	15, // 9: storage_backend.ContextStorageBackend.GetValue:input_type -> storage_backend.GetValueRequest
-	2,  // 10: storage_backend.ContextStorageBackend.OnCreate:input_type -> storage_backend.OnCreateRequest
-	7,  // 11: storage_backend.ContextStorageBackend.OnUpdate:input_type -> storage_backend.OnUpdateRequest
-	10, // 12: storage_backend.ContextStorageBackend.OnDelete:input_type -> storage_backend.OnDeleteRequest
-	12, // 13: storage_backend.ContextStorageBackend.OnDeleteRevision:input_type -> storage_backend.OnDeleteRevisionRequest
-	17, // 14: storage_backend.ContextStorageBackend.GetLockedBy:input_type -> storage_backend.GetLockedByRequest
-	19, // 15: storage_backend.ContextStorageBackend.OnLock:input_type -> storage_backend.OnLockRequest
-	21, // 16: storage_backend.ContextStorageBackend.OnUnlock:input_type -> storage_backend.OnUnlockRequest
-	16, // 17: storage_backend.ValueAndContextStorageBackend.GetValue:output_type -> storage_backend.GetValueResponse
-	4,  // 18: storage_backend.ValueAndContextStorageBackend.OnCreate:output_type -> storage_backend.OnCreateResponse
-	8,  // 19: storage_backend.ValueAndContextStorageBackend.OnUpdate:output_type -> storage_backend.OnUpdateResponse
-	11, // 20: storage_backend.ValueAndContextStorageBackend.OnDelete:output_type -> storage_backend.OnDeleteResponse
-	14, // 21: storage_backend.ValueAndContextStorageBackend.OnDeleteRevision:output_type -> storage_backend.OnDeleteRevisionResponse
-	18, // 22: storage_backend.ValueAndContextStorageBackend.GetLockedBy:output_type -> storage_backend.GetLockedByResponse
-	20, // 23: storage_backend.ValueAndContextStorageBackend.OnLock:output_type -> storage_backend.OnLockResponse
-	22, // 24: storage_backend.ValueAndContextStorageBackend.OnUnlock:output_type -> storage_backend.OnUnlockResponse
-	1,  // 25: storage_backend.ContextStorageBackend.GetPreCreateValue:output_type -> storage_backend.GetPreCreateValueResponse
-	16, // 26: storage_backend.ContextStorageBackend.GetValue:output_type -> storage_backend.GetValueResponse
-	4,  // 27: storage_backend.ContextStorageBackend.OnCreate:output_type -> storage_backend.OnCreateResponse
-	8,  // 28: storage_backend.ContextStorageBackend.OnUpdate:output_type -> storage_backend.OnUpdateResponse
-	11, // 29: storage_backend.ContextStorageBackend.OnDelete:output_type -> storage_backend.OnDeleteResponse
-	14, // 30: storage_backend.ContextStorageBackend.OnDeleteRevision:output_type -> storage_backend.OnDeleteRevisionResponse
-	18, // 31: storage_backend.ContextStorageBackend.GetLockedBy:output_type -> storage_backend.GetLockedByResponse
-	20, // 32: storage_backend.ContextStorageBackend.OnLock:output_type -> storage_backend.OnLockResponse
-	22, // 33: storage_backend.ContextStorageBackend.OnUnlock:output_type -> storage_backend.OnUnlockResponse
-	17, // [17:34] is the sub-list for method output_type
-	0,  // [0:17] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	24, // 0: storage_backend.GetInfoResponse.supported_operations:type_name -> storage_backend.SupportedOperations
+	15, // 1: storage_backend.ValueAndContextStorageBackend.GetValue:input_type -> storage_backend.GetValueRequest
+	3,  // 2: storage_backend.ValueAndContextStorageBackend.OnCreate:input_type -> storage_backend.OnCreateValueAndContextRequest
+	6,  // 3: storage_backend.ValueAndContextStorageBackend.OnUpdate:input_type -> storage_backend.OnUpdateValueAndContextRequest
+	9,  // 4: storage_backend.ValueAndContextStorageBackend.OnDelete:input_type -> storage_backend.OnDeleteValueAndContextRequest
+	13, // 5: storage_backend.ValueAndContextStorageBackend.OnDeleteRevision:input_type -> storage_backend.OnDeleteRevisionValueAndContextRequest
+	17, // 6: storage_backend.ValueAndContextStorageBackend.GetLockedBy:input_type -> storage_backend.GetLockedByRequest
+	19, // 7: storage_backend.ValueAndContextStorageBackend.OnLock:input_type -> storage_backend.OnLockRequest
+	21, // 8: storage_backend.ValueAndContextStorageBackend.OnUnlock:input_type -> storage_backend.OnUnlockRequest
+	0,  // 9: storage_backend.ContextStorageBackend.GetPreCreateValue:input_type -> storage_backend.GetPreCreateValueRequest
+	15, // 10: storage_backend.ContextStorageBackend.GetValue:input_type -> storage_backend.GetValueRequest
+	2,  // 11: storage_backend.ContextStorageBackend.OnCreate:input_type -> storage_backend.OnCreateRequest
+	7,  // 12: storage_backend.ContextStorageBackend.OnUpdate:input_type -> storage_backend.OnUpdateRequest
+	10, // 13: storage_backend.ContextStorageBackend.OnDelete:input_type -> storage_backend.OnDeleteRequest
+	12, // 14: storage_backend.ContextStorageBackend.OnDeleteRevision:input_type -> storage_backend.OnDeleteRevisionRequest
+	17, // 15: storage_backend.ContextStorageBackend.GetLockedBy:input_type -> storage_backend.GetLockedByRequest
+	19, // 16: storage_backend.ContextStorageBackend.OnLock:input_type -> storage_backend.OnLockRequest
+	21, // 17: storage_backend.ContextStorageBackend.OnUnlock:input_type -> storage_backend.OnUnlockRequest
+	23, // 18: storage_backend.StorageBackendInfo.GetInfo:input_type -> storage_backend.GetInfoRequest
+	26, // 19: storage_backend.StorageBackendInfo.CheckHealth:input_type -> storage_backend.CheckHealthRequest
+	16, // 20: storage_backend.ValueAndContextStorageBackend.GetValue:output_type -> storage_backend.GetValueResponse
+	4,  // 21: storage_backend.ValueAndContextStorageBackend.OnCreate:output_type -> storage_backend.OnCreateResponse
+	8,  // 22: storage_backend.ValueAndContextStorageBackend.OnUpdate:output_type -> storage_backend.OnUpdateResponse
+	11, // 23: storage_backend.ValueAndContextStorageBackend.OnDelete:output_type -> storage_backend.OnDeleteResponse
+	14, // 24: storage_backend.ValueAndContextStorageBackend.OnDeleteRevision:output_type -> storage_backend.OnDeleteRevisionResponse
+	18, // 25: storage_backend.ValueAndContextStorageBackend.GetLockedBy:output_type -> storage_backend.GetLockedByResponse
+	20, // 26: storage_backend.ValueAndContextStorageBackend.OnLock:output_type -> storage_backend.OnLockResponse
+	22, // 27: storage_backend.ValueAndContextStorageBackend.OnUnlock:output_type -> storage_backend.OnUnlockResponse
+	1,  // 28: storage_backend.ContextStorageBackend.GetPreCreateValue:output_type -> storage_backend.GetPreCreateValueResponse
+	16, // 29: storage_backend.ContextStorageBackend.GetValue:output_type -> storage_backend.GetValueResponse
+	4,  // 30: storage_backend.ContextStorageBackend.OnCreate:output_type -> storage_backend.OnCreateResponse
+	8,  // 31: storage_backend.ContextStorageBackend.OnUpdate:output_type -> storage_backend.OnUpdateResponse
+	11, // 32: storage_backend.ContextStorageBackend.OnDelete:output_type -> storage_backend.OnDeleteResponse
+	14, // 33: storage_backend.ContextStorageBackend.OnDeleteRevision:output_type -> storage_backend.OnDeleteRevisionResponse
+	18, // 34: storage_backend.ContextStorageBackend.GetLockedBy:output_type -> storage_backend.GetLockedByResponse
+	20, // 35: storage_backend.ContextStorageBackend.OnLock:output_type -> storage_backend.OnLockResponse
+	22, // 36: storage_backend.ContextStorageBackend.OnUnlock:output_type -> storage_backend.OnUnlockResponse
+	25, // 37: storage_backend.StorageBackendInfo.GetInfo:output_type -> storage_backend.GetInfoResponse
+	27, // 38: storage_backend.StorageBackendInfo.CheckHealth:output_type -> storage_backend.CheckHealthResponse
+	20, // [20:39] is the sub-list for method output_type
+	1,  // [1:20] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_storage_backend_proto_init() }
@@ -1888,6 +2204,66 @@ func file_storage_backend_proto_init() {
 				return nil
 			}
 		}
+		file_storage_backend_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storage_backend_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SupportedOperations); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storage_backend_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storage_backend_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckHealthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storage_backend_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckHealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_storage_backend_proto_msgTypes[1].OneofWrappers = []interface{}{}
 	file_storage_backend_proto_msgTypes[3].OneofWrappers = []interface{}{}
@@ -1901,15 +2277,17 @@ func file_storage_backend_proto_init() {
 	file_storage_backend_proto_msgTypes[13].OneofWrappers = []interface{}{}
 	file_storage_backend_proto_msgTypes[16].OneofWrappers = []interface{}{}
 	file_storage_backend_proto_msgTypes[18].OneofWrappers = []interface{}{}
+	file_storage_backend_proto_msgTypes[25].OneofWrappers = []interface{}{}
+	file_storage_backend_proto_msgTypes[27].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_storage_backend_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   28,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_storage_backend_proto_goTypes,
 		DependencyIndexes: file_storage_backend_proto_depIdxs,
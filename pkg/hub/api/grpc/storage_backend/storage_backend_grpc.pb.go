@@ -738,3 +738,125 @@ var ContextStorageBackend_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "storage_backend.proto",
 }
+
+// StorageBackendInfoClient is the client API for StorageBackendInfo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StorageBackendInfoClient interface {
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	CheckHealth(ctx context.Context, in *CheckHealthRequest, opts ...grpc.CallOption) (*CheckHealthResponse, error)
+}
+
+type storageBackendInfoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStorageBackendInfoClient(cc grpc.ClientConnInterface) StorageBackendInfoClient {
+	return &storageBackendInfoClient{cc}
+}
+
+func (c *storageBackendInfoClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	out := new(GetInfoResponse)
+	err := c.cc.Invoke(ctx, "/storage_backend.StorageBackendInfo/GetInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageBackendInfoClient) CheckHealth(ctx context.Context, in *CheckHealthRequest, opts ...grpc.CallOption) (*CheckHealthResponse, error) {
+	out := new(CheckHealthResponse)
+	err := c.cc.Invoke(ctx, "/storage_backend.StorageBackendInfo/CheckHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StorageBackendInfoServer is the server API for StorageBackendInfo service.
+// All implementations must embed UnimplementedStorageBackendInfoServer
+// for forward compatibility
+type StorageBackendInfoServer interface {
+	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	CheckHealth(context.Context, *CheckHealthRequest) (*CheckHealthResponse, error)
+	mustEmbedUnimplementedStorageBackendInfoServer()
+}
+
+// UnimplementedStorageBackendInfoServer must be embedded to have forward compatible implementations.
+type UnimplementedStorageBackendInfoServer struct {
+}
+
+func (UnimplementedStorageBackendInfoServer) GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (UnimplementedStorageBackendInfoServer) CheckHealth(context.Context, *CheckHealthRequest) (*CheckHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckHealth not implemented")
+}
+func (UnimplementedStorageBackendInfoServer) mustEmbedUnimplementedStorageBackendInfoServer() {}
+
+// UnsafeStorageBackendInfoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StorageBackendInfoServer will
+// result in compilation errors.
+type UnsafeStorageBackendInfoServer interface {
+	mustEmbedUnimplementedStorageBackendInfoServer()
+}
+
+func RegisterStorageBackendInfoServer(s grpc.ServiceRegistrar, srv StorageBackendInfoServer) {
+	s.RegisterService(&StorageBackendInfo_ServiceDesc, srv)
+}
+
+func _StorageBackendInfo_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageBackendInfoServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/storage_backend.StorageBackendInfo/GetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageBackendInfoServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageBackendInfo_CheckHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageBackendInfoServer).CheckHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/storage_backend.StorageBackendInfo/CheckHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageBackendInfoServer).CheckHealth(ctx, req.(*CheckHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StorageBackendInfo_ServiceDesc is the grpc.ServiceDesc for StorageBackendInfo service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StorageBackendInfo_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "storage_backend.StorageBackendInfo",
+	HandlerType: (*StorageBackendInfoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _StorageBackendInfo_GetInfo_Handler,
+		},
+		{
+			MethodName: "CheckHealth",
+			Handler:    _StorageBackendInfo_CheckHealth_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "storage_backend.proto",
+}
@@ -0,0 +1,471 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: type_instance_events.proto
+
+package type_instance_events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EventType int32
+
+const (
+	EventType_UNSPECIFIED EventType = 0
+	EventType_CREATED     EventType = 1
+	EventType_UPDATED     EventType = 2
+	EventType_LOCKED      EventType = 3
+	EventType_UNLOCKED    EventType = 4
+	EventType_DELETED     EventType = 5
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "CREATED",
+		2: "UPDATED",
+		3: "LOCKED",
+		4: "UNLOCKED",
+		5: "DELETED",
+	}
+	EventType_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"CREATED":     1,
+		"UPDATED":     2,
+		"LOCKED":      3,
+		"UNLOCKED":    4,
+		"DELETED":     5,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_type_instance_events_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_type_instance_events_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_type_instance_events_proto_rawDescGZIP(), []int{0}
+}
+
+type TypeInstanceEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type            EventType `protobuf:"varint,1,opt,name=type,proto3,enum=type_instance_events.EventType" json:"type,omitempty"`
+	TypeInstanceId  string    `protobuf:"bytes,2,opt,name=type_instance_id,json=typeInstanceId,proto3" json:"type_instance_id,omitempty"`
+	TypeRefPath     string    `protobuf:"bytes,3,opt,name=type_ref_path,json=typeRefPath,proto3" json:"type_ref_path,omitempty"`
+	TypeRefRevision string    `protobuf:"bytes,4,opt,name=type_ref_revision,json=typeRefRevision,proto3" json:"type_ref_revision,omitempty"`
+	ResourceVersion uint32    `protobuf:"varint,5,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	LockedBy        *string   `protobuf:"bytes,6,opt,name=locked_by,json=lockedBy,proto3,oneof" json:"locked_by,omitempty"`
+	// Position of the event in the Hub event log. It is 0 for events emitted for the observed resource versions.
+	Sequence uint64 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *TypeInstanceEvent) Reset() {
+	*x = TypeInstanceEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_type_instance_events_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TypeInstanceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeInstanceEvent) ProtoMessage() {}
+
+func (x *TypeInstanceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_type_instance_events_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeInstanceEvent.ProtoReflect.Descriptor instead.
+func (*TypeInstanceEvent) Descriptor() ([]byte, []int) {
+	return file_type_instance_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TypeInstanceEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_UNSPECIFIED
+}
+
+func (x *TypeInstanceEvent) GetTypeInstanceId() string {
+	if x != nil {
+		return x.TypeInstanceId
+	}
+	return ""
+}
+
+func (x *TypeInstanceEvent) GetTypeRefPath() string {
+	if x != nil {
+		return x.TypeRefPath
+	}
+	return ""
+}
+
+func (x *TypeInstanceEvent) GetTypeRefRevision() string {
+	if x != nil {
+		return x.TypeRefRevision
+	}
+	return ""
+}
+
+func (x *TypeInstanceEvent) GetResourceVersion() uint32 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+func (x *TypeInstanceEvent) GetLockedBy() string {
+	if x != nil && x.LockedBy != nil {
+		return *x.LockedBy
+	}
+	return ""
+}
+
+func (x *TypeInstanceEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type ObservedResourceVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TypeInstanceId  string `protobuf:"bytes,1,opt,name=type_instance_id,json=typeInstanceId,proto3" json:"type_instance_id,omitempty"`
+	ResourceVersion uint32 `protobuf:"varint,2,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+}
+
+func (x *ObservedResourceVersion) Reset() {
+	*x = ObservedResourceVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_type_instance_events_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ObservedResourceVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObservedResourceVersion) ProtoMessage() {}
+
+func (x *ObservedResourceVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_type_instance_events_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObservedResourceVersion.ProtoReflect.Descriptor instead.
+func (*ObservedResourceVersion) Descriptor() ([]byte, []int) {
+	return file_type_instance_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ObservedResourceVersion) GetTypeInstanceId() string {
+	if x != nil {
+		return x.TypeInstanceId
+	}
+	return ""
+}
+
+func (x *ObservedResourceVersion) GetResourceVersion() uint32 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Watch only TypeInstances with given IDs. If empty, all TypeInstances are watched.
+	TypeInstanceIds []string `protobuf:"bytes,1,rep,name=type_instance_ids,json=typeInstanceIds,proto3" json:"type_instance_ids,omitempty"`
+	// Watch only TypeInstances of a given Type.
+	TypeRefPath *string `protobuf:"bytes,2,opt,name=type_ref_path,json=typeRefPath,proto3,oneof" json:"type_ref_path,omitempty"`
+	// Resource versions already observed by the client. Newer resource versions and deletions
+	// of such TypeInstances are emitted before any live event, so the client can resume watching.
+	Observed []*ObservedResourceVersion `protobuf:"bytes,3,rep,name=observed,proto3" json:"observed,omitempty"`
+	// Sequence of the last event received by the client. If set, events stored after it are emitted
+	// before any live event, and the observed resource versions are ignored.
+	// If such events were already pruned, the call fails with the OUT_OF_RANGE status.
+	SinceSequence uint64 `protobuf:"varint,4,opt,name=since_sequence,json=sinceSequence,proto3" json:"since_sequence,omitempty"`
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_type_instance_events_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_type_instance_events_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_type_instance_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WatchRequest) GetTypeInstanceIds() []string {
+	if x != nil {
+		return x.TypeInstanceIds
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetTypeRefPath() string {
+	if x != nil && x.TypeRefPath != nil {
+		return *x.TypeRefPath
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetObserved() []*ObservedResourceVersion {
+	if x != nil {
+		return x.Observed
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetSinceSequence() uint64 {
+	if x != nil {
+		return x.SinceSequence
+	}
+	return 0
+}
+
+var File_type_instance_events_proto protoreflect.FileDescriptor
+
+var file_type_instance_events_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x74, 0x79,
+	0x70, 0x65, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x22, 0xb9, 0x02, 0x0a, 0x11, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a,
+	0x10, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x79, 0x70, 0x65, 0x5f,
+	0x72, 0x65, 0x66, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x74, 0x79, 0x70, 0x65, 0x52, 0x65, 0x66, 0x50, 0x61, 0x74, 0x68, 0x12, 0x2a, 0x0a, 0x11, 0x74,
+	0x79, 0x70, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x79, 0x70, 0x65, 0x52, 0x65, 0x66, 0x52,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x42,
+	0x79, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x22, 0x6e,
+	0x0a, 0x17, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xe7,
+	0x01, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2a, 0x0a, 0x11, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x79, 0x70, 0x65,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x0d, 0x74,
+	0x79, 0x70, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x52, 0x65, 0x66, 0x50, 0x61, 0x74,
+	0x68, 0x88, 0x01, 0x01, 0x12, 0x49, 0x0a, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x12,
+	0x25, 0x0a, 0x0e, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x53, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f,
+	0x72, 0x65, 0x66, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x2a, 0x5d, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08,
+	0x55, 0x4e, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45,
+	0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x05, 0x32, 0x6c, 0x0a, 0x12, 0x54, 0x79, 0x70, 0x65, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x56, 0x0a,
+	0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x22, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x18, 0x5a, 0x16, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x5f,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_type_instance_events_proto_rawDescOnce sync.Once
+	file_type_instance_events_proto_rawDescData = file_type_instance_events_proto_rawDesc
+)
+
+func file_type_instance_events_proto_rawDescGZIP() []byte {
+	file_type_instance_events_proto_rawDescOnce.Do(func() {
+		file_type_instance_events_proto_rawDescData = protoimpl.X.CompressGZIP(file_type_instance_events_proto_rawDescData)
+	})
+	return file_type_instance_events_proto_rawDescData
+}
+
+var file_type_instance_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_type_instance_events_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_type_instance_events_proto_goTypes = []interface{}{
+	(EventType)(0),                  // 0: type_instance_events.EventType
+	(*TypeInstanceEvent)(nil),       // 1: type_instance_events.TypeInstanceEvent
+	(*ObservedResourceVersion)(nil), // 2: type_instance_events.ObservedResourceVersion
+	(*WatchRequest)(nil),            // 3: type_instance_events.WatchRequest
+}
+var file_type_instance_events_proto_depIdxs = []int32{
+	0, // 0: type_instance_events.TypeInstanceEvent.type:type_name -> type_instance_events.EventType
+	2, // 1: type_instance_events.WatchRequest.observed:type_name -> type_instance_events.ObservedResourceVersion
+	3, // 2: type_instance_events.TypeInstanceEvents.Watch:input_type -> type_instance_events.WatchRequest
+	1, // 3: type_instance_events.TypeInstanceEvents.Watch:output_type -> type_instance_events.TypeInstanceEvent
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_type_instance_events_proto_init() }
+func file_type_instance_events_proto_init() {
+	if File_type_instance_events_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_type_instance_events_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TypeInstanceEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_type_instance_events_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ObservedResourceVersion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_type_instance_events_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_type_instance_events_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_type_instance_events_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_type_instance_events_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_type_instance_events_proto_goTypes,
+		DependencyIndexes: file_type_instance_events_proto_depIdxs,
+		EnumInfos:         file_type_instance_events_proto_enumTypes,
+		MessageInfos:      file_type_instance_events_proto_msgTypes,
+	}.Build()
+	File_type_instance_events_proto = out.File
+	file_type_instance_events_proto_rawDesc = nil
+	file_type_instance_events_proto_goTypes = nil
+	file_type_instance_events_proto_depIdxs = nil
+}
@@ -0,0 +1,132 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: type_instance_events.proto
+
+package type_instance_events
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// TypeInstanceEventsClient is the client API for TypeInstanceEvents service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TypeInstanceEventsClient interface {
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (TypeInstanceEvents_WatchClient, error)
+}
+
+type typeInstanceEventsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTypeInstanceEventsClient(cc grpc.ClientConnInterface) TypeInstanceEventsClient {
+	return &typeInstanceEventsClient{cc}
+}
+
+func (c *typeInstanceEventsClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (TypeInstanceEvents_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TypeInstanceEvents_ServiceDesc.Streams[0], "/type_instance_events.TypeInstanceEvents/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &typeInstanceEventsWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TypeInstanceEvents_WatchClient interface {
+	Recv() (*TypeInstanceEvent, error)
+	grpc.ClientStream
+}
+
+type typeInstanceEventsWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *typeInstanceEventsWatchClient) Recv() (*TypeInstanceEvent, error) {
+	m := new(TypeInstanceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TypeInstanceEventsServer is the server API for TypeInstanceEvents service.
+// All implementations must embed UnimplementedTypeInstanceEventsServer
+// for forward compatibility
+type TypeInstanceEventsServer interface {
+	Watch(*WatchRequest, TypeInstanceEvents_WatchServer) error
+	mustEmbedUnimplementedTypeInstanceEventsServer()
+}
+
+// UnimplementedTypeInstanceEventsServer must be embedded to have forward compatible implementations.
+type UnimplementedTypeInstanceEventsServer struct {
+}
+
+func (UnimplementedTypeInstanceEventsServer) Watch(*WatchRequest, TypeInstanceEvents_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedTypeInstanceEventsServer) mustEmbedUnimplementedTypeInstanceEventsServer() {}
+
+// UnsafeTypeInstanceEventsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TypeInstanceEventsServer will
+// result in compilation errors.
+type UnsafeTypeInstanceEventsServer interface {
+	mustEmbedUnimplementedTypeInstanceEventsServer()
+}
+
+func RegisterTypeInstanceEventsServer(s grpc.ServiceRegistrar, srv TypeInstanceEventsServer) {
+	s.RegisterService(&TypeInstanceEvents_ServiceDesc, srv)
+}
+
+func _TypeInstanceEvents_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TypeInstanceEventsServer).Watch(m, &typeInstanceEventsWatchServer{stream})
+}
+
+type TypeInstanceEvents_WatchServer interface {
+	Send(*TypeInstanceEvent) error
+	grpc.ServerStream
+}
+
+type typeInstanceEventsWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *typeInstanceEventsWatchServer) Send(m *TypeInstanceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// TypeInstanceEvents_ServiceDesc is the grpc.ServiceDesc for TypeInstanceEvents service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TypeInstanceEvents_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "type_instance_events.TypeInstanceEvents",
+	HandlerType: (*TypeInstanceEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _TypeInstanceEvents_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "type_instance_events.proto",
+}
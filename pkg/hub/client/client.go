@@ -28,6 +28,7 @@ type Local interface {
 	DeleteTypeInstance(ctx context.Context, id string) error
 	LockTypeInstances(ctx context.Context, in *hublocalgraphql.LockTypeInstancesInput) error
 	UnlockTypeInstances(ctx context.Context, in *hublocalgraphql.UnlockTypeInstancesInput) error
+	MigrateTypeInstanceBackend(ctx context.Context, in *hublocalgraphql.MigrateTypeInstanceBackendInput) error
 	UpdateTypeInstances(ctx context.Context, in []hublocalgraphql.UpdateTypeInstancesInput, opts ...local.TypeInstancesOption) ([]hublocalgraphql.TypeInstance, error)
 	FindTypeInstancesTypeRef(ctx context.Context, ids []string) (map[string]hublocalgraphql.TypeInstanceTypeReference, error)
 	FindTypeInstances(ctx context.Context, ids []string, opts ...local.TypeInstancesOption) (map[string]hublocalgraphql.TypeInstance, error)
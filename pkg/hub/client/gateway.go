@@ -0,0 +1,30 @@
+package client
+
+import (
+	"capact.io/capact/pkg/httputil"
+)
+
+// GatewayConfig holds configuration for calling the Capact Hubs through the Gateway.
+type GatewayConfig struct {
+	// Endpoint is the Gateway GraphQL endpoint. If empty, the Hubs are called directly.
+	Endpoint string `envconfig:"optional"`
+	Username string `envconfig:"optional"`
+	Password string `envconfig:"optional"`
+}
+
+// Enabled returns true if the Hubs should be called through the Gateway.
+func (c GatewayConfig) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+// ClientOptions returns the HTTP client options with the Gateway credentials.
+func (c GatewayConfig) ClientOptions() []httputil.ClientOption {
+	return []httputil.ClientOption{
+		httputil.WithBasicAuth(c.Username, c.Password),
+	}
+}
+
+// NewForGateway returns a new Client to interact with the Capact Local and Public Hub through the Gateway.
+func NewForGateway(cfg GatewayConfig) *Client {
+	return New(cfg.Endpoint, httputil.NewClient(cfg.ClientOptions()...))
+}
@@ -342,3 +342,23 @@ func (c *Client) UnlockTypeInstances(ctx context.Context, in *hublocalgraphql.Un
 
 	return nil
 }
+
+// MigrateTypeInstanceBackend stores all resource versions of a given TypeInstance in the target backend
+// and switches the TypeInstance backend reference. The TypeInstance must be locked by the input owner.
+func (c *Client) MigrateTypeInstanceBackend(ctx context.Context, in *hublocalgraphql.MigrateTypeInstanceBackendInput) error {
+	query := `mutation MigrateTypeInstanceBackend($in: MigrateTypeInstanceBackendInput!) {
+		migrateTypeInstanceBackend(in: $in)
+	}`
+
+	req := graphql.NewRequest(query)
+	req.Var("in", in)
+
+	err := retry.Do(func() error {
+		return c.client.Run(ctx, req, nil)
+	}, retry.Attempts(retryAttempts))
+	if err != nil {
+		return errors.Wrap(err, "while executing mutation to migrate TypeInstance backend")
+	}
+
+	return nil
+}
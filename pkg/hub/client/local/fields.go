@@ -16,6 +16,7 @@ var typeInstancesFieldsRegistry = map[TypeInstancesQueryFields]string{
 	TypeInstanceAllFields:                         typeInstanceAllFields,
 	TypeInstanceUsesAllFields:                     typeInstanceUsesAllFields,
 	TypeInstanceUsedByAllFields:                   typeInstanceUsedByAllFields,
+	TypeInstanceResourceVersionsBackendFields:     resourceVersionsBackendFields,
 	// grow the extracted fields if needed
 }
 
@@ -58,6 +59,16 @@ var (
 				}
 			}`
 
+	resourceVersionsBackendFields = `
+			resourceVersions {
+				resourceVersion
+				spec {
+					backend {
+						context
+					}
+				}
+			}`
+
 	latestResourceVersionFields = fmt.Sprintf(`
 			latestResourceVersion {
 				%s
@@ -30,6 +30,9 @@ const (
 	TypeInstanceUsesAllFields
 	// TypeInstanceUsedByAllFields returns TypeInstance's UsedBy field.
 	TypeInstanceUsedByAllFields
+	// TypeInstanceResourceVersionsBackendFields returns backend contexts of all TypeInstance's resource versions.
+	// Values are not returned, so the Local Hub doesn't fetch them from the storage backend.
+	TypeInstanceResourceVersionsBackendFields
 
 	typeInstanceMaxKey
 )
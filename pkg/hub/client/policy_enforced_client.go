@@ -364,6 +364,7 @@ func (e *PolicyEnforcedClient) findImplementationsForRules(
 			ctx,
 			interfaceRef,
 			public.WithFilter(filter),
+			public.WithSigners(signersForPolicyRule(rule)),
 			public.WithSortingByPathAscAndRevisionDesc,
 		)
 		if err != nil {
@@ -456,6 +457,13 @@ func (e *PolicyEnforcedClient) hubFilterForPolicyRule(rule policy.Rule, allTypeI
 	return filter
 }
 
+func signersForPolicyRule(rule policy.Rule) []string {
+	if rule.ImplementationConstraints.Signers == nil {
+		return nil
+	}
+	return *rule.ImplementationConstraints.Signers
+}
+
 func (e *PolicyEnforcedClient) listAllTypeInstanceValues(ctx context.Context) ([]*hubpublicgraphql.TypeInstanceValue, error) {
 	currentTypeInstancesTypeRef, err := e.hubCli.ListTypeInstancesTypeRef(ctx)
 	if err != nil {
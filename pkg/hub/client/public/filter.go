@@ -19,6 +19,7 @@ func FilterImplementationRevisions(revs []gqlpublicapi.ImplementationRevision, o
 	revs = filterImplementationRevisionsByAttr(revs, opts.attrFilter)
 	revs = filterImplementationRevisionsByRequirementsSatisfiedBy(revs, opts.requirementsSatisfiedBy, opts.requiredTIInjectionSatisfiedBy)
 	revs = filterImplementationRevisionsByRequires(revs, opts.requires)
+	revs = filterImplementationRevisionsBySigners(revs, opts.signers)
 
 	return revs
 }
@@ -40,6 +41,25 @@ func filterImplementationRevisionsByPathPattern(revs []gqlpublicapi.Implementati
 	return out
 }
 
+func filterImplementationRevisionsBySigners(revs []gqlpublicapi.ImplementationRevision, signers map[string]struct{}) []gqlpublicapi.ImplementationRevision {
+	if len(signers) == 0 {
+		return revs
+	}
+
+	var out []gqlpublicapi.ImplementationRevision
+
+	for _, impl := range revs {
+		if impl.Signer == nil {
+			continue
+		}
+		if _, trusted := signers[*impl.Signer]; !trusted {
+			continue
+		}
+		out = append(out, impl)
+	}
+	return out
+}
+
 func filterImplementationRevisionsByRequirementsSatisfiedBy(
 	revs []gqlpublicapi.ImplementationRevision,
 	requirementsSatisfiedBy, requiredTIInjectionSatisfiedBy map[gqlpublicapi.TypeReference]struct{},
@@ -415,6 +415,33 @@ func TestImplementationPathPatternFilters(t *testing.T) {
 	}
 }
 
+func TestImplementationSignersFilters(t *testing.T) {
+	// given
+	expRevision := []gqlpublicapi.ImplementationRevision{
+		fixImplementationRevisionWithSigner("cap.implementation.db.postgres.install", "0.0.1", "capact-maintainers"),
+		fixImplementationRevisionWithSigner("cap.implementation.db.rds.install", "0.0.1", "aws-team"),
+	}
+
+	revisionToFilterOut := []gqlpublicapi.ImplementationRevision{
+		fixImplementationRevisionWithSigner("cap.implementation.db.cloudsql.install", "0.0.1", "unknown"),
+		fixImplementationRevision("cap.implementation.db.postgres.install", "0.0.2"),
+	}
+
+	getOpts := &ListImplementationRevisionsForInterfaceOptions{}
+	getOpts.Apply(WithSigners([]string{"capact-maintainers", "aws-team"}))
+
+	allRevs := append(expRevision, revisionToFilterOut...)
+
+	// when
+	gotRevs := FilterImplementationRevisions(allRevs, getOpts)
+
+	// then
+	assert.Len(t, gotRevs, len(expRevision))
+	for idx := range expRevision {
+		assert.Contains(t, gotRevs, expRevision[idx])
+	}
+}
+
 func TestImplementationRequiresFilters(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -580,6 +607,13 @@ func fixImplementationRevisionWithRequire(implPath, implRev string, req gqlpubli
 	return impl
 }
 
+func fixImplementationRevisionWithSigner(implPath, implRev, signer string) gqlpublicapi.ImplementationRevision {
+	impl := fixImplementationRevision(implPath, implRev)
+	impl.Signer = &signer
+
+	return impl
+}
+
 func fixImplementationRevisionWithAttr(implPath, implRev, attrPath, attrRev string) gqlpublicapi.ImplementationRevision {
 	impl := fixImplementationRevision(implPath, implRev)
 	impl.Metadata.Attributes = []*gqlpublicapi.AttributeRevision{
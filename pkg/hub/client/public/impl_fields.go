@@ -20,6 +20,7 @@ var implRevisionMetadataFields = fmt.Sprintf(`
 
 var implRevisionAllFields = fmt.Sprintf(`
       revision
+      signer
       %s
       spec {
         appVersion
@@ -14,6 +14,7 @@ type ListImplementationRevisionsForInterfaceOptions struct {
 	requirementsSatisfiedBy        map[gqlpublicapi.TypeReference]struct{}
 	requiredTIInjectionSatisfiedBy map[gqlpublicapi.TypeReference]struct{}
 	requires                       map[string]*string
+	signers                        map[string]struct{}
 	sortByPathAscAndRevisionDesc   bool
 }
 
@@ -96,6 +97,21 @@ func WithFilter(filter gqlpublicapi.ImplementationRevisionFilter) ListImplementa
 	}
 }
 
+// WithSigners returns an option, which filters out ImplementationRevisions not signed by any of the given signers.
+// The filter is applied on the client side, as the Public Hub doesn't support it.
+func WithSigners(signers []string) ListImplementationRevisionsForInterfaceOption {
+	return func(opt *ListImplementationRevisionsForInterfaceOptions) {
+		if len(signers) == 0 {
+			return
+		}
+
+		opt.signers = map[string]struct{}{}
+		for _, signer := range signers {
+			opt.signers[signer] = struct{}{}
+		}
+	}
+}
+
 // WithSortingByPathAscAndRevisionDesc returns an options, which ensures
 // that the returned ImplementationRevision slice will be sorted
 // in ascending order by the Implementation path
@@ -0,0 +1,312 @@
+package events
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "capact.io/capact/pkg/hub/api/grpc/type_instance_events"
+)
+
+const (
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// Handler is called for each TypeInstance event. Returning an error stops watching.
+type Handler func(ctx context.Context, event *pb.TypeInstanceEvent) error
+
+// WatcherOption is used to provide additional configuration options to the Watcher.
+type WatcherOption func(*Watcher)
+
+// WithTypeInstanceIDs watches only TypeInstances with given IDs.
+func WithTypeInstanceIDs(ids ...string) WatcherOption {
+	return func(w *Watcher) {
+		w.typeInstanceIDs = ids
+	}
+}
+
+// WithTypeRefPath watches only TypeInstances of a given Type.
+func WithTypeRefPath(path string) WatcherOption {
+	return func(w *Watcher) {
+		w.typeRefPath = &path
+	}
+}
+
+// WithObserved resumes watching from already observed TypeInstance resource versions.
+// Newer resource versions and deletions of such TypeInstances are emitted first.
+func WithObserved(observed map[string]uint32) WatcherOption {
+	return func(w *Watcher) {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		for id, rv := range observed {
+			w.observed[id] = rv
+		}
+	}
+}
+
+// WithSequence resumes watching from the sequence of the last received event.
+// Events stored after it are emitted first. If they were already pruned, Watcher resumes from the observed resource versions.
+func WithSequence(sequence uint64) WatcherOption {
+	return func(w *Watcher) {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.sequence = sequence
+	}
+}
+
+// WithBackoff sets the minimal and maximal delay between reconnection attempts.
+func WithBackoff(min, max time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		w.minBackoff = min
+		w.maxBackoff = max
+	}
+}
+
+// WithDialOptions sets the gRPC dial options. By default, an insecure connection is used, the same as in Local Hub.
+func WithDialOptions(opts ...grpc.DialOption) WatcherOption {
+	return func(w *Watcher) {
+		w.dialOpts = opts
+	}
+}
+
+// WithBasicAuth sends given credentials with each call. Local Hub requires them if the authentication is enabled.
+func WithBasicAuth(username, password string) WatcherOption {
+	return func(w *Watcher) {
+		w.callOpts = append(w.callOpts, grpc.PerRPCCredentials(basicAuth{username: username, password: password}))
+	}
+}
+
+// Watcher watches TypeInstance changes using the Local Hub events gRPC stream.
+//
+// Local Hub stores events in its database, so they are delivered regardless of the replica the Watcher is connected to.
+// If the stream is broken, Watcher reconnects and resumes from the sequence of the last received event.
+//
+// Events are pruned after the retention period. If the events to resume from were already pruned,
+// Watcher resumes from the last observed resource versions instead. Newer resource versions and deletions
+// of already observed TypeInstances are then emitted, but TypeInstances created in the meantime are not.
+type Watcher struct {
+	addr            string
+	dialOpts        []grpc.DialOption
+	callOpts        []grpc.CallOption
+	typeInstanceIDs []string
+	typeRefPath     *string
+	minBackoff      time.Duration
+	maxBackoff      time.Duration
+
+	// mu guards the resume state, which can be read while watching.
+	mu       sync.Mutex
+	observed map[string]uint32
+	sequence uint64
+}
+
+// NewWatcher returns a new Watcher instance for the Local Hub gRPC API available under a given address.
+func NewWatcher(addr string, opts ...WatcherOption) *Watcher {
+	w := &Watcher{
+		addr:       addr,
+		dialOpts:   []grpc.DialOption{grpc.WithInsecure()},
+		observed:   map[string]uint32{},
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Observed returns the last observed resource versions of watched TypeInstances.
+// It can be persisted and passed to WithObserved to resume watching after restart.
+func (w *Watcher) Observed() map[string]uint32 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	out := make(map[string]uint32, len(w.observed))
+	for id, rv := range w.observed {
+		out[id] = rv
+	}
+	return out
+}
+
+// Sequence returns the sequence of the last received event.
+// It can be persisted and passed to WithSequence to resume watching after restart.
+func (w *Watcher) Sequence() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.sequence
+}
+
+// Watch calls the handler for each TypeInstance event until the context is canceled or the handler returns an error.
+// Create and update events with an already observed resource version are skipped.
+// Lock, unlock and delete events may be delivered more than once after reconnecting.
+func (w *Watcher) Watch(ctx context.Context, handler Handler) error {
+	conn, err := grpc.DialContext(ctx, w.addr, w.dialOpts...)
+	if err != nil {
+		return errors.Wrapf(err, "while dialing %s", w.addr)
+	}
+	defer conn.Close()
+
+	cli := pb.NewTypeInstanceEventsClient(conn)
+
+	backoff := w.minBackoff
+	for {
+		received, err := w.watch(ctx, cli, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var handlerErr *handlerError
+		if errors.As(err, &handlerErr) {
+			return handlerErr.err
+		}
+		if status.Code(err) == codes.OutOfRange && w.resetSequence() {
+			// Events to resume from were pruned
+			continue
+		}
+		if !isRetryable(err) {
+			return errors.Wrap(err, "while watching TypeInstance events")
+		}
+
+		if received {
+			backoff = w.minBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > w.maxBackoff {
+			backoff = w.maxBackoff
+		}
+	}
+}
+
+// watch consumes a single stream. It returns true if any event was received.
+func (w *Watcher) watch(ctx context.Context, cli pb.TypeInstanceEventsClient, handler Handler) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := cli.Watch(ctx, w.watchRequest(), w.callOpts...)
+	if err != nil {
+		return false, err
+	}
+
+	received := false
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		if !w.observe(event) {
+			continue
+		}
+
+		if err := handler(ctx, event); err != nil {
+			return received, &handlerError{err: err}
+		}
+	}
+}
+
+func (w *Watcher) watchRequest() *pb.WatchRequest {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	req := &pb.WatchRequest{
+		TypeInstanceIds: w.typeInstanceIDs,
+		TypeRefPath:     w.typeRefPath,
+		SinceSequence:   w.sequence,
+	}
+	for id, rv := range w.observed {
+		req.Observed = append(req.Observed, &pb.ObservedResourceVersion{
+			TypeInstanceId:  id,
+			ResourceVersion: rv,
+		})
+	}
+	return req
+}
+
+// observe records a given event. It returns false if the event was already observed.
+func (w *Watcher) observe(event *pb.TypeInstanceEvent) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if event.Sequence > w.sequence {
+		w.sequence = event.Sequence
+	}
+
+	id := event.TypeInstanceId
+
+	switch event.Type {
+	case pb.EventType_CREATED, pb.EventType_UPDATED:
+		if rv, ok := w.observed[id]; ok && event.ResourceVersion <= rv {
+			return false
+		}
+		w.observed[id] = event.ResourceVersion
+	case pb.EventType_DELETED:
+		delete(w.observed, id)
+	}
+
+	return true
+}
+
+// resetSequence resumes watching from the observed resource versions. It returns false if the sequence was not set.
+func (w *Watcher) resetSequence() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.sequence == 0 {
+		return false
+	}
+	w.sequence = 0
+	return true
+}
+
+func isRetryable(err error) bool {
+	if err == io.EOF {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// basicAuth passes the basic access authentication credentials in the "authorization" metadata.
+type basicAuth struct {
+	username string
+	password string
+}
+
+func (b basicAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	auth := base64.StdEncoding.EncodeToString([]byte(b.username + ":" + b.password))
+	return map[string]string{"authorization": "Basic " + auth}, nil
+}
+
+// RequireTransportSecurity returns false, as Local Hub serves the gRPC API without TLS.
+func (basicAuth) RequireTransportSecurity() bool {
+	return false
+}
+
+type handlerError struct {
+	err error
+}
+
+func (e *handlerError) Error() string {
+	return e.err.Error()
+}
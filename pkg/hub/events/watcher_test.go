@@ -0,0 +1,311 @@
+package events_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "capact.io/capact/pkg/hub/api/grpc/type_instance_events"
+	"capact.io/capact/pkg/hub/events"
+)
+
+func TestWatcher_Watch_ResumesAfterReconnect(t *testing.T) {
+	// given
+	server := &fakeEventsServer{
+		streams: [][]*pb.TypeInstanceEvent{
+			{
+				fixEvent(pb.EventType_CREATED, "ti-1", 1),
+				fixEvent(pb.EventType_UPDATED, "ti-1", 2),
+			},
+			{
+				// replayed as changed since observed and then as a live event
+				fixEvent(pb.EventType_UPDATED, "ti-1", 2),
+				fixEvent(pb.EventType_UPDATED, "ti-1", 3),
+				fixEvent(pb.EventType_LOCKED, "ti-1", 3),
+				fixEvent(pb.EventType_DELETED, "ti-1", 3),
+			},
+		},
+	}
+	srv, listener := setupFakeEventsServer(t, server)
+	defer srv.Stop()
+
+	watcher := events.NewWatcher("bufnet",
+		events.WithTypeRefPath("cap.type.example"),
+		events.WithBackoff(time.Millisecond, time.Millisecond),
+		events.WithDialOptions(dialOptsForListener(listener)...),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []string
+	handler := func(ctx context.Context, event *pb.TypeInstanceEvent) error {
+		got = append(got, event.Type.String()+"/"+event.TypeInstanceId)
+		if event.Type == pb.EventType_DELETED {
+			cancel()
+		}
+		return nil
+	}
+
+	// when
+	err := watcher.Watch(ctx, handler)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CREATED/ti-1", "UPDATED/ti-1", "UPDATED/ti-1", "LOCKED/ti-1", "DELETED/ti-1"}, got)
+
+	requests := server.Requests()
+	require.Len(t, requests, 2)
+	assert.Empty(t, requests[0].Observed)
+	assert.Equal(t, "cap.type.example", requests[1].GetTypeRefPath())
+	require.Len(t, requests[1].Observed, 1)
+	assert.Equal(t, "ti-1", requests[1].Observed[0].TypeInstanceId)
+	assert.EqualValues(t, 2, requests[1].Observed[0].ResourceVersion)
+
+	assert.Empty(t, watcher.Observed())
+}
+
+func TestWatcher_Watch_ResumesFromSequence(t *testing.T) {
+	// given
+	server := &fakeEventsServer{
+		streams: [][]*pb.TypeInstanceEvent{
+			{
+				withSequence(fixEvent(pb.EventType_CREATED, "ti-1", 1), 1),
+				withSequence(fixEvent(pb.EventType_CREATED, "ti-2", 1), 2),
+			},
+			{},
+			{
+				fixEvent(pb.EventType_DELETED, "ti-1", 1),
+			},
+		},
+		errs: []error{
+			status.Error(codes.Unavailable, "replica is going away"),
+			status.Error(codes.OutOfRange, "events stored after sequence 2 were pruned"),
+		},
+	}
+	srv, listener := setupFakeEventsServer(t, server)
+	defer srv.Stop()
+
+	watcher := events.NewWatcher("bufnet",
+		events.WithBackoff(time.Millisecond, time.Millisecond),
+		events.WithDialOptions(dialOptsForListener(listener)...),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []string
+	handler := func(ctx context.Context, event *pb.TypeInstanceEvent) error {
+		got = append(got, event.Type.String()+"/"+event.TypeInstanceId)
+		if event.Type == pb.EventType_DELETED {
+			cancel()
+		}
+		return nil
+	}
+
+	// when
+	err := watcher.Watch(ctx, handler)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CREATED/ti-1", "CREATED/ti-2", "DELETED/ti-1"}, got)
+
+	requests := server.Requests()
+	require.Len(t, requests, 3)
+	assert.Zero(t, requests[0].SinceSequence)
+	assert.EqualValues(t, 2, requests[1].SinceSequence)
+	// pruned events, so resumed from the observed resource versions
+	assert.Zero(t, requests[2].SinceSequence)
+	assert.Len(t, requests[2].Observed, 2)
+
+	assert.EqualValues(t, 2, watcher.Sequence())
+	assert.Equal(t, map[string]uint32{"ti-2": 1}, watcher.Observed())
+}
+
+// TestWatcher_Watch_ConcurrentResumeStateReads is meaningful when run with the race detector.
+func TestWatcher_Watch_ConcurrentResumeStateReads(t *testing.T) {
+	// given
+	var stream []*pb.TypeInstanceEvent
+	for i := 1; i <= 100; i++ {
+		stream = append(stream, withSequence(fixEvent(pb.EventType_UPDATED, "ti-1", uint32(i)), uint64(i)))
+	}
+	server := &fakeEventsServer{
+		streams: [][]*pb.TypeInstanceEvent{stream},
+	}
+	srv, listener := setupFakeEventsServer(t, server)
+	defer srv.Stop()
+
+	watcher := events.NewWatcher("bufnet", events.WithDialOptions(dialOptsForListener(listener)...))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		for ctx.Err() == nil {
+			watcher.Observed()
+			watcher.Sequence()
+		}
+	}()
+
+	// when
+	err := watcher.Watch(ctx, func(_ context.Context, event *pb.TypeInstanceEvent) error {
+		if event.Sequence == 100 {
+			cancel()
+		}
+		return nil
+	})
+	<-readerDone
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint32{"ti-1": 100}, watcher.Observed())
+	assert.EqualValues(t, 100, watcher.Sequence())
+}
+
+func TestWatcher_Watch_ReturnsNonRetryableError(t *testing.T) {
+	// given
+	srv, listener := setupFakeEventsServer(t, &pb.UnimplementedTypeInstanceEventsServer{})
+	defer srv.Stop()
+
+	watcher := events.NewWatcher("bufnet", events.WithDialOptions(dialOptsForListener(listener)...))
+
+	// when
+	err := watcher.Watch(context.Background(), func(context.Context, *pb.TypeInstanceEvent) error {
+		return nil
+	})
+
+	// then
+	assert.EqualError(t, err, "while watching TypeInstance events: rpc error: code = Unimplemented desc = method Watch not implemented")
+}
+
+func TestWatcher_Watch_SendsBasicAuth(t *testing.T) {
+	// given
+	server := &fakeEventsServer{
+		streams: [][]*pb.TypeInstanceEvent{
+			{fixEvent(pb.EventType_CREATED, "ti-1", 1)},
+		},
+	}
+	srv, listener := setupFakeEventsServer(t, server)
+	defer srv.Stop()
+
+	watcher := events.NewWatcher("bufnet",
+		events.WithBasicAuth("graphql", "t0p_s3cr3t"),
+		events.WithDialOptions(dialOptsForListener(listener)...),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// when
+	err := watcher.Watch(ctx, func(context.Context, *pb.TypeInstanceEvent) error {
+		cancel()
+		return nil
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Basic Z3JhcGhxbDp0MHBfczNjcjN0"}, server.Authorization())
+}
+
+// fakeEventsServer sends the next batch of events for each stream and then breaks it
+// with a given error. By default, the Unavailable status is returned.
+type fakeEventsServer struct {
+	pb.UnimplementedTypeInstanceEventsServer
+
+	mu       sync.Mutex
+	streams  [][]*pb.TypeInstanceEvent
+	errs     []error
+	requests []*pb.WatchRequest
+	auth     []string
+}
+
+func (s *fakeEventsServer) Watch(req *pb.WatchRequest, stream pb.TypeInstanceEvents_WatchServer) error {
+	s.mu.Lock()
+	idx := len(s.requests)
+	s.requests = append(s.requests, req)
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		s.auth = append(s.auth, md.Get("authorization")...)
+	}
+	s.mu.Unlock()
+
+	if idx >= len(s.streams) {
+		<-stream.Context().Done()
+		return nil
+	}
+
+	for _, event := range s.streams[idx] {
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+
+	if idx < len(s.errs) {
+		return s.errs[idx]
+	}
+	return status.Error(codes.Unavailable, "replica is going away")
+}
+
+func (s *fakeEventsServer) Requests() []*pb.WatchRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *fakeEventsServer) Authorization() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.auth
+}
+
+func fixEvent(eventType pb.EventType, id string, rv uint32) *pb.TypeInstanceEvent {
+	return &pb.TypeInstanceEvent{
+		Type:            eventType,
+		TypeInstanceId:  id,
+		TypeRefPath:     "cap.type.example",
+		TypeRefRevision: "0.1.0",
+		ResourceVersion: rv,
+	}
+}
+
+func withSequence(event *pb.TypeInstanceEvent, sequence uint64) *pb.TypeInstanceEvent {
+	event.Sequence = sequence
+	return event
+}
+
+const bufSize = 1024 * 1024
+
+func setupFakeEventsServer(t *testing.T, handler pb.TypeInstanceEventsServer) (*grpc.Server, *bufconn.Listener) {
+	t.Helper()
+
+	listener := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	pb.RegisterTypeInstanceEventsServer(srv, handler)
+
+	go func() {
+		err := srv.Serve(listener)
+		require.NoError(t, err)
+	}()
+
+	return srv, listener
+}
+
+func dialOptsForListener(listener *bufconn.Listener) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(),
+	}
+}
@@ -0,0 +1,171 @@
+package storagebackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"capact.io/capact/pkg/hub/client/local"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+)
+
+const requiredSchemaKey = "required"
+
+// Checker verifies that storage backends are healthy and match their registration in the Local Hub.
+type Checker struct {
+	hubCli   TypeInstanceFinder
+	dialOpts []grpc.DialOption
+}
+
+// NewChecker returns a new Checker instance.
+// If no dial options are provided, an insecure connection is used, the same as in Local Hub.
+func NewChecker(hubCli TypeInstanceFinder, dialOpts ...grpc.DialOption) *Checker {
+	if len(dialOpts) == 0 {
+		dialOpts = []grpc.DialOption{grpc.WithInsecure()}
+	}
+
+	return &Checker{
+		hubCli:   hubCli,
+		dialOpts: dialOpts,
+	}
+}
+
+// CheckBackend checks the storage backend registered as a TypeInstance with a given ID.
+// The built-in Hub storage is always considered as valid.
+func (c *Checker) CheckBackend(ctx context.Context, backendID string) error {
+	backendTI, err := c.hubCli.FindTypeInstance(ctx, backendID, local.WithFields(local.TypeInstanceRootFields|local.TypeInstanceTypeRefFields|local.TypeInstanceLatestResourceVersionFields))
+	if err != nil {
+		return errors.Wrap(err, "while finding backend TypeInstance")
+	}
+	if backendTI == nil {
+		return fmt.Errorf("storage backend TypeInstance %s not found", backendID)
+	}
+
+	if backendTI.TypeRef != nil && strings.HasPrefix(backendTI.TypeRef.Path, types.BuiltinHubStorageTypePath) {
+		return nil
+	}
+
+	value, err := typeInstanceValueFromBackend(backendTI)
+	if err != nil {
+		return errors.Wrapf(err, "while getting storage backend %s details", backendID)
+	}
+
+	if err := c.Check(ctx, value); err != nil {
+		return errors.Wrapf(err, "storage backend %s is misconfigured", backendID)
+	}
+
+	return nil
+}
+
+// Check compares capabilities reported by a given storage backend with its registration details and checks its health.
+// Storage backends which don't implement the StorageBackendInfo service are skipped.
+func (c *Checker) Check(ctx context.Context, value *TypeInstanceValue) error {
+	conn, err := grpc.DialContext(ctx, value.URL, c.dialOpts...)
+	if err != nil {
+		return errors.Wrapf(err, "while dialing %s", value.URL)
+	}
+	defer conn.Close()
+
+	cli := pb.NewStorageBackendInfoClient(conn)
+
+	info, err := cli.GetInfo(ctx, &pb.GetInfoRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return errors.Wrap(err, "while getting storage backend info")
+	}
+
+	if info.AcceptValue != value.AcceptValue {
+		return fmt.Errorf("backend reports acceptValue %t, but it is registered with acceptValue %t", info.AcceptValue, value.AcceptValue)
+	}
+
+	if err := checkContextSchema(info.ContextSchema, value.ContextSchema); err != nil {
+		return err
+	}
+
+	health, err := cli.CheckHealth(ctx, &pb.CheckHealthRequest{})
+	if err != nil {
+		return errors.Wrap(err, "while checking storage backend health")
+	}
+	if !health.Healthy {
+		return fmt.Errorf("backend is unhealthy: %s", health.GetMessage())
+	}
+
+	return nil
+}
+
+func checkContextSchema(reported []byte, registered interface{}) error {
+	if len(reported) == 0 || registered == nil {
+		return nil
+	}
+
+	var got interface{}
+	if err := json.Unmarshal(reported, &got); err != nil {
+		return errors.Wrap(err, "while unmarshaling reported context schema")
+	}
+
+	// normalize the registered schema, so both are compared using the JSON semantics
+	raw, err := json.Marshal(registered)
+	if err != nil {
+		return errors.Wrap(err, "while marshaling registered context schema")
+	}
+	var want interface{}
+	if err := json.Unmarshal(raw, &want); err != nil {
+		return errors.Wrap(err, "while unmarshaling registered context schema")
+	}
+
+	if !isSchemaCompatible(got, want) {
+		return errors.New("backend reports a different context schema than the registered one")
+	}
+
+	return nil
+}
+
+// isSchemaCompatible returns true if contexts valid against the registered schema are valid against the reported one.
+// The reported schema may define additional properties, e.g. added in a newer storage backend version,
+// as long as they are not required.
+func isSchemaCompatible(reported, registered interface{}) bool {
+	reportedObj, ok := reported.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(reported, registered)
+	}
+	registeredObj, ok := registered.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	registeredRequired := map[interface{}]struct{}{}
+	if required, ok := registeredObj[requiredSchemaKey].([]interface{}); ok {
+		for _, name := range required {
+			registeredRequired[name] = struct{}{}
+		}
+	}
+	if required, ok := reportedObj[requiredSchemaKey].([]interface{}); ok {
+		for _, name := range required {
+			if _, found := registeredRequired[name]; !found {
+				return false
+			}
+		}
+	}
+
+	for key, registeredVal := range registeredObj {
+		if key == requiredSchemaKey {
+			continue
+		}
+		reportedVal, found := reportedObj[key]
+		if !found || !isSchemaCompatible(reportedVal, registeredVal) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,175 @@
+package storagebackend_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	gqllocalapi "capact.io/capact/pkg/hub/api/graphql/local"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"capact.io/capact/pkg/hub/client/local"
+	storagebackend "capact.io/capact/pkg/hub/storage-backend"
+)
+
+const contextSchema = `{"type":"object","properties":{"name":{"type":"string"}}}`
+
+func TestChecker_CheckBackend(t *testing.T) {
+	tests := []struct {
+		name           string
+		registered     map[string]interface{}
+		infoServer     pb.StorageBackendInfoServer
+		expectedErrMsg string
+	}{
+		{
+			name:       "should pass for a healthy backend matching its registration",
+			registered: fixBackendValue(false),
+			infoServer: storagebackend.NewInfoServer(&pb.GetInfoResponse{
+				ContextSchema: []byte(contextSchema),
+			}, nil),
+		},
+		{
+			name:       "should skip backend without info service",
+			registered: fixBackendValue(true),
+			infoServer: &pb.UnimplementedStorageBackendInfoServer{},
+		},
+		{
+			name:       "should reject backend with different acceptValue",
+			registered: fixBackendValue(true),
+			infoServer: storagebackend.NewInfoServer(&pb.GetInfoResponse{
+				AcceptValue: false,
+			}, nil),
+			expectedErrMsg: "storage backend backend-id is misconfigured: backend reports acceptValue false, but it is registered with acceptValue true",
+		},
+		{
+			name:       "should reject backend with different context schema",
+			registered: fixBackendValue(false),
+			infoServer: storagebackend.NewInfoServer(&pb.GetInfoResponse{
+				ContextSchema: []byte(`{"type":"object"}`),
+			}, nil),
+			expectedErrMsg: "storage backend backend-id is misconfigured: backend reports a different context schema than the registered one",
+		},
+		{
+			name:       "should pass for backend with additional optional context property",
+			registered: fixBackendValue(false),
+			infoServer: storagebackend.NewInfoServer(&pb.GetInfoResponse{
+				ContextSchema: []byte(`{"type":"object","properties":{"name":{"type":"string"},"uninstallOnDelete":{"type":"boolean"}}}`),
+			}, nil),
+		},
+		{
+			name:       "should reject backend with additional required context property",
+			registered: fixBackendValue(false),
+			infoServer: storagebackend.NewInfoServer(&pb.GetInfoResponse{
+				ContextSchema: []byte(`{"type":"object","required":["namespace"],"properties":{"name":{"type":"string"},"namespace":{"type":"string"}}}`),
+			}, nil),
+			expectedErrMsg: "storage backend backend-id is misconfigured: backend reports a different context schema than the registered one",
+		},
+		{
+			name:       "should reject unhealthy backend",
+			registered: fixBackendValue(false),
+			infoServer: storagebackend.NewInfoServer(&pb.GetInfoResponse{}, func(context.Context) error {
+				return errors.New("cannot connect to Kubernetes API")
+			}),
+			expectedErrMsg: "storage backend backend-id is misconfigured: backend is unhealthy: cannot connect to Kubernetes API",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			srv, listener := setupFakeInfoServer(t, tc.infoServer)
+			defer srv.Stop()
+
+			hub := &fakeHub{
+				typeInstance: &gqllocalapi.TypeInstance{
+					ID:      "backend-id",
+					TypeRef: &gqllocalapi.TypeInstanceTypeReference{Path: "cap.type.example.storage"},
+					LatestResourceVersion: &gqllocalapi.TypeInstanceResourceVersion{
+						Spec: &gqllocalapi.TypeInstanceResourceVersionSpec{Value: tc.registered},
+					},
+				},
+			}
+			checker := storagebackend.NewChecker(hub, dialOptsForListener(listener)...)
+
+			// when
+			err := checker.CheckBackend(context.Background(), "backend-id")
+
+			// then
+			if tc.expectedErrMsg != "" {
+				assert.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestChecker_CheckBackend_SkipsBuiltinStorage(t *testing.T) {
+	// given
+	hub := &fakeHub{
+		typeInstance: &gqllocalapi.TypeInstance{
+			ID:      "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			TypeRef: &gqllocalapi.TypeInstanceTypeReference{Path: "cap.core.type.hub.storage.neo4j"},
+		},
+	}
+
+	// when
+	err := storagebackend.NewChecker(hub).CheckBackend(context.Background(), "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")
+
+	// then
+	assert.NoError(t, err)
+}
+
+type fakeHub struct {
+	typeInstance *gqllocalapi.TypeInstance
+}
+
+func (f *fakeHub) FindTypeInstance(_ context.Context, id string, _ ...local.TypeInstancesOption) (*gqllocalapi.TypeInstance, error) {
+	if f.typeInstance == nil || f.typeInstance.ID != id {
+		return nil, nil
+	}
+	return f.typeInstance, nil
+}
+
+func fixBackendValue(acceptValue bool) map[string]interface{} {
+	return map[string]interface{}{
+		"url":         "backend:50051",
+		"acceptValue": acceptValue,
+		"contextSchema": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{"type": "string"},
+			},
+			"type": "object",
+		},
+	}
+}
+
+const bufSize = 1024 * 1024
+
+func setupFakeInfoServer(t *testing.T, handler pb.StorageBackendInfoServer) (*grpc.Server, *bufconn.Listener) {
+	t.Helper()
+
+	listener := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	pb.RegisterStorageBackendInfoServer(srv, handler)
+
+	go func() {
+		err := srv.Serve(listener)
+		require.NoError(t, err)
+	}()
+
+	return srv, listener
+}
+
+func dialOptsForListener(listener *bufconn.Listener) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(),
+	}
+}
@@ -0,0 +1,59 @@
+package storagebackend
+
+import (
+	"context"
+
+	"capact.io/capact/internal/ptr"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+)
+
+// Version holds the storage backend version reported by the StorageBackendInfo service.
+// It is overridden during the build time.
+var Version = "dev"
+
+var _ pb.StorageBackendInfoServer = &InfoServer{}
+
+// HealthCheckFunc returns an error if the storage backend is not able to handle requests.
+type HealthCheckFunc func(ctx context.Context) error
+
+// InfoServer implements the StorageBackendInfo gRPC service based on static storage backend details.
+type InfoServer struct {
+	pb.UnimplementedStorageBackendInfoServer
+
+	info  *pb.GetInfoResponse
+	check HealthCheckFunc
+}
+
+// NewInfoServer returns a new InfoServer instance.
+// If the health check function is nil, the storage backend is always reported as healthy.
+func NewInfoServer(info *pb.GetInfoResponse, check HealthCheckFunc) *InfoServer {
+	if info.Version == "" {
+		info.Version = Version
+	}
+
+	return &InfoServer{
+		info:  info,
+		check: check,
+	}
+}
+
+// GetInfo returns the storage backend version, capabilities and the context JSON schema.
+func (s *InfoServer) GetInfo(_ context.Context, _ *pb.GetInfoRequest) (*pb.GetInfoResponse, error) {
+	return s.info, nil
+}
+
+// CheckHealth reports whether the storage backend is able to handle requests.
+func (s *InfoServer) CheckHealth(ctx context.Context, _ *pb.CheckHealthRequest) (*pb.CheckHealthResponse, error) {
+	if s.check == nil {
+		return &pb.CheckHealthResponse{Healthy: true}, nil
+	}
+
+	if err := s.check(ctx); err != nil {
+		return &pb.CheckHealthResponse{
+			Healthy: false,
+			Message: ptr.String(err.Error()),
+		}, nil
+	}
+
+	return &pb.CheckHealthResponse{Healthy: true}, nil
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/json"
 
-	"capact.io/capact/internal/cli/client"
 	gqllocalapi "capact.io/capact/pkg/hub/api/graphql/local"
+	"capact.io/capact/pkg/hub/client/local"
 	"github.com/pkg/errors"
 )
 
@@ -16,9 +16,13 @@ type TypeInstanceValue struct {
 	ContextSchema interface{} `json:"contextSchema"`
 }
 
+// TypeInstanceFinder finds a TypeInstance in the Local Hub.
+type TypeInstanceFinder interface {
+	FindTypeInstance(ctx context.Context, id string, opts ...local.TypeInstancesOption) (*gqllocalapi.TypeInstance, error)
+}
+
 // NewTypeInstanceValue returns a new TypeInstanceValue instance based on backend used by passed TypeInstance.
-func NewTypeInstanceValue(ctx context.Context, cli client.Hub, typeInstance *gqllocalapi.TypeInstance) (*TypeInstanceValue, error) {
-	var typeInstanceValue *TypeInstanceValue
+func NewTypeInstanceValue(ctx context.Context, cli TypeInstanceFinder, typeInstance *gqllocalapi.TypeInstance) (*TypeInstanceValue, error) {
 	if typeInstance.Backend == nil || typeInstance.Backend.Abstract {
 		return nil, nil
 	}
@@ -26,6 +30,14 @@ func NewTypeInstanceValue(ctx context.Context, cli client.Hub, typeInstance *gql
 	if err != nil {
 		return nil, errors.Wrap(err, "while finding backend TypeInstance")
 	}
+	return typeInstanceValueFromBackend(backendTI)
+}
+
+func typeInstanceValueFromBackend(backendTI *gqllocalapi.TypeInstance) (*TypeInstanceValue, error) {
+	var typeInstanceValue *TypeInstanceValue
+	if backendTI == nil || backendTI.LatestResourceVersion == nil || backendTI.LatestResourceVersion.Spec == nil {
+		return nil, errors.New("missing storage backend value")
+	}
 	valueBytes, err := json.Marshal(backendTI.LatestResourceVersion.Spec.Value)
 	if err != nil {
 		return nil, errors.Wrap(err, "while marshaling storage backend value")
@@ -0,0 +1,85 @@
+package storagebackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	"capact.io/capact/pkg/hub/client/local"
+)
+
+// ValueReader reads TypeInstance values directly from storage backends, without the Local Hub GraphQL API.
+type ValueReader struct {
+	hubCli   TypeInstanceFinder
+	dialOpts []grpc.DialOption
+}
+
+// NewValueReader returns a new ValueReader instance.
+// If no dial options are provided, an insecure connection is used, the same as in Local Hub.
+func NewValueReader(hubCli TypeInstanceFinder, dialOpts ...grpc.DialOption) *ValueReader {
+	if len(dialOpts) == 0 {
+		dialOpts = []grpc.DialOption{grpc.WithInsecure()}
+	}
+
+	return &ValueReader{
+		hubCli:   hubCli,
+		dialOpts: dialOpts,
+	}
+}
+
+// GetValue returns the value of a given TypeInstance resource version from the storage backend with a given ID.
+func (r *ValueReader) GetValue(ctx context.Context, backendID, typeInstanceID string, resourceVersion int, backendCtx interface{}) (interface{}, error) {
+	backendTI, err := r.hubCli.FindTypeInstance(ctx, backendID, local.WithFields(local.TypeInstanceRootFields|local.TypeInstanceLatestResourceVersionFields))
+	if err != nil {
+		return nil, errors.Wrap(err, "while finding backend TypeInstance")
+	}
+	if backendTI == nil {
+		return nil, fmt.Errorf("storage backend TypeInstance %s not found", backendID)
+	}
+
+	backend, err := typeInstanceValueFromBackend(backendTI)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while getting storage backend %s details", backendID)
+	}
+
+	req := &pb.GetValueRequest{
+		TypeInstanceId:  typeInstanceID,
+		ResourceVersion: uint32(resourceVersion),
+	}
+	if backendCtx != nil {
+		req.Context, err = json.Marshal(backendCtx)
+		if err != nil {
+			return nil, errors.Wrap(err, "while marshaling storage backend context")
+		}
+	}
+
+	conn, err := grpc.DialContext(ctx, backend.URL, r.dialOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while dialing %s", backend.URL)
+	}
+	defer conn.Close()
+
+	var res *pb.GetValueResponse
+	if backend.AcceptValue {
+		res, err = pb.NewValueAndContextStorageBackendClient(conn).GetValue(ctx, req)
+	} else {
+		res, err = pb.NewContextStorageBackendClient(conn).GetValue(ctx, req)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "while getting value from storage backend %s", backendID)
+	}
+	if res.Value == nil {
+		return nil, fmt.Errorf("storage backend %s returned empty value", backendID)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(res.Value, &value); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling TypeInstance value")
+	}
+
+	return value, nil
+}
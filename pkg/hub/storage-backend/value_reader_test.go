@@ -0,0 +1,99 @@
+package storagebackend_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	gqllocalapi "capact.io/capact/pkg/hub/api/graphql/local"
+	pb "capact.io/capact/pkg/hub/api/grpc/storage_backend"
+	storagebackend "capact.io/capact/pkg/hub/storage-backend"
+)
+
+func TestValueReader_GetValue(t *testing.T) {
+	// given
+	backend := &fakeContextStorageBackend{
+		values: map[uint32][]byte{
+			2: []byte(`{"name":"release"}`),
+		},
+	}
+	srv, listener := setupFakeContextStorageBackend(t, backend)
+	defer srv.Stop()
+
+	hub := &fakeHub{
+		typeInstance: &gqllocalapi.TypeInstance{
+			ID:      "backend-id",
+			TypeRef: &gqllocalapi.TypeInstanceTypeReference{Path: "cap.type.example.storage"},
+			LatestResourceVersion: &gqllocalapi.TypeInstanceResourceVersion{
+				Spec: &gqllocalapi.TypeInstanceResourceVersionSpec{Value: fixBackendValue(false)},
+			},
+		},
+	}
+	reader := storagebackend.NewValueReader(hub, dialOptsForListener(listener)...)
+
+	// when
+	value, err := reader.GetValue(context.Background(), "backend-id", "ti-id", 2, map[string]interface{}{"name": "release"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "release"}, value)
+
+	require.Len(t, backend.requests, 1)
+	assert.Equal(t, "ti-id", backend.requests[0].TypeInstanceId)
+	assert.JSONEq(t, `{"name":"release"}`, string(backend.requests[0].Context))
+}
+
+func TestValueReader_GetValue_EmptyValue(t *testing.T) {
+	// given
+	backend := &fakeContextStorageBackend{}
+	srv, listener := setupFakeContextStorageBackend(t, backend)
+	defer srv.Stop()
+
+	hub := &fakeHub{
+		typeInstance: &gqllocalapi.TypeInstance{
+			ID:      "backend-id",
+			TypeRef: &gqllocalapi.TypeInstanceTypeReference{Path: "cap.type.example.storage"},
+			LatestResourceVersion: &gqllocalapi.TypeInstanceResourceVersion{
+				Spec: &gqllocalapi.TypeInstanceResourceVersionSpec{Value: fixBackendValue(false)},
+			},
+		},
+	}
+	reader := storagebackend.NewValueReader(hub, dialOptsForListener(listener)...)
+
+	// when
+	_, err := reader.GetValue(context.Background(), "backend-id", "ti-id", 1, nil)
+
+	// then
+	assert.EqualError(t, err, "storage backend backend-id returned empty value")
+}
+
+type fakeContextStorageBackend struct {
+	pb.UnimplementedContextStorageBackendServer
+
+	values   map[uint32][]byte
+	requests []*pb.GetValueRequest
+}
+
+func (f *fakeContextStorageBackend) GetValue(_ context.Context, req *pb.GetValueRequest) (*pb.GetValueResponse, error) {
+	f.requests = append(f.requests, req)
+	return &pb.GetValueResponse{Value: f.values[req.ResourceVersion]}, nil
+}
+
+func setupFakeContextStorageBackend(t *testing.T, handler pb.ContextStorageBackendServer) (*grpc.Server, *bufconn.Listener) {
+	t.Helper()
+
+	listener := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	pb.RegisterContextStorageBackendServer(srv, handler)
+
+	go func() {
+		err := srv.Serve(listener)
+		require.NoError(t, err)
+	}()
+
+	return srv, listener
+}
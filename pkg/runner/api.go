@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 type (
@@ -55,3 +56,22 @@ type Runner interface {
 type StatusReporter interface {
 	Report(ctx context.Context, runnerCtx Context, status interface{}) error
 }
+
+// ProgressEvent describes a single step of the runner execution.
+type ProgressEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+	// Current is the number of the completed steps. It is optional.
+	Current int `json:"current,omitempty"`
+	// Total is the number of all steps, if known. It is optional.
+	Total int `json:"total,omitempty"`
+}
+
+// ProgressReporter provide functionality to report runner progress.
+// It is an optional extension of the StatusReporter.
+type ProgressReporter interface {
+	ReportProgress(ctx context.Context, runnerCtx Context, event ProgressEvent) error
+}
+
+// ProgressFunc is called by Runner to report its progress.
+type ProgressFunc func(ctx context.Context, event ProgressEvent)
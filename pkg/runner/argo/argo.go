@@ -3,6 +3,8 @@ package argo
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"capact.io/capact/pkg/runner"
 
@@ -36,11 +38,15 @@ type (
 	}
 )
 
-var _ runner.Runner = &Runner{}
+var (
+	_ runner.Runner           = &Runner{}
+	_ runner.ProgressInjector = &Runner{}
+)
 
 // Runner provides functionality to run and wait for Argo Workflow.
 type Runner struct {
-	wfClientset wfclientset.Interface
+	wfClientset    wfclientset.Interface
+	reportProgress runner.ProgressFunc
 }
 
 // NewRunner returns new instance of Argo Runner.
@@ -108,6 +114,7 @@ func (r *Runner) WaitForCompletion(ctx context.Context, in runner.WaitForComplet
 		},
 	}
 
+	var lastProgress runner.ProgressEvent
 	workflowCompleted := func(event watch.Event) (bool, error) {
 		switch event.Type {
 		case watch.Modified, watch.Added:
@@ -119,6 +126,11 @@ func (r *Runner) WaitForCompletion(ctx context.Context, in runner.WaitForComplet
 		}
 
 		status, _ := statusFromEvent(&event)
+		if progress, ok := progressFromStatus(status); ok && !sameProgress(progress, lastProgress) {
+			lastProgress = progress
+			r.progress(ctx, progress)
+		}
+
 		if !status.FinishedAt.IsZero() {
 			return true, nil
 		}
@@ -142,6 +154,50 @@ func (r *Runner) WaitForCompletion(ctx context.Context, in runner.WaitForComplet
 	}, nil
 }
 
+// InjectProgressFunc injects the function used to report the Argo Workflow progress.
+func (r *Runner) InjectProgressFunc(fn runner.ProgressFunc) {
+	r.reportProgress = fn
+}
+
+func (r *Runner) progress(ctx context.Context, event runner.ProgressEvent) {
+	if r.reportProgress == nil {
+		return
+	}
+	r.reportProgress(ctx, event)
+}
+
+// progressFromStatus returns the progress event based on the Argo Workflow progress and currently running steps.
+func progressFromStatus(status wfv1.WorkflowStatus) (runner.ProgressEvent, bool) {
+	var running []string
+	for _, node := range status.Nodes {
+		if node.Type != wfv1.NodeTypePod || node.Phase != wfv1.NodeRunning {
+			continue
+		}
+		running = append(running, node.DisplayName)
+	}
+	sort.Strings(running)
+
+	event := runner.ProgressEvent{
+		Message: status.Message,
+	}
+	if status.Progress.IsValid() {
+		event.Current = int(status.Progress.N())
+		event.Total = int(status.Progress.M())
+	}
+	if len(running) > 0 {
+		event.Message = fmt.Sprintf("Running steps: %s", strings.Join(running, ", "))
+	}
+
+	if event.Message == "" && event.Total == 0 {
+		return runner.ProgressEvent{}, false
+	}
+	return event, true
+}
+
+func sameProgress(a, b runner.ProgressEvent) bool {
+	return a.Message == b.Message && a.Current == b.Current && a.Total == b.Total
+}
+
 func statusFromEvent(event *watch.Event) (wfv1.WorkflowStatus, error) {
 	if event == nil {
 		return wfv1.WorkflowStatus{}, errors.New("got nil event")
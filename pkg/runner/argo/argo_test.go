@@ -251,3 +251,56 @@ func (c *WrapRESTClientset) ArgoprojV1alpha1() argoprojv1alpha1.ArgoprojV1alpha1
 func emptyBody() io.ReadCloser {
 	return ioutil.NopCloser(bytes.NewReader([]byte("{}")))
 }
+
+func TestProgressFromStatus(t *testing.T) {
+	tests := map[string]struct {
+		status        wfv1.WorkflowStatus
+		expectedEvent runner.ProgressEvent
+		expectedOK    bool
+	}{
+		"Should report running steps and progress": {
+			status: wfv1.WorkflowStatus{
+				Progress: "1/3",
+				Nodes: wfv1.Nodes{
+					"wf":   {Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeRunning, DisplayName: "wf"},
+					"wf-1": {Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, DisplayName: "install-db"},
+					"wf-2": {Type: wfv1.NodeTypePod, Phase: wfv1.NodeRunning, DisplayName: "render-config"},
+					"wf-3": {Type: wfv1.NodeTypePod, Phase: wfv1.NodeRunning, DisplayName: "install-app"},
+				},
+			},
+			expectedEvent: runner.ProgressEvent{
+				Message: "Running steps: install-app, render-config",
+				Current: 1,
+				Total:   3,
+			},
+			expectedOK: true,
+		},
+		"Should report workflow message if no steps are running": {
+			status: wfv1.WorkflowStatus{
+				Progress: "3/3",
+				Message:  "Stopped with strategy 'Terminate'",
+			},
+			expectedEvent: runner.ProgressEvent{
+				Message: "Stopped with strategy 'Terminate'",
+				Current: 3,
+				Total:   3,
+			},
+			expectedOK: true,
+		},
+		"Should skip empty status": {
+			status:     wfv1.WorkflowStatus{},
+			expectedOK: false,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// when
+			event, ok := progressFromStatus(tc.status)
+
+			// then
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedEvent, event)
+		})
+	}
+}
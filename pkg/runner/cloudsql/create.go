@@ -3,10 +3,6 @@ package cloudsql
 import (
 	"context"
 	"fmt"
-	"html/template"
-	"io/ioutil"
-	"os"
-	"path/filepath"
 	"time"
 
 	"capact.io/capact/pkg/runner"
@@ -16,19 +12,18 @@ import (
 	"github.com/sethvargo/go-password/password"
 	"go.uber.org/zap"
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
-	"sigs.k8s.io/yaml"
 )
 
 type createAction struct {
-	logger          *zap.Logger
-	sqladminService *sqladmin.Service
-	gcpProjectName  string
-	args            *Args
-	dbInstance      *sqladmin.DatabaseInstance
-	outputCfg       OutputConfig
+	logger         *zap.Logger
+	api            SQLAdmin
+	gcpProjectName string
+	args           *Args
+	dbInstance     *sqladmin.DatabaseInstance
+	outputCfg      OutputConfig
 }
 
-func (a *createAction) Start(_ context.Context, in *runner.StartInput) (*runner.StartOutput, error) {
+func (a *createAction) Start(ctx context.Context, in *runner.StartInput) (*runner.StartOutput, error) {
 	var err error
 
 	a.dbInstance, err = a.prepareCreateDatabaseInstanceParameters(&in.RunnerCtx, a.args)
@@ -39,7 +34,7 @@ func (a *createAction) Start(_ context.Context, in *runner.StartInput) (*runner.
 	a.logger = a.logger.With(zap.String("instanceName", a.dbInstance.Name))
 	a.logger.Info("creating database")
 
-	err = a.createDatabaseInstance(a.dbInstance)
+	err = a.createDatabaseInstance(ctx, a.dbInstance)
 	if err != nil {
 		return nil, errors.Wrap(err, "while creating database instance")
 	}
@@ -67,7 +62,7 @@ func (a *createAction) WaitForCompletion(ctx context.Context, _ runner.WaitForCo
 		Password:      a.dbInstance.RootPassword,
 	}
 
-	if err := a.createOutputFiles(a.outputCfg, &a.args.Output, output); err != nil {
+	if err := createOutputFiles(a.logger, a.outputCfg, &a.args.Output, output); err != nil {
 		return nil, errors.Wrap(err, "while writing output")
 	}
 
@@ -98,8 +93,8 @@ func (a *createAction) prepareCreateDatabaseInstanceParameters(runnerCtx *runner
 	return &instance, nil
 }
 
-func (a *createAction) createDatabaseInstance(instance *sqladmin.DatabaseInstance) error {
-	_, err := a.sqladminService.Instances.Insert(a.gcpProjectName, instance).Do()
+func (a *createAction) createDatabaseInstance(ctx context.Context, instance *sqladmin.DatabaseInstance) error {
+	_, err := a.api.InsertInstance(ctx, a.gcpProjectName, instance)
 	return err
 }
 
@@ -108,7 +103,7 @@ func (a *createAction) waitForDatabaseInstanceRunning(ctx context.Context, insta
 		select {
 		case <-time.After(createWaitDelay):
 			a.logger.Debug("checking db instance status")
-			db, err := a.getDatabaseInstance(instanceName)
+			db, err := a.api.GetInstance(ctx, a.gcpProjectName, instanceName)
 			if err != nil {
 				return nil, errors.Wrap(err, "while getting DB instance")
 			}
@@ -121,74 +116,3 @@ func (a *createAction) waitForDatabaseInstanceRunning(ctx context.Context, insta
 		}
 	}
 }
-
-func (a *createAction) getDatabaseInstance(name string) (*sqladmin.DatabaseInstance, error) {
-	return a.sqladminService.Instances.Get(a.gcpProjectName, name).Do()
-}
-
-func (a *createAction) createOutputFiles(cfg OutputConfig, args *OutputArgs, values *createOutputValues) error {
-	if err := a.createCloudSQLInstanceOutputFile(cfg.CloudSQLInstanceFilePath, values); err != nil {
-		return errors.Wrap(err, "while creating default artifact")
-	}
-
-	if args.GoTemplate == nil {
-		return nil
-	}
-
-	if err := a.createAdditionalOutputFile(cfg.AdditionalFilePath, args, values); err != nil {
-		return errors.Wrap(err, "while creating additional artifact")
-	}
-
-	return nil
-}
-
-func (a *createAction) createCloudSQLInstanceOutputFile(path string, output *createOutputValues) error {
-	artifact := &cloudSQLOutput{
-		Name:            output.DBInstance.Name,
-		Project:         output.DBInstance.Project,
-		Region:          output.DBInstance.Region,
-		DatabaseVersion: output.DBInstance.DatabaseVersion,
-	}
-
-	data, err := yaml.Marshal(artifact)
-	if err != nil {
-		return errors.Wrap(err, "while marshaling artifact to YAML")
-	}
-
-	if err := ioutil.WriteFile(path, data, artifactsFileMode); err != nil {
-		return errors.Wrapf(err, "while writing artifact file %s", path)
-	}
-
-	return nil
-}
-
-func (a *createAction) createAdditionalOutputFile(path string, args *OutputArgs, values *createOutputValues) error {
-	// yaml.Unmarshal converts YAML to JSON then uses JSON to unmarshal into an object
-	// but the GoTemplate is defined via YAML, so we need to revert that change
-	artifactTemplate, err := yaml.JSONToYAML(args.GoTemplate)
-	if err != nil {
-		return errors.Wrap(err, "while converting GoTemplate property from JSON to YAML")
-	}
-
-	tmpl, err := template.New("output").Parse(string(artifactTemplate))
-	if err != nil {
-		return errors.Wrap(err, "failed to load template")
-	}
-
-	fd, err := os.Create(filepath.Clean(path))
-	if err != nil {
-		return errors.Wrap(err, "cannot open output file to write")
-	}
-	defer func() {
-		if err := fd.Close(); err != nil {
-			a.logger.Error("failed to close output file descriptor", zap.Error(err))
-		}
-	}()
-
-	err = tmpl.Execute(fd, values)
-	if err != nil {
-		return errors.Wrap(err, "failed to render output file")
-	}
-
-	return nil
-}
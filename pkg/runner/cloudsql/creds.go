@@ -16,6 +16,11 @@ type GCPConfig struct {
 		Filepath   string            `envconfig:"default=/etc/gcp/sa.json"`
 		FileFormat CredentialsFormat `envconfig:"default=json"`
 	}
+	// APIEndpoint overrides the Cloud SQL Admin API endpoint, for example to use a local fake server.
+	// If set, the requests are not authenticated and the ProjectID is required.
+	APIEndpoint string `envconfig:"optional"`
+	// ProjectID overrides the GCP project from the Service Account credentials.
+	ProjectID string `envconfig:"optional"`
 }
 
 // CredentialsFormat represents the possible credentials format. It works with the envconfig library.
@@ -0,0 +1,124 @@
+package cloudsql
+
+import (
+	"context"
+	"fmt"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"github.com/sethvargo/go-password/password"
+	"go.uber.org/zap"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+type createDatabaseAction struct {
+	logger    *zap.Logger
+	api       SQLAdmin
+	instance  *cloudSQLOutput
+	args      *Args
+	outputCfg OutputConfig
+	operation *sqladmin.Operation
+}
+
+func (a *createDatabaseAction) Start(ctx context.Context, _ *runner.StartInput) (*runner.StartOutput, error) {
+	if a.args.Database.Name == "" {
+		return nil, errors.New("database name is required")
+	}
+
+	a.logger = a.logger.With(zap.String("instanceName", a.instance.Name), zap.String("database", a.args.Database.Name))
+	a.logger.Info("creating database")
+
+	db := a.args.Database
+	op, err := a.api.InsertDatabase(ctx, a.instance.Project, a.instance.Name, &db)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating database")
+	}
+	a.operation = op
+
+	return &runner.StartOutput{
+		Status: "Creating database",
+	}, nil
+}
+
+func (a *createDatabaseAction) WaitForCompletion(ctx context.Context, _ runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
+	a.logger.Info("waiting for database to be created")
+	if err := waitForOperation(ctx, a.logger, a.api, a.instance.Project, a.operation); err != nil {
+		return nil, errors.Wrap(err, "while waiting for database creation")
+	}
+
+	output := &databaseOutputValues{
+		Instance: a.instance,
+		Database: &a.args.Database,
+	}
+
+	if err := createAdditionalOutputFile(a.logger, a.outputCfg.AdditionalFilePath, &a.args.Output, output); err != nil {
+		return nil, errors.Wrap(err, "while creating additional artifact")
+	}
+
+	return &runner.WaitForCompletionOutput{
+		Succeeded: true,
+		Message:   fmt.Sprintf("Created database %s in %s", a.args.Database.Name, a.instance.Name),
+	}, nil
+}
+
+type createUserAction struct {
+	logger    *zap.Logger
+	api       SQLAdmin
+	instance  *cloudSQLOutput
+	args      *Args
+	outputCfg OutputConfig
+	user      *sqladmin.User
+	operation *sqladmin.Operation
+}
+
+func (a *createUserAction) Start(ctx context.Context, _ *runner.StartInput) (*runner.StartOutput, error) {
+	if a.args.User.Name == "" {
+		return nil, errors.New("user name is required")
+	}
+
+	user := a.args.User
+	if user.Password == "" {
+		passwd, err := password.Generate(16, 4, 4, false, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "while generating random user password")
+		}
+		user.Password = passwd
+	}
+	a.user = &user
+
+	a.logger = a.logger.With(zap.String("instanceName", a.instance.Name), zap.String("user", user.Name))
+	a.logger.Info("creating user")
+
+	op, err := a.api.InsertUser(ctx, a.instance.Project, a.instance.Name, a.user)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating user")
+	}
+	a.operation = op
+
+	return &runner.StartOutput{
+		Status: "Creating user",
+	}, nil
+}
+
+func (a *createUserAction) WaitForCompletion(ctx context.Context, _ runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
+	a.logger.Info("waiting for user to be created")
+	if err := waitForOperation(ctx, a.logger, a.api, a.instance.Project, a.operation); err != nil {
+		return nil, errors.Wrap(err, "while waiting for user creation")
+	}
+
+	output := &userOutputValues{
+		Instance: a.instance,
+		Username: a.user.Name,
+		Password: a.user.Password,
+	}
+
+	if err := createAdditionalOutputFile(a.logger, a.outputCfg.AdditionalFilePath, &a.args.Output, output); err != nil {
+		return nil, errors.Wrap(err, "while creating additional artifact")
+	}
+
+	return &runner.WaitForCompletionOutput{
+		Succeeded: true,
+		Message:   fmt.Sprintf("Created user %s in %s", a.user.Name, a.instance.Name),
+	}, nil
+}
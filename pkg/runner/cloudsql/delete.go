@@ -0,0 +1,52 @@
+package cloudsql
+
+import (
+	"context"
+	"fmt"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+type deleteAction struct {
+	logger    *zap.Logger
+	api       SQLAdmin
+	instance  *cloudSQLOutput
+	operation *sqladmin.Operation
+}
+
+func (a *deleteAction) Start(ctx context.Context, _ *runner.StartInput) (*runner.StartOutput, error) {
+	a.logger = a.logger.With(zap.String("instanceName", a.instance.Name))
+	a.logger.Info("deleting database instance")
+
+	op, err := a.api.DeleteInstance(ctx, a.instance.Project, a.instance.Name)
+	switch {
+	case isNotFound(err):
+		a.logger.Info("database instance not found, skipping deletion")
+		return &runner.StartOutput{Status: "Database instance already deleted"}, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "while deleting database instance")
+	}
+	a.operation = op
+
+	return &runner.StartOutput{
+		Status: "Deleting database instance",
+	}, nil
+}
+
+func (a *deleteAction) WaitForCompletion(ctx context.Context, _ runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
+	if a.operation != nil {
+		a.logger.Info("waiting for database instance to be deleted")
+		if err := waitForOperation(ctx, a.logger, a.api, a.instance.Project, a.operation); err != nil {
+			return nil, errors.Wrap(err, "while waiting for database instance deletion")
+		}
+	}
+
+	return &runner.WaitForCompletionOutput{
+		Succeeded: true,
+		Message:   fmt.Sprintf("Deleted database %s", a.instance.Name),
+	}, nil
+}
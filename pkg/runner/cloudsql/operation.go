@@ -0,0 +1,86 @@
+package cloudsql
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/api/googleapi"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	"sigs.k8s.io/yaml"
+)
+
+const operationDoneStatus = "DONE"
+
+// waitForOperation waits until a given CloudSQL operation is done.
+// It returns an error if the operation failed.
+func waitForOperation(ctx context.Context, logger *zap.Logger, api SQLAdmin, project string, op *sqladmin.Operation) error {
+	for {
+		current, err := api.GetOperation(ctx, project, op.Name)
+		if err != nil {
+			return errors.Wrapf(err, "while getting operation %s", op.Name)
+		}
+
+		if current.Status == operationDoneStatus {
+			return operationError(current)
+		}
+
+		logger.Debug("waiting for operation to finish", zap.String("operation", op.Name), zap.String("status", current.Status))
+		select {
+		case <-time.After(operationWaitDelay):
+		case <-ctx.Done():
+			return ErrOperationTimeout
+		}
+	}
+}
+
+func operationError(op *sqladmin.Operation) error {
+	if op.Error == nil || len(op.Error.Errors) == 0 {
+		return nil
+	}
+
+	var msgs []string
+	for _, opErr := range op.Error.Errors {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", opErr.Code, opErr.Message))
+	}
+	return fmt.Errorf("%s operation failed: %s", op.OperationType, strings.Join(msgs, "; "))
+}
+
+func isNotFound(err error) bool {
+	apiErr, ok := errors.Cause(err).(*googleapi.Error)
+	return ok && apiErr.Code == http.StatusNotFound
+}
+
+// loadInstanceTypeInstance loads the CloudSQL instance from the TypeInstance file.
+// If the TypeInstance doesn't contain the project, the defaultProject is used.
+func loadInstanceTypeInstance(path, defaultProject string) (*cloudSQLOutput, error) {
+	if path == "" {
+		return nil, errors.New("path to CloudSQL instance TypeInstance is required")
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading CloudSQL instance TypeInstance file %s", path)
+	}
+
+	var input cloudSQLInstanceInput
+	if err := yaml.Unmarshal(data, &input); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling CloudSQL instance TypeInstance")
+	}
+
+	if input.Value.Name == "" {
+		return nil, errors.New("CloudSQL instance TypeInstance doesn't contain the instance name")
+	}
+
+	if input.Value.Project == "" {
+		input.Value.Project = defaultProject
+	}
+
+	return &input.Value, nil
+}
@@ -0,0 +1,82 @@
+package cloudsql
+
+import (
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+// createOutputFiles creates the CloudSQL instance output file and, if the Go template is provided, the additional output file.
+func createOutputFiles(logger *zap.Logger, cfg OutputConfig, args *OutputArgs, values *createOutputValues) error {
+	if err := createCloudSQLInstanceOutputFile(cfg.CloudSQLInstanceFilePath, values); err != nil {
+		return errors.Wrap(err, "while creating default artifact")
+	}
+
+	if err := createAdditionalOutputFile(logger, cfg.AdditionalFilePath, args, values); err != nil {
+		return errors.Wrap(err, "while creating additional artifact")
+	}
+
+	return nil
+}
+
+func createCloudSQLInstanceOutputFile(path string, output *createOutputValues) error {
+	artifact := &cloudSQLOutput{
+		Name:            output.DBInstance.Name,
+		Project:         output.DBInstance.Project,
+		Region:          output.DBInstance.Region,
+		DatabaseVersion: output.DBInstance.DatabaseVersion,
+	}
+
+	data, err := yaml.Marshal(artifact)
+	if err != nil {
+		return errors.Wrap(err, "while marshaling artifact to YAML")
+	}
+
+	if err := ioutil.WriteFile(path, data, artifactsFileMode); err != nil {
+		return errors.Wrapf(err, "while writing artifact file %s", path)
+	}
+
+	return nil
+}
+
+// createAdditionalOutputFile renders the additional output file from the Go template with given values.
+// If the Go template is not provided, the file is not created.
+func createAdditionalOutputFile(logger *zap.Logger, path string, args *OutputArgs, values interface{}) error {
+	if args.GoTemplate == nil {
+		return nil
+	}
+
+	// yaml.Unmarshal converts YAML to JSON then uses JSON to unmarshal into an object
+	// but the GoTemplate is defined via YAML, so we need to revert that change
+	artifactTemplate, err := yaml.JSONToYAML(args.GoTemplate)
+	if err != nil {
+		return errors.Wrap(err, "while converting GoTemplate property from JSON to YAML")
+	}
+
+	tmpl, err := template.New("output").Parse(string(artifactTemplate))
+	if err != nil {
+		return errors.Wrap(err, "failed to load template")
+	}
+
+	fd, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return errors.Wrap(err, "cannot open output file to write")
+	}
+	defer func() {
+		if err := fd.Close(); err != nil {
+			logger.Error("failed to close output file descriptor", zap.Error(err))
+		}
+	}()
+
+	err = tmpl.Execute(fd, values)
+	if err != nil {
+		return errors.Wrap(err, "failed to render output file")
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package cloudsql
+
+import (
+	"context"
+	"fmt"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+// patchAction updates the CloudSQL instance with the fields set in the instance argument.
+// Only the set fields are changed, for example the tier or the database flags.
+type patchAction struct {
+	logger    *zap.Logger
+	api       SQLAdmin
+	instance  *cloudSQLOutput
+	args      *Args
+	outputCfg OutputConfig
+	operation *sqladmin.Operation
+}
+
+func (a *patchAction) Start(ctx context.Context, _ *runner.StartInput) (*runner.StartOutput, error) {
+	a.logger = a.logger.With(zap.String("instanceName", a.instance.Name))
+	a.logger.Info("patching database instance")
+
+	patch := a.args.Instance
+	op, err := a.api.PatchInstance(ctx, a.instance.Project, a.instance.Name, &patch)
+	if err != nil {
+		return nil, errors.Wrap(err, "while patching database instance")
+	}
+	a.operation = op
+
+	return &runner.StartOutput{
+		Status: "Patching database instance",
+	}, nil
+}
+
+func (a *patchAction) WaitForCompletion(ctx context.Context, _ runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
+	a.logger.Info("waiting for database instance to be patched")
+	if err := waitForOperation(ctx, a.logger, a.api, a.instance.Project, a.operation); err != nil {
+		return nil, errors.Wrap(err, "while waiting for database instance patch")
+	}
+
+	patchedDb, err := a.api.GetInstance(ctx, a.instance.Project, a.instance.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting DB instance")
+	}
+
+	// the additional output is not produced, as the patch doesn't change the credentials and they are not known to the runner
+	if a.args.Output.GoTemplate != nil {
+		a.logger.Warn("additional output is not supported for patch command, skipping")
+	}
+
+	output := &createOutputValues{DBInstance: patchedDb}
+	if err := createCloudSQLInstanceOutputFile(a.outputCfg.CloudSQLInstanceFilePath, output); err != nil {
+		return nil, errors.Wrap(err, "while writing output")
+	}
+
+	return &runner.WaitForCompletionOutput{
+		Succeeded: true,
+		Message:   fmt.Sprintf("Patched database %s", a.instance.Name),
+	}, nil
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
-	sqladmin "google.golang.org/api/sqladmin/v1beta4"
 	"sigs.k8s.io/yaml"
 )
 
@@ -18,20 +17,20 @@ type runnerAction interface {
 
 // Runner provides functionality to run and wait for GCP CloudSQL operations.
 type Runner struct {
-	logger          *zap.Logger
-	sqladminService *sqladmin.Service
-	gcpProjectName  string
-	action          runnerAction
-	outputCfg       OutputConfig
+	logger         *zap.Logger
+	api            SQLAdmin
+	gcpProjectName string
+	action         runnerAction
+	cfg            Config
 }
 
 // NewRunner returns new instance of CloudSQL runner.
-func NewRunner(cfg OutputConfig, sqladminService *sqladmin.Service, gcpProjectName string) *Runner {
+func NewRunner(cfg Config, api SQLAdmin, gcpProjectName string) *Runner {
 	return &Runner{
-		outputCfg:       cfg,
-		logger:          &zap.Logger{},
-		sqladminService: sqladminService,
-		gcpProjectName:  gcpProjectName,
+		cfg:            cfg,
+		logger:         &zap.Logger{},
+		api:            api,
+		gcpProjectName: gcpProjectName,
 	}
 }
 
@@ -54,14 +53,20 @@ func (r *Runner) Start(ctx context.Context, in runner.StartInput) (*runner.Start
 		return nil, errors.Wrap(err, "while unmarshaling input parameters")
 	}
 
+	var err error
 	switch args.Command {
 	case CreateCommandType:
 		r.action = &createAction{
-			logger:          r.logger,
-			gcpProjectName:  r.gcpProjectName,
-			sqladminService: r.sqladminService,
-			args:            args,
-			outputCfg:       r.outputCfg,
+			logger:         r.logger,
+			gcpProjectName: r.gcpProjectName,
+			api:            r.api,
+			args:           args,
+			outputCfg:      r.cfg.Output,
+		}
+	case DeleteCommandType, PatchCommandType, CreateDatabaseCommandType, CreateUserCommandType:
+		r.action, err = r.instanceAction(args)
+		if err != nil {
+			return nil, err
 		}
 	default:
 		return nil, ErrUnknownCommand
@@ -70,6 +75,27 @@ func (r *Runner) Start(ctx context.Context, in runner.StartInput) (*runner.Start
 	return r.action.Start(ctx, &in)
 }
 
+// instanceAction returns the action for the command, which works on the existing CloudSQL instance.
+func (r *Runner) instanceAction(args *Args) (runnerAction, error) {
+	instance, err := loadInstanceTypeInstance(r.cfg.InstanceTypeInstanceFilepath, r.gcpProjectName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while loading CloudSQL instance for %q command", args.Command)
+	}
+
+	switch args.Command {
+	case DeleteCommandType:
+		return &deleteAction{logger: r.logger, api: r.api, instance: instance}, nil
+	case PatchCommandType:
+		return &patchAction{logger: r.logger, api: r.api, instance: instance, args: args, outputCfg: r.cfg.Output}, nil
+	case CreateDatabaseCommandType:
+		return &createDatabaseAction{logger: r.logger, api: r.api, instance: instance, args: args, outputCfg: r.cfg.Output}, nil
+	case CreateUserCommandType:
+		return &createUserAction{logger: r.logger, api: r.api, instance: instance, args: args, outputCfg: r.cfg.Output}, nil
+	default:
+		return nil, ErrUnknownCommand
+	}
+}
+
 // WaitForCompletion waits for the started operation to finish.
 func (r *Runner) WaitForCompletion(ctx context.Context, in runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
 	return r.action.WaitForCompletion(ctx, in)
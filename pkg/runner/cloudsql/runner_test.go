@@ -0,0 +1,277 @@
+package cloudsql
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/api/option"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+const (
+	testProject  = "capact"
+	testInstance = "test-db"
+)
+
+func TestRunnerCreateDatabase(t *testing.T) {
+	// given
+	fakeSrv := newFakeSQLAdminServer(t)
+	cfg := fixConfig(t)
+
+	args := []byte(`
+command: createDatabase
+database:
+  name: jira
+output:
+  goTemplate:
+    name: "{{ .Database.Name }}"
+    instance: "{{ .Instance.Name }}"
+`)
+
+	// when
+	out := runRunner(t, fakeSrv, cfg, args)
+
+	// then
+	assert.True(t, out.Succeeded)
+	assert.Equal(t, "Created database jira in test-db", out.Message)
+
+	require.Len(t, fakeSrv.databases, 1)
+	assert.Equal(t, "jira", fakeSrv.databases[0].Name)
+
+	additional, err := ioutil.ReadFile(cfg.Output.AdditionalFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "instance: 'test-db'\nname: 'jira'\n", string(additional))
+}
+
+func TestRunnerCreateUserGeneratesPassword(t *testing.T) {
+	// given
+	fakeSrv := newFakeSQLAdminServer(t)
+	cfg := fixConfig(t)
+
+	args := []byte(`
+command: createUser
+user:
+  name: jira
+output:
+  goTemplate:
+    username: "{{ .Username }}"
+    password: "{{ .Password }}"
+`)
+
+	// when
+	out := runRunner(t, fakeSrv, cfg, args)
+
+	// then
+	assert.True(t, out.Succeeded)
+
+	require.Len(t, fakeSrv.users, 1)
+	assert.Equal(t, "jira", fakeSrv.users[0].Name)
+	assert.Len(t, fakeSrv.users[0].Password, 16)
+
+	additional, err := ioutil.ReadFile(cfg.Output.AdditionalFilePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(additional), "username: 'jira'")
+}
+
+func TestRunnerDeleteNotExistingInstance(t *testing.T) {
+	// given
+	fakeSrv := newFakeSQLAdminServer(t)
+	fakeSrv.instanceExists = false
+	cfg := fixConfig(t)
+
+	// when
+	out := runRunner(t, fakeSrv, cfg, []byte(`command: delete`))
+
+	// then
+	assert.True(t, out.Succeeded)
+	assert.Equal(t, "Deleted database test-db", out.Message)
+}
+
+func TestRunnerPatchSkipsAdditionalOutput(t *testing.T) {
+	// given
+	fakeSrv := newFakeSQLAdminServer(t)
+	cfg := fixConfig(t)
+
+	args := []byte(`
+command: patch
+instance:
+  settings:
+    tier: db-g1-small
+output:
+  goTemplate:
+    password: "{{ .Password }}"
+`)
+
+	// when
+	out := runRunner(t, fakeSrv, cfg, args)
+
+	// then
+	assert.True(t, out.Succeeded)
+	assert.Equal(t, "Patched database test-db", out.Message)
+
+	instance, err := ioutil.ReadFile(cfg.Output.CloudSQLInstanceFilePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(instance), "name: test-db")
+	assert.NoFileExists(t, cfg.Output.AdditionalFilePath)
+}
+
+func TestRunnerPatchFailedOperation(t *testing.T) {
+	// given
+	fakeSrv := newFakeSQLAdminServer(t)
+	fakeSrv.operationErr = &sqladmin.OperationError{Code: "INVALID_TIER", Message: "Invalid tier"}
+	cfg := fixConfig(t)
+
+	r := NewRunner(cfg, fakeSrv.sqlAdmin(t), testProject)
+	r.InjectLogger(zap.NewNop())
+
+	_, err := r.Start(context.Background(), runner.StartInput{Args: []byte("command: patch\ninstance:\n  settings:\n    tier: db-unknown\n")})
+	require.NoError(t, err)
+
+	// when
+	_, err = r.WaitForCompletion(context.Background(), runner.WaitForCompletionInput{})
+
+	// then
+	assert.EqualError(t, err, "while waiting for database instance patch: UPDATE operation failed: INVALID_TIER: Invalid tier")
+}
+
+func TestRunnerRequiresInstanceTypeInstance(t *testing.T) {
+	// given
+	r := NewRunner(Config{}, nil, testProject)
+	r.InjectLogger(zap.NewNop())
+
+	// when
+	_, err := r.Start(context.Background(), runner.StartInput{Args: []byte(`command: delete`)})
+
+	// then
+	assert.EqualError(t, err, `while loading CloudSQL instance for "delete" command: path to CloudSQL instance TypeInstance is required`)
+}
+
+func runRunner(t *testing.T, fakeSrv *fakeSQLAdminServer, cfg Config, args []byte) *runner.WaitForCompletionOutput {
+	t.Helper()
+
+	r := NewRunner(cfg, fakeSrv.sqlAdmin(t), testProject)
+	r.InjectLogger(zap.NewNop())
+
+	_, err := r.Start(context.Background(), runner.StartInput{Args: args})
+	require.NoError(t, err)
+
+	out, err := r.WaitForCompletion(context.Background(), runner.WaitForCompletionInput{})
+	require.NoError(t, err)
+
+	return out
+}
+
+func fixConfig(t *testing.T) Config {
+	t.Helper()
+
+	dir := t.TempDir()
+	instanceTI := filepath.Join(dir, "instance.yaml")
+	err := ioutil.WriteFile(instanceTI, []byte("value:\n  name: test-db\n  region: us-central\n"), 0600)
+	require.NoError(t, err)
+
+	return Config{
+		InstanceTypeInstanceFilepath: instanceTI,
+		Output: OutputConfig{
+			CloudSQLInstanceFilePath: filepath.Join(dir, "cloudSQLInstance.yaml"),
+			AdditionalFilePath:       filepath.Join(dir, "additional.yaml"),
+		},
+	}
+}
+
+// fakeSQLAdminServer is a minimal fake of the Cloud SQL Admin API, which serves the test instance in the test project.
+// All operations are finished immediately.
+type fakeSQLAdminServer struct {
+	mu             sync.Mutex
+	instanceExists bool
+	operationErr   *sqladmin.OperationError
+	operationType  string
+	databases      []sqladmin.Database
+	users          []sqladmin.User
+}
+
+func newFakeSQLAdminServer(t *testing.T) *fakeSQLAdminServer {
+	t.Helper()
+	return &fakeSQLAdminServer{instanceExists: true}
+}
+
+func (s *fakeSQLAdminServer) sqlAdmin(t *testing.T) SQLAdmin {
+	t.Helper()
+
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	svc, err := sqladmin.NewService(context.Background(),
+		option.WithEndpoint(srv.URL+"/"),
+		option.WithHTTPClient(srv.Client()),
+	)
+	require.NoError(t, err)
+
+	return NewSQLAdmin(svc)
+}
+
+func (s *fakeSQLAdminServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instancePath := "/projects/" + testProject + "/instances/" + testInstance
+	path := r.URL.Path
+
+	switch {
+	case strings.HasSuffix(path, "/projects/"+testProject+"/operations/op"):
+		op := &sqladmin.Operation{Name: "op", Status: operationDoneStatus, OperationType: s.operationType}
+		if s.operationErr != nil {
+			op.Error = &sqladmin.OperationErrors{Errors: []*sqladmin.OperationError{s.operationErr}}
+		}
+		s.writeJSON(w, op)
+	case !s.instanceExists || !strings.Contains(path, instancePath):
+		http.Error(w, `{"error": {"code": 404, "message": "not found"}}`, http.StatusNotFound)
+	case r.Method == http.MethodPost && strings.HasSuffix(path, instancePath+"/databases"):
+		var db sqladmin.Database
+		s.decodeJSON(w, r, &db)
+		s.databases = append(s.databases, db)
+		s.writeOperation(w, "CREATE_DATABASE")
+	case r.Method == http.MethodPost && strings.HasSuffix(path, instancePath+"/users"):
+		var user sqladmin.User
+		s.decodeJSON(w, r, &user)
+		s.users = append(s.users, user)
+		s.writeOperation(w, "CREATE_USER")
+	case r.Method == http.MethodPatch && strings.HasSuffix(path, instancePath):
+		s.writeOperation(w, "UPDATE")
+	case r.Method == http.MethodDelete && strings.HasSuffix(path, instancePath):
+		s.writeOperation(w, "DELETE")
+	case r.Method == http.MethodGet && strings.HasSuffix(path, instancePath):
+		s.writeJSON(w, &sqladmin.DatabaseInstance{Name: testInstance, Project: testProject, State: "RUNNABLE"})
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func (s *fakeSQLAdminServer) writeOperation(w http.ResponseWriter, opType string) {
+	s.operationType = opType
+	s.writeJSON(w, &sqladmin.Operation{Name: "op", Status: "PENDING", OperationType: opType})
+}
+
+func (s *fakeSQLAdminServer) decodeJSON(w http.ResponseWriter, r *http.Request, out interface{}) {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+func (s *fakeSQLAdminServer) writeJSON(w http.ResponseWriter, out interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,57 @@
+package cloudsql
+
+import (
+	"context"
+
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+// SQLAdmin provides the GCP Cloud SQL Admin API operations used by the runner.
+type SQLAdmin interface {
+	InsertInstance(ctx context.Context, project string, instance *sqladmin.DatabaseInstance) (*sqladmin.Operation, error)
+	GetInstance(ctx context.Context, project, name string) (*sqladmin.DatabaseInstance, error)
+	PatchInstance(ctx context.Context, project, name string, instance *sqladmin.DatabaseInstance) (*sqladmin.Operation, error)
+	DeleteInstance(ctx context.Context, project, name string) (*sqladmin.Operation, error)
+	InsertDatabase(ctx context.Context, project, instance string, database *sqladmin.Database) (*sqladmin.Operation, error)
+	InsertUser(ctx context.Context, project, instance string, user *sqladmin.User) (*sqladmin.Operation, error)
+	GetOperation(ctx context.Context, project, name string) (*sqladmin.Operation, error)
+}
+
+var _ SQLAdmin = &sqlAdminService{}
+
+type sqlAdminService struct {
+	svc *sqladmin.Service
+}
+
+// NewSQLAdmin returns the SQLAdmin, which uses a given GCP Cloud SQL Admin API service.
+func NewSQLAdmin(svc *sqladmin.Service) SQLAdmin {
+	return &sqlAdminService{svc: svc}
+}
+
+func (s *sqlAdminService) InsertInstance(ctx context.Context, project string, instance *sqladmin.DatabaseInstance) (*sqladmin.Operation, error) {
+	return s.svc.Instances.Insert(project, instance).Context(ctx).Do()
+}
+
+func (s *sqlAdminService) GetInstance(ctx context.Context, project, name string) (*sqladmin.DatabaseInstance, error) {
+	return s.svc.Instances.Get(project, name).Context(ctx).Do()
+}
+
+func (s *sqlAdminService) PatchInstance(ctx context.Context, project, name string, instance *sqladmin.DatabaseInstance) (*sqladmin.Operation, error) {
+	return s.svc.Instances.Patch(project, name, instance).Context(ctx).Do()
+}
+
+func (s *sqlAdminService) DeleteInstance(ctx context.Context, project, name string) (*sqladmin.Operation, error) {
+	return s.svc.Instances.Delete(project, name).Context(ctx).Do()
+}
+
+func (s *sqlAdminService) InsertDatabase(ctx context.Context, project, instance string, database *sqladmin.Database) (*sqladmin.Operation, error) {
+	return s.svc.Databases.Insert(project, instance, database).Context(ctx).Do()
+}
+
+func (s *sqlAdminService) InsertUser(ctx context.Context, project, instance string, user *sqladmin.User) (*sqladmin.Operation, error) {
+	return s.svc.Users.Insert(project, instance, user).Context(ctx).Do()
+}
+
+func (s *sqlAdminService) GetOperation(ctx context.Context, project, name string) (*sqladmin.Operation, error) {
+	return s.svc.Operations.Get(project, name).Context(ctx).Do()
+}
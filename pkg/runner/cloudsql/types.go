@@ -17,7 +17,8 @@ const (
 	// PostgresRootUser defines the CloudSQL database instance root user name.
 	PostgresRootUser = "postgres"
 
-	createWaitDelay = 10 * time.Second
+	createWaitDelay    = 10 * time.Second
+	operationWaitDelay = 5 * time.Second
 
 	artifactsFileMode os.FileMode = 0644
 )
@@ -28,15 +29,33 @@ type CommandType string
 const (
 	// CreateCommandType is an operation to create a new CloudSQL instance.
 	CreateCommandType = "create"
+	// DeleteCommandType is an operation to delete a CloudSQL instance.
+	DeleteCommandType = "delete"
+	// PatchCommandType is an operation to update a CloudSQL instance, such as the tier or the database flags.
+	PatchCommandType = "patch"
+	// CreateDatabaseCommandType is an operation to create a new database in a CloudSQL instance.
+	CreateDatabaseCommandType = "createDatabase"
+	// CreateUserCommandType is an operation to create a new user in a CloudSQL instance.
+	CreateUserCommandType = "createUser"
 )
 
 var (
 	// ErrInstanceCreateTimeout indicates the operation timed out.
 	ErrInstanceCreateTimeout = errors.New("timed out waiting for DB instance to be ready")
+	// ErrOperationTimeout indicates the CloudSQL operation timed out.
+	ErrOperationTimeout = errors.New("timed out waiting for operation to finish")
 	// ErrUnknownCommand indicates an unknown operation command.
 	ErrUnknownCommand = errors.New("unknown command")
 )
 
+// Config stores the configuration for the CloudSQL runner.
+type Config struct {
+	// InstanceTypeInstanceFilepath is a path to the CloudSQL instance TypeInstance.
+	// It is required for all commands except create.
+	InstanceTypeInstanceFilepath string
+	Output                       OutputConfig
+}
+
 // OutputConfig stores the configuration for the CloudSQL runner output files.
 type OutputConfig struct {
 	CloudSQLInstanceFilePath string `envconfig:"default=/tmp/cloudSQLInstance.yaml"`
@@ -49,6 +68,8 @@ type Args struct {
 	Command      CommandType               `yaml:"command"`
 	GenerateName bool                      `yaml:"generateName"`
 	Instance     sqladmin.DatabaseInstance `yaml:"instance"`
+	Database     sqladmin.Database         `yaml:"database"`
+	User         sqladmin.User             `yaml:"user"`
 	Output       OutputArgs                `yaml:"output"`
 }
 
@@ -65,6 +86,21 @@ type createOutputValues struct {
 	Password      string `yaml:"password"`
 }
 
+type databaseOutputValues struct {
+	Instance *cloudSQLOutput
+	Database *sqladmin.Database
+}
+
+type userOutputValues struct {
+	Instance *cloudSQLOutput
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+type cloudSQLInstanceInput struct {
+	Value cloudSQLOutput `yaml:"value"`
+}
+
 type cloudSQLOutput struct {
 	Name            string `yaml:"name"`
 	Project         string `yaml:"project"`
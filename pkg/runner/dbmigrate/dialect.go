@@ -0,0 +1,100 @@
+package dbmigrate
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/go-sql-driver/mysql"
+	// PostgreSQL driver registration
+	_ "github.com/lib/pq"
+)
+
+// dialect holds the driver specific SQL and connection details.
+type dialect struct {
+	driverName string
+	// placeholder returns the query placeholder for the n-th argument, starting from 1.
+	placeholder func(n int) string
+	// tableExistsQuery counts the tables with the name given as the first argument in the current schema.
+	tableExistsQuery string
+	dsn              func(cfg DatabaseConfig, database, sslMode string) string
+}
+
+func dialectFor(driver DriverType) (dialect, error) {
+	switch driver {
+	case PostgreSQLDriverType:
+		return dialect{
+			driverName: "postgres",
+			placeholder: func(n int) string {
+				return fmt.Sprintf("$%d", n)
+			},
+			tableExistsQuery: "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1",
+			dsn:              postgresDSN,
+		}, nil
+	case MySQLDriverType:
+		return dialect{
+			driverName: "mysql",
+			placeholder: func(int) string {
+				return "?"
+			},
+			tableExistsQuery: "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?",
+			dsn:              mysqlDSN,
+		}, nil
+	default:
+		return dialect{}, fmt.Errorf("unsupported driver %q", driver)
+	}
+}
+
+func postgresDSN(cfg DatabaseConfig, database, sslMode string) string {
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(cfg.Superuser.Username, cfg.Superuser.Password),
+		Host:   hostPort(cfg, 5432),
+		Path:   "/" + database,
+	}
+	if sslMode != "" {
+		dsn.RawQuery = url.Values{"sslmode": []string{sslMode}}.Encode()
+	}
+	return dsn.String()
+}
+
+func mysqlDSN(cfg DatabaseConfig, database, sslMode string) string {
+	dsn := mysql.NewConfig()
+	dsn.User = cfg.Superuser.Username
+	dsn.Passwd = cfg.Superuser.Password
+	dsn.Net = "tcp"
+	dsn.Addr = hostPort(cfg, 3306)
+	dsn.DBName = database
+	dsn.TLSConfig = mysqlTLSConfig(sslMode)
+	// migration files usually contain multiple statements
+	dsn.MultiStatements = true
+	dsn.ParseTime = true
+	return dsn.FormatDSN()
+}
+
+// mysqlTLSConfig maps the PostgreSQL sslmode values to the MySQL driver tls parameter.
+// Other values, such as "true" or "skip-verify", are passed as they are.
+func mysqlTLSConfig(sslMode string) string {
+	switch sslMode {
+	case "disable":
+		return "false"
+	case "allow", "prefer":
+		return "preferred"
+	case "require":
+		// the same as for PostgreSQL, the server certificate is not verified
+		return "skip-verify"
+	case "verify-ca", "verify-full":
+		return "true"
+	default:
+		return sslMode
+	}
+}
+
+func hostPort(cfg DatabaseConfig, defaultPort int) string {
+	port := cfg.Port.String()
+	if port == "" {
+		port = strconv.Itoa(defaultPort)
+	}
+	return net.JoinHostPort(cfg.Host, port)
+}
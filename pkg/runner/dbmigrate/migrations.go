@@ -0,0 +1,140 @@
+package dbmigrate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// migrationFileRegex matches file names such as `0001_create_users.up.sql`.
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// LoadMigrations loads the migrations from a given directory, sorted by version.
+// Each migration requires the up file. The down file is optional.
+func LoadMigrations(dir string) ([]Migration, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading migrations directory")
+	}
+
+	byVersion := map[uint64]*Migration{}
+	hasUp := map[uint64]bool{}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		match := migrationFileRegex.FindStringSubmatch(file.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while parsing version of %q", file.Name())
+		}
+		name, direction := match[2], match[3]
+
+		migration, found := byVersion[version]
+		if !found {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("duplicated migration version %d: %q and %q", version, migration.Name, name)
+		}
+
+		content, err := ioutil.ReadFile(filepath.Clean(filepath.Join(dir, file.Name())))
+		if err != nil {
+			return nil, errors.Wrapf(err, "while reading %q", file.Name())
+		}
+
+		if direction == "up" {
+			migration.Up = string(content)
+			hasUp[version] = true
+		} else {
+			migration.Down = string(content)
+			migration.HasDown = true
+		}
+	}
+
+	var migrations []Migration
+	for _, migration := range byVersion {
+		if !hasUp[migration.Version] {
+			return nil, fmt.Errorf("up migration for %s not found", migration.ID())
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// PlanUp returns the pending migrations in the order to apply them.
+func PlanUp(migrations []Migration, applied []AppliedMigration, targetVersion *uint64) []Migration {
+	appliedVersions := map[uint64]struct{}{}
+	for _, m := range applied {
+		appliedVersions[m.Version] = struct{}{}
+	}
+
+	var out []Migration
+	for _, m := range migrations {
+		if _, found := appliedVersions[m.Version]; found {
+			continue
+		}
+		if targetVersion != nil && m.Version > *targetVersion {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// PlanDown returns the applied migrations in the order to revert them.
+// If the target version is set, all migrations above it are reverted. Otherwise, a given number of steps is reverted.
+func PlanDown(migrations []Migration, applied []AppliedMigration, targetVersion *uint64, steps int) ([]Migration, error) {
+	bySourceVersion := map[uint64]Migration{}
+	for _, m := range migrations {
+		bySourceVersion[m.Version] = m
+	}
+
+	var out []Migration
+	for i := len(applied) - 1; i >= 0; i-- {
+		if targetVersion != nil && applied[i].Version <= *targetVersion {
+			break
+		}
+		if targetVersion == nil && len(out) >= steps {
+			break
+		}
+
+		m, found := bySourceVersion[applied[i].Version]
+		if !found {
+			return nil, fmt.Errorf("applied migration %s not found in source", formatID(applied[i].Version, applied[i].Name))
+		}
+		if !m.HasDown {
+			return nil, fmt.Errorf("down migration for %s not found", m.ID())
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// PendingMigrations returns the migrations from the source, which are not applied.
+func PendingMigrations(migrations []Migration, applied []AppliedMigration) []PendingMigration {
+	var out []PendingMigration
+	for _, m := range PlanUp(migrations, applied, nil) {
+		out = append(out, PendingMigration{Version: m.Version, Name: m.Name})
+	}
+	return out
+}
+
+func formatID(version uint64, name string) string {
+	return fmt.Sprintf("%d_%s", version, name)
+}
@@ -0,0 +1,133 @@
+package dbmigrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	// when
+	migrations, err := LoadMigrations("testdata/migrations")
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+
+	assert.Equal(t, "1_create_users", migrations[0].ID())
+	assert.Contains(t, migrations[0].Up, "CREATE TABLE users")
+	assert.Equal(t, "DROP TABLE users;\n", migrations[0].Down)
+	assert.True(t, migrations[0].HasDown)
+
+	assert.Equal(t, "2_add_email", migrations[1].ID())
+	assert.Equal(t, "3_seed_admin", migrations[2].ID())
+	assert.False(t, migrations[2].HasDown)
+}
+
+func TestLoadMigrationsMissingUp(t *testing.T) {
+	// when
+	_, err := LoadMigrations("testdata/missing-up")
+
+	// then
+	assert.EqualError(t, err, "up migration for 1_create_users not found")
+}
+
+func TestPlanUp(t *testing.T) {
+	// given
+	migrations := fixMigrations()
+	applied := []AppliedMigration{{Version: 1, Name: "create_users"}}
+	target := uint64(2)
+
+	tests := map[string]struct {
+		targetVersion *uint64
+		expectedIDs   []string
+	}{
+		"all pending": {
+			expectedIDs: []string{"2_add_email", "3_seed_admin"},
+		},
+		"up to target version": {
+			targetVersion: &target,
+			expectedIDs:   []string{"2_add_email"},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// when
+			planned := PlanUp(migrations, applied, tc.targetVersion)
+
+			// then
+			assert.Equal(t, tc.expectedIDs, ids(planned))
+		})
+	}
+}
+
+func TestPlanDown(t *testing.T) {
+	// given
+	migrations := fixMigrations()
+	target := uint64(0)
+
+	tests := map[string]struct {
+		applied       []AppliedMigration
+		targetVersion *uint64
+		steps         int
+		expectedIDs   []string
+		expectedErr   string
+	}{
+		"single step": {
+			applied:     []AppliedMigration{{Version: 1}, {Version: 2}},
+			steps:       1,
+			expectedIDs: []string{"2_add_email"},
+		},
+		"down to target version": {
+			applied:       []AppliedMigration{{Version: 1}, {Version: 2}},
+			targetVersion: &target,
+			expectedIDs:   []string{"2_add_email", "1_create_users"},
+		},
+		"nothing applied": {
+			steps: 1,
+		},
+		"missing down migration": {
+			applied:     []AppliedMigration{{Version: 1}, {Version: 2}, {Version: 3}},
+			steps:       1,
+			expectedErr: "down migration for 3_seed_admin not found",
+		},
+		"applied migration not in source": {
+			applied:     []AppliedMigration{{Version: 4, Name: "removed"}},
+			steps:       1,
+			expectedErr: "applied migration 4_removed not found in source",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// when
+			planned, err := PlanDown(migrations, tc.applied, tc.targetVersion, tc.steps)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedIDs, ids(planned))
+		})
+	}
+}
+
+func fixMigrations() []Migration {
+	return []Migration{
+		{Version: 1, Name: "create_users", Up: "CREATE TABLE users;", Down: "DROP TABLE users;", HasDown: true},
+		{Version: 2, Name: "add_email", Up: "ALTER TABLE users ADD COLUMN email;", Down: "ALTER TABLE users DROP COLUMN email;", HasDown: true},
+		{Version: 3, Name: "seed_admin", Up: "INSERT INTO users VALUES ('admin');"},
+	}
+}
+
+func ids(migrations []Migration) []string {
+	var out []string
+	for _, m := range migrations {
+		out = append(out, m.ID())
+	}
+	return out
+}
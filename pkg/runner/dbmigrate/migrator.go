@@ -0,0 +1,135 @@
+package dbmigrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var tableNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Migrator applies and reverts migrations, and records them in the migrations table.
+type Migrator struct {
+	log     *zap.Logger
+	db      *sql.DB
+	dialect dialect
+	table   string
+}
+
+// NewMigrator returns a new Migrator instance.
+func NewMigrator(log *zap.Logger, db *sql.DB, driver DriverType, table string) (*Migrator, error) {
+	d, err := dialectFor(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	// the table name cannot be passed as a query argument
+	if !tableNameRegex.MatchString(table) {
+		return nil, fmt.Errorf("invalid migrations table name %q", table)
+	}
+
+	return &Migrator{
+		log:     log,
+		db:      db,
+		dialect: d,
+		table:   table,
+	}, nil
+}
+
+// Applied returns the applied migrations sorted by version.
+// If the migrations table doesn't exist, no migrations are returned.
+func (m *Migrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	var count int
+	if err := m.db.QueryRowContext(ctx, m.dialect.tableExistsQuery, m.table).Scan(&count); err != nil {
+		return nil, errors.Wrap(err, "while checking if migrations table exists")
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	// #nosec G201
+	query := fmt.Sprintf("SELECT version, name, applied_at FROM %s ORDER BY version", m.table)
+	rows, err := m.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "while querying applied migrations")
+	}
+	defer rows.Close()
+
+	var out []AppliedMigration
+	for rows.Next() {
+		var applied AppliedMigration
+		if err := rows.Scan(&applied.Version, &applied.Name, &applied.AppliedAt); err != nil {
+			return nil, errors.Wrap(err, "while scanning applied migration")
+		}
+		out = append(out, applied)
+	}
+	return out, errors.Wrap(rows.Err(), "while reading applied migrations")
+}
+
+// EnsureTable creates the migrations table if it doesn't exist.
+func (m *Migrator) EnsureTable(ctx context.Context) error {
+	// #nosec G201
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version BIGINT NOT NULL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+)`, m.table)
+
+	_, err := m.db.ExecContext(ctx, query)
+	return errors.Wrap(err, "while creating migrations table")
+}
+
+// Apply executes the up migration and records it in the migrations table, in a single transaction.
+// Note that MySQL commits the DDL statements implicitly, so a failed migration may be applied partially.
+func (m *Migrator) Apply(ctx context.Context, migration Migration) error {
+	// #nosec G201
+	record := fmt.Sprintf("INSERT INTO %s (version, name, applied_at) VALUES (%s, %s, %s)",
+		m.table, m.dialect.placeholder(1), m.dialect.placeholder(2), m.dialect.placeholder(3))
+
+	return m.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return errors.Wrapf(err, "while applying migration %s", migration.ID())
+		}
+		if _, err := tx.ExecContext(ctx, record, migration.Version, migration.Name, time.Now().UTC()); err != nil {
+			return errors.Wrapf(err, "while recording migration %s", migration.ID())
+		}
+		return nil
+	})
+}
+
+// Revert executes the down migration and removes it from the migrations table, in a single transaction.
+func (m *Migrator) Revert(ctx context.Context, migration Migration) error {
+	// #nosec G201
+	record := fmt.Sprintf("DELETE FROM %s WHERE version = %s", m.table, m.dialect.placeholder(1))
+
+	return m.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return errors.Wrapf(err, "while reverting migration %s", migration.ID())
+		}
+		if _, err := tx.ExecContext(ctx, record, migration.Version); err != nil {
+			return errors.Wrapf(err, "while removing migration %s record", migration.ID())
+		}
+		return nil
+	})
+}
+
+func (m *Migrator) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "while starting transaction")
+	}
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			m.log.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+		}
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(), "while committing transaction")
+}
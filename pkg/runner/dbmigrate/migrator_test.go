@@ -0,0 +1,118 @@
+package dbmigrate
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMigratorApplied(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	appliedAt := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM information_schema.tables")).
+		WithArgs("schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, name, applied_at FROM schema_migrations ORDER BY version")).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}).AddRow(1, "create_users", appliedAt))
+
+	migrator, err := NewMigrator(zap.NewNop(), db, PostgreSQLDriverType, defaultMigrationsTable)
+	require.NoError(t, err)
+
+	// when
+	applied, err := migrator.Applied(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []AppliedMigration{{Version: 1, Name: "create_users", AppliedAt: appliedAt}}, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigratorAppliedWithoutTable(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM information_schema.tables")).
+		WithArgs("schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	migrator, err := NewMigrator(zap.NewNop(), db, MySQLDriverType, defaultMigrationsTable)
+	require.NoError(t, err)
+
+	// when
+	applied, err := migrator.Applied(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigratorApply(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	migration := fixMigrations()[0]
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(migration.Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)")).
+		WithArgs(migration.Version, migration.Name, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	migrator, err := NewMigrator(zap.NewNop(), db, PostgreSQLDriverType, defaultMigrationsTable)
+	require.NoError(t, err)
+
+	// when
+	err = migrator.Apply(context.Background(), migration)
+
+	// then
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigratorRevertRollsBackOnError(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	migration := fixMigrations()[0]
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(migration.Down)).WillReturnError(errors.New("table is locked"))
+	mock.ExpectRollback()
+
+	migrator, err := NewMigrator(zap.NewNop(), db, MySQLDriverType, defaultMigrationsTable)
+	require.NoError(t, err)
+
+	// when
+	err = migrator.Revert(context.Background(), migration)
+
+	// then
+	assert.EqualError(t, err, "while reverting migration 1_create_users: table is locked")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewMigratorInvalidTable(t *testing.T) {
+	// when
+	_, err := NewMigrator(zap.NewNop(), nil, PostgreSQLDriverType, "migrations; DROP TABLE users")
+
+	// then
+	assert.EqualError(t, err, `invalid migrations table name "migrations; DROP TABLE users"`)
+}
@@ -0,0 +1,261 @@
+package dbmigrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"capact.io/capact/internal/getter"
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+var _ runner.Runner = &migrateRunner{}
+
+// migrateRunner provides functionality to apply and revert versioned SQL migrations.
+type migrateRunner struct {
+	cfg Config
+	log *zap.Logger
+
+	args       Arguments
+	database   string
+	db         *sql.DB
+	migrator   *Migrator
+	migrations []Migration
+}
+
+// NewRunner returns a new database migration runner instance.
+func NewRunner(cfg Config) runner.Runner {
+	return &migrateRunner{
+		cfg: cfg,
+	}
+}
+
+// Start downloads the migrations and connects to the database.
+func (r *migrateRunner) Start(ctx context.Context, in runner.StartInput) (*runner.StartOutput, error) {
+	r.args = DefaultArguments()
+	if err := yaml.Unmarshal(in.Args, &r.args); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling runner arguments")
+	}
+
+	if r.cfg.Command != UpCommandType && r.cfg.Command != DownCommandType {
+		return nil, errors.New("Unsupported command")
+	}
+
+	dbCfg, err := r.loadDatabaseConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading database TypeInstance")
+	}
+
+	r.migrations, err = r.loadMigrations(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading migrations")
+	}
+
+	d, err := dialectFor(r.args.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	r.database = dbCfg.DefaultDBName
+	if r.args.Database != "" {
+		r.database = r.args.Database
+	}
+
+	r.db, err = sql.Open(d.driverName, d.dsn(dbCfg, r.database, r.args.SSLMode))
+	if err != nil {
+		return nil, errors.Wrap(err, "while opening database connection")
+	}
+	if err := r.db.PingContext(ctx); err != nil {
+		return nil, errors.Wrap(err, "while connecting to database")
+	}
+
+	r.migrator, err = NewMigrator(r.log, r.db, r.args.Driver, r.args.Table)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating migrator")
+	}
+
+	return &runner.StartOutput{
+		Status: fmt.Sprintf("Running %s command", r.cfg.Command),
+	}, nil
+}
+
+// WaitForCompletion applies or reverts the migrations one by one and saves the migration state.
+// In dry run mode, it only lists the migrations to apply or revert.
+func (r *migrateRunner) WaitForCompletion(ctx context.Context, in runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
+	if r.migrator == nil {
+		return &runner.WaitForCompletionOutput{}, errors.New("runner not started yet")
+	}
+	defer r.closeDB()
+
+	applied, err := r.migrator.Applied(ctx)
+	if err != nil {
+		return &runner.WaitForCompletionOutput{}, err
+	}
+
+	var planned []Migration
+	switch r.cfg.Command {
+	case UpCommandType:
+		planned = PlanUp(r.migrations, applied, r.args.TargetVersion)
+	case DownCommandType:
+		planned, err = PlanDown(r.migrations, applied, r.args.TargetVersion, r.args.Steps)
+		if err != nil {
+			return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while planning migrations")
+		}
+	}
+
+	verb := map[CommandType]string{UpCommandType: "applied", DownCommandType: "reverted"}[r.cfg.Command]
+	if in.RunnerCtx.DryRun {
+		if err := r.saveOutput(applied); err != nil {
+			return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while saving output")
+		}
+		return &runner.WaitForCompletionOutput{
+			Succeeded: true,
+			Message:   fmt.Sprintf("Dry run: %d migrations to be %s%s", len(planned), verb, listIDs(planned)),
+		}, nil
+	}
+
+	if err := r.execute(ctx, planned); err != nil {
+		return &runner.WaitForCompletionOutput{}, err
+	}
+
+	applied, err = r.migrator.Applied(ctx)
+	if err != nil {
+		return &runner.WaitForCompletionOutput{}, err
+	}
+	if err := r.saveOutput(applied); err != nil {
+		return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while saving output")
+	}
+
+	return &runner.WaitForCompletionOutput{
+		Succeeded: true,
+		Message:   fmt.Sprintf("%d migrations %s%s", len(planned), verb, listIDs(planned)),
+	}, nil
+}
+
+// Name returns the runner name.
+func (r *migrateRunner) Name() string {
+	return "dbmigrate"
+}
+
+// InjectLogger sets the logger on the runner.
+func (r *migrateRunner) InjectLogger(logger *zap.Logger) {
+	r.log = logger
+}
+
+func (r *migrateRunner) execute(ctx context.Context, planned []Migration) error {
+	if r.cfg.Command == UpCommandType {
+		if err := r.migrator.EnsureTable(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, migration := range planned {
+		var err error
+		if r.cfg.Command == UpCommandType {
+			r.log.Info("Applying migration", zap.String("migration", migration.ID()))
+			err = r.migrator.Apply(ctx, migration)
+		} else {
+			r.log.Info("Reverting migration", zap.String("migration", migration.ID()))
+			err = r.migrator.Revert(ctx, migration)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *migrateRunner) loadDatabaseConfig() (DatabaseConfig, error) {
+	if r.cfg.DatabaseTypeInstancePath == "" {
+		return DatabaseConfig{}, errors.New("RUNNER_DATABASE_TYPE_INSTANCE_PATH must be set")
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(r.cfg.DatabaseTypeInstancePath))
+	if err != nil {
+		return DatabaseConfig{}, errors.Wrap(err, "while reading file")
+	}
+
+	var ti DatabaseTypeInstance
+	if err := yaml.Unmarshal(data, &ti); err != nil {
+		return DatabaseConfig{}, errors.Wrap(err, "while unmarshaling")
+	}
+	if ti.Value.Host == "" {
+		return DatabaseConfig{}, errors.New("database host must be specified")
+	}
+
+	return ti.Value, nil
+}
+
+func (r *migrateRunner) loadMigrations(ctx context.Context) ([]Migration, error) {
+	if r.args.Source == "" {
+		return nil, errors.New("source must be specified")
+	}
+
+	// in case of git repository as a source, the download needs to be done in empty directory
+	r.log.Debug("Downloading source into workdir", zap.String("workdir", r.cfg.WorkDir))
+	_, err := os.Stat(r.cfg.WorkDir)
+	if strings.HasPrefix(r.args.Source, "git") && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("the workdir directory %q must not exist when cloning git repository", r.cfg.WorkDir)
+	}
+
+	if err := getter.Download(ctx, r.args.Source, r.cfg.WorkDir, nil); err != nil {
+		return nil, errors.Wrap(err, "while downloading source")
+	}
+
+	dir := filepath.Join(r.cfg.WorkDir, filepath.Clean("/"+r.args.Path))
+	migrations, err := LoadMigrations(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, fmt.Errorf("no migrations found in %q", r.args.Path)
+	}
+	return migrations, nil
+}
+
+func (r *migrateRunner) saveOutput(applied []AppliedMigration) error {
+	state := MigrationState{
+		Driver:   r.args.Driver,
+		Database: r.database,
+		Table:    r.args.Table,
+		Applied:  applied,
+		Pending:  PendingMigrations(r.migrations, applied),
+	}
+	if len(applied) > 0 {
+		state.Version = applied[len(applied)-1].Version
+	}
+
+	data, err := yaml.Marshal(OutputFile{Value: state})
+	if err != nil {
+		return errors.Wrap(err, "while marshaling migration state")
+	}
+
+	r.log.Debug("Saving migration state output", zap.String("path", r.cfg.Output.MigrationStateFilePath))
+	return runner.SaveToFile(r.cfg.Output.MigrationStateFilePath, data)
+}
+
+func (r *migrateRunner) closeDB() {
+	if err := r.db.Close(); err != nil {
+		r.log.Error("Failed to close database connection", zap.Error(err))
+	}
+}
+
+func listIDs(migrations []Migration) string {
+	if len(migrations) == 0 {
+		return ""
+	}
+
+	var ids []string
+	for _, m := range migrations {
+		ids = append(ids, m.ID())
+	}
+	return ": " + strings.Join(ids, ", ")
+}
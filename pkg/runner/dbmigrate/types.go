@@ -0,0 +1,134 @@
+package dbmigrate
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const defaultMigrationsTable = "schema_migrations"
+
+// Config holds Runner related configuration.
+type Config struct {
+	Command CommandType
+	WorkDir string `envconfig:"default=/workspace"`
+	// DatabaseTypeInstancePath is a path to the TypeInstance with the database connection details.
+	DatabaseTypeInstancePath string
+	Output                   struct {
+		MigrationStateFilePath string `envconfig:"default=/tmp/migration-state.yaml"`
+	}
+}
+
+// CommandType represents the operation type to be performed by the runner.
+type CommandType string
+
+const (
+	// UpCommandType is an operation to apply the pending migrations.
+	UpCommandType = "up"
+	// DownCommandType is an operation to revert the applied migrations.
+	DownCommandType = "down"
+)
+
+// DriverType is the database engine type.
+type DriverType string
+
+const (
+	// PostgreSQLDriverType is the PostgreSQL database driver.
+	PostgreSQLDriverType DriverType = "postgres"
+	// MySQLDriverType is the MySQL database driver.
+	MySQLDriverType DriverType = "mysql"
+)
+
+// DefaultArguments returns runner Arguments with default values.
+func DefaultArguments() Arguments {
+	return Arguments{
+		Driver: PostgreSQLDriverType,
+		Table:  defaultMigrationsTable,
+		Steps:  1,
+	}
+}
+
+// Arguments stores the input arguments for the runner operation.
+type Arguments struct {
+	// Source is a location of the migrations, such as URL to Tarball or Git repository.
+	// All formats supported by go-getter are allowed.
+	Source string `json:"source"`
+	// Path is a directory inside the Source with the migration files.
+	Path   string     `json:"path"`
+	Driver DriverType `json:"driver"`
+	// Database overrides the database name from the TypeInstance.
+	Database string `json:"database"`
+	// SSLMode is passed as the `sslmode` for PostgreSQL, and as the `tls` parameter for MySQL.
+	SSLMode string `json:"sslMode"`
+	// Table stores the applied migrations.
+	Table string `json:"table"`
+	// TargetVersion limits the up command to migrations up to the version,
+	// and reverts all migrations above the version for the down command.
+	TargetVersion *uint64 `json:"targetVersion"`
+	// Steps is a number of migrations reverted by the down command, if the TargetVersion is not set.
+	Steps int `json:"steps"`
+}
+
+// DatabaseTypeInstance defines the shape of the TypeInstance with the database connection details.
+// It is compatible with the PostgreSQL config produced by the CloudSQL runner.
+type DatabaseTypeInstance struct {
+	Value DatabaseConfig `json:"value"`
+}
+
+// DatabaseConfig holds the database connection details.
+type DatabaseConfig struct {
+	Host string `json:"host"`
+	// Port is a number or a string, as the CloudSQL runner output templates often render it quoted.
+	Port          json.Number `json:"port"`
+	DefaultDBName string      `json:"defaultDBName"`
+	Superuser     Credential  `json:"superuser"`
+}
+
+// Credential holds the database user credentials.
+type Credential struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Migration is a single versioned migration loaded from the source.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+	// HasDown indicates whether the down migration file exists.
+	HasDown bool
+}
+
+// ID returns the human readable migration identifier.
+func (m Migration) ID() string {
+	return formatID(m.Version, m.Name)
+}
+
+// AppliedMigration is a migration recorded in the migrations table.
+type AppliedMigration struct {
+	Version   uint64    `json:"version"`
+	Name      string    `json:"name"`
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+// PendingMigration is a migration from the source, which is not applied.
+type PendingMigration struct {
+	Version uint64 `json:"version"`
+	Name    string `json:"name"`
+}
+
+// MigrationState holds the state of the database migrations after the runner execution.
+type MigrationState struct {
+	Driver   DriverType `json:"driver"`
+	Database string     `json:"database"`
+	Table    string     `json:"table"`
+	// Version is the latest applied migration version. It is 0 if no migrations are applied.
+	Version uint64             `json:"version"`
+	Applied []AppliedMigration `json:"applied"`
+	Pending []PendingMigration `json:"pending"`
+}
+
+// OutputFile defines the shape of the output file.
+type OutputFile struct {
+	Value interface{} `json:"value"`
+}
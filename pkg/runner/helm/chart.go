@@ -0,0 +1,325 @@
+package helm
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"capact.io/capact/internal/getter"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/registry"
+	"sigs.k8s.io/yaml"
+)
+
+const ociScheme = "oci://"
+
+// RepositoryCredentialsInput defines type under which the chart repository credentials are stored in TypeInstance.
+type RepositoryCredentialsInput struct {
+	Value RepositoryCredentials `json:"value"`
+}
+
+// RepositoryCredentials holds credentials for the Helm chart repository or OCI registry.
+type RepositoryCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// CABundle holds PEM encoded CA certificates used to verify the chart repository or OCI registry certificate.
+	CABundle              string `json:"caBundle"`
+	InsecureSkipTLSVerify bool   `json:"insecureSkipTLSVerify"`
+}
+
+// chartLoader locates and loads Helm charts from HTTP chart repositories, OCI registries and go-getter sources.
+type chartLoader struct {
+	log                   *zap.Logger
+	repositoryCachePath   string
+	repositoryCredentials string
+}
+
+func newChartLoader(log *zap.Logger, cfg Config) *chartLoader {
+	return &chartLoader{
+		log:                   log,
+		repositoryCachePath:   cfg.RepositoryCachePath,
+		repositoryCredentials: cfg.RepositoryCredentialsTI,
+	}
+}
+
+// Load loads a given Helm chart. The path options are used only for HTTP chart repositories.
+func (l *chartLoader) Load(ctx context.Context, pathOpts *action.ChartPathOptions, chrt Chart) (*chart.Chart, error) {
+	creds, err := l.loadCredentials()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading repository credentials")
+	}
+
+	chrt = normalizeChart(chrt)
+
+	switch {
+	case chrt.Source != "":
+		return l.loadFromSource(ctx, chrt.Source)
+	case isOCIChart(chrt):
+		return l.loadFromRegistry(creds, chrt)
+	default:
+		return l.loadFromRepository(pathOpts, creds, chrt)
+	}
+}
+
+func (l *chartLoader) loadFromRepository(pathOpts *action.ChartPathOptions, creds *RepositoryCredentials, chrt Chart) (*chart.Chart, error) {
+	if creds != nil {
+		pathOpts.Username = creds.Username
+		pathOpts.Password = creds.Password
+		pathOpts.InsecureSkipTLSverify = creds.InsecureSkipTLSVerify
+
+		if creds.CABundle != "" {
+			caFile, err := l.saveCABundle(creds.CABundle)
+			if err != nil {
+				return nil, err
+			}
+			// the CA bundle is needed only to locate the chart
+			defer l.removeTempPath(caFile)
+			pathOpts.CaFile = caFile
+		}
+	}
+
+	chartPath, err := pathOpts.LocateChart(chrt.Name, &cli.EnvSettings{
+		RepositoryCache: l.repositoryCachePath,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while locating Helm chart")
+	}
+
+	chartData, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading Helm chart")
+	}
+	return chartData, nil
+}
+
+func (l *chartLoader) loadFromRegistry(creds *RepositoryCredentials, chrt Chart) (*chart.Chart, error) {
+	if chrt.Version == "" {
+		return nil, errors.New("chart version is required for charts stored in OCI registry")
+	}
+
+	registryCachePath := filepath.Join(l.repositoryCachePath, "registry")
+	cache, err := registry.NewCache(
+		registry.CacheOptRoot(registryCachePath),
+		registry.CacheOptWriter(ioutil.Discard),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating OCI registry cache")
+	}
+
+	resolver, err := newRegistryResolver(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := registry.NewClient(
+		registry.ClientOptCache(cache),
+		registry.ClientOptCredentialsFile(filepath.Join(registryCachePath, "config.json")),
+		registry.ClientOptResolver(resolver),
+		registry.ClientOptWriter(ioutil.Discard),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating OCI registry client")
+	}
+
+	rawRef := fmt.Sprintf("%s/%s:%s", strings.TrimPrefix(chrt.Repo, ociScheme), chrt.Name, chrt.Version)
+	ref, err := registry.ParseReference(rawRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while parsing OCI reference %q", rawRef)
+	}
+
+	l.log.Debug("Pulling Helm chart from OCI registry", zap.String("reference", rawRef))
+	buff, err := client.PullChart(ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while pulling Helm chart %q", rawRef)
+	}
+
+	chartData, err := loader.LoadArchive(buff)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading Helm chart")
+	}
+	return chartData, nil
+}
+
+// newRegistryResolver returns the OCI registry resolver. It uses the repository credentials and TLS settings
+// both to authenticate to the registry and to pull charts, so no separate login is needed.
+func newRegistryResolver(creds *RepositoryCredentials) (*registry.Resolver, error) {
+	tlsConfig, err := registryTLSConfig(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	authOpts := []docker.AuthorizerOpt{docker.WithAuthClient(httpClient)}
+	if creds != nil && creds.Username != "" {
+		authOpts = append(authOpts, docker.WithAuthCreds(func(string) (string, string, error) {
+			return creds.Username, creds.Password, nil
+		}))
+	}
+
+	return &registry.Resolver{
+		Resolver: docker.NewResolver(docker.ResolverOptions{
+			Hosts: docker.ConfigureDefaultRegistries(
+				docker.WithClient(httpClient),
+				docker.WithAuthorizer(docker.NewDockerAuthorizer(authOpts...)),
+			),
+		}),
+	}, nil
+}
+
+func registryTLSConfig(creds *RepositoryCredentials) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if creds == nil {
+		return cfg, nil
+	}
+
+	// #nosec G402: skipping the verification is explicitly requested in the repository credentials
+	cfg.InsecureSkipVerify = creds.InsecureSkipTLSVerify
+
+	if creds.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(creds.CABundle)) {
+			return nil, errors.New("CA bundle does not contain any valid PEM encoded certificate")
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
+
+func (l *chartLoader) loadFromSource(ctx context.Context, source string) (*chart.Chart, error) {
+	dst, err := ioutil.TempDir("", "chart")
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating directory for Helm chart")
+	}
+	// the directory must not exist when cloning git repository
+	if err := os.Remove(dst); err != nil {
+		return nil, errors.Wrap(err, "while preparing directory for Helm chart")
+	}
+	// the chart is loaded into memory, so the downloaded files are not needed afterwards
+	defer l.removeTempPath(dst)
+
+	l.log.Debug("Downloading Helm chart", zap.String("source", source), zap.String("dst", dst))
+	if err := getter.Download(ctx, source, dst, nil); err != nil {
+		return nil, errors.Wrap(err, "while downloading Helm chart")
+	}
+
+	chartPath, err := findChartDir(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	chartData, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading Helm chart")
+	}
+	return chartData, nil
+}
+
+func (l *chartLoader) loadCredentials() (*RepositoryCredentials, error) {
+	if l.repositoryCredentials == "" {
+		return nil, nil
+	}
+
+	l.log.Debug("Reading repository credentials from file", zap.String("path", l.repositoryCredentials))
+	bytes, err := ioutil.ReadFile(filepath.Clean(l.repositoryCredentials))
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading file %q", l.repositoryCredentials)
+	}
+
+	var in RepositoryCredentialsInput
+	if err := yaml.Unmarshal(bytes, &in); err != nil {
+		return nil, errors.Wrapf(err, "while parsing %q", l.repositoryCredentials)
+	}
+	return &in.Value, nil
+}
+
+func (l *chartLoader) saveCABundle(caBundle string) (string, error) {
+	file, err := ioutil.TempFile("", "ca-bundle")
+	if err != nil {
+		return "", errors.Wrap(err, "while creating CA bundle file")
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(caBundle); err != nil {
+		l.removeTempPath(file.Name())
+		return "", errors.Wrap(err, "while writing CA bundle file")
+	}
+	return file.Name(), nil
+}
+
+// removeTempPath removes a given temporary file or directory. A failure is only logged, as it doesn't affect the command result.
+func (l *chartLoader) removeTempPath(path string) {
+	if err := os.RemoveAll(path); err != nil {
+		l.log.Warn("Cannot remove temporary path", zap.String("path", path), zap.Error(err))
+	}
+}
+
+// findChartDir returns the directory with the Chart.yaml file.
+// Chart archives contain the chart in a nested directory, so it is also checked if it's the only one.
+func findChartDir(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, chartutil.ChartfileName)); err == nil {
+		return dir, nil
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "while reading directory %q", dir)
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		nested := filepath.Join(dir, entries[0].Name())
+		if _, err := os.Stat(filepath.Join(nested, chartutil.ChartfileName)); err == nil {
+			return nested, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s file not found in the downloaded Helm chart", chartutil.ChartfileName)
+}
+
+// normalizeChart returns the chart with the OCI reference split into the repository and the chart name.
+// As a result, the output Helm release TypeInstance can be used for the upgrade.
+func normalizeChart(chrt Chart) Chart {
+	if !strings.HasPrefix(chrt.Name, ociScheme) {
+		return chrt
+	}
+
+	idx := strings.LastIndex(chrt.Name, "/")
+	if idx < len(ociScheme) {
+		return chrt
+	}
+	chrt.Repo, chrt.Name = chrt.Name[:idx], chrt.Name[idx+1:]
+	return chrt
+}
+
+func (c Chart) location() string {
+	if c.Source != "" {
+		return c.Source
+	}
+	return c.Repo
+}
+
+func isOCIChart(chrt Chart) bool {
+	return strings.HasPrefix(chrt.Repo, ociScheme) || strings.HasPrefix(chrt.Name, ociScheme)
+}
@@ -0,0 +1,161 @@
+package helm
+
+import (
+	"context"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNormalizeChart(t *testing.T) {
+	tests := map[string]struct {
+		givenChart Chart
+		expChart   Chart
+	}{
+		"Should split OCI reference into repository and name": {
+			givenChart: Chart{Name: "oci://registry.example.com/charts/postgresql", Version: "10.2.5"},
+			expChart:   Chart{Name: "postgresql", Repo: "oci://registry.example.com/charts", Version: "10.2.5"},
+		},
+		"Should not change chart from OCI repository": {
+			givenChart: Chart{Name: "postgresql", Repo: "oci://registry.example.com/charts", Version: "10.2.5"},
+			expChart:   Chart{Name: "postgresql", Repo: "oci://registry.example.com/charts", Version: "10.2.5"},
+		},
+		"Should not change chart from HTTP repository": {
+			givenChart: Chart{Name: "postgresql", Repo: "https://charts.bitnami.com/bitnami", Version: "10.2.5"},
+			expChart:   Chart{Name: "postgresql", Repo: "https://charts.bitnami.com/bitnami", Version: "10.2.5"},
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// when
+			chrt := normalizeChart(tc.givenChart)
+
+			// then
+			assert.Equal(t, tc.expChart, chrt)
+		})
+	}
+}
+
+func TestRegistryTLSConfig(t *testing.T) {
+	// given
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	tests := map[string]struct {
+		creds          *RepositoryCredentials
+		expSkipVerify  bool
+		expTrustsCA    bool
+		expErrorString string
+	}{
+		"Should verify certificates by default": {
+			creds: nil,
+		},
+		"Should skip verification if requested": {
+			creds:         &RepositoryCredentials{InsecureSkipTLSVerify: true},
+			expSkipVerify: true,
+		},
+		"Should trust the CA bundle": {
+			creds:       &RepositoryCredentials{CABundle: string(caBundle)},
+			expTrustsCA: true,
+		},
+		"Should reject invalid CA bundle": {
+			creds:          &RepositoryCredentials{CABundle: "not a certificate"},
+			expErrorString: "CA bundle does not contain any valid PEM encoded certificate",
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// when
+			cfg, err := registryTLSConfig(tc.creds)
+
+			// then
+			if tc.expErrorString != "" {
+				assert.EqualError(t, err, tc.expErrorString)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expSkipVerify, cfg.InsecureSkipVerify)
+
+			if !tc.expTrustsCA {
+				return
+			}
+			cli := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+			resp, err := cli.Get(srv.URL)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+		})
+	}
+}
+
+func TestFindChartDir(t *testing.T) {
+	tests := map[string]struct {
+		givenChartDir string
+		expErr        string
+	}{
+		"Should find chart in the root directory": {
+			givenChartDir: ".",
+		},
+		"Should find chart in the nested directory": {
+			givenChartDir: "postgresql",
+		},
+		"Should fail when chart is nested too deep": {
+			givenChartDir: "charts/postgresql",
+			expErr:        "Chart.yaml file not found in the downloaded Helm chart",
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// given
+			dir := t.TempDir()
+			chartDir := filepath.Join(dir, tc.givenChartDir)
+			require.NoError(t, os.MkdirAll(chartDir, 0755))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte("name: postgresql"), 0600))
+
+			// when
+			found, err := findChartDir(dir)
+
+			// then
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Clean(chartDir), found)
+		})
+	}
+}
+
+func TestChartLoader_LoadFromSourceRemovesDownloadedFiles(t *testing.T) {
+	// given
+	tmpDir := t.TempDir()
+	oldTmpDir := os.Getenv("TMPDIR")
+	require.NoError(t, os.Setenv("TMPDIR", tmpDir))
+	defer os.Setenv("TMPDIR", oldTmpDir)
+
+	srcDir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(srcDir, "Chart.yaml"), []byte("apiVersion: v2\nname: postgresql\nversion: 10.2.5\n"), 0600))
+
+	loader := newChartLoader(zap.NewNop(), Config{})
+
+	// when
+	chartData, err := loader.loadFromSource(context.Background(), srcDir)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, "postgresql", chartData.Name())
+	entries, err := ioutil.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -38,6 +38,10 @@ func (r *helmRunner) Do(ctx context.Context, in runner.StartInput) (*runner.Wait
 		return nil, err
 	}
 
+	if cmdInput.Args.RunTests && cmdInput.Args.Output.Additional.UseHelmTemplateStorage {
+		return nil, errors.New("running tests is not supported together with the Helm template storage for additional output")
+	}
+
 	renderer := NewRenderer()
 	outputter := NewOutputter(r.log, renderer)
 
@@ -47,6 +51,10 @@ func (r *helmRunner) Do(ctx context.Context, in runner.StartInput) (*runner.Wait
 		helmCmd = newInstaller(r.log, r.cfg, actionCfgProducer, outputter)
 	case UpgradeCommandType:
 		helmCmd = newUpgrader(r.log, r.cfg, actionCfgProducer, outputter)
+	case UninstallCommandType:
+		helmCmd = newUninstaller(r.log, r.cfg, actionCfgProducer, outputter)
+	case RollbackCommandType:
+		helmCmd = newRollbacker(r.log, r.cfg, actionCfgProducer, outputter)
 	default:
 		return nil, errors.New("Unsupported command")
 	}
@@ -121,10 +129,12 @@ func (r *helmRunner) readCommandData(in runner.StartInput) (Input, error) {
 }
 
 func (r *helmRunner) saveOutput(out Output) error {
-	r.log.Debug("Saving Helm release output", zap.String("path", r.cfg.Output.HelmReleaseFilePath))
-	err := runner.SaveToFile(r.cfg.Output.HelmReleaseFilePath, out.Release)
-	if err != nil {
-		return errors.Wrap(err, "while saving Helm release output")
+	if out.Release != nil {
+		r.log.Debug("Saving Helm release output", zap.String("path", r.cfg.Output.HelmReleaseFilePath))
+		err := runner.SaveToFile(r.cfg.Output.HelmReleaseFilePath, out.Release)
+		if err != nil {
+			return errors.Wrap(err, "while saving Helm release output")
+		}
 	}
 
 	if out.Additional == nil {
@@ -132,7 +142,7 @@ func (r *helmRunner) saveOutput(out Output) error {
 	}
 
 	r.log.Debug("Saving additional output", zap.String("path", r.cfg.Output.AdditionalFilePath))
-	err = runner.SaveToFile(r.cfg.Output.AdditionalFilePath, out.Additional)
+	err := runner.SaveToFile(r.cfg.Output.AdditionalFilePath, out.Additional)
 	if err != nil {
 		return errors.Wrap(err, "while saving default output")
 	}
@@ -8,29 +8,27 @@ import (
 
 	"github.com/pkg/errors"
 	"helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/chart/loader"
-	"helm.sh/helm/v3/pkg/cli"
 )
 
 type installer struct {
-	actionCfgProducer   actionConfigProducer
-	log                 *zap.Logger
-	out                 outputter
-	repositoryCachePath string
-	helmDriver          string
+	actionCfgProducer actionConfigProducer
+	log               *zap.Logger
+	out               outputter
+	charts            *chartLoader
+	helmDriver        string
 }
 
 func newInstaller(log *zap.Logger, cfg Config, actionCfgProducer actionConfigProducer, outputter outputter) *installer {
 	return &installer{
-		log:                 log,
-		actionCfgProducer:   actionCfgProducer,
-		repositoryCachePath: cfg.RepositoryCachePath,
-		helmDriver:          cfg.HelmDriver,
-		out:                 outputter,
+		log:               log,
+		actionCfgProducer: actionCfgProducer,
+		charts:            newChartLoader(log, cfg),
+		helmDriver:        cfg.HelmDriver,
+		out:               outputter,
 	}
 }
 
-func (i *installer) Do(_ context.Context, in Input) (Output, Status, error) {
+func (i *installer) Do(ctx context.Context, in Input) (Output, Status, error) {
 	actCfg, err := i.actionCfgProducer(in.Ctx.Platform.Namespace)
 	if err != nil {
 		return Output{}, Status{}, errors.Wrap(err, "while creating Helm action config")
@@ -38,22 +36,17 @@ func (i *installer) Do(_ context.Context, in Input) (Output, Status, error) {
 
 	installCli := i.initActionInstallFromInput(actCfg, in)
 
-	name, chartName, err := i.nameAndChart(installCli, in.Args.Name, in.Args.Chart.Name)
+	chrt := normalizeChart(in.Args.Chart)
+
+	name, _, err := i.nameAndChart(installCli, in.Args.Name, chrt.Name)
 	if err != nil {
 		return Output{}, Status{}, errors.Wrap(err, "while getting release name")
 	}
 	installCli.ReleaseName = name
 
-	chartPath, err := installCli.ChartPathOptions.LocateChart(chartName, &cli.EnvSettings{
-		RepositoryCache: i.repositoryCachePath,
-	})
-	if err != nil {
-		return Output{}, Status{}, errors.Wrap(err, "while locating Helm chart")
-	}
-
-	chartData, err := loader.Load(chartPath)
+	chartData, err := i.charts.Load(ctx, &installCli.ChartPathOptions, chrt)
 	if err != nil {
-		return Output{}, Status{}, errors.Wrap(err, "while loading Helm chart")
+		return Output{}, Status{}, err
 	}
 
 	values, err := readValueOverrides(in.Args.Values, in.Args.ValuesFromFile)
@@ -70,7 +63,7 @@ func (i *installer) Do(_ context.Context, in Input) (Output, Status, error) {
 		return Output{}, Status{}, errors.Wrap(err, "Helm release is nil")
 	}
 
-	releaseOut, err := i.out.ProduceHelmRelease(in.Args.Output.HelmRelease, in.Args.Chart.Repo, i.helmDriver, helmRelease)
+	releaseOut, err := i.out.ProduceHelmRelease(in.Args.Output.HelmRelease, chrt, i.helmDriver, helmRelease)
 	if err != nil {
 		return Output{}, Status{}, errors.Wrap(err, "while saving default output")
 	}
@@ -80,10 +73,18 @@ func (i *installer) Do(_ context.Context, in Input) (Output, Status, error) {
 		return Output{}, Status{}, errors.Wrap(err, "while rendering and saving additional output")
 	}
 
+	additionalOut, testFailure, err := testReleaseIfRequested(actCfg, in, helmRelease, additionalOut)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while testing Helm release")
+	}
+
 	status := Status{
 		Succeeded: true,
 		Message:   fmt.Sprintf("release %q installed successfully in namespace %q", helmRelease.Name, helmRelease.Namespace),
 	}
+	if testFailure != "" {
+		status = Status{Succeeded: false, Message: testFailure}
+	}
 
 	return Output{
 		Release:    releaseOut,
@@ -93,7 +94,6 @@ func (i *installer) Do(_ context.Context, in Input) (Output, Status, error) {
 
 func (i *installer) initActionInstallFromInput(cfg *action.Configuration, in Input) *action.Install {
 	installCli := action.NewInstall(cfg)
-	installCli.Wait = true
 
 	// context
 	installCli.DryRun = in.Ctx.DryRun
@@ -102,6 +102,9 @@ func (i *installer) initActionInstallFromInput(cfg *action.Configuration, in Inp
 
 	// common args
 	installCli.DisableHooks = in.Args.CommonArgs.NoHooks
+	installCli.Wait = in.Args.CommonArgs.Wait
+	installCli.WaitForJobs = in.Args.CommonArgs.WaitForJobs
+	installCli.Atomic = in.Args.CommonArgs.Atomic
 	installCli.ChartPathOptions.Version = in.Args.CommonArgs.Chart.Version
 	installCli.ChartPathOptions.RepoURL = in.Args.CommonArgs.Chart.Repo
 
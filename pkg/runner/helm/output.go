@@ -67,7 +67,8 @@ func NewOutputter(log *zap.Logger, renderer ChartRenderer) *Outputter {
 }
 
 // ProduceHelmRelease creates an output artifacts with the Helm release data.
-func (o *Outputter) ProduceHelmRelease(args ReleaseOutputArgs, repository, driver string, helmRelease *release.Release) ([]byte, error) {
+// The chart is used only to get the chart location, other details are taken from the Helm release.
+func (o *Outputter) ProduceHelmRelease(args ReleaseOutputArgs, chrt Chart, driver string, helmRelease *release.Release) ([]byte, error) {
 	outputData := OutputFile{}
 
 	if args.UseHelmReleaseStorage {
@@ -78,7 +79,7 @@ func (o *Outputter) ProduceHelmRelease(args ReleaseOutputArgs, repository, drive
 					Namespace: helmRelease.Namespace,
 					Driver:    ptr.String(driver),
 				},
-				ChartLocation: repository,
+				ChartLocation: chrt.location(),
 			},
 		}
 	} else {
@@ -88,7 +89,8 @@ func (o *Outputter) ProduceHelmRelease(args ReleaseOutputArgs, repository, drive
 			Chart: Chart{
 				Name:    helmRelease.Chart.Metadata.Name,
 				Version: helmRelease.Chart.Metadata.Version,
-				Repo:    repository,
+				Repo:    chrt.Repo,
+				Source:  chrt.Source,
 			},
 		}
 	}
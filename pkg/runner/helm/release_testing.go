@@ -0,0 +1,130 @@
+package helm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	"sigs.k8s.io/yaml"
+)
+
+const testResultsOutputKey = "tests"
+
+// TestResult holds the result of a single Helm test.
+type TestResult struct {
+	Name        string `json:"name"`
+	Phase       string `json:"phase"`
+	StartedAt   string `json:"startedAt,omitempty"`
+	CompletedAt string `json:"completedAt,omitempty"`
+}
+
+// runTests runs the Helm tests for a given release.
+// It returns the test results and the failure message, if any test failed.
+func runTests(cfg *action.Configuration, rel *release.Release, timeout time.Duration) ([]TestResult, string, error) {
+	testCli := action.NewReleaseTesting(cfg)
+	testCli.Namespace = rel.Namespace
+	testCli.Timeout = timeout
+
+	testedRel, err := testCli.Run(rel.Name)
+	if testedRel == nil {
+		return nil, "", errors.Wrap(err, "while running Helm tests")
+	}
+
+	results := testResults(testedRel)
+	if err != nil {
+		return results, fmt.Sprintf("release %q tests failed: %s", rel.Name, err), nil
+	}
+
+	return results, "", nil
+}
+
+// testResults returns the results of the test hooks from a given release.
+func testResults(rel *release.Release) []TestResult {
+	var results []TestResult
+	for _, hook := range rel.Hooks {
+		if !isTestHook(hook) {
+			continue
+		}
+
+		result := TestResult{
+			Name:  hook.Name,
+			Phase: hook.LastRun.Phase.String(),
+		}
+		if !hook.LastRun.StartedAt.IsZero() {
+			result.StartedAt = hook.LastRun.StartedAt.UTC().Format(time.RFC3339)
+		}
+		if !hook.LastRun.CompletedAt.IsZero() {
+			result.CompletedAt = hook.LastRun.CompletedAt.UTC().Format(time.RFC3339)
+		}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+func isTestHook(hook *release.Hook) bool {
+	for _, event := range hook.Events {
+		if event == release.HookTest {
+			return true
+		}
+	}
+	return false
+}
+
+// addTestResults adds the test results under the `tests` key to the additional output value.
+func addTestResults(additional []byte, results []TestResult) ([]byte, error) {
+	outputData := OutputFile{}
+	if len(additional) > 0 {
+		if err := yaml.Unmarshal(additional, &outputData); err != nil {
+			return nil, errors.Wrap(err, "while unmarshaling additional output")
+		}
+	}
+
+	value := map[string]interface{}{}
+	if outputData.Value != nil {
+		var ok bool
+		value, ok = outputData.Value.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("test results can be added only to the additional output which is an object")
+		}
+	}
+
+	if _, exists := value[testResultsOutputKey]; exists {
+		return nil, fmt.Errorf("additional output already contains the %q property", testResultsOutputKey)
+	}
+
+	value[testResultsOutputKey] = results
+	outputData.Value = value
+
+	bytes, err := yaml.Marshal(&outputData)
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshaling yaml")
+	}
+	return bytes, nil
+}
+
+// testReleaseIfRequested runs the Helm tests if requested and adds the results to the additional output.
+// It returns the updated additional output and the failure message, if any test failed.
+func testReleaseIfRequested(cfg *action.Configuration, in Input, rel *release.Release, additional []byte) ([]byte, string, error) {
+	if !in.Args.RunTests || in.Ctx.DryRun {
+		return additional, "", nil
+	}
+
+	results, failure, err := runTests(cfg, rel, in.Ctx.Timeout.Duration())
+	if err != nil {
+		return nil, "", err
+	}
+
+	additional, err = addTestResults(additional, results)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "while adding test results to additional output")
+	}
+
+	return additional, failure, nil
+}
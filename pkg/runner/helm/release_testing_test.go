@@ -0,0 +1,98 @@
+package helm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+func TestTestResults(t *testing.T) {
+	// given
+	started := helmtime.Time{Time: time.Date(2021, 10, 1, 10, 0, 0, 0, time.UTC)}
+	completed := helmtime.Time{Time: time.Date(2021, 10, 1, 10, 0, 5, 0, time.UTC)}
+
+	rel := &release.Release{
+		Hooks: []*release.Hook{
+			{
+				Name:    "postgresql-test-connection",
+				Events:  []release.HookEvent{release.HookTest},
+				LastRun: release.HookExecution{StartedAt: started, CompletedAt: completed, Phase: release.HookPhaseFailed},
+			},
+			{
+				Name:   "postgresql-migrate",
+				Events: []release.HookEvent{release.HookPostInstall},
+			},
+			{
+				Name:    "postgresql-test-auth",
+				Events:  []release.HookEvent{release.HookTest},
+				LastRun: release.HookExecution{StartedAt: started, CompletedAt: completed, Phase: release.HookPhaseSucceeded},
+			},
+		},
+	}
+
+	// when
+	results := testResults(rel)
+
+	// then
+	assert.Equal(t, []TestResult{
+		{Name: "postgresql-test-auth", Phase: "Succeeded", StartedAt: "2021-10-01T10:00:00Z", CompletedAt: "2021-10-01T10:00:05Z"},
+		{Name: "postgresql-test-connection", Phase: "Failed", StartedAt: "2021-10-01T10:00:00Z", CompletedAt: "2021-10-01T10:00:05Z"},
+	}, results)
+}
+
+func TestAddTestResults(t *testing.T) {
+	results := []TestResult{{Name: "postgresql-test-connection", Phase: "Succeeded"}}
+
+	tests := map[string]struct {
+		givenAdditional []byte
+		expAdditional   string
+		expErr          string
+	}{
+		"Should create additional output with test results": {
+			givenAdditional: nil,
+			expAdditional: `backend: null
+value:
+  tests:
+  - name: postgresql-test-connection
+    phase: Succeeded
+`,
+		},
+		"Should add test results to existing additional output": {
+			givenAdditional: []byte("value:\n  host: postgresql\n"),
+			expAdditional: `backend: null
+value:
+  host: postgresql
+  tests:
+  - name: postgresql-test-connection
+    phase: Succeeded
+`,
+		},
+		"Should fail when additional output is not an object": {
+			givenAdditional: []byte("value: postgresql\n"),
+			expErr:          "test results can be added only to the additional output which is an object",
+		},
+		"Should fail when additional output contains tests property": {
+			givenAdditional: []byte("value:\n  tests: true\n"),
+			expErr:          `additional output already contains the "tests" property`,
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// when
+			out, err := addTestResults(tc.givenAdditional, results)
+
+			// then
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expAdditional, string(out))
+		})
+	}
+}
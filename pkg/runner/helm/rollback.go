@@ -0,0 +1,109 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/action"
+)
+
+type rollbacker struct {
+	actionCfgProducer actionConfigProducer
+	log               *zap.Logger
+	out               outputter
+	helmReleasePath   string
+	helmDriver        string
+}
+
+func newRollbacker(log *zap.Logger, cfg Config, actionCfgProducer actionConfigProducer, outputter outputter) helmCommand {
+	return &rollbacker{
+		log:               log,
+		actionCfgProducer: actionCfgProducer,
+		out:               outputter,
+		helmReleasePath:   cfg.HelmReleasePath,
+		helmDriver:        cfg.HelmDriver,
+	}
+}
+
+// Do executes rollback process.
+//
+// It uses the Helm Release namespace instead of the namespace from the Runner Context, same as the upgrade command.
+// The output Helm release is produced from the release revision created by the rollback.
+func (i *rollbacker) Do(_ context.Context, in Input) (Output, Status, error) {
+	if i.helmReleasePath == "" {
+		return Output{}, Status{}, errors.New("path to Helm Release is required for rollback")
+	}
+
+	helmReleaseData, err := loadHelmReleaseData(i.log, i.helmReleasePath)
+	if err != nil {
+		return Output{}, Status{}, err
+	}
+
+	actCfg, err := i.actionCfgProducer(helmReleaseData.Namespace)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while creating Helm action config")
+	}
+
+	rollbackCli := i.initActionRollbackFromInput(actCfg, in)
+
+	if err := rollbackCli.Run(helmReleaseData.Name); err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while rolling back Helm release")
+	}
+
+	helmRelease, err := action.NewGet(actCfg).Run(helmReleaseData.Name)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while getting Helm release")
+	}
+
+	releaseOut, err := i.out.ProduceHelmRelease(in.Args.Output.HelmRelease, helmReleaseData.Chart, i.helmDriver, helmRelease)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while saving default output")
+	}
+
+	additionalOut, err := i.out.ProduceAdditional(in.Args.Output, helmRelease.Chart, i.helmDriver, helmRelease)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while rendering and saving additional output")
+	}
+
+	additionalOut, testFailure, err := testReleaseIfRequested(actCfg, in, helmRelease, additionalOut)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while testing Helm release")
+	}
+
+	status := Status{
+		Succeeded: true,
+		Message:   fmt.Sprintf("release %q rolled back successfully in namespace %q", helmRelease.Name, helmRelease.Namespace),
+	}
+	if testFailure != "" {
+		status = Status{Succeeded: false, Message: testFailure}
+	}
+
+	return Output{
+		Release:    releaseOut,
+		Additional: additionalOut,
+	}, status, nil
+}
+
+func (i *rollbacker) initActionRollbackFromInput(cfg *action.Configuration, in Input) *action.Rollback {
+	rollbackCli := action.NewRollback(cfg)
+
+	// context
+	rollbackCli.DryRun = in.Ctx.DryRun
+	rollbackCli.Timeout = in.Ctx.Timeout.Duration()
+
+	// common args
+	rollbackCli.DisableHooks = in.Args.CommonArgs.NoHooks
+	rollbackCli.Wait = in.Args.CommonArgs.Wait
+	rollbackCli.WaitForJobs = in.Args.CommonArgs.WaitForJobs
+
+	// rollback args
+	rollbackCli.MaxHistory = in.Args.RollbackArgs.MaxHistory
+	rollbackCli.Version = in.Args.RollbackArgs.Revision
+	rollbackCli.Force = in.Args.RollbackArgs.Force
+	rollbackCli.Recreate = in.Args.RollbackArgs.Recreate
+	rollbackCli.CleanupOnFail = in.Args.RollbackArgs.CleanupOnFail
+
+	return rollbackCli
+}
@@ -7,18 +7,19 @@ import (
 )
 
 type outputter interface {
-	ProduceHelmRelease(args ReleaseOutputArgs, repository, driver string, helmRelease *release.Release) ([]byte, error)
+	ProduceHelmRelease(args ReleaseOutputArgs, chrt Chart, driver string, helmRelease *release.Release) ([]byte, error)
 	ProduceAdditional(args OutputArgs, chrt *chart.Chart, driver string, rel *release.Release) ([]byte, error)
 }
 
 // Config holds Runner related configuration.
 type Config struct {
-	OptionalKubeconfigTI string `envconfig:"optional"`
-	Command              CommandType
-	HelmReleasePath      string `envconfig:"optional"`
-	HelmDriver           string `envconfig:"default=secrets"`
-	RepositoryCachePath  string `envconfig:"default=/tmp/helm"`
-	Output               struct {
+	OptionalKubeconfigTI    string `envconfig:"optional"`
+	Command                 CommandType
+	HelmReleasePath         string `envconfig:"optional"`
+	HelmDriver              string `envconfig:"default=secrets"`
+	RepositoryCachePath     string `envconfig:"default=/tmp/helm"`
+	RepositoryCredentialsTI string `envconfig:"optional"`
+	Output                  struct {
 		HelmReleaseFilePath string `envconfig:"default=/tmp/helm-release.yaml"`
 		AdditionalFilePath  string `envconfig:"default=/tmp/additional.yaml"`
 	}
@@ -32,17 +33,30 @@ const (
 	InstallCommandType = "install"
 	// UpgradeCommandType is an operation to upgrade an Helm release.
 	UpgradeCommandType = "upgrade"
+	// UninstallCommandType is an operation to uninstall an Helm release.
+	UninstallCommandType = "uninstall"
+	// RollbackCommandType is an operation to roll back an Helm release to a previous revision.
+	RollbackCommandType = "rollback"
 	// MaxHistoryDefault limits the maximum number of revisions saved per release.
 	// Same value as defined by `helm upgrade` cmd: https://github.com/helm/helm/blob/a499b4b179307c267bdf3ec49b880e3dbd2a5591/pkg/cli/environment.go#L37-L38
 	MaxHistoryDefault = 10
+	// RollbackMaxHistoryDefault limits the maximum number of revisions saved per release during rollback.
+	// Same value as defined by `helm rollback` cmd.
+	RollbackMaxHistoryDefault = 10
 )
 
 // DefaultArguments returns Helm Arguments with default values.
 func DefaultArguments() Arguments {
 	return Arguments{
+		CommonArgs: CommonArgs{
+			Wait: true,
+		},
 		UpgradeArgs: UpgradeArgs{
 			MaxHistory: MaxHistoryDefault,
 		},
+		RollbackArgs: RollbackArgs{
+			MaxHistory: RollbackMaxHistoryDefault,
+		},
 	}
 }
 
@@ -51,6 +65,8 @@ type Arguments struct {
 	CommonArgs
 	InstallArgs
 	UpgradeArgs
+	UninstallArgs
+	RollbackArgs
 }
 
 // CommonArgs stores common arguments used in every operation.
@@ -60,6 +76,15 @@ type CommonArgs struct {
 	NoHooks        bool                   `json:"noHooks"`
 	Chart          Chart                  `json:"chart"`
 	Output         OutputArgs             `json:"output"`
+	// Wait specifies whether to wait until all resources are ready or deleted.
+	Wait bool `json:"wait"`
+	// WaitForJobs specifies whether to wait until all Jobs are completed. Used only if Wait is set.
+	WaitForJobs bool `json:"waitForJobs"`
+	// Atomic specifies whether to roll back the changes if the install or upgrade fails. It implies Wait.
+	Atomic bool `json:"atomic"`
+	// RunTests specifies whether to run the Helm tests after the install, upgrade or rollback.
+	// The test results are added to the additional output under the `tests` property.
+	RunTests bool `json:"runTests"`
 }
 
 // InstallArgs stores input arguments to the install operation.
@@ -76,6 +101,23 @@ type UpgradeArgs struct {
 	MaxHistory  int  `json:"maxHistory"`
 }
 
+// UninstallArgs stores input arguments for the uninstall operation.
+type UninstallArgs struct {
+	KeepHistory bool `json:"keepHistory"`
+}
+
+// RollbackArgs stores input arguments for the rollback operation.
+type RollbackArgs struct {
+	// Revision specifies the revision to roll back to. If not set, the release is rolled back to the previous revision.
+	Revision      int  `json:"revision"`
+	Force         bool `json:"force"`
+	Recreate      bool `json:"recreate"`
+	CleanupOnFail bool `json:"cleanupOnFail"`
+	// MaxHistory limits the maximum number of revisions saved per release.
+	// It uses a separate key, as the `maxHistory` key is already used by the upgrade operation.
+	MaxHistory int `json:"rollbackMaxHistory"`
+}
+
 // OutputArgs stores input arguments for generating the output files.
 type OutputArgs struct {
 	HelmRelease ReleaseOutputArgs    `json:"helmRelease"`
@@ -98,10 +140,13 @@ type AdditionalOutputArgs struct {
 }
 
 // Chart represents a Helm chart.
+// The chart can be stored in an HTTP chart repository, in an OCI registry, if the name or the repository has the `oci://` prefix,
+// or in any location supported by go-getter, such as Git repository or HTTP archive, if the source is set.
 type Chart struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
 	Repo    string `json:"repo"`
+	Source  string `json:"source,omitempty"`
 }
 
 // ChartRelease represents a Helm chart release.
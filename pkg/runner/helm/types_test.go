@@ -10,16 +10,34 @@ import (
 
 func TestDefaultArguments(t *testing.T) {
 	tests := map[string]struct {
-		givenArgs     []byte
-		expMaxHistory int
+		givenArgs             []byte
+		expMaxHistory         int
+		expRollbackMaxHistory int
+		expWait               bool
 	}{
-		"Should set default to MaxHistory": {
-			givenArgs:     []byte(`{}`),
-			expMaxHistory: MaxHistoryDefault,
+		"Should set default to MaxHistory and Wait": {
+			givenArgs:             []byte(`{}`),
+			expMaxHistory:         MaxHistoryDefault,
+			expRollbackMaxHistory: RollbackMaxHistoryDefault,
+			expWait:               true,
 		},
 		"Should override MaxHistory": {
-			givenArgs:     []byte(`{"maxHistory": 12}`),
-			expMaxHistory: 12,
+			givenArgs:             []byte(`{"maxHistory": 12}`),
+			expMaxHistory:         12,
+			expRollbackMaxHistory: RollbackMaxHistoryDefault,
+			expWait:               true,
+		},
+		"Should override rollback MaxHistory": {
+			givenArgs:             []byte(`{"rollbackMaxHistory": 5}`),
+			expMaxHistory:         MaxHistoryDefault,
+			expRollbackMaxHistory: 5,
+			expWait:               true,
+		},
+		"Should override Wait": {
+			givenArgs:             []byte(`{"wait": false}`),
+			expMaxHistory:         MaxHistoryDefault,
+			expRollbackMaxHistory: RollbackMaxHistoryDefault,
+			expWait:               false,
 		},
 	}
 	for tn, tc := range tests {
@@ -32,7 +50,9 @@ func TestDefaultArguments(t *testing.T) {
 
 			// then
 			require.NoError(t, err)
-			assert.Equal(t, tc.expMaxHistory, args.MaxHistory)
+			assert.Equal(t, tc.expMaxHistory, args.UpgradeArgs.MaxHistory)
+			assert.Equal(t, tc.expRollbackMaxHistory, args.RollbackArgs.MaxHistory)
+			assert.Equal(t, tc.expWait, args.Wait)
 		})
 	}
 }
@@ -0,0 +1,180 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const deletionCheckPollInterval = 2 * time.Second
+
+type uninstaller struct {
+	actionCfgProducer actionConfigProducer
+	log               *zap.Logger
+	out               outputter
+	helmReleasePath   string
+	helmDriver        string
+}
+
+func newUninstaller(log *zap.Logger, cfg Config, actionCfgProducer actionConfigProducer, outputter outputter) helmCommand {
+	return &uninstaller{
+		log:               log,
+		actionCfgProducer: actionCfgProducer,
+		out:               outputter,
+		helmReleasePath:   cfg.HelmReleasePath,
+		helmDriver:        cfg.HelmDriver,
+	}
+}
+
+// Do executes uninstall process.
+//
+// It uses the Helm Release namespace instead of the namespace from the Runner Context, same as the upgrade command.
+// If the wait argument is set, it waits until all release resources are deleted.
+// Release which doesn't exist is treated as already uninstalled.
+// The output Helm release describes the uninstalled release, so the Action can update or delete the Helm release TypeInstance.
+func (i *uninstaller) Do(_ context.Context, in Input) (Output, Status, error) {
+	if i.helmReleasePath == "" {
+		return Output{}, Status{}, errors.New("path to Helm Release is required for uninstall")
+	}
+
+	helmReleaseData, err := loadHelmReleaseData(i.log, i.helmReleasePath)
+	if err != nil {
+		return Output{}, Status{}, err
+	}
+
+	actCfg, err := i.actionCfgProducer(helmReleaseData.Namespace)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while creating Helm action config")
+	}
+
+	uninstallCli := i.initActionUninstallFromInput(actCfg, in)
+
+	res, err := uninstallCli.Run(helmReleaseData.Name)
+	switch {
+	case errors.Is(err, driver.ErrReleaseNotFound):
+		i.log.Info("Helm release not found, skipping uninstall", zap.String("name", helmReleaseData.Name))
+		releaseOut, err := i.produceUninstalledRelease(helmReleaseData, nil)
+		if err != nil {
+			return Output{}, Status{}, err
+		}
+		return Output{Release: releaseOut}, Status{
+			Succeeded: true,
+			Message:   fmt.Sprintf("release %q not found in namespace %q", helmReleaseData.Name, helmReleaseData.Namespace),
+		}, nil
+	case err != nil:
+		return Output{}, Status{}, errors.Wrap(err, "while uninstalling Helm release")
+	}
+
+	if in.Args.Wait && !in.Ctx.DryRun && res != nil && res.Release != nil {
+		err := i.waitForDeletion(actCfg, res.Release.Manifest, in.Ctx.Timeout.Duration())
+		if err != nil {
+			return Output{}, Status{}, errors.Wrap(err, "while waiting for Helm release resources deletion")
+		}
+	}
+
+	var uninstalled *release.Release
+	if res != nil {
+		uninstalled = res.Release
+	}
+	releaseOut, err := i.produceUninstalledRelease(helmReleaseData, uninstalled)
+	if err != nil {
+		return Output{}, Status{}, err
+	}
+
+	status := Status{
+		Succeeded: true,
+		Message:   fmt.Sprintf("release %q uninstalled successfully in namespace %q", helmReleaseData.Name, helmReleaseData.Namespace),
+	}
+
+	return Output{Release: releaseOut}, status, nil
+}
+
+// produceUninstalledRelease creates the Helm release output for the uninstalled release.
+// The output is always stored as a TypeInstance value, as the release is no longer available in the Helm storage.
+// If Helm didn't return the uninstalled release, the output is created from the input Helm release data.
+func (i *uninstaller) produceUninstalledRelease(helmReleaseData ChartRelease, uninstalled *release.Release) ([]byte, error) {
+	if uninstalled == nil || uninstalled.Chart == nil || uninstalled.Chart.Metadata == nil {
+		uninstalled = &release.Release{
+			Name:      helmReleaseData.Name,
+			Namespace: helmReleaseData.Namespace,
+			Chart: &chart.Chart{
+				Metadata: &chart.Metadata{
+					Name:    helmReleaseData.Chart.Name,
+					Version: helmReleaseData.Chart.Version,
+				},
+			},
+		}
+	}
+
+	releaseOut, err := i.out.ProduceHelmRelease(ReleaseOutputArgs{}, helmReleaseData.Chart, i.helmDriver, uninstalled)
+	if err != nil {
+		return nil, errors.Wrap(err, "while saving default output")
+	}
+
+	return releaseOut, nil
+}
+
+func (i *uninstaller) initActionUninstallFromInput(cfg *action.Configuration, in Input) *action.Uninstall {
+	uninstallCli := action.NewUninstall(cfg)
+
+	// context
+	uninstallCli.DryRun = in.Ctx.DryRun
+	uninstallCli.Timeout = in.Ctx.Timeout.Duration()
+
+	// common args
+	uninstallCli.DisableHooks = in.Args.CommonArgs.NoHooks
+
+	// uninstall args
+	uninstallCli.KeepHistory = in.Args.UninstallArgs.KeepHistory
+
+	return uninstallCli
+}
+
+// waitForDeletion waits until the resources from a given release manifest are deleted.
+// Resources annotated with the keep resource policy are skipped, as Helm doesn't delete them.
+func (i *uninstaller) waitForDeletion(cfg *action.Configuration, manifest string, timeout time.Duration) error {
+	resources, err := cfg.KubeClient.Build(strings.NewReader(manifest), false)
+	if err != nil {
+		return errors.Wrap(err, "while building resources from Helm release manifest")
+	}
+
+	return wait.PollImmediate(deletionCheckPollInterval, timeout, func() (bool, error) {
+		for _, info := range resources {
+			if keptByPolicy(info.Object) {
+				continue
+			}
+
+			err := info.Get()
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return false, errors.Wrapf(err, "while getting %s %q", info.Mapping.GroupVersionKind.Kind, info.Name)
+			}
+
+			i.log.Debug("Waiting for resource deletion", zap.String("kind", info.Mapping.GroupVersionKind.Kind), zap.String("name", info.Name))
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+func keptByPolicy(obj interface{}) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return accessor.GetAnnotations()[kube.ResourcePolicyAnno] == kube.KeepPolicy
+}
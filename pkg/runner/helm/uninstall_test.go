@@ -0,0 +1,168 @@
+package helm
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const uninstalledReleaseOutput = `backend: null
+value:
+  chart:
+    name: postgresql
+    repo: https://charts.bitnami.com/bitnami
+    version: 10.2.5
+  name: example-release
+  namespace: default
+`
+
+func TestUninstaller_Do(t *testing.T) {
+	tests := map[string]struct {
+		givenReleases   []*release.Release
+		givenBuildError error
+		expStatus       Status
+		expReleaseOut   string
+		expErr          string
+	}{
+		"Should uninstall the release and produce the uninstalled Helm release": {
+			givenReleases: []*release.Release{fixRelease("example-release", "default")},
+			expStatus: Status{
+				Succeeded: true,
+				Message:   `release "example-release" uninstalled successfully in namespace "default"`,
+			},
+			expReleaseOut: uninstalledReleaseOutput,
+		},
+		"Should succeed and produce the Helm release from input when release is missing": {
+			expStatus: Status{
+				Succeeded: true,
+				Message:   `release "example-release" not found in namespace "default"`,
+			},
+			expReleaseOut: uninstalledReleaseOutput,
+		},
+		"Should fail when uninstall fails": {
+			givenReleases:   []*release.Release{fixRelease("example-release", "default")},
+			givenBuildError: errors.New("build error"),
+			expErr:          "while uninstalling Helm release: uninstallation completed with 1 error(s): unable to build kubernetes objects for delete: build error",
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// given
+			releases := storage.Init(driver.NewMemory())
+			for _, rel := range tc.givenReleases {
+				require.NoError(t, releases.Create(rel))
+			}
+
+			cfg := Config{
+				HelmReleasePath: fixHelmReleaseInputFile(t),
+				HelmDriver:      "secrets",
+			}
+			actionCfgProducer := func(_ string) (*action.Configuration, error) {
+				return &action.Configuration{
+					Releases: releases,
+					KubeClient: &kubefake.FailingKubeClient{
+						PrintingKubeClient: kubefake.PrintingKubeClient{Out: ioutil.Discard},
+						BuildError:         tc.givenBuildError,
+					},
+					Log: func(_ string, _ ...interface{}) {},
+				}, nil
+			}
+			uninstaller := newUninstaller(zap.NewNop(), cfg, actionCfgProducer, NewOutputter(zap.NewNop(), NewRenderer()))
+
+			// when
+			out, status, err := uninstaller.Do(context.Background(), Input{Args: DefaultArguments()})
+
+			// then
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expStatus, status)
+			assert.Equal(t, tc.expReleaseOut, string(out.Release))
+			assert.Nil(t, out.Additional)
+
+			_, err = releases.Last("example-release")
+			assert.True(t, errors.Is(err, driver.ErrReleaseNotFound))
+		})
+	}
+}
+
+func TestKeptByPolicy(t *testing.T) {
+	tests := map[string]struct {
+		givenAnnotations map[string]string
+		expKept          bool
+	}{
+		"Should be kept with keep resource policy": {
+			givenAnnotations: map[string]string{"helm.sh/resource-policy": "keep"},
+			expKept:          true,
+		},
+		"Should not be kept with other resource policy": {
+			givenAnnotations: map[string]string{"helm.sh/resource-policy": "delete"},
+			expKept:          false,
+		},
+		"Should not be kept without annotations": {
+			expKept: false,
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// given
+			obj := &unstructured.Unstructured{}
+			obj.SetAnnotations(tc.givenAnnotations)
+
+			// when
+			kept := keptByPolicy(obj)
+
+			// then
+			assert.Equal(t, tc.expKept, kept)
+		})
+	}
+}
+
+func fixRelease(name, ns string) *release.Release {
+	return &release.Release{
+		Name:      name,
+		Namespace: ns,
+		Version:   1,
+		Info:      &release.Info{Status: release.StatusDeployed},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				Name:    "postgresql",
+				Version: "10.2.5",
+			},
+		},
+	}
+}
+
+func fixHelmReleaseInputFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "helm-release.yaml")
+	err := ioutil.WriteFile(path, []byte(`value:
+  name: example-release
+  namespace: default
+  chart:
+    name: postgresql
+    repo: https://charts.bitnami.com/bitnami
+    version: 10.2.5
+`), 0600)
+	require.NoError(t, err)
+
+	return path
+}
@@ -9,28 +9,26 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/chart/loader"
-	"helm.sh/helm/v3/pkg/cli"
 	"sigs.k8s.io/yaml"
 )
 
 type upgrader struct {
-	actionCfgProducer   actionConfigProducer
-	log                 *zap.Logger
-	out                 outputter
-	repositoryCachePath string
-	helmReleasePath     string
-	helmDriver          string
+	actionCfgProducer actionConfigProducer
+	log               *zap.Logger
+	out               outputter
+	charts            *chartLoader
+	helmReleasePath   string
+	helmDriver        string
 }
 
 func newUpgrader(log *zap.Logger, cfg Config, actionCfgProducer actionConfigProducer, outputter outputter) helmCommand {
 	return &upgrader{
-		log:                 log,
-		actionCfgProducer:   actionCfgProducer,
-		out:                 outputter,
-		repositoryCachePath: cfg.RepositoryCachePath,
-		helmReleasePath:     cfg.HelmReleasePath,
-		helmDriver:          cfg.HelmDriver,
+		log:               log,
+		actionCfgProducer: actionCfgProducer,
+		out:               outputter,
+		charts:            newChartLoader(log, cfg),
+		helmReleasePath:   cfg.HelmReleasePath,
+		helmDriver:        cfg.HelmDriver,
 	}
 }
 
@@ -39,12 +37,12 @@ func newUpgrader(log *zap.Logger, cfg Config, actionCfgProducer actionConfigProd
 // It uses the Helm Release namespace instead of the namespace from the Runner Context.
 // As a result you can execute Action from Namespace A to upgrade Helm chart in Namespace B.
 // TODO: In the future, Namespace usage needs to be revisited and discussed if it is a desired behavior or not.
-func (i *upgrader) Do(_ context.Context, in Input) (Output, Status, error) {
+func (i *upgrader) Do(ctx context.Context, in Input) (Output, Status, error) {
 	if i.helmReleasePath == "" {
 		return Output{}, Status{}, errors.New("path to Helm Release is required for upgrade")
 	}
 
-	helmReleaseData, err := i.loadHelmReleaseData(i.helmReleasePath)
+	helmReleaseData, err := loadHelmReleaseData(i.log, i.helmReleasePath)
 	if err != nil {
 		return Output{}, Status{}, err
 	}
@@ -58,16 +56,9 @@ func (i *upgrader) Do(_ context.Context, in Input) (Output, Status, error) {
 
 	upgradeCli := i.initActionUpgradeFromInput(actCfg, in, helmChartRel)
 
-	chartPath, err := upgradeCli.ChartPathOptions.LocateChart(helmChartRel.Chart.Name, &cli.EnvSettings{
-		RepositoryCache: i.repositoryCachePath,
-	})
+	chartData, err := i.charts.Load(ctx, &upgradeCli.ChartPathOptions, helmChartRel.Chart)
 	if err != nil {
-		return Output{}, Status{}, errors.Wrap(err, "while locating Helm chart")
-	}
-
-	chartData, err := loader.Load(chartPath)
-	if err != nil {
-		return Output{}, Status{}, errors.Wrap(err, "while loading Helm chart")
+		return Output{}, Status{}, err
 	}
 
 	values, err := readValueOverrides(in.Args.Values, in.Args.ValuesFromFile)
@@ -84,7 +75,7 @@ func (i *upgrader) Do(_ context.Context, in Input) (Output, Status, error) {
 		return Output{}, Status{}, errors.Wrap(err, "Helm release is nil")
 	}
 
-	releaseOut, err := i.out.ProduceHelmRelease(in.Args.Output.HelmRelease, helmChartRel.Chart.Repo, i.helmDriver, helmRelease)
+	releaseOut, err := i.out.ProduceHelmRelease(in.Args.Output.HelmRelease, helmChartRel.Chart, i.helmDriver, helmRelease)
 	if err != nil {
 		return Output{}, Status{}, errors.Wrap(err, "while saving default output")
 	}
@@ -94,10 +85,18 @@ func (i *upgrader) Do(_ context.Context, in Input) (Output, Status, error) {
 		return Output{}, Status{}, errors.Wrap(err, "while rendering and saving additional output")
 	}
 
+	additionalOut, testFailure, err := testReleaseIfRequested(actCfg, in, helmRelease, additionalOut)
+	if err != nil {
+		return Output{}, Status{}, errors.Wrap(err, "while testing Helm release")
+	}
+
 	status := Status{
 		Succeeded: true,
 		Message:   fmt.Sprintf("release %q upgraded successfully in namespace %q", helmRelease.Name, helmRelease.Namespace),
 	}
+	if testFailure != "" {
+		status = Status{Succeeded: false, Message: testFailure}
+	}
 
 	return Output{
 		Release:    releaseOut,
@@ -105,8 +104,8 @@ func (i *upgrader) Do(_ context.Context, in Input) (Output, Status, error) {
 	}, status, nil
 }
 
-func (i *upgrader) loadHelmReleaseData(path string) (ChartRelease, error) {
-	i.log.Debug("Reading Helm Release data from file", zap.String("path", path))
+func loadHelmReleaseData(log *zap.Logger, path string) (ChartRelease, error) {
+	log.Debug("Reading Helm Release data from file", zap.String("path", path))
 	bytes, err := ioutil.ReadFile(filepath.Clean(path))
 	if err != nil {
 		return ChartRelease{}, errors.Wrapf(err, "while reading values from file %q", path)
@@ -119,25 +118,30 @@ func (i *upgrader) loadHelmReleaseData(path string) (ChartRelease, error) {
 	return chartReleaseIn.Value, nil
 }
 
+// mergeHelmChartData overrides the stored chart details with the ones from the input.
+// If the input specifies the chart location, it replaces the stored one as a whole,
+// so the stored source doesn't take precedence over the repository from the input and vice versa.
 func (i *upgrader) mergeHelmChartData(helmRelease ChartRelease, in Input) ChartRelease {
-	if in.Args.Chart.Name != "" {
-		helmRelease.Chart.Name = in.Args.Chart.Name
+	inChart := normalizeChart(in.Args.Chart)
+	if inChart.Source != "" || inChart.Repo != "" {
+		helmRelease.Chart.Source = inChart.Source
+		helmRelease.Chart.Repo = inChart.Repo
 	}
 
-	if in.Args.Chart.Repo != "" {
-		helmRelease.Chart.Repo = in.Args.Chart.Repo
+	if inChart.Name != "" {
+		helmRelease.Chart.Name = inChart.Name
 	}
 
-	if in.Args.Chart.Version != "" {
-		helmRelease.Chart.Version = in.Args.Chart.Version
+	if inChart.Version != "" {
+		helmRelease.Chart.Version = inChart.Version
 	}
 
+	helmRelease.Chart = normalizeChart(helmRelease.Chart)
 	return helmRelease
 }
 
 func (i *upgrader) initActionUpgradeFromInput(cfg *action.Configuration, in Input, helmChartRel ChartRelease) *action.Upgrade {
 	upgradeCli := action.NewUpgrade(cfg)
-	upgradeCli.Wait = true
 
 	// context
 	upgradeCli.DryRun = in.Ctx.DryRun
@@ -145,6 +149,9 @@ func (i *upgrader) initActionUpgradeFromInput(cfg *action.Configuration, in Inpu
 
 	// common args
 	upgradeCli.DisableHooks = in.Args.CommonArgs.NoHooks
+	upgradeCli.Wait = in.Args.CommonArgs.Wait
+	upgradeCli.WaitForJobs = in.Args.CommonArgs.WaitForJobs
+	upgradeCli.Atomic = in.Args.CommonArgs.Atomic
 	upgradeCli.ChartPathOptions.Version = in.Args.CommonArgs.Chart.Version
 	upgradeCli.ChartPathOptions.RepoURL = in.Args.CommonArgs.Chart.Repo
 
@@ -0,0 +1,81 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgrader_MergeHelmChartData(t *testing.T) {
+	stored := ChartRelease{
+		Name:      "example-release",
+		Namespace: "default",
+		Chart: Chart{
+			Name:    "postgresql",
+			Version: "10.2.5",
+			Source:  "git::https://github.com/bitnami/charts.git//bitnami/postgresql",
+		},
+	}
+
+	tests := map[string]struct {
+		givenChart Chart
+		expChart   Chart
+	}{
+		"Should keep the stored chart when the input doesn't specify it": {
+			givenChart: Chart{},
+			expChart:   stored.Chart,
+		},
+		"Should override only the version": {
+			givenChart: Chart{Version: "10.3.0"},
+			expChart: Chart{
+				Name:    "postgresql",
+				Version: "10.3.0",
+				Source:  "git::https://github.com/bitnami/charts.git//bitnami/postgresql",
+			},
+		},
+		"Should use the repository from the input instead of the stored source": {
+			givenChart: Chart{Repo: "https://charts.bitnami.com/bitnami", Version: "10.3.0"},
+			expChart: Chart{
+				Name:    "postgresql",
+				Version: "10.3.0",
+				Repo:    "https://charts.bitnami.com/bitnami",
+			},
+		},
+		"Should use the OCI reference from the input instead of the stored source": {
+			givenChart: Chart{Name: "oci://registry.example.com/charts/postgresql", Version: "10.3.0"},
+			expChart: Chart{
+				Name:    "postgresql",
+				Version: "10.3.0",
+				Repo:    "oci://registry.example.com/charts",
+			},
+		},
+		"Should use the source from the input": {
+			givenChart: Chart{Source: "https://example.com/postgresql-10.3.0.tgz"},
+			expChart: Chart{
+				Name:    "postgresql",
+				Version: "10.2.5",
+				Source:  "https://example.com/postgresql-10.3.0.tgz",
+			},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// given
+			upgrader := &upgrader{}
+			in := Input{
+				Args: Arguments{
+					CommonArgs: CommonArgs{Chart: tc.givenChart},
+				},
+			}
+
+			// when
+			out := upgrader.mergeHelmChartData(stored, in)
+
+			// then
+			assert.Equal(t, tc.expChart, out.Chart)
+			assert.Equal(t, stored.Name, out.Name)
+			assert.Equal(t, stored.Namespace, out.Namespace)
+		})
+	}
+}
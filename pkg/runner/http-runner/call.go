@@ -0,0 +1,346 @@
+package httprunner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// maxErrorBodyLength limits the response body included in the error messages.
+const maxErrorBodyLength = 512
+
+// PollFailedError indicates that the polled resource reached one of the failure values.
+type PollFailedError struct {
+	Call  string
+	Value string
+}
+
+func (e PollFailedError) Error() string {
+	return fmt.Sprintf("call %q reached failure value %q", e.Call, e.Value)
+}
+
+type caller struct {
+	log    *zap.Logger
+	client *http.Client
+	auth   *Auth
+}
+
+// Do sends the request rendered from a given call, with retries and polling if configured.
+func (c *caller) Do(ctx context.Context, call Call, data TemplateData) (Response, error) {
+	req, err := renderCall(call, data)
+	if err != nil {
+		return Response{}, errors.Wrap(err, "while rendering call")
+	}
+
+	if call.Poll == nil {
+		return c.doWithRetry(ctx, req)
+	}
+
+	interval := call.Poll.Interval.Duration()
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	for {
+		resp, err := c.doWithRetry(ctx, req)
+		if err != nil {
+			return Response{}, err
+		}
+
+		value, err := evaluateJSONPath(call.Poll.JSONPath, resp.Body)
+		if err != nil {
+			return Response{}, errors.Wrap(err, "while evaluating poll JSONPath")
+		}
+
+		switch {
+		case contains(call.Poll.SuccessValues, value):
+			return resp, nil
+		case contains(call.Poll.FailureValues, value):
+			return Response{}, PollFailedError{Call: call.Name, Value: value}
+		}
+
+		c.log.Debug("Waiting for poll condition", zap.String("call", call.Name), zap.String("value", value))
+		if err := sleep(ctx, interval); err != nil {
+			return Response{}, errors.Wrapf(err, "while polling, last value %q", value)
+		}
+	}
+}
+
+func (c *caller) doWithRetry(ctx context.Context, req renderedCall) (Response, error) {
+	attempts, interval, maxInterval := 1, defaultRetryInitialInterval, defaultRetryMaxInterval
+	if req.retry != nil {
+		if req.retry.MaxAttempts > 1 {
+			attempts = req.retry.MaxAttempts
+		}
+		if req.retry.InitialInterval > 0 {
+			interval = req.retry.InitialInterval.Duration()
+		}
+		if req.retry.MaxInterval > 0 {
+			maxInterval = req.retry.MaxInterval.Duration()
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, req)
+		retryable := err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		if !retryable || attempt >= attempts {
+			if err != nil {
+				return Response{}, err
+			}
+			return resp, checkStatusCode(resp, req.expectedStatusCodes)
+		}
+
+		c.log.Debug("Retrying call", zap.Int("attempt", attempt), zap.Int("statusCode", resp.StatusCode), zap.Error(err))
+		if err := sleep(ctx, interval); err != nil {
+			return Response{}, errors.Wrap(err, "while waiting for retry")
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func (c *caller) send(ctx context.Context, call renderedCall) (Response, error) {
+	var body io.Reader
+	if call.body != nil {
+		body = bytes.NewReader(call.body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, call.method, call.url, body)
+	if err != nil {
+		return Response{}, errors.Wrap(err, "while creating request")
+	}
+
+	if call.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range call.headers {
+		req.Header.Set(key, value)
+	}
+	setAuthHeader(req, c.auth)
+
+	c.log.Debug("Sending request", zap.String("method", call.method), zap.String("url", call.url))
+	res, err := c.client.Do(req)
+	if err != nil {
+		return Response{}, errors.Wrap(err, "while executing request")
+	}
+	defer res.Body.Close()
+
+	rawBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return Response{}, errors.Wrap(err, "while reading response body")
+	}
+
+	headers := map[string]string{}
+	for key := range res.Header {
+		headers[key] = res.Header.Get(key)
+	}
+
+	return Response{
+		StatusCode: res.StatusCode,
+		Headers:    headers,
+		Body:       decodeBody(rawBody),
+	}, nil
+}
+
+type renderedCall struct {
+	method              string
+	url                 string
+	headers             map[string]string
+	body                []byte
+	expectedStatusCodes []int
+	retry               *Retry
+}
+
+func renderCall(call Call, data TemplateData) (renderedCall, error) {
+	rawURL, err := renderString(call.URL, data)
+	if err != nil {
+		return renderedCall{}, errors.Wrap(err, "while rendering URL")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return renderedCall{}, errors.Wrap(err, "while parsing URL")
+	}
+
+	if len(call.QueryParameters) > 0 {
+		query := u.Query()
+		for key, values := range call.QueryParameters {
+			for _, value := range values {
+				rendered, err := renderString(value, data)
+				if err != nil {
+					return renderedCall{}, errors.Wrapf(err, "while rendering %q query parameter", key)
+				}
+				query.Add(key, rendered)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	headers := map[string]string{}
+	for key, value := range call.Headers {
+		headers[key], err = renderString(value, data)
+		if err != nil {
+			return renderedCall{}, errors.Wrapf(err, "while rendering %q header", key)
+		}
+	}
+
+	var body []byte
+	if call.Body != nil {
+		rendered, err := renderValue(call.Body, data)
+		if err != nil {
+			return renderedCall{}, errors.Wrap(err, "while rendering body")
+		}
+		body, err = json.Marshal(rendered)
+		if err != nil {
+			return renderedCall{}, errors.Wrap(err, "while marshaling body")
+		}
+	}
+
+	method := call.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	return renderedCall{
+		method:              strings.ToUpper(method),
+		url:                 u.String(),
+		headers:             headers,
+		body:                body,
+		expectedStatusCodes: call.ExpectedStatusCodes,
+		retry:               call.Retry,
+	}, nil
+}
+
+// renderValue renders all strings in a given value, so the rendered data doesn't need to be escaped.
+func renderValue(in interface{}, data TemplateData) (interface{}, error) {
+	switch v := in.(type) {
+	case string:
+		return renderString(v, data)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			rendered, err := renderValue(value, data)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = rendered
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, value := range v {
+			rendered, err := renderValue(value, data)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, rendered)
+		}
+		return out, nil
+	default:
+		return in, nil
+	}
+}
+
+func renderString(in string, data TemplateData) (string, error) {
+	if !strings.Contains(in, "{{") {
+		return in, nil
+	}
+
+	out, err := renderTemplate(in, data)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func renderTemplate(in string, data TemplateData) ([]byte, error) {
+	tmpl, err := template.New("tmpl").Funcs(sprig.TxtFuncMap()).Option("missingkey=error").Parse(in)
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing template")
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, errors.Wrap(err, "while executing template")
+	}
+	return out.Bytes(), nil
+}
+
+func evaluateJSONPath(expr string, data interface{}) (string, error) {
+	jp := jsonpath.New("poll").AllowMissingKeys(true)
+	if err := jp.Parse(expr); err != nil {
+		return "", errors.Wrap(err, "while parsing JSONPath")
+	}
+
+	var out bytes.Buffer
+	if err := jp.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func checkStatusCode(resp Response, expected []int) error {
+	if len(expected) == 0 && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	for _, code := range expected {
+		if resp.StatusCode == code {
+			return nil
+		}
+	}
+
+	body := fmt.Sprint(resp.Body)
+	if len(body) > maxErrorBodyLength {
+		body = body[:maxErrorBodyLength] + "..."
+	}
+	return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+}
+
+func decodeBody(raw []byte) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return string(raw)
+	}
+	return body
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
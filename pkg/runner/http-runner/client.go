@@ -0,0 +1,61 @@
+package httprunner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+func newHTTPClient(auth *Auth) (*http.Client, error) {
+	if auth == nil || auth.TLS == nil {
+		return &http.Client{}, nil
+	}
+
+	// #nosec G402
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: auth.TLS.InsecureSkipVerify,
+	}
+
+	if auth.TLS.Certificate != "" || auth.TLS.Key != "" {
+		cert, err := tls.X509KeyPair([]byte(auth.TLS.Certificate), []byte(auth.TLS.Key))
+		if err != nil {
+			return nil, errors.Wrap(err, "while loading client certificate")
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if auth.TLS.CA != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(auth.TLS.CA)) {
+			return nil, errors.New("no valid certificates found in CA")
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	return &http.Client{Transport: transport}, nil
+}
+
+func validateAuth(auth *Auth) error {
+	if auth == nil {
+		return nil
+	}
+	if auth.Basic != nil && auth.Bearer != nil {
+		return errors.New("both basic and bearer credentials must not be provided")
+	}
+	return nil
+}
+
+func setAuthHeader(req *http.Request, auth *Auth) {
+	switch {
+	case auth == nil:
+	case auth.Basic != nil:
+		req.SetBasicAuth(auth.Basic.Username, auth.Basic.Password)
+	case auth.Bearer != nil:
+		req.Header.Set("Authorization", "Bearer "+auth.Bearer.Token)
+	}
+}
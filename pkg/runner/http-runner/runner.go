@@ -0,0 +1,196 @@
+package httprunner
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+var _ runner.Runner = &httpRunner{}
+
+// httpRunner provides functionality to execute sequential HTTP calls.
+type httpRunner struct {
+	cfg Config
+	log *zap.Logger
+
+	args   Arguments
+	caller *caller
+}
+
+// NewRunner returns a new HTTP runner instance.
+func NewRunner(cfg Config) runner.Runner {
+	return &httpRunner{
+		cfg: cfg,
+	}
+}
+
+// Start validates the arguments and prepares the HTTP client.
+func (r *httpRunner) Start(_ context.Context, in runner.StartInput) (*runner.StartOutput, error) {
+	if err := yaml.Unmarshal(in.Args, &r.args); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling runner arguments")
+	}
+
+	if err := r.validateCalls(); err != nil {
+		return nil, errors.Wrap(err, "while validating calls")
+	}
+
+	auth, err := r.loadAuth()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading auth")
+	}
+
+	client, err := newHTTPClient(auth)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating HTTP client")
+	}
+
+	r.caller = &caller{
+		log:    r.log,
+		client: client,
+		auth:   auth,
+	}
+
+	return &runner.StartOutput{
+		Status: fmt.Sprintf("Executing %d calls", len(r.args.Calls)),
+	}, nil
+}
+
+// WaitForCompletion executes the calls one by one and saves the rendered output.
+// In dry run mode, calls are not executed.
+func (r *httpRunner) WaitForCompletion(ctx context.Context, in runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
+	if r.caller == nil {
+		return &runner.WaitForCompletionOutput{}, errors.New("runner not started yet")
+	}
+
+	if in.RunnerCtx.DryRun {
+		return &runner.WaitForCompletionOutput{
+			Succeeded: true,
+			Message:   fmt.Sprintf("Dry run: %d calls not executed", len(r.args.Calls)),
+		}, nil
+	}
+
+	data := TemplateData{
+		Values:    r.args.Values,
+		Responses: map[string]Response{},
+	}
+
+	for _, call := range r.args.Calls {
+		r.log.Info("Executing call", zap.String("name", call.Name))
+		resp, err := r.caller.Do(ctx, call, data)
+		if pollErr := (PollFailedError{}); errors.As(err, &pollErr) {
+			return &runner.WaitForCompletionOutput{
+				Succeeded: false,
+				Message:   pollErr.Error(),
+			}, nil
+		}
+		if err != nil {
+			return &runner.WaitForCompletionOutput{}, errors.Wrapf(err, "while executing call %q", call.Name)
+		}
+		data.Responses[call.Name] = resp
+	}
+
+	if err := r.saveOutput(data); err != nil {
+		return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while saving output")
+	}
+
+	return &runner.WaitForCompletionOutput{
+		Succeeded: true,
+		Message:   fmt.Sprintf("%d calls executed", len(r.args.Calls)),
+	}, nil
+}
+
+// Name returns the runner name.
+func (r *httpRunner) Name() string {
+	return "http"
+}
+
+// InjectLogger sets the logger on the runner.
+func (r *httpRunner) InjectLogger(logger *zap.Logger) {
+	r.log = logger
+}
+
+func (r *httpRunner) validateCalls() error {
+	if len(r.args.Calls) == 0 {
+		return errors.New("at least one call must be specified")
+	}
+
+	names := map[string]struct{}{}
+	for idx, call := range r.args.Calls {
+		if call.Name == "" {
+			return fmt.Errorf("name of call %d must be specified", idx)
+		}
+		if _, found := names[call.Name]; found {
+			return fmt.Errorf("call name %q must be unique", call.Name)
+		}
+		names[call.Name] = struct{}{}
+
+		if call.URL == "" {
+			return fmt.Errorf("URL of call %q must be specified", call.Name)
+		}
+		if call.Poll != nil && (call.Poll.JSONPath == "" || len(call.Poll.SuccessValues) == 0) {
+			return fmt.Errorf("poll of call %q requires JSONPath and success values", call.Name)
+		}
+	}
+	return nil
+}
+
+func (r *httpRunner) loadAuth() (*Auth, error) {
+	auth := r.args.Auth
+
+	if r.cfg.OptionalAuthTI != "" {
+		data, err := ioutil.ReadFile(filepath.Clean(r.cfg.OptionalAuthTI))
+		switch {
+		case os.IsNotExist(err):
+			r.log.Debug("optional auth TI specified but file does not exist")
+		case err != nil:
+			return nil, errors.Wrap(err, "while reading auth TypeInstance")
+		default:
+			if auth != nil {
+				return nil, errors.New("auth must not be provided both in arguments and TypeInstance")
+			}
+
+			var ti AuthTypeInstance
+			if err := yaml.Unmarshal(data, &ti); err != nil {
+				return nil, errors.Wrap(err, "while unmarshaling auth TypeInstance")
+			}
+			auth = &ti.Value
+		}
+	}
+
+	if err := validateAuth(auth); err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
+
+func (r *httpRunner) saveOutput(data TemplateData) error {
+	if strings.TrimSpace(r.args.Output.GoTemplate) == "" {
+		r.log.Debug("No additional output to render and save. skipping...")
+		return nil
+	}
+
+	rendered, err := renderTemplate(r.args.Output.GoTemplate, data)
+	if err != nil {
+		return errors.Wrap(err, "while rendering additional output")
+	}
+
+	additional, err := runner.NestingOutputUnderValue(rendered)
+	if err != nil {
+		return err
+	}
+
+	r.log.Debug("Saving additional output", zap.String("path", r.cfg.Output.AdditionalFilePath))
+	if err := runner.SaveToFile(r.cfg.Output.AdditionalFilePath, additional); err != nil {
+		return errors.Wrap(err, "while saving additional output")
+	}
+	return nil
+}
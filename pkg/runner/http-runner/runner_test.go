@@ -0,0 +1,233 @@
+package httprunner
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"capact.io/capact/pkg/runner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRunnerChainsCalls(t *testing.T) {
+	// given
+	var (
+		mu           sync.Mutex
+		statusChecks int
+		createCalls  int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/tenants":
+			createCalls++
+			if createCalls == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "acme", body["name"])
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"id": "t-1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/tenants/t-1":
+			statusChecks++
+			status := "PROVISIONING"
+			if statusChecks > 1 {
+				status = "READY"
+			}
+			_, _ = w.Write([]byte(`{"id": "t-1", "status": "` + status + `", "endpoint": "https://acme.example.com"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := fixConfig(t)
+	args := `
+values:
+  name: acme
+auth:
+  bearer:
+    token: s3cr3t
+calls:
+  - name: create
+    method: POST
+    url: ` + srv.URL + `/tenants
+    body:
+      name: "{{ .Values.name }}"
+    retry:
+      maxAttempts: 2
+      initialInterval: 1ms
+  - name: status
+    url: "` + srv.URL + `/tenants/{{ .Responses.create.Body.id }}"
+    poll:
+      jsonPath: "{.status}"
+      successValues: [READY]
+      failureValues: [FAILED]
+      interval: 1ms
+output:
+  goTemplate: |
+    id: "{{ .Responses.status.Body.id }}"
+    endpoint: "{{ .Responses.status.Body.endpoint }}"
+`
+
+	// when
+	out, err := runRunner(t, cfg, args)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, out.Succeeded)
+	assert.Equal(t, "2 calls executed", out.Message)
+	assert.Equal(t, 2, createCalls)
+	assert.Equal(t, 2, statusChecks)
+
+	additional, err := ioutil.ReadFile(cfg.Output.AdditionalFilePath)
+	require.NoError(t, err)
+	assert.YAMLEq(t, "value:\n  id: t-1\n  endpoint: https://acme.example.com\n", string(additional))
+}
+
+func TestRunnerPollFailureValue(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "FAILED"}`))
+	}))
+	defer srv.Close()
+
+	args := `
+calls:
+  - name: status
+    url: ` + srv.URL + `
+    poll:
+      jsonPath: "{.status}"
+      successValues: [READY]
+      failureValues: [FAILED]
+`
+
+	// when
+	out, err := runRunner(t, fixConfig(t), args)
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, out.Succeeded)
+	assert.Equal(t, `call "status" reached failure value "FAILED"`, out.Message)
+}
+
+func TestRunnerUnexpectedStatusCode(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`already exists`))
+	}))
+	defer srv.Close()
+
+	args := `
+calls:
+  - name: create
+    method: POST
+    url: ` + srv.URL + `
+`
+
+	// when
+	_, err := runRunner(t, fixConfig(t), args)
+
+	// then
+	assert.EqualError(t, err, `while executing call "create": unexpected status code 409: already exists`)
+}
+
+func TestRunnerAuthFromTypeInstance(t *testing.T) {
+	// given
+	var user, pass string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ = r.BasicAuth()
+	}))
+	defer srv.Close()
+
+	cfg := fixConfig(t)
+	cfg.OptionalAuthTI = filepath.Join(t.TempDir(), "auth.yaml")
+	err := ioutil.WriteFile(cfg.OptionalAuthTI, []byte("value:\n  basic:\n    username: admin\n    password: s3cr3t\n"), 0600)
+	require.NoError(t, err)
+
+	// when
+	out, err := runRunner(t, cfg, "calls:\n  - name: get\n    url: "+srv.URL)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, out.Succeeded)
+	assert.Equal(t, "admin", user)
+	assert.Equal(t, "s3cr3t", pass)
+}
+
+func TestRunnerValidation(t *testing.T) {
+	tests := map[string]struct {
+		args        string
+		expectedErr string
+	}{
+		"no calls": {
+			args:        "values: {}",
+			expectedErr: "while validating calls: at least one call must be specified",
+		},
+		"duplicated name": {
+			args:        "calls: [{name: a, url: http://a}, {name: a, url: http://b}]",
+			expectedErr: `while validating calls: call name "a" must be unique`,
+		},
+		"poll without success values": {
+			args:        "calls: [{name: a, url: http://a, poll: {jsonPath: '{.status}'}}]",
+			expectedErr: `while validating calls: poll of call "a" requires JSONPath and success values`,
+		},
+		"basic and bearer auth": {
+			args:        "auth: {basic: {username: a}, bearer: {token: b}}\ncalls: [{name: a, url: http://a}]",
+			expectedErr: "while loading auth: both basic and bearer credentials must not be provided",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// given
+			r := NewRunner(fixConfig(t))
+			r.(*httpRunner).InjectLogger(zap.NewNop())
+
+			// when
+			_, err := r.Start(context.Background(), runner.StartInput{Args: []byte(tc.args)})
+
+			// then
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func runRunner(t *testing.T, cfg Config, args string) (*runner.WaitForCompletionOutput, error) {
+	t.Helper()
+
+	r := NewRunner(cfg)
+	r.(*httpRunner).InjectLogger(zap.NewNop())
+
+	_, err := r.Start(context.Background(), runner.StartInput{Args: []byte(strings.TrimSpace(args))})
+	require.NoError(t, err)
+
+	return r.WaitForCompletion(context.Background(), runner.WaitForCompletionInput{})
+}
+
+func fixConfig(t *testing.T) Config {
+	t.Helper()
+
+	var cfg Config
+	cfg.Output.AdditionalFilePath = filepath.Join(t.TempDir(), "additional.yaml")
+	return cfg
+}
@@ -0,0 +1,127 @@
+package httprunner
+
+import (
+	"net/url"
+	"time"
+
+	"capact.io/capact/pkg/runner"
+)
+
+const (
+	defaultPollInterval         = 5 * time.Second
+	defaultRetryInitialInterval = time.Second
+	defaultRetryMaxInterval     = 30 * time.Second
+)
+
+// Config holds Runner related configuration.
+type Config struct {
+	// OptionalAuthTI is a path to the TypeInstance with the Auth used for all calls.
+	// If the file doesn't exist, the auth from the arguments is used.
+	OptionalAuthTI string `envconfig:"optional"`
+	Output         struct {
+		AdditionalFilePath string `envconfig:"default=/tmp/additional.yaml"`
+	}
+}
+
+// Arguments stores the input arguments for the HTTP runner operation.
+type Arguments struct {
+	// Values are available in the call and output templates under the `.Values` field.
+	Values map[string]interface{} `json:"values"`
+	// Auth is used for all calls. It must not be set if the auth is provided in the TypeInstance.
+	Auth *Auth `json:"auth"`
+	// Calls are executed sequentially. Responses of the previous calls are available
+	// in the templates under the `.Responses.<call name>` field.
+	Calls  []Call     `json:"calls"`
+	Output OutputArgs `json:"output"`
+}
+
+// Call describes a single HTTP request.
+//
+// URL, header and query parameter values, and all string values in the Body are Go templates.
+type Call struct {
+	// Name identifies the call response in the templates.
+	Name            string            `json:"name"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	Headers         map[string]string `json:"headers"`
+	QueryParameters url.Values        `json:"queryParameters"`
+	// Body is sent as JSON.
+	Body interface{} `json:"body"`
+	// ExpectedStatusCodes defaults to all 2xx status codes.
+	ExpectedStatusCodes []int  `json:"expectedStatusCodes"`
+	Retry               *Retry `json:"retry"`
+	Poll                *Poll  `json:"poll"`
+}
+
+// Auth holds auth data for the HTTP calls. Basic and bearer auth are mutually exclusive,
+// but both can be used together with TLS client certificates.
+type Auth struct {
+	Basic  *BasicAuth  `json:"basic"`
+	Bearer *BearerAuth `json:"bearer"`
+	TLS    *TLSAuth    `json:"tls"`
+}
+
+// BasicAuth holds basic auth data.
+type BasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// BearerAuth holds the token sent in the Authorization header.
+type BearerAuth struct {
+	Token string `json:"token"`
+}
+
+// TLSAuth holds PEM encoded client certificate and key, and the CA used to verify the server.
+type TLSAuth struct {
+	Certificate        string `json:"certificate"`
+	Key                string `json:"key"`
+	CA                 string `json:"ca"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"`
+}
+
+// Retry defines how the call is retried on connection errors, and 429 or 5xx status codes.
+// The interval is doubled after each attempt, up to MaxInterval.
+type Retry struct {
+	MaxAttempts     int             `json:"maxAttempts"`
+	InitialInterval runner.Duration `json:"initialInterval"`
+	MaxInterval     runner.Duration `json:"maxInterval"`
+}
+
+// Poll defines how the call is repeated until the JSONPath expression evaluated
+// on the response body returns one of the SuccessValues.
+type Poll struct {
+	// JSONPath uses the kubectl syntax, for example `{.status.phase}`.
+	JSONPath string `json:"jsonPath"`
+	// SuccessValues finish the polling.
+	SuccessValues []string `json:"successValues"`
+	// FailureValues finish the polling and fail the runner.
+	FailureValues []string        `json:"failureValues"`
+	Interval      runner.Duration `json:"interval"`
+}
+
+// OutputArgs stores input arguments for generating the output artifacts.
+type OutputArgs struct {
+	// GoTemplate is rendered with the `.Values` and the `.Responses` to produce the additional output.
+	GoTemplate string `json:"goTemplate"`
+}
+
+// Response holds the details of the HTTP response available in the templates.
+type Response struct {
+	StatusCode int
+	// Headers holds the first value of each response header.
+	Headers map[string]string
+	// Body is the decoded JSON body, or a string if the body is not a valid JSON.
+	Body interface{}
+}
+
+// TemplateData is passed to the call and output templates.
+type TemplateData struct {
+	Values    map[string]interface{}
+	Responses map[string]Response
+}
+
+// AuthTypeInstance defines the shape of the auth TypeInstance.
+type AuthTypeInstance struct {
+	Value Auth `json:"value"`
+}
@@ -0,0 +1,181 @@
+package kustomize
+
+import (
+	"context"
+	"sort"
+
+	"capact.io/capact/internal/ptr"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// applyPriority defines kinds which must be applied before other objects, as the objects may depend on them.
+var applyPriority = map[string]int{
+	"Namespace":                0,
+	"CustomResourceDefinition": 1,
+}
+
+// Applier applies and deletes Kubernetes objects using server-side apply.
+type Applier struct {
+	log          *zap.Logger
+	dynamic      dynamic.Interface
+	mapper       *restmapper.DeferredDiscoveryRESTMapper
+	fieldManager string
+}
+
+// NewApplier returns a new Applier instance.
+func NewApplier(log *zap.Logger, cfg *rest.Config, fieldManager string) (*Applier, error) {
+	dynamicCli, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating dynamic client")
+	}
+
+	discoveryCli, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating discovery client")
+	}
+
+	return &Applier{
+		log:          log,
+		dynamic:      dynamicCli,
+		mapper:       restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryCli)),
+		fieldManager: fieldManager,
+	}, nil
+}
+
+// Apply applies given objects. Namespaced objects without the namespace are created in the defaultNamespace.
+// It returns the objects in the state returned by the Kubernetes API server.
+func (a *Applier) Apply(ctx context.Context, objs []*unstructured.Unstructured, defaultNamespace string, dryRun bool) ([]*unstructured.Unstructured, error) {
+	sorted := make([]*unstructured.Unstructured, len(objs))
+	copy(sorted, objs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return priority(sorted[i]) < priority(sorted[j])
+	})
+
+	opts := metav1.PatchOptions{
+		FieldManager: a.fieldManager,
+		Force:        ptr.Bool(true),
+	}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	applied := make([]*unstructured.Unstructured, 0, len(sorted))
+	for _, obj := range sorted {
+		res, err := a.resourceFor(obj, defaultNamespace)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := obj.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrapf(err, "while marshaling %s", refFor(obj))
+		}
+
+		a.log.Info("Applying object", zap.Stringer("object", refFor(obj)))
+		out, err := res.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while applying %s", refFor(obj))
+		}
+		applied = append(applied, out)
+	}
+
+	return applied, nil
+}
+
+// Get returns the current state of a given object.
+func (a *Applier) Get(ctx context.Context, ref ObjectRef) (*unstructured.Unstructured, error) {
+	res, err := a.resourceFor(ref.toUnstructured(), "")
+	if err != nil {
+		return nil, err
+	}
+	return res.Get(ctx, ref.Name, metav1.GetOptions{})
+}
+
+// Delete deletes given objects in the reverse order. Objects which don't exist are skipped.
+func (a *Applier) Delete(ctx context.Context, refs []ObjectRef, dryRun bool) error {
+	propagation := metav1.DeletePropagationBackground
+	opts := metav1.DeleteOptions{PropagationPolicy: &propagation}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	for i := len(refs) - 1; i >= 0; i-- {
+		ref := refs[i]
+
+		res, err := a.resourceFor(ref.toUnstructured(), "")
+		if meta.IsNoMatchError(errors.Cause(err)) {
+			a.log.Info("Skipping object with unknown kind", zap.Stringer("object", ref))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		a.log.Info("Deleting object", zap.Stringer("object", ref))
+		err = res.Delete(ctx, ref.Name, opts)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "while deleting %s", ref)
+		}
+	}
+
+	return nil
+}
+
+func (a *Applier) resourceFor(obj *unstructured.Unstructured, defaultNamespace string) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// the kind may have been registered by a CustomResourceDefinition applied a moment ago
+		a.mapper.Reset()
+		mapping, err = a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "while getting REST mapping for %s", refFor(obj))
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return a.dynamic.Resource(mapping.Resource), nil
+	}
+
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(defaultNamespace)
+	}
+	return a.dynamic.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
+
+func (r ObjectRef) toUnstructured() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(r.APIVersion, r.Kind))
+	obj.SetNamespace(r.Namespace)
+	obj.SetName(r.Name)
+	return obj
+}
+
+func refFor(obj *unstructured.Unstructured) ObjectRef {
+	return ObjectRef{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+func priority(obj *unstructured.Unstructured) int {
+	if p, ok := applyPriority[obj.GetKind()]; ok {
+		return p
+	}
+	return len(applyPriority)
+}
@@ -0,0 +1,144 @@
+package kustomize
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// IsReady checks whether a given object is ready to use.
+// Workloads are ready when all their replicas are updated and available, Jobs when they are completed
+// and PersistentVolumeClaims when they are bound. Other objects are ready when they report the `Ready` condition,
+// or immediately when they don't report any conditions.
+// If the object isn't ready, the returned message describes the reason.
+func IsReady(obj *unstructured.Unstructured) (bool, string, error) {
+	if !observedLatestGeneration(obj) {
+		return false, "waiting for the controller to observe the latest generation", nil
+	}
+
+	switch obj.GetKind() {
+	case "Deployment", "StatefulSet", "ReplicaSet":
+		return replicasReady(obj)
+	case "DaemonSet":
+		return daemonSetReady(obj)
+	case "Job":
+		return jobReady(obj)
+	case "PersistentVolumeClaim":
+		phase, _, err := unstructured.NestedString(obj.Object, "status", "phase")
+		if err != nil {
+			return false, "", errors.Wrap(err, "while reading status.phase")
+		}
+		return phase == "Bound", fmt.Sprintf("phase is %q", phase), nil
+	}
+
+	conditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, "", errors.Wrap(err, "while reading status.conditions")
+	}
+	if !found {
+		return true, "", nil
+	}
+
+	status, found := conditionStatus(conditions, "Ready")
+	if !found {
+		return true, "", nil
+	}
+	return status == "True", "Ready condition is not True", nil
+}
+
+func observedLatestGeneration(obj *unstructured.Unstructured) bool {
+	observed, found, err := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if err != nil || !found {
+		return true
+	}
+	return observed >= obj.GetGeneration()
+}
+
+func replicasReady(obj *unstructured.Unstructured) (bool, string, error) {
+	replicas, found, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if err != nil {
+		return false, "", errors.Wrap(err, "while reading spec.replicas")
+	}
+	if !found {
+		replicas = 1
+	}
+
+	// StatefulSets don't report available replicas in older Kubernetes versions
+	availableField := "availableReplicas"
+	if obj.GetKind() == "StatefulSet" {
+		availableField = "readyReplicas"
+	}
+
+	for _, field := range []string{"updatedReplicas", availableField} {
+		if obj.GetKind() == "ReplicaSet" && field == "updatedReplicas" {
+			continue
+		}
+
+		current, _, err := unstructured.NestedInt64(obj.Object, "status", field)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "while reading status.%s", field)
+		}
+		if current < replicas {
+			return false, fmt.Sprintf("%d of %d replicas are %s", current, replicas, readableField(field)), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+func daemonSetReady(obj *unstructured.Unstructured) (bool, string, error) {
+	desired, _, err := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+	if err != nil {
+		return false, "", errors.Wrap(err, "while reading status.desiredNumberScheduled")
+	}
+
+	for _, field := range []string{"updatedNumberScheduled", "numberAvailable"} {
+		current, _, err := unstructured.NestedInt64(obj.Object, "status", field)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "while reading status.%s", field)
+		}
+		if current < desired {
+			return false, fmt.Sprintf("%d of %d pods are %s", current, desired, readableField(field)), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+func jobReady(obj *unstructured.Unstructured) (bool, string, error) {
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, "", errors.Wrap(err, "while reading status.conditions")
+	}
+
+	if status, _ := conditionStatus(conditions, "Failed"); status == "True" {
+		return false, "", errors.New("Job failed")
+	}
+
+	status, _ := conditionStatus(conditions, "Complete")
+	return status == "True", "Job is not completed", nil
+}
+
+func conditionStatus(conditions []interface{}, condType string) (string, bool) {
+	for _, item := range conditions {
+		cond, ok := item.(map[string]interface{})
+		if !ok || cond["type"] != condType {
+			continue
+		}
+		status, _ := cond["status"].(string)
+		return status, true
+	}
+	return "", false
+}
+
+func readableField(field string) string {
+	switch field {
+	case "updatedReplicas", "updatedNumberScheduled":
+		return "updated"
+	case "readyReplicas":
+		return "ready"
+	default:
+		return "available"
+	}
+}
@@ -0,0 +1,130 @@
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestIsReady(t *testing.T) {
+	tests := map[string]struct {
+		givenObject string
+		expReady    bool
+		expMessage  string
+	}{
+		"Should be ready when all Deployment replicas are available": {
+			givenObject: `
+kind: Deployment
+metadata: {generation: 2}
+spec: {replicas: 2}
+status: {observedGeneration: 2, updatedReplicas: 2, availableReplicas: 2}`,
+			expReady: true,
+		},
+		"Should not be ready when Deployment replicas are not available": {
+			givenObject: `
+kind: Deployment
+metadata: {generation: 1}
+spec: {replicas: 3}
+status: {observedGeneration: 1, updatedReplicas: 3, availableReplicas: 1}`,
+			expReady:   false,
+			expMessage: "1 of 3 replicas are available",
+		},
+		"Should not be ready when the latest generation is not observed": {
+			givenObject: `
+kind: Deployment
+metadata: {generation: 2}
+spec: {replicas: 1}
+status: {observedGeneration: 1, updatedReplicas: 1, availableReplicas: 1}`,
+			expReady:   false,
+			expMessage: "waiting for the controller to observe the latest generation",
+		},
+		"Should not be ready when StatefulSet replicas are not ready": {
+			givenObject: `
+kind: StatefulSet
+spec: {replicas: 2}
+status: {updatedReplicas: 2, readyReplicas: 1}`,
+			expReady:   false,
+			expMessage: "1 of 2 replicas are ready",
+		},
+		"Should be ready when DaemonSet pods are available": {
+			givenObject: `
+kind: DaemonSet
+status: {desiredNumberScheduled: 3, updatedNumberScheduled: 3, numberAvailable: 3}`,
+			expReady: true,
+		},
+		"Should not be ready when Job is running": {
+			givenObject: `
+kind: Job
+status: {active: 1}`,
+			expReady:   false,
+			expMessage: "Job is not completed",
+		},
+		"Should be ready when PersistentVolumeClaim is bound": {
+			givenObject: `
+kind: PersistentVolumeClaim
+status: {phase: Bound}`,
+			expReady: true,
+		},
+		"Should use Ready condition for custom resources": {
+			givenObject: `
+kind: PostgreSQL
+status:
+  conditions:
+    - {type: Synced, status: "True"}
+    - {type: Ready, status: "False"}`,
+			expReady:   false,
+			expMessage: "Ready condition is not True",
+		},
+		"Should be ready when object has no status": {
+			givenObject: `
+kind: ConfigMap
+data: {key: value}`,
+			expReady: true,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			obj := fixUnstructured(t, tc.givenObject)
+
+			// when
+			ready, msg, err := IsReady(obj)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expReady, ready)
+			if !tc.expReady {
+				assert.Equal(t, tc.expMessage, msg)
+			}
+		})
+	}
+}
+
+func TestIsReadyFailedJob(t *testing.T) {
+	// given
+	obj := fixUnstructured(t, `
+kind: Job
+status:
+  conditions:
+    - {type: Failed, status: "True"}`)
+
+	// when
+	_, _, err := IsReady(obj)
+
+	// then
+	assert.EqualError(t, err, "Job failed")
+}
+
+func fixUnstructured(t *testing.T, raw string) *unstructured.Unstructured {
+	t.Helper()
+
+	data, err := yaml.YAMLToJSON([]byte("apiVersion: test/v1\n" + raw))
+	require.NoError(t, err)
+
+	obj := &unstructured.Unstructured{}
+	require.NoError(t, obj.UnmarshalJSON(data))
+	return obj
+}
@@ -0,0 +1,182 @@
+package kustomize
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/kustomize/api/filesys"
+	"sigs.k8s.io/kustomize/api/krusty"
+)
+
+// templateFileExt is the extension of the files rendered as Go templates.
+const templateFileExt = ".tpl"
+
+// kustomizationFileNames are the file names recognized by kustomize.
+var kustomizationFileNames = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// TemplateData holds data available in the manifest templates.
+type TemplateData struct {
+	Values    map[string]interface{}
+	Namespace string
+}
+
+// RenderTemplates renders all files with the ".tpl" extension in a given directory, using the Go template syntax.
+// The output is saved next to the template without the ".tpl" extension, and the template is removed.
+// Other files are left untouched, so they can contain a literal "{{".
+func RenderTemplates(dir string, data TemplateData) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != templateFileExt {
+			return nil
+		}
+
+		raw, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return errors.Wrapf(err, "while reading %q", path)
+		}
+
+		tmpl, err := template.New(filepath.Base(path)).
+			Funcs(sprig.TxtFuncMap()).
+			Option("missingkey=zero").
+			Parse(string(raw))
+		if err != nil {
+			return errors.Wrapf(err, "while parsing template %q", path)
+		}
+
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, data); err != nil {
+			return errors.Wrapf(err, "while rendering template %q", path)
+		}
+
+		// the same as Helm, missing values are rendered as empty strings
+		rendered := strings.ReplaceAll(out.String(), "<no value>", "")
+		if err := ioutil.WriteFile(strings.TrimSuffix(path, templateFileExt), []byte(rendered), info.Mode()); err != nil {
+			return errors.Wrapf(err, "while saving rendered template %q", path)
+		}
+
+		return errors.Wrapf(os.Remove(path), "while removing template %q", path)
+	})
+}
+
+// LoadObjects returns the Kubernetes objects defined in a given directory.
+// If the directory contains a kustomization file, the objects are built with kustomize.
+// Otherwise, all YAML files from the directory and its subdirectories are loaded in lexical order.
+func LoadObjects(dir string) ([]*unstructured.Unstructured, error) {
+	if hasKustomization(dir) {
+		resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), dir)
+		if err != nil {
+			return nil, errors.Wrap(err, "while building kustomization")
+		}
+
+		raw, err := resMap.AsYaml()
+		if err != nil {
+			return nil, errors.Wrap(err, "while marshaling kustomization output")
+		}
+
+		return DecodeObjects(raw)
+	}
+
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && isYAMLFile(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing manifest files")
+	}
+	sort.Strings(files)
+
+	var objs []*unstructured.Unstructured
+	for _, path := range files {
+		raw, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, errors.Wrapf(err, "while reading %q", path)
+		}
+
+		decoded, err := DecodeObjects(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while decoding %q", path)
+		}
+		objs = append(objs, decoded...)
+	}
+
+	return objs, nil
+}
+
+// DecodeObjects decodes multi-document YAML or JSON into Kubernetes objects.
+// Empty documents are skipped and items of List objects are returned as separate objects.
+func DecodeObjects(raw []byte) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(raw), 4096)
+	for {
+		var doc json.RawMessage
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "while decoding YAML document")
+		}
+		if len(doc) == 0 || string(doc) == "null" {
+			continue
+		}
+
+		// unstructured decoder keeps integers as int64, the same as objects returned by the Kubernetes API server
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(doc); err != nil {
+			return nil, errors.Wrap(err, "while decoding Kubernetes object")
+		}
+
+		if obj.IsList() {
+			err := obj.EachListItem(func(item runtime.Object) error {
+				objs = append(objs, item.(*unstructured.Unstructured))
+				return nil
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "while reading List items")
+			}
+			continue
+		}
+
+		if obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, errors.New("each object must have the kind and metadata.name fields")
+		}
+		objs = append(objs, obj)
+	}
+
+	return objs, nil
+}
+
+func hasKustomization(dir string) bool {
+	for _, name := range kustomizationFileNames {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isYAMLFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml" || filepath.Base(path) == "Kustomization"
+}
@@ -0,0 +1,146 @@
+package kustomize
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRenderAndLoadPlainManifests(t *testing.T) {
+	// given
+	dir := copyTestdata(t, "plain")
+	data := TemplateData{
+		Values: map[string]interface{}{
+			"name":  "nginx",
+			"image": "nginx:1.21",
+		},
+		Namespace: "capact-demo",
+	}
+
+	// when
+	err := RenderTemplates(dir, data)
+	require.NoError(t, err)
+
+	objs, err := LoadObjects(dir)
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, objs, 3)
+
+	cm := objs[0]
+	assert.Equal(t, "ConfigMap", cm.GetKind())
+	tmpl, _, err := unstructured.NestedString(cm.Object, "data", "template")
+	require.NoError(t, err)
+	assert.Equal(t, "{{ .Values.name }}", tmpl)
+
+	deploy := objs[1]
+	assert.Equal(t, "Deployment", deploy.GetKind())
+	assert.Equal(t, "nginx", deploy.GetName())
+	assert.Equal(t, "capact-demo", deploy.GetNamespace())
+
+	replicas, _, err := unstructured.NestedInt64(deploy.Object, "spec", "replicas")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, replicas)
+
+	containers, _, err := unstructured.NestedSlice(deploy.Object, "spec", "template", "spec", "containers")
+	require.NoError(t, err)
+	require.Len(t, containers, 1)
+	assert.Equal(t, "nginx:1.21", containers[0].(map[string]interface{})["image"])
+
+	svc := objs[2]
+	assert.Equal(t, "Service", svc.GetKind())
+	assert.Equal(t, "nginx", svc.GetName())
+	assert.Empty(t, svc.GetNamespace())
+}
+
+func TestRenderAndLoadKustomization(t *testing.T) {
+	// given
+	dir := copyTestdata(t, "kustomization")
+	data := TemplateData{
+		Values: map[string]interface{}{
+			"prefix":   "demo",
+			"greeting": "Hello: World",
+		},
+	}
+
+	// when
+	err := RenderTemplates(dir, data)
+	require.NoError(t, err)
+
+	objs, err := LoadObjects(dir)
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, objs, 1)
+	cm := objs[0]
+	assert.Equal(t, "ConfigMap", cm.GetKind())
+	assert.Equal(t, "demo-config", cm.GetName())
+	assert.Equal(t, "capact", cm.GetLabels()["app.kubernetes.io/managed-by"])
+
+	greeting, _, err := unstructured.NestedString(cm.Object, "data", "greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello: World", greeting)
+}
+
+func TestRenderTemplatesRemovesTemplateFiles(t *testing.T) {
+	// given
+	dir := copyTestdata(t, "plain")
+
+	// when
+	err := RenderTemplates(dir, TemplateData{})
+	require.NoError(t, err)
+
+	// then
+	for _, name := range []string{"deployment.yaml", filepath.Join("nested", "service.yaml")} {
+		assert.FileExists(t, filepath.Join(dir, name))
+		assert.NoFileExists(t, filepath.Join(dir, name+templateFileExt))
+	}
+}
+
+func TestDecodeObjectsRequiresName(t *testing.T) {
+	// given
+	raw := []byte("apiVersion: v1\nkind: ConfigMap\ndata: {}\n")
+
+	// when
+	_, err := DecodeObjects(raw)
+
+	// then
+	assert.EqualError(t, err, "each object must have the kind and metadata.name fields")
+}
+
+func copyTestdata(t *testing.T, name string) string {
+	t.Helper()
+
+	src := filepath.Join("testdata", name)
+	dst := t.TempDir()
+
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(target, data, 0600)
+	})
+	require.NoError(t, err)
+
+	return dst
+}
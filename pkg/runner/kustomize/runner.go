@@ -0,0 +1,342 @@
+package kustomize
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"capact.io/capact/internal/getter"
+	"capact.io/capact/pkg/runner"
+
+	"github.com/Masterminds/sprig"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+)
+
+var _ runner.Runner = &kustomizeRunner{}
+
+// kustomizeRunner provides functionality to apply Kubernetes manifests and wait until they are ready.
+type kustomizeRunner struct {
+	cfg Config
+	log *zap.Logger
+
+	applier *Applier
+	args    Arguments
+	// applied holds the objects created by the apply command, or removed by the delete command.
+	applied []ObjectRef
+}
+
+// NewRunner returns a new Kustomize runner instance.
+func NewRunner(cfg Config) runner.Runner {
+	return &kustomizeRunner{
+		cfg: cfg,
+	}
+}
+
+// Start applies or deletes the Kubernetes objects.
+func (r *kustomizeRunner) Start(ctx context.Context, in runner.StartInput) (*runner.StartOutput, error) {
+	r.args = DefaultArguments()
+	if err := yaml.Unmarshal(in.Args, &r.args); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling runner arguments")
+	}
+
+	k8sCfg, err := r.loadKubeconfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading kubeconfig")
+	}
+
+	r.applier, err = NewApplier(r.log, k8sCfg, r.cfg.FieldManager)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating applier")
+	}
+
+	switch r.cfg.Command {
+	case ApplyCommandType:
+		err = r.apply(ctx, in.RunnerCtx)
+	case DeleteCommandType:
+		err = r.delete(ctx, in.RunnerCtx)
+	default:
+		return nil, errors.New("Unsupported command")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "while running command %q", r.cfg.Command)
+	}
+
+	return &runner.StartOutput{
+		Status: fmt.Sprintf("Running %s command", r.cfg.Command),
+	}, nil
+}
+
+// WaitForCompletion waits until the applied objects are ready or the deleted objects are removed.
+func (r *kustomizeRunner) WaitForCompletion(ctx context.Context, in runner.WaitForCompletionInput) (*runner.WaitForCompletionOutput, error) {
+	if r.applier == nil {
+		return &runner.WaitForCompletionOutput{}, errors.New("runner not started yet")
+	}
+
+	var cancel context.CancelFunc
+	if in.RunnerCtx.Timeout.Duration() > 0 {
+		ctx, cancel = context.WithTimeout(ctx, in.RunnerCtx.Timeout.Duration())
+		defer cancel()
+	}
+
+	if r.cfg.Command == DeleteCommandType {
+		if !in.RunnerCtx.DryRun {
+			if err := r.waitForDeletion(ctx); err != nil {
+				return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while waiting for deletion")
+			}
+		}
+		return &runner.WaitForCompletionOutput{
+			Succeeded: true,
+			Message:   fmt.Sprintf("%d objects deleted", len(r.applied)),
+		}, nil
+	}
+
+	if r.args.Wait && !in.RunnerCtx.DryRun {
+		if err := r.waitForReadiness(ctx); err != nil {
+			return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while waiting for readiness")
+		}
+	}
+
+	if err := r.saveOutput(ctx, in.RunnerCtx); err != nil {
+		return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while saving output")
+	}
+
+	return &runner.WaitForCompletionOutput{
+		Succeeded: true,
+		Message:   fmt.Sprintf("%d objects applied in namespace %q", len(r.applied), in.RunnerCtx.Platform.Namespace),
+	}, nil
+}
+
+// Name returns the runner name.
+func (r *kustomizeRunner) Name() string {
+	return "kustomize"
+}
+
+// InjectLogger sets the logger on the runner.
+func (r *kustomizeRunner) InjectLogger(logger *zap.Logger) {
+	r.log = logger
+}
+
+func (r *kustomizeRunner) apply(ctx context.Context, runnerCtx runner.Context) error {
+	if r.args.Source == "" {
+		return errors.New("source must be specified")
+	}
+
+	// in case of git repository as a source, the download needs to be done in empty directory
+	r.log.Debug("Downloading source into workdir", zap.String("workdir", r.cfg.WorkDir))
+	_, err := os.Stat(r.cfg.WorkDir)
+	if strings.HasPrefix(r.args.Source, "git") && !os.IsNotExist(err) {
+		return fmt.Errorf("the workdir directory %q must not exist when cloning git repository", r.cfg.WorkDir)
+	}
+
+	if err := getter.Download(ctx, r.args.Source, r.cfg.WorkDir, nil); err != nil {
+		return errors.Wrap(err, "while downloading source")
+	}
+
+	dir := filepath.Join(r.cfg.WorkDir, filepath.Clean("/"+r.args.Path))
+	err = RenderTemplates(dir, TemplateData{
+		Values:    r.args.Values,
+		Namespace: runnerCtx.Platform.Namespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "while rendering manifest templates")
+	}
+
+	objs, err := LoadObjects(dir)
+	if err != nil {
+		return errors.Wrap(err, "while loading manifests")
+	}
+	if len(objs) == 0 {
+		return fmt.Errorf("no Kubernetes objects found in %q", r.args.Path)
+	}
+
+	applied, err := r.applier.Apply(ctx, objs, runnerCtx.Platform.Namespace, runnerCtx.DryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range applied {
+		r.applied = append(r.applied, refFor(obj))
+	}
+	return nil
+}
+
+func (r *kustomizeRunner) delete(ctx context.Context, runnerCtx runner.Context) error {
+	if r.cfg.ObjectsTypeInstancePath == "" {
+		return errors.New("RUNNER_OBJECTS_TYPE_INSTANCE_PATH must be set for the delete command")
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(r.cfg.ObjectsTypeInstancePath))
+	if err != nil {
+		return errors.Wrap(err, "while reading objects TypeInstance")
+	}
+
+	var ti struct {
+		Value AppliedObjects `json:"value"`
+	}
+	if err := yaml.Unmarshal(data, &ti); err != nil {
+		return errors.Wrap(err, "while unmarshaling objects TypeInstance")
+	}
+
+	r.applied = ti.Value.Objects
+	return r.applier.Delete(ctx, r.applied, runnerCtx.DryRun)
+}
+
+func (r *kustomizeRunner) waitForReadiness(ctx context.Context) error {
+	var lastMessage string
+	err := wait.PollImmediateUntil(r.cfg.PollInterval, func() (bool, error) {
+		for _, ref := range r.applied {
+			obj, err := r.applier.Get(ctx, ref)
+			if err != nil {
+				return false, errors.Wrapf(err, "while getting %s", ref)
+			}
+
+			ready, msg, err := IsReady(obj)
+			if err != nil {
+				return false, errors.Wrapf(err, "while checking readiness of %s", ref)
+			}
+			if !ready {
+				lastMessage = fmt.Sprintf("%s is not ready: %s", ref, msg)
+				r.log.Debug("Waiting for object", zap.String("reason", lastMessage))
+				return false, nil
+			}
+		}
+		return true, nil
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout && lastMessage != "" {
+		return errors.Wrap(err, lastMessage)
+	}
+	return err
+}
+
+func (r *kustomizeRunner) waitForDeletion(ctx context.Context) error {
+	return wait.PollImmediateUntil(r.cfg.PollInterval, func() (bool, error) {
+		for _, ref := range r.applied {
+			_, err := r.applier.Get(ctx, ref)
+			switch {
+			case err == nil:
+				r.log.Debug("Waiting for object removal", zap.Stringer("object", ref))
+				return false, nil
+			case apierrors.IsNotFound(err), meta.IsNoMatchError(errors.Cause(err)):
+				// the object, or even its kind, doesn't exist anymore
+			default:
+				return false, errors.Wrapf(err, "while getting %s", ref)
+			}
+		}
+		return true, nil
+	}, ctx.Done())
+}
+
+func (r *kustomizeRunner) saveOutput(ctx context.Context, runnerCtx runner.Context) error {
+	objects, err := yaml.Marshal(OutputFile{
+		Value: AppliedObjects{
+			Namespace: runnerCtx.Platform.Namespace,
+			Objects:   r.applied,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "while marshaling objects output")
+	}
+
+	r.log.Debug("Saving objects output", zap.String("path", r.cfg.Output.ObjectsFilePath))
+	if err := runner.SaveToFile(r.cfg.Output.ObjectsFilePath, objects); err != nil {
+		return errors.Wrap(err, "while saving objects output")
+	}
+
+	if strings.TrimSpace(r.args.Output.GoTemplate) == "" {
+		r.log.Debug("No additional output to render and save. skipping...")
+		return nil
+	}
+
+	additional, err := r.renderAdditionalOutput(ctx)
+	if err != nil {
+		return errors.Wrap(err, "while rendering additional output")
+	}
+
+	r.log.Debug("Saving additional output", zap.String("path", r.cfg.Output.AdditionalFilePath))
+	if err := runner.SaveToFile(r.cfg.Output.AdditionalFilePath, additional); err != nil {
+		return errors.Wrap(err, "while saving additional output")
+	}
+
+	return nil
+}
+
+func (r *kustomizeRunner) renderAdditionalOutput(ctx context.Context) ([]byte, error) {
+	// objects are fetched again, so the template can use the status reported after they became ready
+	var objects []map[string]interface{}
+	for _, ref := range r.applied {
+		obj, err := r.applier.Get(ctx, ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while getting %s", ref)
+		}
+		objects = append(objects, obj.Object)
+	}
+
+	tmpl, err := template.New("output").Funcs(sprig.TxtFuncMap()).Parse(r.args.Output.GoTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing output template")
+	}
+
+	var out bytes.Buffer
+	err = tmpl.Execute(&out, map[string]interface{}{
+		"Values":  r.args.Values,
+		"Objects": objects,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while executing output template")
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal(out.Bytes(), &value); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling rendered output")
+	}
+
+	data, err := yaml.Marshal(OutputFile{Value: value})
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshaling additional output")
+	}
+	return data, nil
+}
+
+func (r *kustomizeRunner) loadKubeconfig() (*rest.Config, error) {
+	if r.cfg.OptionalKubeconfigTI == "" {
+		return config.GetConfig()
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(r.cfg.OptionalKubeconfigTI))
+	switch {
+	case os.IsNotExist(err):
+		r.log.Debug("optional Kubeconfig TI specified but file does not exist")
+		return config.GetConfig()
+	case err != nil:
+		return nil, errors.Wrap(err, "while reading kubeconfig TypeInstance")
+	}
+
+	var ti struct {
+		Value struct {
+			Config map[string]interface{} `json:"config"`
+		} `json:"value"`
+	}
+	if err := yaml.Unmarshal(data, &ti); err != nil {
+		return nil, errors.Wrap(err, "while unmarshaling kubeconfig TypeInstance")
+	}
+
+	kubeconfig, err := yaml.Marshal(ti.Value.Config)
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshaling kubeconfig")
+	}
+
+	return clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+}
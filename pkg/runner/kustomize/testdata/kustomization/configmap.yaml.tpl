@@ -0,0 +1,6 @@
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  greeting: {{ .Values.greeting | quote }}
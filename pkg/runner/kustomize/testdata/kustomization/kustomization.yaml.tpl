@@ -0,0 +1,5 @@
+namePrefix: {{ .Values.prefix }}-
+commonLabels:
+  app.kubernetes.io/managed-by: capact
+resources:
+  - configmap.yaml
@@ -0,0 +1,14 @@
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: Service
+    metadata:
+      name: {{ .Values.name }}
+    spec:
+      selector:
+        app: {{ .Values.name }}
+      ports:
+        - port: 80
+---
+# empty document
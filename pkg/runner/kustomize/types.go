@@ -0,0 +1,85 @@
+package kustomize
+
+import (
+	"time"
+)
+
+// Config holds Runner related configuration.
+type Config struct {
+	OptionalKubeconfigTI string `envconfig:"optional"`
+	Command              CommandType
+	WorkDir              string        `envconfig:"default=/workspace"`
+	FieldManager         string        `envconfig:"default=capact-kustomize-runner"`
+	PollInterval         time.Duration `envconfig:"default=2s"`
+	// ObjectsTypeInstancePath is a path to the TypeInstance produced by the apply command.
+	// Applicable only for the delete command.
+	ObjectsTypeInstancePath string `envconfig:"optional"`
+	Output                  struct {
+		ObjectsFilePath    string `envconfig:"default=/tmp/objects.yaml"`
+		AdditionalFilePath string `envconfig:"default=/tmp/additional.yaml"`
+	}
+}
+
+// CommandType represents the operation type to be performed by the runner.
+type CommandType string
+
+const (
+	// ApplyCommandType is an operation to apply the Kubernetes manifests.
+	ApplyCommandType = "apply"
+	// DeleteCommandType is an operation to delete the Kubernetes objects created by the apply operation.
+	DeleteCommandType = "delete"
+)
+
+// DefaultArguments returns runner Arguments with default values.
+func DefaultArguments() Arguments {
+	return Arguments{
+		Wait: true,
+	}
+}
+
+// Arguments stores the input arguments for the runner operation.
+type Arguments struct {
+	// Source is a location of the manifests, such as URL to Tarball or Git repository.
+	// All formats supported by go-getter are allowed.
+	Source string `json:"source"`
+	// Path is a directory inside the Source with the manifests or the kustomization file.
+	Path string `json:"path"`
+	// Values are available in the manifest templates under the `.Values` field.
+	Values map[string]interface{} `json:"values"`
+	// Wait specifies whether the runner waits until all applied objects are ready.
+	Wait   bool       `json:"wait"`
+	Output OutputArgs `json:"output"`
+}
+
+// OutputArgs stores input arguments for generating the output files.
+type OutputArgs struct {
+	// GoTemplate is rendered with the `.Values` and the applied `.Objects` to produce the additional output.
+	GoTemplate string `json:"goTemplate"`
+}
+
+// ObjectRef identifies a single Kubernetes object.
+type ObjectRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// String returns the human readable representation of the object reference.
+func (r ObjectRef) String() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// AppliedObjects holds the details about objects created by the apply operation.
+type AppliedObjects struct {
+	Namespace string      `json:"namespace"`
+	Objects   []ObjectRef `json:"objects"`
+}
+
+// OutputFile defines the shape of the output file.
+type OutputFile struct {
+	Value interface{} `json:"value"`
+}
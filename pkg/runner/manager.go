@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"capact.io/capact/internal/logger"
 
@@ -57,6 +58,8 @@ func (r *Manager) Execute(ctx context.Context) error {
 	defer cancel()
 
 	log := r.log.With(zap.String("runner", r.runner.Name()), zap.Bool("dryRun", runnerInputData.Context.DryRun))
+	progressFuncInto(r.progressFunc(log, runnerInputData.Context), r.runner)
+
 	log.Debug("Starting runner")
 	sout, err := r.runner.Start(ctx, StartInput{
 		RunnerCtx: runnerInputData.Context,
@@ -85,6 +88,28 @@ func (r *Manager) Execute(ctx context.Context) error {
 	return wout.ErrorOrNil()
 }
 
+// progressFunc returns a function, which reports the runner progress using the status reporter, if supported.
+// Progress reporting is best effort, so errors are only logged.
+func (r *Manager) progressFunc(log *zap.Logger, runnerCtx Context) ProgressFunc {
+	reporter, ok := r.statusReporter.(ProgressReporter)
+	return func(ctx context.Context, event ProgressEvent) {
+		if event.Timestamp.IsZero() {
+			event.Timestamp = time.Now()
+		}
+		log.Debug("Runner progress",
+			zap.String("message", event.Message),
+			zap.Int("current", event.Current),
+			zap.Int("total", event.Total),
+		)
+		if !ok {
+			return
+		}
+		if err := reporter.ReportProgress(ctx, runnerCtx, event); err != nil {
+			log.Warn("Cannot report runner progress", zap.Error(err))
+		}
+	}
+}
+
 func (r *Manager) readRunnerInput() (InputData, error) {
 	var ctx Context
 	err := r.unmarshalFromFile(r.cfg.ContextPath, &ctx)
@@ -128,3 +153,15 @@ func loggerInto(log *zap.Logger, runner interface{}) {
 		s.InjectLogger(log)
 	}
 }
+
+// ProgressInjector is used by the Manager to inject progress reporting function to Runner.
+type ProgressInjector interface {
+	InjectProgressFunc(ProgressFunc)
+}
+
+// progressFuncInto sets progress reporting function on `runner` if requested.
+func progressFuncInto(fn ProgressFunc, runner interface{}) {
+	if s, ok := runner.(ProgressInjector); ok {
+		s.InjectProgressFunc(fn)
+	}
+}
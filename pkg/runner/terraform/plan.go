@@ -0,0 +1,52 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	planFile = "capact.tfplan"
+
+	noChangesMessage = "No changes. Infrastructure is up-to-date."
+)
+
+// jsonPlan holds the part of the `terraform show -json` output, which is needed to summarize the plan.
+type jsonPlan struct {
+	ResourceChanges []struct {
+		Change struct {
+			Actions []string `json:"actions"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// SummarizePlan counts the resource changes from the plan in the Terraform JSON output format.
+func SummarizePlan(rawPlan []byte) (PlanSummary, error) {
+	var plan jsonPlan
+	if err := json.Unmarshal(rawPlan, &plan); err != nil {
+		return PlanSummary{}, errors.Wrap(err, "while unmarshaling JSON plan")
+	}
+
+	var summary PlanSummary
+	for _, rc := range plan.ResourceChanges {
+		for _, action := range rc.Change.Actions {
+			switch action {
+			case "create":
+				summary.Add++
+			case "update":
+				summary.Change++
+			case "delete":
+				summary.Destroy++
+			}
+		}
+	}
+
+	summary.Message = noChangesMessage
+	if summary.Add+summary.Change+summary.Destroy > 0 {
+		summary.Message = fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy.", summary.Add, summary.Change, summary.Destroy)
+	}
+
+	return summary, nil
+}
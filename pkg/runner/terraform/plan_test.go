@@ -0,0 +1,98 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSummarizePlan(t *testing.T) {
+	// given
+	rawPlan, err := ioutil.ReadFile("testdata/plan.json")
+	require.NoError(t, err)
+
+	// when
+	summary, err := SummarizePlan(rawPlan)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, PlanSummary{
+		Add:     2,
+		Change:  1,
+		Destroy: 1,
+		Message: "Plan: 2 to add, 1 to change, 1 to destroy.",
+	}, summary)
+}
+
+func TestSummarizePlanWithoutChanges(t *testing.T) {
+	// given
+	rawPlan := []byte(`{"format_version": "0.1", "resource_changes": [{"change": {"actions": ["no-op"]}}]}`)
+
+	// when
+	summary, err := SummarizePlan(rawPlan)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, PlanSummary{Message: "No changes. Infrastructure is up-to-date."}, summary)
+}
+
+func TestInjectPlanTypeInstance(t *testing.T) {
+	const planTI = `
+value:
+  module:
+    name: cloudsql
+    source: /tmp/cloudsql.tgz
+  plan: cGxhbg==
+`
+	tests := map[string]struct {
+		givenArgs    Arguments
+		expSavedPlan bool
+		expErr       string
+	}{
+		"Should save plan for apply command": {
+			givenArgs:    Arguments{Command: ApplyCommand, Module: Module{Source: "/tmp/cloudsql.tgz"}},
+			expSavedPlan: true,
+		},
+		"Should reject plan created for a different module": {
+			givenArgs: Arguments{Command: ApplyCommand, Module: Module{Source: "/tmp/other.tgz"}},
+			expErr:    `plan was created for module "/tmp/cloudsql.tgz", but "/tmp/other.tgz" is applied`,
+		},
+		"Should reject plan for destroy command": {
+			givenArgs: Arguments{Command: DestroyCommand, Module: Module{Source: "/tmp/cloudsql.tgz"}},
+			expErr:    `plan TypeInstance can be used only with the "apply" command`,
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// given
+			workDir := t.TempDir()
+			planTIPath := filepath.Join(t.TempDir(), "plan.yaml")
+			require.NoError(t, ioutil.WriteFile(planTIPath, []byte(planTI), 0600))
+
+			r := &terraformRunner{
+				cfg: Config{WorkDir: workDir, PlanTypeInstanceFilepath: planTIPath},
+				log: zap.NewNop(),
+			}
+
+			// when
+			savedPlan, err := r.injectPlanTypeInstance(tc.givenArgs)
+
+			// then
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expSavedPlan, savedPlan)
+
+			plan, err := ioutil.ReadFile(filepath.Join(workDir, planFile))
+			require.NoError(t, err)
+			assert.Equal(t, "plan", string(plan))
+		})
+	}
+}
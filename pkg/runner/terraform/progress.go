@@ -0,0 +1,70 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"capact.io/capact/pkg/runner"
+)
+
+var (
+	// planSummaryRegex matches the plan summary, such as "Plan: 2 to add, 1 to change, 1 to destroy."
+	planSummaryRegex = regexp.MustCompile(`^Plan: .*\d+ to destroy\.`)
+	planCountRegex   = regexp.MustCompile(`(\d+) to (add|change|destroy)`)
+	// resourceDoneRegex matches the resource change completion, such as "aws_instance.web: Creation complete after 2s [id=i-123]"
+	resourceDoneRegex = regexp.MustCompile(`: (Creation|Modifications|Destruction) complete after`)
+)
+
+// progressTracker reports the number of changed resources based on the Terraform command output.
+// The total number of resources is known only if the command prints the plan summary.
+type progressTracker struct {
+	mu      sync.Mutex
+	report  runner.ProgressFunc
+	current int
+	total   int
+}
+
+func newProgressTracker(report runner.ProgressFunc) *progressTracker {
+	return &progressTracker{report: report}
+}
+
+// Observe processes a single line of the Terraform command output.
+func (p *progressTracker) Observe(ctx context.Context, command, line string) {
+	if p == nil || p.report == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case planSummaryRegex.MatchString(line):
+		// each command prints its own plan, so the progress starts from the beginning
+		p.current, p.total = 0, 0
+		for _, match := range planCountRegex.FindAllStringSubmatch(line, -1) {
+			count, _ := strconv.Atoi(match[1])
+			p.total += count
+		}
+	case resourceDoneRegex.MatchString(line):
+		p.current++
+	default:
+		return
+	}
+
+	if p.current == 0 && p.total == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("terraform %s %d resources", command, p.current)
+	if p.total > 0 {
+		message = fmt.Sprintf("terraform %s %d/%d resources", command, p.current, p.total)
+	}
+	p.report(ctx, runner.ProgressEvent{
+		Message: message,
+		Current: p.current,
+		Total:   p.total,
+	})
+}
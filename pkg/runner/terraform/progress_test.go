@@ -0,0 +1,71 @@
+package terraform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"capact.io/capact/pkg/runner"
+)
+
+func TestProgressTracker(t *testing.T) {
+	tests := map[string]struct {
+		command   string
+		output    []string
+		expEvents []runner.ProgressEvent
+	}{
+		"Should report apply progress with total from the plan summary": {
+			command: "apply",
+			output: []string{
+				"Plan: 2 to add, 1 to change, 0 to destroy.",
+				"google_sql_database_instance.db: Creating...",
+				"google_sql_database_instance.db: Creation complete after 5m2s [id=db]",
+				"google_sql_user.user: Modifications complete after 1s [id=user]",
+				"google_sql_database.app: Creation complete after 2s [id=app]",
+				"Apply complete! Resources: 2 added, 1 changed, 0 destroyed.",
+			},
+			expEvents: []runner.ProgressEvent{
+				{Message: "terraform apply 0/3 resources", Current: 0, Total: 3},
+				{Message: "terraform apply 1/3 resources", Current: 1, Total: 3},
+				{Message: "terraform apply 2/3 resources", Current: 2, Total: 3},
+				{Message: "terraform apply 3/3 resources", Current: 3, Total: 3},
+			},
+		},
+		"Should report destroy progress without the plan summary": {
+			command: "destroy",
+			output: []string{
+				"google_sql_database.app: Destroying... [id=app]",
+				"google_sql_database.app: Destruction complete after 3s",
+			},
+			expEvents: []runner.ProgressEvent{
+				{Message: "terraform destroy 1 resources", Current: 1},
+			},
+		},
+		"Should not report progress without changes": {
+			command: "apply",
+			output: []string{
+				"No changes. Infrastructure is up-to-date.",
+				"Apply complete! Resources: 0 added, 0 changed, 0 destroyed.",
+			},
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// given
+			var events []runner.ProgressEvent
+			tracker := newProgressTracker(func(_ context.Context, event runner.ProgressEvent) {
+				events = append(events, event)
+			})
+
+			// when
+			for _, line := range tc.output {
+				tracker.Observe(context.Background(), tc.command, line)
+			}
+
+			// then
+			assert.Equal(t, tc.expEvents, events)
+		})
+	}
+}
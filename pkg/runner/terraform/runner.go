@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"capact.io/capact/internal/getter"
@@ -17,12 +18,19 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
-var _ runner.Runner = &terraformRunner{}
+var (
+	_ runner.Runner           = &terraformRunner{}
+	_ runner.ProgressInjector = &terraformRunner{}
+)
+
+// httpBackendPasswordEnv is the environment variable read by the Terraform HTTP backend if the password is not set in the configuration.
+const httpBackendPasswordEnv = "TF_HTTP_PASSWORD"
 
 // Runner provides functionality to run and wait for Helm operations.
 type terraformRunner struct {
-	cfg Config
-	log *zap.Logger
+	cfg            Config
+	log            *zap.Logger
+	reportProgress runner.ProgressFunc
 
 	terraform *terraform
 }
@@ -42,6 +50,11 @@ func (r *terraformRunner) Start(ctx context.Context, in runner.StartInput) (*run
 		return nil, errors.Wrap(err, "while unmarshaling runner arguments")
 	}
 
+	// destroying with an empty state would be a no-op reported as a success
+	if args.Command == DestroyCommand && r.cfg.StateTypeInstanceFilepath == "" && !r.usesStateBackend() {
+		return nil, errors.New("destroy command requires the state TypeInstance or the state backend")
+	}
+
 	// both go-getter and terraform are using envs so setting them globally
 	// it can be used to set credentials, paths to credentials, variables, args...
 	err = r.setEnvVars(args.Env)
@@ -67,13 +80,23 @@ func (r *terraformRunner) Start(ctx context.Context, in runner.StartInput) (*run
 		return nil, errors.Wrap(err, "while splitting state TypeInstance")
 	}
 
+	savedPlan, err := r.injectPlanTypeInstance(args)
+	if err != nil {
+		return nil, errors.Wrap(err, "while injecting plan TypeInstance")
+	}
+
+	err = r.configureStateBackend()
+	if err != nil {
+		return nil, errors.Wrap(err, "while configuring state backend")
+	}
+
 	err = r.mergeInputVariables(args.Variables)
 	if err != nil {
 		return nil, errors.Wrap(err, "while merging variables")
 	}
 
-	tfCmd := newTFCmd(r.log, r.cfg.WorkDir)
-	r.terraform = newTerraform(r.log, args, tfCmd)
+	tfCmd := newTFCmd(r.log, r.cfg.WorkDir, newProgressTracker(r.reportProgress))
+	r.terraform = newTerraform(r.log, args, tfCmd, savedPlan)
 
 	err = r.terraform.Start(ctx, in.RunnerCtx.DryRun)
 	if err != nil {
@@ -101,6 +124,14 @@ func (r *terraformRunner) WaitForCompletion(ctx context.Context, in runner.WaitF
 		return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "terraform failed to finish")
 	}
 
+	if r.terraform.args.Command == PlanCommand {
+		plan := r.terraform.planOutput
+		if err := r.saveOutput(Output{Plan: plan}); err != nil {
+			return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while saving output files")
+		}
+		return &runner.WaitForCompletionOutput{Succeeded: true, Message: plan.Summary.Message}, nil
+	}
+
 	release, err := r.terraform.releaseInfo()
 	if err != nil {
 		return &runner.WaitForCompletionOutput{}, errors.New("failed to get release info")
@@ -109,9 +140,12 @@ func (r *terraformRunner) WaitForCompletion(ctx context.Context, in runner.WaitF
 	if err != nil {
 		return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while getting additional info")
 	}
-	tfstate, err := r.terraform.ReadTFStateFile(r.cfg.WorkDir)
-	if err != nil {
-		return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while getting terraform.tfstate file")
+	var tfstate []byte
+	if !r.usesStateBackend() {
+		tfstate, err = r.terraform.ReadTFStateFile(r.cfg.WorkDir)
+		if err != nil {
+			return &runner.WaitForCompletionOutput{}, errors.Wrap(err, "while getting terraform.tfstate file")
+		}
 	}
 
 	variables, err := r.terraform.ReadVariablesFile(r.cfg.WorkDir)
@@ -127,7 +161,7 @@ func (r *terraformRunner) WaitForCompletion(ctx context.Context, in runner.WaitF
 	output := Output{
 		Release:    release,
 		Additional: additional,
-		State:      state,
+		State:      &state,
 	}
 
 	err = r.saveOutput(output)
@@ -148,6 +182,11 @@ func (r *terraformRunner) InjectLogger(logger *zap.Logger) {
 	r.log = logger
 }
 
+// InjectProgressFunc injects the function used to report the Terraform apply and destroy progress.
+func (r *terraformRunner) InjectProgressFunc(fn runner.ProgressFunc) {
+	r.reportProgress = fn
+}
+
 func (r *terraformRunner) setEnvVars(env []string) error {
 	for _, e := range env {
 		s := strings.Split(e, "=")
@@ -184,9 +223,12 @@ func (r *terraformRunner) injectStateTypeInstance() error {
 		return errors.Wrap(err, "while unmarshaling StateTypeInstance")
 	}
 
-	stateFilepath := path.Join(r.cfg.WorkDir, stateFile)
-	if err := runner.SaveToFile(stateFilepath, state.Value.State); err != nil {
-		return errors.Wrapf(err, "while writing state file %s", stateFilepath)
+	// with the remote state backend, the local state file would be migrated to the backend during init
+	if !r.usesStateBackend() {
+		stateFilepath := path.Join(r.cfg.WorkDir, stateFile)
+		if err := runner.SaveToFile(stateFilepath, state.Value.State); err != nil {
+			return errors.Wrapf(err, "while writing state file %s", stateFilepath)
+		}
 	}
 
 	if err := runner.SaveToFile(varsFilepath, state.Value.Variables); err != nil {
@@ -196,6 +238,85 @@ func (r *terraformRunner) injectStateTypeInstance() error {
 	return nil
 }
 
+// injectPlanTypeInstance saves the plan from the plan TypeInstance in the workdir.
+// It returns true if the plan was saved, so the apply command has to use it.
+func (r *terraformRunner) injectPlanTypeInstance(args Arguments) (bool, error) {
+	if r.cfg.PlanTypeInstanceFilepath == "" {
+		return false, nil
+	}
+
+	if args.Command != ApplyCommand {
+		return false, fmt.Errorf("plan TypeInstance can be used only with the %q command", ApplyCommand)
+	}
+
+	r.log.Debug("Reading TypeInstance with TF plan", zap.String("path", r.cfg.PlanTypeInstanceFilepath))
+	data, err := ioutil.ReadFile(filepath.Clean(r.cfg.PlanTypeInstanceFilepath))
+	if err != nil {
+		return false, errors.Wrapf(err, "while reading plan file %s", r.cfg.PlanTypeInstanceFilepath)
+	}
+
+	plan := struct {
+		Value PlanTypeInstance `json:"value"`
+	}{}
+	if err := yaml.Unmarshal(data, &plan); err != nil {
+		return false, errors.Wrap(err, "while unmarshaling PlanTypeInstance")
+	}
+
+	if len(plan.Value.Plan) == 0 {
+		return false, errors.New("plan TypeInstance doesn't contain the plan")
+	}
+
+	// the plan references the module configuration, so it cannot be applied for a different module
+	if plan.Value.Module.Source != args.Module.Source {
+		return false, fmt.Errorf("plan was created for module %q, but %q is applied", plan.Value.Module.Source, args.Module.Source)
+	}
+
+	planFilepath := path.Join(r.cfg.WorkDir, planFile)
+	if err := runner.SaveToFile(planFilepath, plan.Value.Plan); err != nil {
+		return false, errors.Wrapf(err, "while writing plan file %s", planFilepath)
+	}
+
+	return true, nil
+}
+
+func (r *terraformRunner) usesStateBackend() bool {
+	return r.cfg.StateBackend.Address != ""
+}
+
+// configureStateBackend creates the Terraform override file, which configures the HTTP backend for the module.
+func (r *terraformRunner) configureStateBackend() error {
+	if !r.usesStateBackend() {
+		return nil
+	}
+
+	backendCfg := r.cfg.StateBackend
+	r.log.Debug("Configuring Terraform HTTP backend", zap.String("address", backendCfg.Address))
+
+	var buff strings.Builder
+	buff.WriteString("terraform {\n  backend \"http\" {\n")
+	fmt.Fprintf(&buff, "    address        = %q\n", backendCfg.Address)
+	fmt.Fprintf(&buff, "    lock_address   = %q\n", backendCfg.Address)
+	fmt.Fprintf(&buff, "    unlock_address = %q\n", backendCfg.Address)
+	if backendCfg.Username != "" {
+		fmt.Fprintf(&buff, "    username       = %q\n", backendCfg.Username)
+	}
+	buff.WriteString("  }\n}\n")
+
+	overrideFilepath := path.Join(r.cfg.WorkDir, backendOverrideFile)
+	if err := runner.SaveToFile(overrideFilepath, []byte(buff.String())); err != nil {
+		return errors.Wrapf(err, "while writing backend override file %s", overrideFilepath)
+	}
+
+	// password is passed via environment variable, so it is not stored in the working directory
+	if backendCfg.Password != "" {
+		if err := os.Setenv(httpBackendPasswordEnv, backendCfg.Password); err != nil {
+			return errors.Wrapf(err, "while setting env %s", httpBackendPasswordEnv)
+		}
+	}
+
+	return nil
+}
+
 func (r *terraformRunner) mergeInputVariables(variables string) error {
 	// variables file has to end with a new line
 	variables = variables + "\n"
@@ -251,20 +372,40 @@ func (r *terraformRunner) saveOutput(out Output) error {
 		}
 	}
 
-	r.log.Debug("Saving state output", zap.String("path", r.cfg.Output.TfstateFilePath))
-	stateData, err := yaml.Marshal(out.State)
-	if err != nil {
-		return errors.Wrap(err, "while marshaling state")
-	}
+	if out.State != nil {
+		r.log.Debug("Saving state output", zap.String("path", r.cfg.Output.TfstateFilePath))
+		stateData, err := yaml.Marshal(out.State)
+		if err != nil {
+			return errors.Wrap(err, "while marshaling state")
+		}
 
-	nestingStateData, err := runner.NestingOutputUnderValue(stateData)
-	if err != nil {
-		return errors.Wrap(err, "while nesting Terrafrom state data under value")
+		nestingStateData, err := runner.NestingOutputUnderValue(stateData)
+		if err != nil {
+			return errors.Wrap(err, "while nesting Terrafrom state data under value")
+		}
+
+		err = runner.SaveToFile(r.cfg.Output.TfstateFilePath, nestingStateData)
+		if err != nil {
+			return errors.Wrap(err, "while saving tfstate output")
+		}
 	}
 
-	err = runner.SaveToFile(r.cfg.Output.TfstateFilePath, nestingStateData)
-	if err != nil {
-		return errors.Wrap(err, "while saving tfstate output")
+	if out.Plan != nil {
+		r.log.Debug("Saving plan output", zap.String("path", r.cfg.Output.PlanFilePath))
+		planData, err := yaml.Marshal(out.Plan)
+		if err != nil {
+			return errors.Wrap(err, "while marshaling plan")
+		}
+
+		nestingPlanData, err := runner.NestingOutputUnderValue(planData)
+		if err != nil {
+			return errors.Wrap(err, "while nesting Terraform plan data under value")
+		}
+
+		err = runner.SaveToFile(r.cfg.Output.PlanFilePath, nestingPlanData)
+		if err != nil {
+			return errors.Wrap(err, "while saving plan output")
+		}
 	}
 
 	return nil
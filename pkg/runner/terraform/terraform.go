@@ -16,23 +16,28 @@ import (
 )
 
 const (
-	variablesFile = "terraform.tfvars"
-	stateFile     = "terraform.tfstate"
+	variablesFile       = "terraform.tfvars"
+	stateFile           = "terraform.tfstate"
+	backendOverrideFile = "capact_backend_override.tf"
 )
 
 type terraform struct {
-	tfCmd     *tfCmd
-	log       *zap.Logger
-	args      Arguments
-	_waitCh   chan error
-	runOutput []byte
+	tfCmd      *tfCmd
+	log        *zap.Logger
+	args       Arguments
+	savedPlan  bool
+	_waitCh    chan error
+	runOutput  []byte
+	planOutput *PlanTypeInstance
 }
 
-func newTerraform(log *zap.Logger, args Arguments, tfCmd *tfCmd) *terraform {
+// newTerraform returns a new terraform instance. If savedPlan is true, then apply uses the plan file from the workdir.
+func newTerraform(log *zap.Logger, args Arguments, tfCmd *tfCmd, savedPlan bool) *terraform {
 	return &terraform{
-		log:   log,
-		args:  args,
-		tfCmd: tfCmd,
+		log:       log,
+		args:      args,
+		tfCmd:     tfCmd,
+		savedPlan: savedPlan,
 	}
 }
 
@@ -52,6 +57,18 @@ func (t *terraform) Start(ctx context.Context, dryRun bool) error {
 			t._waitCh <- errors.Wrap(err, "while running terraform")
 			return
 		}
+
+		if t.args.Command == PlanCommand {
+			plan, err := t.showPlan()
+			if err != nil {
+				t._waitCh <- errors.Wrap(err, "while reading terraform plan")
+				return
+			}
+			t.planOutput = plan
+			close(t._waitCh)
+			return
+		}
+
 		// TODO returning error here is misleading as resources were deployed
 		out, err := t.tfCmd.Output()
 		if err != nil {
@@ -89,12 +106,21 @@ func (t *terraform) releaseInfo() ([]byte, error) {
 }
 
 func (t *terraform) run(ctx context.Context, dryRun bool) error {
+	if t.args.Command == PlanCommand {
+		// planning doesn't change the infrastructure, so it is the same for the dry run
+		return t.tfCmd.Plan(ctx, "-out="+planFile)
+	}
+
 	if dryRun {
 		return t.tfCmd.Plan(ctx)
 	}
 
 	switch t.args.Command {
 	case ApplyCommand:
+		if t.savedPlan {
+			return t.tfCmd.Apply(ctx, planFile)
+		}
+
 		if err := t.tfCmd.Plan(ctx); err != nil {
 			return err
 		}
@@ -107,6 +133,37 @@ func (t *terraform) run(ctx context.Context, dryRun bool) error {
 	return fmt.Errorf("command `%s` is not supported", t.args.Command)
 }
 
+// showPlan returns the plan TypeInstance based on the plan file saved by the plan command.
+func (t *terraform) showPlan() (*PlanTypeInstance, error) {
+	plan, err := t.readFile(path.Join(t.tfCmd.workDir, planFile))
+	if err != nil {
+		return nil, err
+	}
+
+	jsonOut, err := t.tfCmd.Show(planFile, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting JSON plan")
+	}
+
+	summary, err := SummarizePlan(jsonOut)
+	if err != nil {
+		return nil, errors.Wrap(err, "while summarizing plan")
+	}
+
+	details, err := t.tfCmd.Show(planFile, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting human readable plan")
+	}
+	summary.Details = string(details)
+
+	return &PlanTypeInstance{
+		Module:  Release{Name: t.args.Module.Name, Source: t.args.Module.Source},
+		Plan:    plan,
+		JSON:    jsonOut,
+		Summary: summary,
+	}, nil
+}
+
 func (t *terraform) ReadTFStateFile(dir string) ([]byte, error) {
 	return t.readFile(path.Join(dir, stateFile))
 }
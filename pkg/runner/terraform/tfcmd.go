@@ -14,14 +14,16 @@ import (
 // TODO: Refactor and use Terraform Go client instead of the binary
 
 type tfCmd struct {
-	workDir string
-	log     *zap.Logger
+	workDir  string
+	log      *zap.Logger
+	progress *progressTracker
 }
 
-func newTFCmd(log *zap.Logger, workDir string) *tfCmd {
+func newTFCmd(log *zap.Logger, workDir string, progress *progressTracker) *tfCmd {
 	return &tfCmd{
-		log:     log,
-		workDir: workDir,
+		log:      log,
+		workDir:  workDir,
+		progress: progress,
 	}
 }
 
@@ -29,12 +31,12 @@ func (t *tfCmd) Init(ctx context.Context) error {
 	return t.executeAndStreamOutput(ctx, "init")
 }
 
-func (t *tfCmd) Plan(ctx context.Context) error {
-	return t.executeAndStreamOutput(ctx, "plan")
+func (t *tfCmd) Plan(ctx context.Context, args ...string) error {
+	return t.executeAndStreamOutput(ctx, "plan", args...)
 }
 
-func (t *tfCmd) Apply(ctx context.Context) error {
-	return t.executeAndStreamOutput(ctx, "apply", "-auto-approve")
+func (t *tfCmd) Apply(ctx context.Context, args ...string) error {
+	return t.executeAndStreamOutput(ctx, "apply", append([]string{"-auto-approve"}, args...)...)
 }
 
 func (t *tfCmd) Destroy(ctx context.Context) error {
@@ -42,10 +44,26 @@ func (t *tfCmd) Destroy(ctx context.Context) error {
 }
 
 func (t *tfCmd) Output() ([]byte, error) {
-	cmd := t.cmd("output", "-json")
+	return t.executeAndGetOutput("output", "-json")
+}
+
+// Show returns the human readable or the JSON representation of a given plan file.
+func (t *tfCmd) Show(planFile string, asJSON bool) ([]byte, error) {
+	if asJSON {
+		return t.executeAndGetOutput("show", "-json", planFile)
+	}
+	return t.executeAndGetOutput("show", planFile)
+}
+
+func (t *tfCmd) executeAndGetOutput(command string, args ...string) ([]byte, error) {
+	cmd := t.cmd(command, args...)
 	t.log.Info("Running command", zap.Strings("args", cmd.Args))
 
-	out, err := cmd.CombinedOutput()
+	// stderr is not mixed with stdout, as it would break the JSON output
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return nil, errors.Wrapf(err, "while waiting for command to exit: %s", exitErr.Stderr)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "while waiting for command to exit")
 	}
@@ -78,7 +96,14 @@ func (t *tfCmd) executeAndStreamOutput(ctx context.Context, command string, args
 		Named("tf").
 		WithOptions(zap.WithCaller(false), zap.AddStacktrace(zap.PanicLevel))
 
-	t.readAndPrintConcurrently(ctx, stdOut, func(s string) { cmdOutLogger.Info(s) })
+	// only apply and destroy report the resource changes progress
+	trackProgress := command == "apply" || command == "destroy"
+	t.readAndPrintConcurrently(ctx, stdOut, func(s string) {
+		cmdOutLogger.Info(s)
+		if trackProgress {
+			t.progress.Observe(ctx, command, s)
+		}
+	})
 	t.readAndPrintConcurrently(ctx, stdErr, func(s string) { cmdOutLogger.Error(s) })
 
 	err = cmd.Wait()
@@ -1,5 +1,7 @@
 package terraform
 
+import "encoding/json"
+
 // CommandType represents the operation type to be performed by the runner.
 type CommandType string
 
@@ -20,7 +22,6 @@ type Arguments struct {
 	Env       []string         `yaml:"env"`
 	Variables string           `yaml:"variables"`
 	Output    AdditionalOutput `yaml:"output"`
-	// TODO destroy needs tfstate file
 }
 
 // Module stores the source details of the Terraform module.
@@ -39,14 +40,25 @@ type Config struct {
 	WorkDir                   string `envconfig:"default=/workspace"`
 	TerraformPath             string `envconfig:"default=terraform"`
 	StateTypeInstanceFilepath string `envconfig:"optional"`
+	PlanTypeInstanceFilepath  string `envconfig:"optional"`
+	StateBackend              StateBackendConfig
 	Output                    OutputConfig
 }
 
+// StateBackendConfig holds the optional Terraform HTTP backend configuration.
+// If the address is set, the Terraform state is stored remotely instead of in the state TypeInstance.
+type StateBackendConfig struct {
+	Address  string `envconfig:"optional"`
+	Username string `envconfig:"optional"`
+	Password string `envconfig:"optional"`
+}
+
 // OutputConfig stores the configuration for the generated output file.
 type OutputConfig struct {
 	TerraformReleaseFilePath string `envconfig:"default=/tmp/terraform-release.yaml"`
 	AdditionalFilePath       string `envconfig:"default=/tmp/additional.yaml"`
 	TfstateFilePath          string `envconfig:"default=/tmp/terraform.tfstate"`
+	PlanFilePath             string `envconfig:"default=/tmp/terraform-plan.yaml"`
 }
 
 // Release stores the details about the Terraform release.
@@ -61,9 +73,28 @@ type StateTypeInstance struct {
 	Variables []byte `json:"variables"`
 }
 
+// PlanTypeInstance stores the details about the Terraform plan TypeInstance.
+// The binary plan is used to apply exactly the reviewed changes.
+type PlanTypeInstance struct {
+	Module  Release         `json:"module"`
+	Plan    []byte          `json:"plan"`
+	JSON    json.RawMessage `json:"json"`
+	Summary PlanSummary     `json:"summary"`
+}
+
+// PlanSummary stores the human readable summary of the Terraform plan.
+type PlanSummary struct {
+	Add     int    `json:"add"`
+	Change  int    `json:"change"`
+	Destroy int    `json:"destroy"`
+	Message string `json:"message"`
+	Details string `json:"details"`
+}
+
 // Output stores the generated output artifacts.
 type Output struct {
 	Release    []byte
 	Additional []byte
-	State      StateTypeInstance
+	State      *StateTypeInstance
+	Plan       *PlanTypeInstance
 }
@@ -27,8 +27,22 @@ type Config struct {
 	ManifestsPath string `envconfig:"optional"`
 
 	// UpdateOnGitCommit makes populator to populate a new data
-	// only when a git commit chaned in source repository
+	// only when a git commit chaned in source repository,
+	// or a digest of OCI artifact or HTTP archive changed
 	UpdateOnGitCommit bool `envconfig:"default=false"`
 
+	// TrustedKeysDir is a path to a directory with public keys of trusted
+	// manifest signers. The signer name is the key file name without the
+	// `.pem` extension. If not set, signatures are not verified
+	TrustedKeysDir string `envconfig:"optional"`
+
+	// UnsignedManifestsPolicy defines how manifests without a valid
+	// signature are handled when TrustedKeysDir is set
+	UnsignedManifestsPolicy UnsignedManifestsPolicy `envconfig:"default=reject"`
+
+	// Sync holds configuration of the periodic synchronization, which is
+	// used only in the serve mode
+	Sync SyncConfig
+
 	Logger logger.Config
 }
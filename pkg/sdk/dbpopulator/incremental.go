@@ -0,0 +1,247 @@
+package dbpopulator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// refPrefix is a prefix of all paths, which can be referenced from manifests.
+const refPrefix = "cap."
+
+// manifestNode groups all revisions of a single manifest, such as a Type, stored under the same path.
+// It is the smallest unit, which is reloaded during the incremental update.
+type manifestNode struct {
+	kind  string
+	path  string
+	files []manifestPath
+	// digest is computed from content of all revisions.
+	digest string
+	// refs holds paths of all manifests referenced by any revision.
+	refs []string
+}
+
+// updatePlan describes which manifests have to be updated in the database.
+type updatePlan struct {
+	// full is set when the database doesn't have any information about stored manifests,
+	// and all manifests have to be reloaded.
+	full bool
+	// reload holds paths of manifests, which have to be loaded into the database.
+	reload []string
+	// removed holds paths of stored manifests, which don't exist in sources anymore.
+	removed []string
+}
+
+func (p updatePlan) isEmpty() bool {
+	return len(p.reload) == 0 && len(p.removed) == 0
+}
+
+// outdated returns paths of stored manifests, which have to be replaced or removed.
+func (p updatePlan) outdated() []string {
+	return append(append([]string{}, p.reload...), p.removed...)
+}
+
+// planUpdate compares manifests from sources with digests of manifests stored in the database.
+// Changed, new and removed manifests are reloaded together with all manifests, which depend on them,
+// as the relations between the database nodes are recreated only when a given manifest is loaded.
+func planUpdate(nodes map[string]*manifestNode, stored map[string]string) updatePlan {
+	dependents := map[string][]string{}
+	for _, node := range nodes {
+		for _, ref := range node.refs {
+			dependents[ref] = append(dependents[ref], node.path)
+		}
+	}
+
+	var queue []string
+	for path, node := range nodes {
+		if digest, found := stored[path]; !found || digest != node.digest {
+			queue = append(queue, path)
+		}
+	}
+
+	var removed []string
+	for path := range stored {
+		if _, found := nodes[path]; !found {
+			removed = append(removed, path)
+			queue = append(queue, path)
+		}
+	}
+
+	visited := map[string]struct{}{}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+
+		if _, found := visited[path]; found {
+			continue
+		}
+		visited[path] = struct{}{}
+		queue = append(queue, dependents[path]...)
+	}
+
+	var reload []string
+	for path := range visited {
+		if _, found := nodes[path]; found {
+			reload = append(reload, path)
+		}
+	}
+
+	sort.Strings(reload)
+	sort.Strings(removed)
+
+	return updatePlan{
+		full:    len(stored) == 0,
+		reload:  reload,
+		removed: removed,
+	}
+}
+
+// loadManifestNodes reads manifests from all sources and groups them by the manifest path.
+func loadManifestNodes(sources []SourceInfo) (map[string]*manifestNode, error) {
+	merged := GroupManifests{}
+	signers := map[string]string{}
+	for _, source := range sources {
+		for file, signer := range source.Signers {
+			signers[file] = signer
+		}
+
+		grouped, err := Group(source.Files, source.RootDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "while grouping manifests")
+		}
+		merged.MergeWith(grouped)
+	}
+
+	nodes := map[string]*manifestNode{}
+	fileDigests := map[string][]string{}
+	refs := map[string]map[string]struct{}{}
+	for kind, manifests := range merged {
+		for _, manifest := range manifests {
+			content, err := ioutil.ReadFile(filepath.Clean(manifest.path))
+			if err != nil {
+				return nil, errors.Wrapf(err, "while reading file from path %s", manifest.path)
+			}
+
+			var value map[string]interface{}
+			if err := yaml.Unmarshal(content, &value); err != nil {
+				return nil, errors.Wrapf(err, "while unmarshaling manifest content from path %s", manifest.path)
+			}
+
+			path := strings.Join([]string{manifest.prefix, manifestName(value)}, ".")
+			node, found := nodes[path]
+			if !found {
+				node = &manifestNode{kind: kind, path: path}
+				nodes[path] = node
+				refs[path] = map[string]struct{}{}
+			}
+			manifest.signer = signers[manifest.path]
+			node.files = append(node.files, manifest)
+
+			// the file location is not used, as sources are downloaded to random directories
+			// the signer is included, so the manifest is reloaded when it is signed with another key
+			sum := sha256.Sum256(append([]byte(manifest.prefix+"\n"+manifest.signer+"\n"), content...))
+			fileDigests[path] = append(fileDigests[path], hex.EncodeToString(sum[:]))
+
+			collectReferences(value, refs[path])
+			if kind == "Interface" {
+				// Interface is always loaded into the InterfaceGroup with the same path as the Interface prefix
+				refs[path][manifest.prefix] = struct{}{}
+			}
+		}
+	}
+
+	for path, node := range nodes {
+		sort.Slice(node.files, func(i, j int) bool { return node.files[i].path < node.files[j].path })
+		node.digest = combinedDigest(fileDigests[path])
+
+		delete(refs[path], path)
+		for ref := range refs[path] {
+			node.refs = append(node.refs, ref)
+		}
+		sort.Strings(node.refs)
+	}
+
+	return nodes, nil
+}
+
+func manifestName(value map[string]interface{}) string {
+	metadata, ok := value["metadata"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+func combinedDigest(digests []string) string {
+	sorted := append([]string{}, digests...)
+	sort.Strings(sorted)
+
+	sum := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// collectReferences collects all paths referenced in a given manifest part.
+// It collects more paths than necessary, e.g. also from Implementation action arguments,
+// which only results in reloading more manifests than needed.
+func collectReferences(in interface{}, refs map[string]struct{}) {
+	switch val := in.(type) {
+	case string:
+		if strings.HasPrefix(val, refPrefix) {
+			refs[val] = struct{}{}
+		}
+	case []interface{}:
+		for _, item := range val {
+			collectReferences(item, refs)
+		}
+	case map[string]interface{}:
+		for key, item := range val {
+			if strings.HasPrefix(key, refPrefix) {
+				refs[key] = struct{}{}
+			}
+			if key == "requires" {
+				collectRequiresReferences(item, refs)
+			}
+			collectReferences(item, refs)
+		}
+	}
+}
+
+// collectRequiresReferences collects Type paths from the Implementation requirements.
+// Requirement item names can be relative to the requirement prefix.
+func collectRequiresReferences(in interface{}, refs map[string]struct{}) {
+	requires, ok := in.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for prefix, requirement := range requires {
+		items, ok := requirement.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, list := range items {
+			listItems, ok := list.([]interface{})
+			if !ok {
+				continue
+			}
+			for _, listItem := range listItems {
+				item, ok := listItem.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name, ok := item["name"].(string)
+				if !ok || strings.HasPrefix(name, refPrefix) {
+					continue
+				}
+				refs[strings.Join([]string{prefix, name}, ".")] = struct{}{}
+			}
+		}
+	}
+}
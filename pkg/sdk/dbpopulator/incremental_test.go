@@ -0,0 +1,227 @@
+package dbpopulator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanUpdate(t *testing.T) {
+	nodes := map[string]*manifestNode{
+		"cap.type.app.config": {
+			path:   "cap.type.app.config",
+			digest: "type-digest",
+		},
+		"cap.interface.app": {
+			path:   "cap.interface.app",
+			digest: "group-digest",
+		},
+		"cap.interface.app.install": {
+			path:   "cap.interface.app.install",
+			digest: "interface-digest",
+			refs:   []string{"cap.interface.app", "cap.type.app.config"},
+		},
+		"cap.implementation.app.install": {
+			path:   "cap.implementation.app.install",
+			digest: "impl-digest",
+			refs:   []string{"cap.interface.app.install", "cap.type.removed"},
+		},
+		"cap.attribute.app.enabled": {
+			path:   "cap.attribute.app.enabled",
+			digest: "attr-digest",
+		},
+	}
+	unchanged := map[string]string{
+		"cap.type.app.config":            "type-digest",
+		"cap.interface.app":              "group-digest",
+		"cap.interface.app.install":      "interface-digest",
+		"cap.implementation.app.install": "impl-digest",
+		"cap.attribute.app.enabled":      "attr-digest",
+	}
+
+	tests := map[string]struct {
+		stored       map[string]string
+		expectedPlan updatePlan
+	}{
+		"Should reload all manifests if there is no stored digests": {
+			stored: map[string]string{},
+			expectedPlan: updatePlan{
+				full: true,
+				reload: []string{
+					"cap.attribute.app.enabled",
+					"cap.implementation.app.install",
+					"cap.interface.app",
+					"cap.interface.app.install",
+					"cap.type.app.config",
+				},
+			},
+		},
+		"Should do nothing if manifests did not change": {
+			stored:       unchanged,
+			expectedPlan: updatePlan{},
+		},
+		"Should reload changed Type with all dependent manifests": {
+			stored: withDigest(unchanged, "cap.type.app.config", "old-digest"),
+			expectedPlan: updatePlan{
+				reload: []string{
+					"cap.implementation.app.install",
+					"cap.interface.app.install",
+					"cap.type.app.config",
+				},
+			},
+		},
+		"Should reload only changed Implementation": {
+			stored: withDigest(unchanged, "cap.implementation.app.install", "old-digest"),
+			expectedPlan: updatePlan{
+				reload: []string{"cap.implementation.app.install"},
+			},
+		},
+		"Should remove deleted manifest and reload dependent manifests": {
+			stored: withDigest(unchanged, "cap.type.removed", "removed-digest"),
+			expectedPlan: updatePlan{
+				reload:  []string{"cap.implementation.app.install"},
+				removed: []string{"cap.type.removed"},
+			},
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// when
+			plan := planUpdate(nodes, tc.stored)
+
+			// then
+			assert.Equal(t, tc.expectedPlan, plan)
+		})
+	}
+}
+
+func TestLoadManifestNodes(t *testing.T) {
+	// given
+	files := map[string]string{
+		"type/app/config.yaml": `
+ocfVersion: 0.0.1
+revision: 0.1.0
+kind: Type
+metadata:
+  name: config
+  attributes:
+    cap.attribute.app.enabled:
+      revision: 0.1.0
+spec:
+  jsonSchema:
+    value: "{}"
+`,
+		"type/app/config-v2.yaml": `
+ocfVersion: 0.0.1
+revision: 0.2.0
+kind: Type
+metadata:
+  name: config
+spec:
+  jsonSchema:
+    value: "{}"
+`,
+		"interface/app/install.yaml": `
+ocfVersion: 0.0.1
+revision: 0.1.0
+kind: Interface
+metadata:
+  name: install
+spec:
+  output:
+    typeInstances:
+      config:
+        typeRef:
+          path: cap.type.app.config
+          revision: 0.1.0
+`,
+		"implementation/app/install.yaml": `
+ocfVersion: 0.0.1
+revision: 0.1.0
+kind: Implementation
+metadata:
+  name: install
+spec:
+  implements:
+    - path: cap.interface.app.install
+      revision: 0.1.0
+  requires:
+    cap.core.type.platform:
+      oneOf:
+        - name: kubernetes
+          revision: 0.1.0
+`,
+	}
+	firstDir, secondDir := fixManifestsDir(t, files), fixManifestsDir(t, files)
+
+	// when
+	first, err := loadManifestNodes([]SourceInfo{fixSourceInfo(t, firstDir)})
+	require.NoError(t, err)
+	second, err := loadManifestNodes([]SourceInfo{fixSourceInfo(t, secondDir)})
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, first, 3)
+
+	typeNode := first["cap.type.app.config"]
+	require.NotNil(t, typeNode)
+	assert.Equal(t, "Type", typeNode.kind)
+	assert.Len(t, typeNode.files, 2)
+	assert.Equal(t, []string{"cap.attribute.app.enabled"}, typeNode.refs)
+
+	ifaceNode := first["cap.interface.app.install"]
+	require.NotNil(t, ifaceNode)
+	assert.Equal(t, []string{"cap.interface.app", "cap.type.app.config"}, ifaceNode.refs)
+
+	implNode := first["cap.implementation.app.install"]
+	require.NotNil(t, implNode)
+	assert.Equal(t, []string{"cap.core.type.platform", "cap.core.type.platform.kubernetes", "cap.interface.app.install"}, implNode.refs)
+
+	for path, node := range first {
+		assert.Equal(t, node.digest, second[path].digest, "digest of %s should not depend on the source location", path)
+	}
+}
+
+func withDigest(in map[string]string, path, digest string) map[string]string {
+	out := map[string]string{}
+	for k, v := range in {
+		out[k] = v
+	}
+	out[path] = digest
+	return out
+}
+
+func fixManifestsDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+func fixSourceInfo(t *testing.T, dir string) SourceInfo {
+	t.Helper()
+
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	return SourceInfo{RootDir: dir, Files: files}
+}
@@ -0,0 +1,195 @@
+package dbpopulator
+
+import (
+	"context"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// populationLockTTL defines how long the population lock is held without renewal. The lock expires if the populator was killed,
+	// so the next population can remove the leftovers.
+	populationLockTTL = time.Minute
+	// populationLockRenewInterval defines how often the lock is renewed during population.
+	populationLockRenewInterval = populationLockTTL / 3
+)
+
+var lockConstraintQuery = `
+CREATE CONSTRAINT population_lock_name IF NOT EXISTS ON (l:PopulationLock) ASSERT l.name IS UNIQUE
+`
+
+// acquireLockQuery returns 1 if the lock was acquired. Removing a non-existing property takes the write lock on the node,
+// so the concurrent transactions check the holder one after another.
+var acquireLockQuery = `
+MERGE (l:PopulationLock {name: "populator"})
+REMOVE l._lock
+WITH l
+WHERE l.holder IS NULL OR l.holder = $holder OR l.expiresAt < timestamp()
+SET l.holder = $holder, l.expiresAt = timestamp() + $ttl
+RETURN count(l)
+`
+
+// renewLockQuery returns 1 if the lock is still held by a given holder.
+var renewLockQuery = `
+MATCH (l:PopulationLock {name: "populator", holder: $holder})
+SET l.expiresAt = timestamp() + $ttl
+RETURN count(l)
+`
+
+var releaseLockQuery = `
+MATCH (l:PopulationLock {name: "populator", holder: $holder})
+REMOVE l.holder, l.expiresAt
+RETURN count(l)
+`
+
+// populationLock is the population lock held by a given holder. It is renewed periodically until it is released.
+type populationLock struct {
+	log     *zap.Logger
+	session neo4j.Session
+	holder  string
+
+	// lost is closed if the lock cannot be renewed, so the population must not be finished.
+	lost    chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// acquirePopulationLock ensures that only one populator modifies the unpublished nodes at a time.
+// The lock uses a dedicated session, as the neo4j sessions cannot be used concurrently.
+func acquirePopulationLock(log *zap.Logger, driver neo4j.Driver, holder string) (*populationLock, error) {
+	session := driver.NewSession(neo4j.SessionConfig{})
+	if err := acquireLock(session, holder); err != nil {
+		if cErr := session.Close(); cErr != nil {
+			log.Error("Cannot close population lock session", zap.Error(cErr))
+		}
+		return nil, err
+	}
+
+	lock := &populationLock{
+		log:     log,
+		session: session,
+		holder:  holder,
+		lost:    make(chan struct{}),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go lock.keepAlive()
+
+	return lock, nil
+}
+
+// Lost returns a channel which is closed if the lock cannot be renewed.
+func (l *populationLock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Release stops renewing the lock and releases it, so the next population doesn't wait for the lock expiration.
+func (l *populationLock) Release() error {
+	close(l.stop)
+	<-l.stopped
+
+	err := releaseLock(l.session, l.holder)
+	if cErr := l.session.Close(); cErr != nil && err == nil {
+		err = errors.Wrap(cErr, "while closing population lock session")
+	}
+	return err
+}
+
+func (l *populationLock) keepAlive() {
+	defer close(l.stopped)
+
+	ticker := time.NewTicker(populationLockRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+
+		err := renewLock(l.session, l.holder)
+		if err != nil {
+			l.log.Error("Cannot renew population lock", zap.Error(err))
+			close(l.lost)
+			return
+		}
+	}
+}
+
+// contextWithLock returns a context which is canceled if a given lock is lost.
+func contextWithLock(ctx context.Context, lock *populationLock) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-lock.Lost():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+func acquireLock(session neo4j.Session, holder string) error {
+	result, err := session.Run(lockConstraintQuery, nil)
+	if err != nil {
+		return errors.Wrap(err, "while creating population lock constraint")
+	}
+	if _, err := result.Consume(); err != nil {
+		return errors.Wrap(err, "while creating population lock constraint")
+	}
+
+	acquired, err := runLockQuery(session, acquireLockQuery, holder)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return errors.New("population lock is held by another populator")
+	}
+	return nil
+}
+
+func renewLock(session neo4j.Session, holder string) error {
+	renewed, err := runLockQuery(session, renewLockQuery, holder)
+	if err != nil {
+		return err
+	}
+	if !renewed {
+		return errors.New("population lock was taken over by another populator")
+	}
+	return nil
+}
+
+func releaseLock(session neo4j.Session, holder string) error {
+	_, err := session.WriteTransaction(func(transaction neo4j.Transaction) (interface{}, error) {
+		return nil, runQuery(transaction, releaseLockQuery, map[string]interface{}{"holder": holder})
+	})
+	return errors.Wrap(err, "while executing neo4j transaction")
+}
+
+// runLockQuery runs a given lock query and returns true if it matched the lock.
+func runLockQuery(session neo4j.Session, query, holder string) (bool, error) {
+	matched, err := session.WriteTransaction(func(transaction neo4j.Transaction) (interface{}, error) {
+		result, err := transaction.Run(query, map[string]interface{}{
+			"holder": holder,
+			"ttl":    populationLockTTL.Milliseconds(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		record, err := result.Single()
+		if err != nil {
+			return nil, err
+		}
+		return record.Values[0], nil
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "while executing neo4j transaction")
+	}
+
+	count, ok := matched.(int64)
+	return ok && count > 0, nil
+}
@@ -3,9 +3,11 @@ package dbpopulator
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -15,12 +17,26 @@ import (
 type SourceInfo struct {
 	RootDir string
 	Files   []string
-	GitHash []byte
+	// Revision is the git commit of the source, or the digest of the OCI artifact or HTTP archive.
+	Revision []byte
+	// Signers holds the identity of the trusted signer for each verified manifest file.
+	Signers map[string]string
 }
 
 type manifestPath struct {
 	path   string
 	prefix string
+	signer string
+}
+
+// Path returns the manifest file path.
+func (m manifestPath) Path() string {
+	return m.path
+}
+
+// Prefix returns the manifest prefix computed from the file location in the root directory.
+func (m manifestPath) Prefix() string {
+	return m.prefix
 }
 
 const (
@@ -104,7 +120,8 @@ WITH *, value.metadata.attributes as attributes
 CALL {
  WITH attributes, metadata
  UNWIND keys(attributes) as path
-  MATCH (attribute:Attribute:unpublished{path: path})-[:CONTAINS]->(revision:AttributeRevision {revision: attributes[path].revision})
+  MATCH (attribute:Attribute{path: path})-[:CONTAINS]->(revision:AttributeRevision {revision: attributes[path].revision})
+  WHERE NOT attribute:outdated
   CREATE (metadata)-[:CHARACTERIZED_BY]->(revision)
   CREATE (revision)-[:CHARACTERIZES]->(metadata)
  RETURN count([]) as _tmp8
@@ -140,7 +157,8 @@ CREATE (metadata)-[:MAINTAINED_BY]->(maintainer)
 `
 
 var interfaceQuery = `
-MATCH (interfaceGroup:InterfaceGroup:unpublished{path: "<PREFIX>"})
+MATCH (interfaceGroup:InterfaceGroup{path: "<PREFIX>"})
+WHERE NOT interfaceGroup:outdated
 
 MERGE (interface:Interface:unpublished{
   path: apoc.text.join(["<PREFIX>", value.metadata.name], "."),
@@ -207,8 +225,9 @@ CALL {
   CREATE (inputTypeInstance)-[:OF_TYPE]->(typeReference)
   CREATE (input)-[:HAS]->(inputTypeInstance)
   WITH *
-  MATCH (:Type:unpublished{
+  MATCH (type:Type{
     path: typeInstances[name].typeRef.path})-[:CONTAINS]->(typeRevision:TypeRevision{revision:typeInstances[name].typeRef.revision})
+  WHERE NOT type:outdated
   CREATE (input)-[:HAS]->(typeRevision)
   CREATE (typeRevision)-[:USED_BY]->(interfaceRevision)
  RETURN count([]) as _tmp1
@@ -225,8 +244,9 @@ CALL {
   CREATE (outputTypeInstance)-[:OF_TYPE]->(typeReference)
   CREATE (output)-[:OUTPUTS]->(outputTypeInstance)
   WITH *
-  MATCH (:Type:unpublished{
+  MATCH (type:Type{
     path: typeInstances[name].typeRef.path})-[:CONTAINS]->(typeRevision:TypeRevision{revision:typeInstances[name].typeRef.revision})
+  WHERE NOT type:outdated
   CREATE (output)-[:OUTPUTS]->(typeRevision)
   CREATE (typeRevision)-[:USED_BY]->(interfaceRevision)
  RETURN count([]) as _tmp2
@@ -246,7 +266,7 @@ MERGE (implementation:Implementation:unpublished{
   path: apoc.text.join(["<PREFIX>", value.metadata.name], "."),
   prefix: "<PREFIX>",
   name: value.metadata.name})
-CREATE (implementationRevision:ImplementationRevision:unpublished {revision: value.revision})
+CREATE (implementationRevision:ImplementationRevision:unpublished {revision: value.revision, signer: $signer})
 
 CREATE (implementation)-[:CONTAINS]->(implementationRevision)
 
@@ -276,7 +296,8 @@ WITH *
 CALL {
  WITH value, implementationRevision, spec
  UNWIND value.spec.implements as interface
-  MATCH (interfaceRevision: InterfaceRevision:unpublished {revision: interface.revision})-[:DESCRIBED_BY]->(m:GenericMetadata{path: interface.path})
+  MATCH (interfaceRevision: InterfaceRevision {revision: interface.revision})-[:DESCRIBED_BY]->(m:GenericMetadata{path: interface.path})
+  WHERE NOT interfaceRevision:outdated
   CREATE (interfaceReference: InterfaceReference:unpublished{path: interface.path, revision: interface.revision})
   CREATE (spec)-[:IMPLEMENTS]->(interfaceReference)
   CREATE (implementationRevision)-[:IMPLEMENTS]->(interfaceRevision)
@@ -302,7 +323,8 @@ CALL {
 			{prefix:prefix, name:listItem.name})
 		YIELD value as resolve
 		WITH *
-    MATCH (type:Type:unpublished{path: resolve.path })-[:CONTAINS]->(typeRevision:TypeRevision {revision: listItem.revision})
+    MATCH (type:Type{path: resolve.path })-[:CONTAINS]->(typeRevision:TypeRevision {revision: listItem.revision})
+    WHERE NOT type:outdated
     MERGE (typeReference:TypeReference:unpublished{
       path: resolve.path,
       revision: listItem.revision})
@@ -407,7 +429,8 @@ WITH *, value.metadata.attributes as attributes
 CALL {
  WITH attributes, metadata
  UNWIND keys(attributes) as path
-  MATCH (attribute:Attribute:unpublished{path: path})-[:CONTAINS]->(revision:AttributeRevision {revision: attributes[path].revision})
+  MATCH (attribute:Attribute{path: path})-[:CONTAINS]->(revision:AttributeRevision {revision: attributes[path].revision})
+  WHERE NOT attribute:outdated
   CREATE (metadata)-[:CHARACTERIZED_BY]->(revision)
   CREATE (revision)-[:CHARACTERIZES]->(metadata)
  RETURN count([]) as _tmp8
@@ -464,9 +487,9 @@ CREATE (metadata)-[:MAINTAINED_BY]->(maintainer)
 
 var swapQuery = `
 CALL {
- MATCH (n:published)
+ MATCH (n:outdated)
  CALL apoc.create.addLabels( n, [ "to_remove" ] ) YIELD node
- CALL apoc.create.removeLabels( n, [ "published" ] ) YIELD node as node1
+ CALL apoc.create.removeLabels( n, [ "published", "outdated" ] ) YIELD node as node1
  return count(n)
 }
 
@@ -476,19 +499,135 @@ CALL apoc.create.removeLabels( n, [ "unpublished" ] ) YIELD node as node1
 RETURN count(n)
 `
 
+// resetQuery removes leftovers from the previous interrupted population.
+// It must be run only by the population lock holder, otherwise it removes nodes of the population in progress.
+var resetQuery = `
+CALL {
+ MATCH (n) WHERE n:unpublished OR n:loading OR n:to_remove
+ DETACH DELETE n
+ RETURN count(n) as _tmp0
+}
+MATCH (n:outdated)
+REMOVE n:outdated
+RETURN count(n)
+`
+
+// outdatedQuery marks published nodes of manifests, which are reloaded or removed.
+// Nodes shared with other manifests, such as Maintainers or TypeReferences, are marked only if all their owners are outdated.
+// The nodes stay published until the swap, so Public Hub reads are consistent during the update.
+var outdatedQuery = `
+MATCH (n:published) WHERE all(path IN n.ownerPaths WHERE path IN $paths)
+SET n:outdated
+RETURN count(n)
+`
+
+// releaseOwnersQuery removes outdated manifests from owners of the shared nodes, which stay published.
+var releaseOwnersQuery = `
+MATCH (n:published) WHERE NOT n:outdated AND any(path IN n.ownerPaths WHERE path IN $paths)
+SET n.ownerPaths = [path IN n.ownerPaths WHERE NOT path IN $paths]
+RETURN count(n)
+`
+
+var allOutdatedQuery = `
+MATCH (n:published)
+SET n:outdated
+RETURN count(n)
+`
+
+// ownerQuery adds the owner to all nodes created or merged for a given manifest, so they can be found during the next incremental update.
+var ownerQuery = `
+MATCH (n:loading)
+SET n.ownerPaths = CASE WHEN $path IN coalesce(n.ownerPaths, []) THEN n.ownerPaths ELSE coalesce(n.ownerPaths, []) + $path END
+REMOVE n:loading
+RETURN count(n)
+`
+
+var removeAllDigestsQuery = `
+MATCH (d:ManifestDigest)
+DELETE d
+`
+
+var removeDigestsQuery = `
+UNWIND $paths as path
+MATCH (d:ManifestDigest {path: path})
+DELETE d
+`
+
+var saveDigestsQuery = `
+UNWIND $digests as digest
+MERGE (d:ManifestDigest {path: digest.path})
+SET d.digest = digest.digest
+`
+
 var cleanQuery = `
 call apoc.periodic.iterate("MATCH (n:to_remove) return n", "DETACH DELETE n", {batchSize:1000})
 yield batches, total return batches, total
 `
 
 // Populate imports Public Hub manifests into a Neo4j database.
-func Populate(ctx context.Context, log *zap.Logger, session neo4j.Session, sources []SourceInfo, publishPath string) (bool, error) {
-	err := populate(ctx, log, session, sources, publishPath)
+// Only manifests changed since the last population are reloaded, together with all manifests which depend on them.
+// If the database doesn't contain digests of stored manifests, all manifests are reloaded.
+func Populate(ctx context.Context, log *zap.Logger, driver neo4j.Driver, sources []SourceInfo, publishPath string) (populated bool, err error) {
+	nodes, err := loadManifestNodes(sources)
+	if err != nil {
+		return false, errors.Wrap(err, "while loading manifests")
+	}
+
+	lock, err := acquirePopulationLock(log, driver, uuid.New().String())
+	if err != nil {
+		return false, errors.Wrap(err, "while acquiring population lock")
+	}
+	defer func() {
+		if err := lock.Release(); err != nil {
+			log.Error("Cannot release population lock", zap.Error(err))
+		}
+	}()
+
+	ctx, cancel := contextWithLock(ctx, lock)
+	defer cancel()
+
+	session := driver.NewSession(neo4j.SessionConfig{})
+	defer func() {
+		if sErr := session.Close(); sErr != nil && err == nil {
+			err = errors.Wrap(sErr, "while closing neo4j session")
+		}
+	}()
+
+	stored, err := storedDigests(session)
+	if err != nil {
+		return false, errors.Wrap(err, "while getting digests of stored manifests")
+	}
+
+	plan := planUpdate(nodes, stored)
+	if plan.isEmpty() {
+		log.Info("Manifests did not change. Finishing")
+		return false, nil
+	}
+	log.Info("Updating manifests", zap.Bool("full", plan.full), zap.Int("reloaded", len(plan.reload)), zap.Int("removed", len(plan.removed)))
+
+	err = reset(session)
+	if err != nil {
+		return false, errors.Wrap(err, "while removing leftovers from previous population")
+	}
+
+	err = markOutdated(session, plan)
+	if err != nil {
+		return false, errors.Wrap(err, "while marking outdated manifests")
+	}
+
+	err = populate(ctx, log, session, nodes, plan.reload, publishPath)
 	if err != nil {
 		return false, errors.Wrap(err, "while adding new manifests")
 	}
 
-	err = swap(session)
+	// Another populator may have removed the unpublished nodes, if the lock expired in the meantime.
+	select {
+	case <-lock.Lost():
+		return false, errors.New("population lock was lost")
+	default:
+	}
+
+	err = swap(session, nodes, plan)
 	if err != nil {
 		return false, errors.Wrap(err, "while swapping manifests")
 	}
@@ -529,6 +668,11 @@ func IsDataInDB(session neo4j.Session, log *zap.Logger, commits []string) (bool,
 // TODO: gather commits per repository, now only repositories from the last run are cached
 func SaveCommitsMetadata(session neo4j.Session, commits []string) error {
 	_, err := session.WriteTransaction(func(transaction neo4j.Transaction) (interface{}, error) {
+		// with incremental updates, the previous ContentMetadata is not removed during the swap
+		if err := runQuery(transaction, "MATCH (n:ContentMetadata) DETACH DELETE n", nil); err != nil {
+			return nil, errors.Wrap(err, "while removing previous ContentMetadata")
+		}
+
 		contentMetadata := "CREATE (n:ContentMetadata:published { commits: '%s', timestamp: '%s'}) RETURN *"
 		q := fmt.Sprintf(contentMetadata, encodeCommits(commits), time.Now())
 		result, err := transaction.Run(q, nil)
@@ -544,7 +688,7 @@ func SaveCommitsMetadata(session neo4j.Session, commits []string) error {
 	return errors.Wrap(err, "while executing neo4j transaction")
 }
 
-func populate(ctx context.Context, log *zap.Logger, session neo4j.Session, sources []SourceInfo, publishPath string) error {
+func populate(ctx context.Context, log *zap.Logger, session neo4j.Session, nodes map[string]*manifestNode, reload []string, publishPath string) error {
 	var queries = map[string]string{
 		"RepoMetadata":   repoMetadataQuery,
 		"Attribute":      attributeQuery,
@@ -554,38 +698,36 @@ func populate(ctx context.Context, log *zap.Logger, session neo4j.Session, sourc
 		"Implementation": implementationQuery,
 	}
 
-	mergedGroupedManifests := GroupManifests{}
-	for _, source := range sources {
-		grouped, err := Group(source.Files, source.RootDir)
-		if err != nil {
-			return errors.Wrap(err, "while grouping manifests")
-		}
-		mergedGroupedManifests.MergeWith(grouped)
+	reloadByKind := map[string][]*manifestNode{}
+	for _, path := range reload {
+		node := nodes[path]
+		reloadByKind[node.kind] = append(reloadByKind[node.kind], node)
 	}
 
 	_, err := session.WriteTransaction(func(transaction neo4j.Transaction) (interface{}, error) {
 		for _, kind := range ordered {
-			manifestPaths := mergedGroupedManifests[kind]
 			query := queries[kind]
-			for _, manifest := range manifestPaths {
-				q := renderQuery(query, publishPath, manifest.path, manifest.prefix)
-
-				select {
-				case <-ctx.Done():
-					// returning error to not commit transaction
-					return nil, errors.New("canceled")
-				default:
-					log.Info("Processing manifest", zap.String("manifest", manifest.path))
-					log.Debug("Executing query", zap.String("query", q))
-					result, err := transaction.Run(q, nil)
-					if err != nil {
-						return nil, errors.Wrapf(err, "when adding manifest %s", manifest.path)
-					}
-					err = result.Err()
-					if err != nil {
-						return nil, errors.Wrapf(err, "when adding manifest %s", manifest.path)
+			for _, node := range reloadByKind[kind] {
+				for _, manifest := range node.files {
+					q := renderQuery(query, publishPath, manifest.path, manifest.prefix)
+
+					select {
+					case <-ctx.Done():
+						// returning error to not commit transaction
+						return nil, errors.New("canceled")
+					default:
+						log.Info("Processing manifest", zap.String("manifest", manifest.path))
+						log.Debug("Executing query", zap.String("query", q))
+						params := map[string]interface{}{"signer": nullableString(manifest.signer)}
+						if err := runQuery(transaction, q, params); err != nil {
+							return nil, errors.Wrapf(err, "when adding manifest %s", manifest.path)
+						}
 					}
 				}
+
+				if err := runQuery(transaction, ownerQuery, map[string]interface{}{"path": node.path}); err != nil {
+					return nil, errors.Wrapf(err, "when setting owner of manifest %s nodes", node.path)
+				}
 			}
 		}
 		return nil, nil
@@ -593,14 +735,93 @@ func populate(ctx context.Context, log *zap.Logger, session neo4j.Session, sourc
 	return errors.Wrap(err, "while executing neo4j transaction")
 }
 
-func swap(session neo4j.Session) error {
+// nullableString returns nil for an empty string, so the property is not set on the node.
+func nullableString(in string) interface{} {
+	if in == "" {
+		return nil
+	}
+	return in
+}
+
+func runQuery(transaction neo4j.Transaction, query string, params map[string]interface{}) error {
+	result, err := transaction.Run(query, params)
+	if err != nil {
+		return err
+	}
+	return result.Err()
+}
+
+func reset(session neo4j.Session) error {
 	_, err := session.WriteTransaction(func(transaction neo4j.Transaction) (interface{}, error) {
-		_, err := transaction.Run(swapQuery, nil)
-		return nil, err
+		return nil, runQuery(transaction, resetQuery, nil)
+	})
+	return errors.Wrap(err, "while executing neo4j transaction")
+}
+
+func markOutdated(session neo4j.Session, plan updatePlan) error {
+	_, err := session.WriteTransaction(func(transaction neo4j.Transaction) (interface{}, error) {
+		if plan.full {
+			return nil, runQuery(transaction, allOutdatedQuery, nil)
+		}
+		return nil, runQuery(transaction, outdatedQuery, map[string]interface{}{"paths": plan.outdated()})
 	})
 	return errors.Wrap(err, "while executing neo4j transaction")
 }
 
+// swap publishes the loaded manifests, and saves their digests in the same transaction.
+func swap(session neo4j.Session, nodes map[string]*manifestNode, plan updatePlan) error {
+	var digests []interface{}
+	for _, path := range plan.reload {
+		digests = append(digests, map[string]interface{}{
+			"path":   path,
+			"digest": nodes[path].digest,
+		})
+	}
+
+	_, err := session.WriteTransaction(func(transaction neo4j.Transaction) (interface{}, error) {
+		if !plan.full {
+			if err := runQuery(transaction, releaseOwnersQuery, map[string]interface{}{"paths": plan.outdated()}); err != nil {
+				return nil, errors.Wrap(err, "while releasing owners of shared nodes")
+			}
+		}
+
+		if err := runQuery(transaction, swapQuery, nil); err != nil {
+			return nil, err
+		}
+
+		removeQuery, removeParams := removeDigestsQuery, map[string]interface{}{"paths": plan.removed}
+		if plan.full {
+			removeQuery, removeParams = removeAllDigestsQuery, nil
+		}
+		if err := runQuery(transaction, removeQuery, removeParams); err != nil {
+			return nil, errors.Wrap(err, "while removing digests")
+		}
+
+		return nil, errors.Wrap(runQuery(transaction, saveDigestsQuery, map[string]interface{}{"digests": digests}), "while saving digests")
+	})
+	return errors.Wrap(err, "while executing neo4j transaction")
+}
+
+func storedDigests(session neo4j.Session) (map[string]string, error) {
+	result, err := session.Run("MATCH (d:ManifestDigest) RETURN d.path, d.digest", map[string]interface{}{})
+	if err != nil {
+		return nil, errors.Wrap(err, "while querying ManifestDigest")
+	}
+
+	out := map[string]string{}
+	var record *neo4j.Record
+	for result.NextRecord(&record) {
+		path, pathOK := record.Values[0].(string)
+		digest, digestOK := record.Values[1].(string)
+		if !pathOK || !digestOK {
+			return nil, fmt.Errorf("failed to convert database response: %v", record.Values)
+		}
+		out[path] = digest
+	}
+
+	return out, errors.Wrap(result.Err(), "while executing neo4j transaction")
+}
+
 func cleanOld(session neo4j.Session) error {
 	_, err := session.WriteTransaction(func(transaction neo4j.Transaction) (interface{}, error) {
 		_, err := transaction.Run(cleanQuery, nil)
@@ -662,8 +883,20 @@ func warmup(session neo4j.Session) error {
 	return errors.Wrap(err, "while warming up the data")
 }
 
+var (
+	// createUnpublishedRegex matches the labels of a created node, such as `CREATE (metadata:GenericMetadata:unpublished`.
+	createUnpublishedRegex = regexp.MustCompile(`(CREATE\s*\(\w+\s*:[^{()]*?):unpublished`)
+	// mergeUnpublishedRegex matches a merged node pattern, such as `MERGE (license: License:unpublished{name: value.metadata.license.name})`.
+	mergeUnpublishedRegex = regexp.MustCompile(`(?s)MERGE\s*\((\w+)\s*:[^{()]*?:unpublished\s*\{.*?\}\)`)
+)
+
+// renderQuery renders a query for a given manifest. All created and merged nodes get the additional `loading` label,
+// which is used to set the nodes owner once all revisions of the manifest are loaded.
+// The label is set after MERGE, so the nodes shared between manifests, such as TypeReferences, are not duplicated.
 func renderQuery(query, publishPath, manifestPath, prefix string) string {
 	json := fmt.Sprintf("call apoc.load.json(\"%s/%s\") yield value", publishPath, manifestPath)
 	renderedQuery := strings.ReplaceAll(query, "<PREFIX>", prefix)
+	renderedQuery = createUnpublishedRegex.ReplaceAllString(renderedQuery, "$1:unpublished:loading")
+	renderedQuery = mergeUnpublishedRegex.ReplaceAllString(renderedQuery, "$0 SET $1:loading")
 	return fmt.Sprintf("%s\n%s", json, renderedQuery)
 }
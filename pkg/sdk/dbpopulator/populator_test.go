@@ -0,0 +1,47 @@
+package dbpopulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderQuery(t *testing.T) {
+	tests := map[string]struct {
+		query         string
+		expectedQuery string
+	}{
+		"Should add loading label to created nodes": {
+			query:         `CREATE (metadata:GenericMetadata:unpublished {path: "<PREFIX>"})`,
+			expectedQuery: `CREATE (metadata:GenericMetadata:unpublished:loading {path: "cap.type"})`,
+		},
+		"Should set loading label after MERGE": {
+			query: `MERGE (typeReference: TypeReference:unpublished{
+  path: typeRef.path,
+  revision: typeRef.revision})
+CREATE (input)-[:OF_TYPE]->(typeReference)`,
+			expectedQuery: `MERGE (typeReference: TypeReference:unpublished{
+  path: typeRef.path,
+  revision: typeRef.revision}) SET typeReference:loading
+CREATE (input)-[:OF_TYPE]->(typeReference)`,
+		},
+		"Should set loading label after MERGE with function calls": {
+			query:         `MERGE (repo:RepoMetadata:unpublished{path: apoc.text.join(["<PREFIX>", value.metadata.name], ".")})`,
+			expectedQuery: `MERGE (repo:RepoMetadata:unpublished{path: apoc.text.join(["cap.type", value.metadata.name], ".")}) SET repo:loading`,
+		},
+		"Should not modify relationships": {
+			query:         `MERGE (interfaceGroup)-[:CONTAINS]->(interface)`,
+			expectedQuery: `MERGE (interfaceGroup)-[:CONTAINS]->(interface)`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// when
+			query := renderQuery(tc.query, "localhost:3000", "type/config.yaml", "cap.type")
+
+			// then
+			assert.Equal(t, "call apoc.load.json(\"localhost:3000/type/config.yaml\") yield value\n"+tc.expectedQuery, query)
+		})
+	}
+}
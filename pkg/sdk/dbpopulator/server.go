@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"sync"
 
 	"github.com/pkg/errors"
 	"sigs.k8s.io/yaml"
@@ -13,9 +14,8 @@ import (
 
 // MustServeJSON serves Hub Manifests. Manifests are converted from YAML to JSON when requested
 // It will run os.Exit(1), if an error occurs.
-func MustServeJSON(ctx context.Context, listenPort int, validPaths []string) {
-	http.HandleFunc("/", jsonHandler(validPaths))
-	srv := http.Server{Addr: fmt.Sprintf("0.0.0.0:%d", listenPort)}
+func MustServeJSON(ctx context.Context, listenPort int, handler http.Handler) {
+	srv := http.Server{Addr: fmt.Sprintf("0.0.0.0:%d", listenPort), Handler: handler}
 	go func() {
 		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
 			log.Fatalf("ListenAndServe(): %v", err)
@@ -26,36 +26,61 @@ func MustServeJSON(ctx context.Context, listenPort int, validPaths []string) {
 	_ = srv.Shutdown(ctx)
 }
 
-func jsonHandler(validPaths []string) func(http.ResponseWriter, *http.Request) {
+// JSONHandler serves Hub Manifests converted from YAML to JSON.
+// The served manifests can be replaced, e.g. after each synchronization in the serve mode.
+type JSONHandler struct {
+	mu    sync.RWMutex
+	paths map[string]struct{}
+}
+
+// NewJSONHandler returns a new JSONHandler instance, which serves the given manifests.
+func NewJSONHandler(validPaths []string) *JSONHandler {
+	h := &JSONHandler{}
+	h.SetPaths(validPaths)
+	return h
+}
+
+// SetPaths replaces the served manifests.
+func (h *JSONHandler) SetPaths(validPaths []string) {
 	// for faster lookup...
 	paths := map[string]struct{}{}
 	for _, path := range validPaths {
 		paths[path] = struct{}{}
 	}
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Add("Content-Type", "application/json")
-		_, ok := paths[r.URL.Path]
-		if !ok {
-			http.NotFound(w, r)
-			return
-		}
-		content, err := ioutil.ReadFile(r.URL.Path)
-		if err != nil {
-			errMsg := errors.Wrapf(err, "cannot read %s", r.URL.Path).Error()
-			http.Error(w, errMsg, http.StatusInternalServerError)
-			return
-		}
-		converted, err := yaml.YAMLToJSON(content)
-		if err != nil {
-			errMsg := errors.Wrapf(err, "cannot convert %s to JSON", r.URL.Path).Error()
-			http.Error(w, errMsg, http.StatusInternalServerError)
-			return
-		}
-		_, err = w.Write(converted)
-		if err != nil {
-			errMsg := errors.Wrapf(err, "cannot write response for %s", r.URL.Path).Error()
-			http.Error(w, errMsg, http.StatusInternalServerError)
-			return
-		}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.paths = paths
+}
+
+// ServeHTTP serves a given manifest converted to JSON.
+func (h *JSONHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+
+	h.mu.RLock()
+	_, ok := h.paths[r.URL.Path]
+	h.mu.RUnlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	content, err := ioutil.ReadFile(r.URL.Path)
+	if err != nil {
+		errMsg := errors.Wrapf(err, "cannot read %s", r.URL.Path).Error()
+		http.Error(w, errMsg, http.StatusInternalServerError)
+		return
+	}
+	converted, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		errMsg := errors.Wrapf(err, "cannot convert %s to JSON", r.URL.Path).Error()
+		http.Error(w, errMsg, http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(converted)
+	if err != nil {
+		errMsg := errors.Wrapf(err, "cannot write response for %s", r.URL.Path).Error()
+		http.Error(w, errMsg, http.StatusInternalServerError)
+		return
 	}
 }
@@ -0,0 +1,66 @@
+package dbpopulator
+
+import (
+	"fmt"
+
+	"capact.io/capact/pkg/sdk/manifest"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// UnsignedManifestsPolicy defines how manifests without a valid signature are handled. It works with the envconfig library.
+type UnsignedManifestsPolicy string
+
+const (
+	// RejectUnsignedManifests fails the population if any manifest doesn't have a valid signature.
+	RejectUnsignedManifests UnsignedManifestsPolicy = "reject"
+	// QuarantineUnsignedManifests skips manifests without a valid signature, and populates the rest of them.
+	QuarantineUnsignedManifests UnsignedManifestsPolicy = "quarantine"
+)
+
+// Validate returns errors if policy is unknown.
+func (p UnsignedManifestsPolicy) Validate() error {
+	switch p {
+	case RejectUnsignedManifests, QuarantineUnsignedManifests:
+		return nil
+	}
+	return fmt.Errorf("unknown unsigned manifests policy %q. Possible options: %s and %s", p, RejectUnsignedManifests, QuarantineUnsignedManifests)
+}
+
+// Unmarshal fulfills the envconfig interface for unmarshaling.
+func (p *UnsignedManifestsPolicy) Unmarshal(s string) error {
+	policy := UnsignedManifestsPolicy(s)
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	*p = policy
+	return nil
+}
+
+// VerifySignatures verifies detached signatures of all manifests from a given source against trusted keys.
+// The signatures must be issued for the manifest paths computed from the file locations in the source root directory.
+// It returns the source with signers of verified manifests.
+// Manifests without a valid signature are handled according to a given policy.
+func VerifySignatures(log *zap.Logger, source SourceInfo, keys manifest.TrustedKeys, policy UnsignedManifestsPolicy) (SourceInfo, error) {
+	out := SourceInfo{
+		RootDir:  source.RootDir,
+		Revision: source.Revision,
+		Signers:  map[string]string{},
+	}
+
+	for _, file := range source.Files {
+		signer, err := keys.VerifyFile(getPrefix(file, source.RootDir), file)
+		if err != nil {
+			if policy != QuarantineUnsignedManifests {
+				return SourceInfo{}, errors.Wrapf(err, "while verifying signature of %s", file)
+			}
+			log.Warn("Quarantining manifest without valid signature", zap.String("manifest", file), zap.Error(err))
+			continue
+		}
+
+		out.Files = append(out.Files, file)
+		out.Signers[file] = signer
+	}
+
+	return out, nil
+}
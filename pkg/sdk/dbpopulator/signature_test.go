@@ -0,0 +1,74 @@
+package dbpopulator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"capact.io/capact/pkg/sdk/manifest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestVerifySignatures(t *testing.T) {
+	// given
+	priv, pub, err := manifest.GenerateKeyPair()
+	require.NoError(t, err)
+	key, err := manifest.ParsePrivateKey(priv)
+	require.NoError(t, err)
+
+	keysDir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(keysDir, "capact-maintainers.pem"), pub, 0o600))
+	keys, err := manifest.LoadTrustedKeys(keysDir)
+	require.NoError(t, err)
+
+	const content = "kind: Implementation\nmetadata:\n  name: install"
+	dir := fixManifestsDir(t, map[string]string{
+		"implementation/app/install.yaml": content,
+		"implementation/app/upgrade.yaml": "kind: Implementation\nmetadata:\n  name: upgrade",
+	})
+	signed := filepath.Join(dir, "implementation", "app", "install.yaml")
+	unsigned := filepath.Join(dir, "implementation", "app", "upgrade.yaml")
+
+	sig, err := manifest.Sign("cap.implementation.app.install", []byte(content), key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(manifest.SignatureFilePath(signed), sig, 0o600))
+
+	source := SourceInfo{RootDir: dir, Files: []string{signed, unsigned}}
+
+	tests := map[string]struct {
+		policy         UnsignedManifestsPolicy
+		expectedSource SourceInfo
+		expectedErr    string
+	}{
+		"Should reject source with unsigned manifest": {
+			policy:      RejectUnsignedManifests,
+			expectedErr: "while verifying signature of " + unsigned + ": manifest is not signed",
+		},
+		"Should quarantine unsigned manifest": {
+			policy: QuarantineUnsignedManifests,
+			expectedSource: SourceInfo{
+				RootDir: dir,
+				Files:   []string{signed},
+				Signers: map[string]string{signed: "capact-maintainers"},
+			},
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// when
+			out, err := VerifySignatures(zap.NewNop(), source, keys, tc.policy)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSource, out)
+		})
+	}
+}
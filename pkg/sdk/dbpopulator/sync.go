@@ -0,0 +1,295 @@
+package dbpopulator
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const (
+	metricsNamespace = "capact"
+	metricsSubsystem = "populator"
+
+	// maxWebhookPayloadSize limits the size of the webhook payload, which is read to verify its signature.
+	maxWebhookPayloadSize = 10 << 20
+)
+
+// SyncConfig holds configuration of the periodic synchronization in the serve mode.
+type SyncConfig struct {
+	// Interval is the time between successful synchronizations.
+	Interval time.Duration `envconfig:"default=10m"`
+
+	// MinBackoff is the delay before retrying a failed synchronization.
+	// It is doubled after each subsequent failure.
+	MinBackoff time.Duration `envconfig:"default=30s"`
+
+	// MaxBackoff limits the delay before retrying a failed synchronization.
+	MaxBackoff time.Duration `envconfig:"default=10m"`
+
+	// StatusAddr is the TCP address the status, metrics and webhook
+	// endpoints bind to.
+	StatusAddr string `envconfig:"default=:8082"`
+
+	// WebhookSecret is used to authenticate webhook requests. Requests must
+	// be signed with it in the `X-Hub-Signature-256` header, or contain it
+	// as the Bearer token. If not set, the webhook endpoint is disabled
+	WebhookSecret string `envconfig:"optional"`
+}
+
+// SyncFunc synchronizes manifests from sources once.
+type SyncFunc func(ctx context.Context) (SyncResult, error)
+
+// SyncResult holds the result of a successful synchronization.
+type SyncResult struct {
+	// Revisions holds revisions of all sources, such as git commits or OCI artifact digests.
+	// They are known only if UpdateOnGitCommit is enabled.
+	Revisions []string
+	// Populated is set when manifests changed and were populated into the database.
+	Populated bool
+}
+
+// SyncStatus describes the state of the periodic synchronization.
+type SyncStatus struct {
+	LastSyncTime        *time.Time `json:"lastSyncTime,omitempty"`
+	LastSuccessTime     *time.Time `json:"lastSuccessTime,omitempty"`
+	LastPopulateTime    *time.Time `json:"lastPopulateTime,omitempty"`
+	LastDuration        string     `json:"lastDuration,omitempty"`
+	LastRevisions       []string   `json:"lastRevisions,omitempty"`
+	LastError           string     `json:"lastError,omitempty"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	NextSyncTime        *time.Time `json:"nextSyncTime,omitempty"`
+}
+
+// Syncer runs the synchronization periodically, or when it is triggered by a webhook.
+// Failed synchronizations are retried with an exponential backoff.
+type Syncer struct {
+	log     *zap.Logger
+	cfg     SyncConfig
+	syncFn  SyncFunc
+	trigger chan struct{}
+	metrics *syncMetrics
+
+	mu     sync.RWMutex
+	status SyncStatus
+}
+
+type syncMetrics struct {
+	registry            *prometheus.Registry
+	syncs               *prometheus.CounterVec
+	duration            prometheus.Histogram
+	lastSuccess         prometheus.Gauge
+	consecutiveFailures prometheus.Gauge
+}
+
+// NewSyncer returns a new Syncer instance.
+func NewSyncer(log *zap.Logger, cfg SyncConfig, syncFn SyncFunc) *Syncer {
+	return &Syncer{
+		log:     log,
+		cfg:     cfg,
+		syncFn:  syncFn,
+		trigger: make(chan struct{}, 1),
+		metrics: newSyncMetrics(),
+	}
+}
+
+func newSyncMetrics() *syncMetrics {
+	m := &syncMetrics{
+		registry: prometheus.NewRegistry(),
+		syncs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "syncs_total",
+			Help:      "Total number of manifests synchronizations by result.",
+		}, []string{"result"}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "sync_duration_seconds",
+			Help:      "Duration of manifests synchronizations.",
+			Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600},
+		}),
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "last_success_timestamp_seconds",
+			Help:      "Unix timestamp of the last successful manifests synchronization.",
+		}),
+		consecutiveFailures: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "consecutive_failures",
+			Help:      "Number of manifests synchronizations failed in a row.",
+		}),
+	}
+	m.registry.MustRegister(m.syncs, m.duration, m.lastSuccess, m.consecutiveFailures)
+	return m
+}
+
+// Run runs the synchronization loop. It blocks until the context is canceled.
+func (s *Syncer) Run(ctx context.Context) error {
+	for {
+		delay := s.syncOnce(ctx)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		case <-s.trigger:
+			timer.Stop()
+			s.log.Info("Synchronization triggered by webhook")
+		}
+	}
+}
+
+// Trigger schedules the synchronization right after the current one finishes.
+func (s *Syncer) Trigger() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+		// synchronization is already scheduled
+	}
+}
+
+// WebhookEnabled returns true if the synchronization can be triggered by a webhook.
+func (s *Syncer) WebhookEnabled() bool {
+	return s.cfg.WebhookSecret != ""
+}
+
+// Status returns the current synchronization status.
+func (s *Syncer) Status() SyncStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := s.status
+	out.LastRevisions = append([]string(nil), s.status.LastRevisions...)
+	return out
+}
+
+// Handler returns the HTTP handler, which exposes the `/healthz`, `/status`, `/metrics` and `/webhook` endpoints.
+// The `/webhook` endpoint is exposed only if the webhook secret is set.
+func (s *Syncer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("populator - OK"))
+	})
+	mux.HandleFunc("/status", s.serveStatus)
+	mux.Handle("/metrics", promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{}))
+	if s.WebhookEnabled() {
+		mux.HandleFunc("/webhook", s.serveWebhook)
+	}
+	return mux
+}
+
+// syncOnce runs the synchronization and returns the delay before the next one.
+func (s *Syncer) syncOnce(ctx context.Context) time.Duration {
+	start := time.Now()
+	s.log.Info("Synchronizing manifests")
+	res, err := s.syncFn(ctx)
+	end := time.Now()
+	duration := end.Sub(start)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status.LastSyncTime = &end
+	s.status.LastDuration = duration.String()
+	s.metrics.duration.Observe(duration.Seconds())
+
+	var delay time.Duration
+	if err != nil {
+		s.status.LastError = err.Error()
+		s.status.ConsecutiveFailures++
+		s.metrics.syncs.WithLabelValues("failure").Inc()
+		delay = s.backoff(s.status.ConsecutiveFailures)
+		s.log.Error("Cannot synchronize manifests", zap.Error(err), zap.Int("consecutive failures", s.status.ConsecutiveFailures), zap.Duration("retry in", delay))
+	} else {
+		s.status.LastError = ""
+		s.status.ConsecutiveFailures = 0
+		s.status.LastSuccessTime = &end
+		s.status.LastRevisions = res.Revisions
+		if res.Populated {
+			s.status.LastPopulateTime = &end
+		}
+		s.metrics.syncs.WithLabelValues("success").Inc()
+		s.metrics.lastSuccess.Set(float64(end.Unix()))
+		delay = s.cfg.Interval
+		s.log.Info("Manifests synchronized", zap.Bool("populated", res.Populated), zap.Duration("duration", duration))
+	}
+	s.metrics.consecutiveFailures.Set(float64(s.status.ConsecutiveFailures))
+
+	next := end.Add(delay)
+	s.status.NextSyncTime = &next
+	return delay
+}
+
+// backoff returns the exponential delay before retrying a failed synchronization.
+func (s *Syncer) backoff(failures int) time.Duration {
+	delay := s.cfg.MinBackoff
+	for i := 1; i < failures && delay < s.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.cfg.MaxBackoff {
+		return s.cfg.MaxBackoff
+	}
+	return delay
+}
+
+func (s *Syncer) serveStatus(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.Status()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Syncer) serveWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST method is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize))
+	if err != nil {
+		http.Error(w, "cannot read payload", http.StatusBadRequest)
+		return
+	}
+	if !s.isWebhookAuthorized(r, payload) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	s.Trigger()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// isWebhookAuthorized checks the GitHub compatible payload signature, or the Bearer token.
+func (s *Syncer) isWebhookAuthorized(r *http.Request, payload []byte) bool {
+	secret := s.cfg.WebhookSecret
+	if secret == "" {
+		// unauthenticated requests are never accepted
+		return false
+	}
+
+	if signature := r.Header.Get("X-Hub-Signature-256"); signature != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write(payload)
+		expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		return hmac.Equal([]byte(signature), []byte(expected))
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+}
@@ -0,0 +1,164 @@
+package dbpopulator
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSyncer_SyncOnce(t *testing.T) {
+	// given
+	cfg := SyncConfig{
+		Interval:   10 * time.Minute,
+		MinBackoff: 30 * time.Second,
+		MaxBackoff: 2 * time.Minute,
+	}
+	errUnavailable := errors.New("neo4j is not available")
+	results := []error{errUnavailable, errUnavailable, errUnavailable, errUnavailable, nil}
+
+	var calls int
+	syncer := NewSyncer(zap.NewNop(), cfg, func(ctx context.Context) (SyncResult, error) {
+		err := results[calls]
+		calls++
+		return SyncResult{Revisions: []string{"abc"}, Populated: true}, err
+	})
+
+	// when
+	var delays []time.Duration
+	for range results {
+		delays = append(delays, syncer.syncOnce(context.Background()))
+	}
+
+	// then
+	assert.Equal(t, []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 2 * time.Minute, 10 * time.Minute}, delays)
+
+	status := syncer.Status()
+	assert.Zero(t, status.ConsecutiveFailures)
+	assert.Empty(t, status.LastError)
+	assert.Equal(t, []string{"abc"}, status.LastRevisions)
+	assert.NotNil(t, status.LastSuccessTime)
+	assert.NotNil(t, status.LastPopulateTime)
+}
+
+func TestSyncer_Webhook(t *testing.T) {
+	const (
+		secret  = "webhook-secret"
+		payload = `{"ref": "refs/heads/main"}`
+	)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(payload))
+	validSignature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := map[string]struct {
+		method            string
+		headers           map[string]string
+		expectedStatus    int
+		expectedTriggered bool
+	}{
+		"Should trigger synchronization for valid signature": {
+			method:            http.MethodPost,
+			headers:           map[string]string{"X-Hub-Signature-256": validSignature},
+			expectedStatus:    http.StatusAccepted,
+			expectedTriggered: true,
+		},
+		"Should trigger synchronization for valid Bearer token": {
+			method:            http.MethodPost,
+			headers:           map[string]string{"Authorization": "Bearer " + secret},
+			expectedStatus:    http.StatusAccepted,
+			expectedTriggered: true,
+		},
+		"Should reject invalid signature": {
+			method:         http.MethodPost,
+			headers:        map[string]string{"X-Hub-Signature-256": "sha256=abc"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"Should reject request without credentials": {
+			method:         http.MethodPost,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"Should reject GET request": {
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// given
+			syncer := NewSyncer(zap.NewNop(), SyncConfig{WebhookSecret: secret}, nil)
+
+			req := httptest.NewRequest(tc.method, "/webhook", strings.NewReader(payload))
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			// when
+			syncer.Handler().ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedTriggered, len(syncer.trigger) == 1)
+		})
+	}
+}
+
+func TestSyncer_WebhookWithoutSecret(t *testing.T) {
+	// given
+	syncer := NewSyncer(zap.NewNop(), SyncConfig{}, nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	syncer.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("{}")))
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Len(t, syncer.trigger, 0)
+}
+
+func TestSyncer_Handler(t *testing.T) {
+	// given
+	syncer := NewSyncer(zap.NewNop(), SyncConfig{Interval: time.Minute}, func(ctx context.Context) (SyncResult, error) {
+		return SyncResult{Revisions: []string{"abc"}}, nil
+	})
+	syncer.syncOnce(context.Background())
+
+	tests := map[string]struct {
+		path         string
+		expectedBody string
+	}{
+		"Should return synchronization status": {
+			path:         "/status",
+			expectedBody: `"lastRevisions":["abc"]`,
+		},
+		"Should return synchronization metrics": {
+			path:         "/metrics",
+			expectedBody: `capact_populator_syncs_total{result="success"} 1`,
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			// when
+			syncer.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			// then
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,412 @@
+package manifest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// ChangeImpact describes how a change affects the manifest consumers.
+type ChangeImpact string
+
+const (
+	// CompatibleChange doesn't break existing consumers, for example a new optional field.
+	CompatibleChange ChangeImpact = "compatible"
+	// BreakingChange breaks existing consumers, for example a new required field or a removed output.
+	BreakingChange ChangeImpact = "breaking"
+	// DocumentationChange doesn't affect the manifest contract, for example an updated description.
+	DocumentationChange ChangeImpact = "documentation"
+)
+
+// Change describes a single difference between two manifest revisions.
+type Change struct {
+	// Location is the path to the changed element, for example `spec.jsonSchema.properties.host`.
+	Location string
+	Impact   ChangeImpact
+	Message  string
+}
+
+// String returns the human-readable change description.
+func (c Change) String() string {
+	return fmt.Sprintf("[%s] %s: %s", c.Impact, c.Location, c.Message)
+}
+
+// RevisionBump defines which part of the SemVer revision was incremented.
+type RevisionBump int
+
+const (
+	// PatchBump increments the patch version.
+	PatchBump RevisionBump = iota + 1
+	// MinorBump increments the minor version.
+	MinorBump
+	// MajorBump increments the major version.
+	MajorBump
+)
+
+// String returns the revision bump name.
+func (b RevisionBump) String() string {
+	switch b {
+	case PatchBump:
+		return "patch"
+	case MinorBump:
+		return "minor"
+	case MajorBump:
+		return "major"
+	}
+	return "unknown"
+}
+
+// DiffResult holds all changes between two revisions of a manifest.
+type DiffResult struct {
+	Kind        types.ManifestKind
+	OldRevision string
+	NewRevision string
+	Changes     []Change
+}
+
+// HasBreakingChanges returns true if any of the changes is breaking.
+func (r DiffResult) HasBreakingChanges() bool {
+	return r.hasChanges(BreakingChange)
+}
+
+func (r DiffResult) hasChanges(impact ChangeImpact) bool {
+	for _, c := range r.Changes {
+		if c.Impact == impact {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredBump returns the minimal revision bump for the detected changes.
+// Breaking changes require a major bump. For revisions before 1.0.0, a minor bump is enough, as SemVer
+// doesn't guarantee compatibility in the initial development phase.
+// Compatible changes require a minor bump. Documentation changes, such as a description update, require a patch bump.
+func (r DiffResult) RequiredBump() RevisionBump {
+	switch {
+	case r.HasBreakingChanges():
+		if strings.HasPrefix(r.OldRevision, "0.") {
+			return MinorBump
+		}
+		return MajorBump
+	case r.hasChanges(CompatibleChange):
+		return MinorBump
+	default:
+		return PatchBump
+	}
+}
+
+// CheckRevisionBump returns an error if the new revision is not greater than the old one,
+// or if the revision bump is too small for the detected changes.
+func (r DiffResult) CheckRevisionBump() error {
+	got, err := revisionBump(r.OldRevision, r.NewRevision)
+	if err != nil {
+		return err
+	}
+
+	if required := r.RequiredBump(); got < required {
+		return fmt.Errorf("revision bump from %s to %s is %s, but detected changes require at least %s bump", r.OldRevision, r.NewRevision, got, required)
+	}
+	return nil
+}
+
+func revisionBump(oldRevision, newRevision string) (RevisionBump, error) {
+	oldVer, err := semver.StrictNewVersion(oldRevision)
+	if err != nil {
+		return 0, errors.Wrapf(err, "while parsing old revision %q", oldRevision)
+	}
+	newVer, err := semver.StrictNewVersion(newRevision)
+	if err != nil {
+		return 0, errors.Wrapf(err, "while parsing new revision %q", newRevision)
+	}
+
+	if !newVer.GreaterThan(oldVer) {
+		return 0, fmt.Errorf("new revision %s must be greater than old revision %s", newRevision, oldRevision)
+	}
+
+	switch {
+	case newVer.Major() != oldVer.Major():
+		return MajorBump, nil
+	case newVer.Minor() != oldVer.Minor():
+		return MinorBump, nil
+	default:
+		return PatchBump, nil
+	}
+}
+
+// Diff compares two revisions of the same Type or Interface manifest, provided as YAML,
+// and classifies all changes in the Type JSON Schema or in the Interface input and output.
+func Diff(oldYAML, newYAML []byte) (DiffResult, error) {
+	oldMetadata, err := UnmarshalMetadata(oldYAML)
+	if err != nil {
+		return DiffResult{}, errors.Wrap(err, "while reading old manifest metadata")
+	}
+	newMetadata, err := UnmarshalMetadata(newYAML)
+	if err != nil {
+		return DiffResult{}, errors.Wrap(err, "while reading new manifest metadata")
+	}
+	if oldMetadata.Kind != newMetadata.Kind {
+		return DiffResult{}, fmt.Errorf("cannot compare %s with %s", oldMetadata.Kind, newMetadata.Kind)
+	}
+
+	switch oldMetadata.Kind {
+	case types.TypeManifestKind:
+		var oldType, newType types.Type
+		if err := unmarshalManifests(oldYAML, newYAML, &oldType, &newType); err != nil {
+			return DiffResult{}, err
+		}
+		changes, err := diffTypes(oldType, newType)
+		if err != nil {
+			return DiffResult{}, err
+		}
+		return DiffResult{
+			Kind:        types.TypeManifestKind,
+			OldRevision: oldType.Revision,
+			NewRevision: newType.Revision,
+			Changes:     changes,
+		}, nil
+	case types.InterfaceManifestKind:
+		var oldIface, newIface types.Interface
+		if err := unmarshalManifests(oldYAML, newYAML, &oldIface, &newIface); err != nil {
+			return DiffResult{}, err
+		}
+		changes, err := diffInterfaces(oldIface, newIface)
+		if err != nil {
+			return DiffResult{}, err
+		}
+		return DiffResult{
+			Kind:        types.InterfaceManifestKind,
+			OldRevision: oldIface.Revision,
+			NewRevision: newIface.Revision,
+			Changes:     changes,
+		}, nil
+	default:
+		return DiffResult{}, fmt.Errorf("comparing %s manifests is not supported", oldMetadata.Kind)
+	}
+}
+
+func unmarshalManifests(oldYAML, newYAML []byte, oldOut, newOut interface{}) error {
+	if err := yaml.Unmarshal(oldYAML, oldOut); err != nil {
+		return errors.Wrap(err, "while unmarshalling old manifest")
+	}
+	if err := yaml.Unmarshal(newYAML, newOut); err != nil {
+		return errors.Wrap(err, "while unmarshalling new manifest")
+	}
+	return nil
+}
+
+func diffTypes(oldType, newType types.Type) ([]Change, error) {
+	if err := checkSameName(oldType.Metadata.Name, newType.Metadata.Name); err != nil {
+		return nil, err
+	}
+
+	changes := diffMetadata(oldType.Metadata.Description, newType.Metadata.Description, oldType.Metadata.DisplayName, newType.Metadata.DisplayName)
+
+	schemaChanges, err := diffJSONSchemas("spec.jsonSchema", oldType.Spec.JSONSchema.Value, newType.Spec.JSONSchema.Value)
+	if err != nil {
+		return nil, err
+	}
+	return append(changes, schemaChanges...), nil
+}
+
+func diffInterfaces(oldIface, newIface types.Interface) ([]Change, error) {
+	if err := checkSameName(oldIface.Metadata.Name, newIface.Metadata.Name); err != nil {
+		return nil, err
+	}
+
+	changes := diffMetadata(oldIface.Metadata.Description, newIface.Metadata.Description, oldIface.Metadata.DisplayName, newIface.Metadata.DisplayName)
+
+	paramChanges, err := diffInputParameters(parametersOf(oldIface), parametersOf(newIface))
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, paramChanges...)
+	changes = append(changes, diffInputTypeInstances(oldIface.Spec.Input.TypeInstances, newIface.Spec.Input.TypeInstances)...)
+	changes = append(changes, diffOutputTypeInstances(oldIface.Spec.Output.TypeInstances, newIface.Spec.Output.TypeInstances)...)
+
+	return changes, nil
+}
+
+func checkSameName(oldName, newName string) error {
+	if oldName != newName {
+		return fmt.Errorf("cannot compare different manifests %q and %q", oldName, newName)
+	}
+	return nil
+}
+
+// diffMetadata compares the manifest metadata, which is displayed to users, but doesn't affect the manifest contract.
+func diffMetadata(oldDescription, newDescription string, oldDisplayName, newDisplayName *string) []Change {
+	var changes []Change
+	if oldDescription != newDescription {
+		changes = append(changes, Change{Location: "metadata.description", Impact: DocumentationChange, Message: "description changed"})
+	}
+	if stringOrEmpty(oldDisplayName) != stringOrEmpty(newDisplayName) {
+		changes = append(changes, Change{Location: "metadata.displayName", Impact: DocumentationChange, Message: "display name changed"})
+	}
+	return changes
+}
+
+func stringOrEmpty(in *string) string {
+	if in == nil {
+		return ""
+	}
+	return *in
+}
+
+func parametersOf(iface types.Interface) map[string]types.ParametersParameter {
+	if iface.Spec.Input.Parameters == nil {
+		return nil
+	}
+	return iface.Spec.Input.Parameters.ParametersParameterMap
+}
+
+func diffInputParameters(oldParams, newParams map[string]types.ParametersParameter) ([]Change, error) {
+	var changes []Change
+	for _, name := range sortedUnion(keysOf(oldParams), keysOf(newParams)) {
+		location := fmt.Sprintf("spec.input.parameters.%s", name)
+		oldParam, inOld := oldParams[name]
+		newParam, inNew := newParams[name]
+
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Location: location, Impact: CompatibleChange, Message: "input parameter added"})
+		case !inNew:
+			changes = append(changes, Change{Location: location, Impact: BreakingChange, Message: "input parameter removed"})
+		case oldParam.TypeRef != nil || newParam.TypeRef != nil:
+			changes = append(changes, diffTypeRefs(location+".typeRef", oldParam.TypeRef, newParam.TypeRef)...)
+		case oldParam.JSONSchema != nil && newParam.JSONSchema != nil:
+			schemaChanges, err := diffJSONSchemas(location+".jsonSchema", oldParam.JSONSchema.Value, newParam.JSONSchema.Value)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, schemaChanges...)
+		}
+	}
+	return changes, nil
+}
+
+func diffInputTypeInstances(oldTIs, newTIs map[string]types.InputTypeInstance) []Change {
+	var changes []Change
+	for _, name := range sortedUnion(keysOf(oldTIs), keysOf(newTIs)) {
+		location := fmt.Sprintf("spec.input.typeInstances.%s", name)
+		oldTI, inOld := oldTIs[name]
+		newTI, inNew := newTIs[name]
+
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Location: location, Impact: BreakingChange, Message: "input TypeInstance added"})
+		case !inNew:
+			changes = append(changes, Change{Location: location, Impact: CompatibleChange, Message: "input TypeInstance removed"})
+		default:
+			changes = append(changes, diffTypeRefs(location+".typeRef", &oldTI.TypeRef, &newTI.TypeRef)...)
+			if added := missingIn(verbsToStrings(oldTI.Verbs), verbsToStrings(newTI.Verbs)); len(added) > 0 {
+				changes = append(changes, Change{Location: location + ".verbs", Impact: BreakingChange, Message: fmt.Sprintf("verbs %s added", quoteAll(added))})
+			}
+			if removed := missingIn(verbsToStrings(newTI.Verbs), verbsToStrings(oldTI.Verbs)); len(removed) > 0 {
+				changes = append(changes, Change{Location: location + ".verbs", Impact: CompatibleChange, Message: fmt.Sprintf("verbs %s removed", quoteAll(removed))})
+			}
+		}
+	}
+	return changes
+}
+
+func diffOutputTypeInstances(oldTIs, newTIs map[string]types.OutputTypeInstance) []Change {
+	var changes []Change
+	for _, name := range sortedUnion(keysOf(oldTIs), keysOf(newTIs)) {
+		location := fmt.Sprintf("spec.output.typeInstances.%s", name)
+		oldTI, inOld := oldTIs[name]
+		newTI, inNew := newTIs[name]
+
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Location: location, Impact: CompatibleChange, Message: "output TypeInstance added"})
+		case !inNew:
+			changes = append(changes, Change{Location: location, Impact: BreakingChange, Message: "output TypeInstance removed"})
+		default:
+			changes = append(changes, diffTypeRefs(location+".typeRef", oldTI.TypeRef, newTI.TypeRef)...)
+		}
+	}
+	return changes
+}
+
+// diffTypeRefs compares Type references. A different Type is a breaking change.
+// A different revision of the same Type is reported as compatible, as the Type revisions are compared separately.
+func diffTypeRefs(location string, oldRef, newRef *types.TypeRef) []Change {
+	switch {
+	case oldRef == nil && newRef == nil:
+		return nil
+	case oldRef == nil || newRef == nil || oldRef.Path != newRef.Path:
+		return []Change{{Location: location, Impact: BreakingChange, Message: fmt.Sprintf("Type changed from %s to %s", typeRefString(oldRef), typeRefString(newRef))}}
+	case oldRef.Revision != newRef.Revision:
+		return []Change{{Location: location, Impact: CompatibleChange, Message: fmt.Sprintf("Type revision changed from %s to %s", oldRef.Revision, newRef.Revision)}}
+	}
+	return nil
+}
+
+func typeRefString(ref *types.TypeRef) string {
+	if ref == nil {
+		return "inline JSON Schema"
+	}
+	return fmt.Sprintf("%q", fmt.Sprintf("%s:%s", ref.Path, ref.Revision))
+}
+
+func verbsToStrings(verbs []types.Verb) []string {
+	var out []string
+	for _, v := range verbs {
+		out = append(out, string(v))
+	}
+	return out
+}
+
+// keysOf returns keys of a map with string keys.
+func keysOf(in interface{}) []string {
+	var out []string
+	for _, key := range reflect.ValueOf(in).MapKeys() {
+		out = append(out, key.String())
+	}
+	return out
+}
+
+func sortedUnion(a, b []string) []string {
+	set := map[string]struct{}{}
+	for _, s := range append(a, b...) {
+		set[s] = struct{}{}
+	}
+
+	var out []string
+	for s := range set {
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// missingIn returns elements from the second slice, which are not present in the first one.
+func missingIn(in, elems []string) []string {
+	set := map[string]struct{}{}
+	for _, s := range in {
+		set[s] = struct{}{}
+	}
+
+	var out []string
+	for _, s := range elems {
+		if _, found := set[s]; !found {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func quoteAll(in []string) string {
+	var out []string
+	for _, s := range in {
+		out = append(out, fmt.Sprintf("%q", s))
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,325 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// jsonSchema is a generic representation of a JSON Schema object.
+type jsonSchema map[string]interface{}
+
+// schemaDiffer compares two JSON Schemas. Local references, such as `#/definitions/foo`,
+// are resolved against the root schemas.
+type schemaDiffer struct {
+	oldRoot jsonSchema
+	newRoot jsonSchema
+	changes []Change
+}
+
+// lowerBoundKeywords narrow the accepted values when their value increases.
+var lowerBoundKeywords = []string{"minimum", "exclusiveMinimum", "minLength", "minItems", "minProperties"}
+
+// upperBoundKeywords narrow the accepted values when their value decreases.
+var upperBoundKeywords = []string{"maximum", "exclusiveMaximum", "maxLength", "maxItems", "maxProperties"}
+
+// annotationKeywords describe the schema, but don't affect the accepted values.
+var annotationKeywords = []string{"title", "description", "examples"}
+
+// diffJSONSchemas compares two JSON Schemas provided as strings.
+func diffJSONSchemas(location, oldValue, newValue string) ([]Change, error) {
+	oldSchema, err := parseJSONSchema(oldValue)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while parsing old JSON Schema from %s", location)
+	}
+	newSchema, err := parseJSONSchema(newValue)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while parsing new JSON Schema from %s", location)
+	}
+
+	d := &schemaDiffer{oldRoot: oldSchema, newRoot: newSchema}
+	d.diff(location, oldSchema, newSchema, map[string]struct{}{})
+
+	return d.changes, nil
+}
+
+func parseJSONSchema(value string) (jsonSchema, error) {
+	out := jsonSchema{}
+	if strings.TrimSpace(value) == "" {
+		return out, nil
+	}
+	if err := json.Unmarshal([]byte(value), &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (d *schemaDiffer) add(location string, impact ChangeImpact, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Location: location,
+		Impact:   impact,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// diff compares two schemas recursively. The visited set prevents infinite loops for recursive references.
+func (d *schemaDiffer) diff(location string, oldSchema, newSchema jsonSchema, visited map[string]struct{}) {
+	oldSchema, oldRef := resolveRef(d.oldRoot, oldSchema)
+	newSchema, newRef := resolveRef(d.newRoot, newSchema)
+	if oldRef != "" && oldRef == newRef {
+		if _, found := visited[oldRef]; found {
+			return
+		}
+		visited[oldRef] = struct{}{}
+		defer delete(visited, oldRef)
+	}
+
+	d.diffTypes(location, oldSchema, newSchema)
+	d.diffEnums(location, oldSchema, newSchema)
+	d.diffConst(location, oldSchema, newSchema)
+	d.diffBounds(location, oldSchema, newSchema)
+	d.diffFormats(location, oldSchema, newSchema)
+	d.diffAnnotations(location, oldSchema, newSchema)
+	d.diffProperties(location, oldSchema, newSchema, visited)
+
+	oldItems, newItems := schemaOf(oldSchema["items"]), schemaOf(newSchema["items"])
+	if oldItems != nil && newItems != nil {
+		d.diff(location+".items", oldItems, newItems, visited)
+	}
+}
+
+func (d *schemaDiffer) diffTypes(location string, oldSchema, newSchema jsonSchema) {
+	oldTypes, newTypes := stringsOf(oldSchema["type"]), stringsOf(newSchema["type"])
+	switch {
+	case len(oldTypes) == 0 && len(newTypes) == 0:
+		return
+	case len(newTypes) == 0:
+		d.add(location, CompatibleChange, "type constraint %s removed", quoteAll(oldTypes))
+	case len(oldTypes) == 0:
+		d.add(location, BreakingChange, "type constraint %s added", quoteAll(newTypes))
+	default:
+		if removed := missingIn(newTypes, oldTypes); len(removed) > 0 {
+			d.add(location, BreakingChange, "type changed from %s to %s", quoteAll(oldTypes), quoteAll(newTypes))
+			return
+		}
+		if added := missingIn(oldTypes, newTypes); len(added) > 0 {
+			d.add(location, CompatibleChange, "types %s allowed", quoteAll(added))
+		}
+	}
+}
+
+func (d *schemaDiffer) diffEnums(location string, oldSchema, newSchema jsonSchema) {
+	oldEnum, oldFound := oldSchema["enum"].([]interface{})
+	newEnum, newFound := newSchema["enum"].([]interface{})
+	switch {
+	case !oldFound && !newFound:
+		return
+	case !newFound:
+		d.add(location, CompatibleChange, "enum constraint removed")
+	case !oldFound:
+		d.add(location, BreakingChange, "enum constraint added")
+	default:
+		oldValues, newValues := valuesToStrings(oldEnum), valuesToStrings(newEnum)
+		if removed := missingIn(newValues, oldValues); len(removed) > 0 {
+			d.add(location, BreakingChange, "enum narrowed, values %s removed", strings.Join(removed, ", "))
+		}
+		if added := missingIn(oldValues, newValues); len(added) > 0 {
+			d.add(location, CompatibleChange, "enum extended, values %s added", strings.Join(added, ", "))
+		}
+	}
+}
+
+func (d *schemaDiffer) diffConst(location string, oldSchema, newSchema jsonSchema) {
+	oldConst, oldFound := oldSchema["const"]
+	newConst, newFound := newSchema["const"]
+	switch {
+	case !oldFound && !newFound:
+		return
+	case !newFound:
+		d.add(location, CompatibleChange, "const constraint removed")
+	case !oldFound || !reflect.DeepEqual(oldConst, newConst):
+		d.add(location, BreakingChange, "const constraint changed to %s", valueToString(newConst))
+	}
+}
+
+func (d *schemaDiffer) diffBounds(location string, oldSchema, newSchema jsonSchema) {
+	for _, keyword := range lowerBoundKeywords {
+		d.diffBound(location, keyword, oldSchema, newSchema, func(oldVal, newVal float64) bool { return newVal > oldVal })
+	}
+	for _, keyword := range upperBoundKeywords {
+		d.diffBound(location, keyword, oldSchema, newSchema, func(oldVal, newVal float64) bool { return newVal < oldVal })
+	}
+}
+
+func (d *schemaDiffer) diffBound(location, keyword string, oldSchema, newSchema jsonSchema, narrows func(oldVal, newVal float64) bool) {
+	oldVal, oldFound := oldSchema[keyword].(float64)
+	newVal, newFound := newSchema[keyword].(float64)
+	switch {
+	case !oldFound && !newFound:
+		return
+	case !newFound:
+		d.add(location, CompatibleChange, "%q constraint removed", keyword)
+	case !oldFound:
+		d.add(location, BreakingChange, "%q constraint added", keyword)
+	case oldVal == newVal:
+		return
+	case narrows(oldVal, newVal):
+		d.add(location, BreakingChange, "%q constraint narrowed from %v to %v", keyword, oldVal, newVal)
+	default:
+		d.add(location, CompatibleChange, "%q constraint relaxed from %v to %v", keyword, oldVal, newVal)
+	}
+}
+
+func (d *schemaDiffer) diffFormats(location string, oldSchema, newSchema jsonSchema) {
+	for _, keyword := range []string{"pattern", "format"} {
+		oldVal, oldFound := oldSchema[keyword].(string)
+		newVal, newFound := newSchema[keyword].(string)
+		switch {
+		case !oldFound && !newFound, oldVal == newVal:
+			continue
+		case !newFound:
+			d.add(location, CompatibleChange, "%q constraint removed", keyword)
+		default:
+			d.add(location, BreakingChange, "%q constraint changed to %q", keyword, newVal)
+		}
+	}
+}
+
+func (d *schemaDiffer) diffAnnotations(location string, oldSchema, newSchema jsonSchema) {
+	for _, keyword := range annotationKeywords {
+		if !reflect.DeepEqual(oldSchema[keyword], newSchema[keyword]) {
+			d.add(location, DocumentationChange, "%q annotation changed", keyword)
+		}
+	}
+}
+
+func (d *schemaDiffer) diffProperties(location string, oldSchema, newSchema jsonSchema, visited map[string]struct{}) {
+	oldProps, newProps := schemaOf(oldSchema["properties"]), schemaOf(newSchema["properties"])
+	oldRequired, newRequired := stringsOf(oldSchema["required"]), stringsOf(newSchema["required"])
+
+	for _, name := range sortedUnion(keysOf(oldProps), keysOf(newProps)) {
+		propLocation := fmt.Sprintf("%s.properties.%s", location, name)
+		oldProp, inOld := oldProps[name]
+		newProp, inNew := newProps[name]
+
+		switch {
+		case !inOld && contains(newRequired, name):
+			d.add(propLocation, BreakingChange, "required property added")
+		case !inOld:
+			d.add(propLocation, CompatibleChange, "optional property added")
+		case !inNew:
+			d.add(propLocation, BreakingChange, "property removed")
+		default:
+			d.diff(propLocation, schemaOf(oldProp), schemaOf(newProp), visited)
+		}
+	}
+
+	// added and removed properties are already reported, so only the existing ones are checked
+	for _, name := range missingIn(oldRequired, newRequired) {
+		if hasKey(oldProps, name) && hasKey(newProps, name) {
+			d.add(fmt.Sprintf("%s.properties.%s", location, name), BreakingChange, "property became required")
+		}
+	}
+	for _, name := range missingIn(newRequired, oldRequired) {
+		if hasKey(oldProps, name) && hasKey(newProps, name) {
+			d.add(fmt.Sprintf("%s.properties.%s", location, name), CompatibleChange, "property became optional")
+		}
+	}
+
+	oldAdditional, newAdditional := oldSchema["additionalProperties"], newSchema["additionalProperties"]
+	if allowsAdditional(oldAdditional) && !allowsAdditional(newAdditional) {
+		d.add(location, BreakingChange, "additional properties are no longer allowed")
+	} else if !allowsAdditional(oldAdditional) && allowsAdditional(newAdditional) {
+		d.add(location, CompatibleChange, "additional properties are allowed")
+	}
+}
+
+// resolveRef returns the schema referenced by a local `$ref` and the reference itself.
+// If the schema doesn't have a local reference, it is returned as is.
+func resolveRef(root, schema jsonSchema) (jsonSchema, string) {
+	ref, ok := schema["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/") {
+		return schema, ""
+	}
+
+	var current interface{} = map[string]interface{}(root)
+	for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		obj := schemaOf(current)
+		if obj == nil {
+			return schema, ""
+		}
+		current = obj[segment]
+	}
+
+	resolved := schemaOf(current)
+	if resolved == nil {
+		return schema, ""
+	}
+	return resolved, ref
+}
+
+func schemaOf(in interface{}) jsonSchema {
+	switch obj := in.(type) {
+	case map[string]interface{}:
+		return obj
+	case jsonSchema:
+		return obj
+	}
+	return nil
+}
+
+// stringsOf returns a string slice for a JSON value, which can be a single string or an array of strings.
+func stringsOf(in interface{}) []string {
+	switch val := in.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		var out []string
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// allowsAdditional returns false only if the additional properties are explicitly forbidden.
+func allowsAdditional(in interface{}) bool {
+	val, ok := in.(bool)
+	return !ok || val
+}
+
+func valuesToStrings(in []interface{}) []string {
+	var out []string
+	for _, val := range in {
+		out = append(out, valueToString(val))
+	}
+	return out
+}
+
+func valueToString(in interface{}) string {
+	out, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Sprintf("%v", in)
+	}
+	return string(out)
+}
+
+func hasKey(schema jsonSchema, key string) bool {
+	_, found := schema[key]
+	return found
+}
+
+func contains(in []string, elem string) bool {
+	for _, s := range in {
+		if s == elem {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,325 @@
+package manifest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"capact.io/capact/pkg/sdk/manifest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff_Type(t *testing.T) {
+	const oldSchema = `{
+		"type": "object",
+		"required": ["host"],
+		"properties": {
+			"host": {"type": "string"},
+			"port": {"type": "integer", "maximum": 65535},
+			"mode": {"type": "string", "enum": ["dev", "prod", "test"]}
+		}
+	}`
+
+	tests := map[string]struct {
+		newRevision     string
+		newSchema       string
+		expectedChanges []manifest.Change
+		expectedBump    manifest.RevisionBump
+		expectedErr     string
+	}{
+		"No changes": {
+			newRevision:     "0.1.1",
+			newSchema:       oldSchema,
+			expectedChanges: nil,
+			expectedBump:    manifest.PatchBump,
+		},
+		"New optional field is compatible": {
+			newRevision: "0.2.0",
+			newSchema: `{
+				"type": "object",
+				"required": ["host"],
+				"properties": {
+					"host": {"type": "string"},
+					"port": {"type": "integer", "maximum": 65535},
+					"mode": {"type": "string", "enum": ["dev", "prod", "test"]},
+					"user": {"type": "string"}
+				}
+			}`,
+			expectedChanges: []manifest.Change{
+				{Location: "spec.jsonSchema.properties.user", Impact: manifest.CompatibleChange, Message: "optional property added"},
+			},
+			expectedBump: manifest.MinorBump,
+		},
+		"New optional field with too small revision bump": {
+			newRevision: "0.1.1",
+			newSchema: `{
+				"type": "object",
+				"required": ["host"],
+				"properties": {
+					"host": {"type": "string"},
+					"port": {"type": "integer", "maximum": 65535},
+					"mode": {"type": "string", "enum": ["dev", "prod", "test"]},
+					"user": {"type": "string"}
+				}
+			}`,
+			expectedChanges: []manifest.Change{
+				{Location: "spec.jsonSchema.properties.user", Impact: manifest.CompatibleChange, Message: "optional property added"},
+			},
+			expectedBump: manifest.MinorBump,
+			expectedErr:  "revision bump from 0.1.0 to 0.1.1 is patch, but detected changes require at least minor bump",
+		},
+		"New required field, narrowed enum and constraint are breaking": {
+			newRevision: "0.2.0",
+			newSchema: `{
+				"type": "object",
+				"required": ["host", "port", "user"],
+				"properties": {
+					"host": {"type": "string"},
+					"port": {"type": "integer", "maximum": 1024},
+					"mode": {"type": "string", "enum": ["dev", "prod"]},
+					"user": {"type": "string"}
+				}
+			}`,
+			expectedChanges: []manifest.Change{
+				{Location: "spec.jsonSchema.properties.mode", Impact: manifest.BreakingChange, Message: `enum narrowed, values "test" removed`},
+				{Location: "spec.jsonSchema.properties.port", Impact: manifest.BreakingChange, Message: `"maximum" constraint narrowed from 65535 to 1024`},
+				{Location: "spec.jsonSchema.properties.user", Impact: manifest.BreakingChange, Message: "required property added"},
+				{Location: "spec.jsonSchema.properties.port", Impact: manifest.BreakingChange, Message: "property became required"},
+			},
+			expectedBump: manifest.MinorBump,
+		},
+		"Removed field and changed type are breaking": {
+			newRevision: "0.2.0",
+			newSchema: `{
+				"type": "object",
+				"required": ["host"],
+				"properties": {
+					"host": {"type": "object"},
+					"port": {"type": "integer", "maximum": 65535}
+				}
+			}`,
+			expectedChanges: []manifest.Change{
+				{Location: "spec.jsonSchema.properties.host", Impact: manifest.BreakingChange, Message: `type changed from "string" to "object"`},
+				{Location: "spec.jsonSchema.properties.mode", Impact: manifest.BreakingChange, Message: "property removed"},
+			},
+			expectedBump: manifest.MinorBump,
+		},
+		"Extended enum and optional field are compatible": {
+			newRevision: "0.2.0",
+			newSchema: `{
+				"type": "object",
+				"properties": {
+					"host": {"type": "string"},
+					"port": {"type": "integer", "maximum": 65535},
+					"mode": {"type": "string", "enum": ["dev", "prod", "test", "stage"]}
+				}
+			}`,
+			expectedChanges: []manifest.Change{
+				{Location: "spec.jsonSchema.properties.mode", Impact: manifest.CompatibleChange, Message: `enum extended, values "stage" added`},
+				{Location: "spec.jsonSchema.properties.host", Impact: manifest.CompatibleChange, Message: "property became optional"},
+			},
+			expectedBump: manifest.MinorBump,
+		},
+		"Description change requires patch bump": {
+			newRevision: "0.1.1",
+			newSchema: `{
+				"type": "object",
+				"required": ["host"],
+				"properties": {
+					"host": {"type": "string", "description": "Database host"},
+					"port": {"type": "integer", "maximum": 65535},
+					"mode": {"type": "string", "enum": ["dev", "prod", "test"]}
+				}
+			}`,
+			expectedChanges: []manifest.Change{
+				{Location: "spec.jsonSchema.properties.host", Impact: manifest.DocumentationChange, Message: `"description" annotation changed`},
+			},
+			expectedBump: manifest.PatchBump,
+		},
+		"Description and optional field require minor bump": {
+			newRevision: "0.1.1",
+			newSchema: `{
+				"type": "object",
+				"title": "Config",
+				"required": ["host"],
+				"properties": {
+					"host": {"type": "string"},
+					"port": {"type": "integer", "maximum": 65535},
+					"mode": {"type": "string", "enum": ["dev", "prod", "test"]},
+					"user": {"type": "string"}
+				}
+			}`,
+			expectedChanges: []manifest.Change{
+				{Location: "spec.jsonSchema", Impact: manifest.DocumentationChange, Message: `"title" annotation changed`},
+				{Location: "spec.jsonSchema.properties.user", Impact: manifest.CompatibleChange, Message: "optional property added"},
+			},
+			expectedBump: manifest.MinorBump,
+			expectedErr:  "revision bump from 0.1.0 to 0.1.1 is patch, but detected changes require at least minor bump",
+		},
+		"Revision must be greater": {
+			newRevision:  "0.1.0",
+			newSchema:    oldSchema,
+			expectedBump: manifest.PatchBump,
+			expectedErr:  "new revision 0.1.0 must be greater than old revision 0.1.0",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// given
+			oldYAML := fixTypeManifest("0.1.0", oldSchema)
+			newYAML := fixTypeManifest(tc.newRevision, tc.newSchema)
+
+			// when
+			res, err := manifest.Diff(oldYAML, newYAML)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChanges, res.Changes)
+			assert.Equal(t, tc.expectedBump, res.RequiredBump())
+
+			err = res.CheckRevisionBump()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDiff_Interface(t *testing.T) {
+	// given
+	oldYAML := []byte(`
+ocfVersion: 0.0.1
+revision: 1.0.0
+kind: Interface
+metadata:
+  prefix: cap.interface.app
+  name: install
+spec:
+  input:
+    parameters:
+      input-parameters:
+        jsonSchema:
+          value: |-
+            {"type": "object", "properties": {"name": {"type": "string"}}}
+      additional-parameters:
+        typeRef:
+          path: cap.type.app.extra
+          revision: 0.1.0
+    typeInstances:
+      database:
+        typeRef:
+          path: cap.type.db.config
+          revision: 0.1.0
+        verbs: ["get"]
+  output:
+    typeInstances:
+      config:
+        typeRef:
+          path: cap.type.app.config
+          revision: 0.1.0
+      release:
+        typeRef:
+          path: cap.type.helm.release
+          revision: 0.1.0
+`)
+	newYAML := []byte(`
+ocfVersion: 0.0.1
+revision: 1.1.0
+kind: Interface
+metadata:
+  prefix: cap.interface.app
+  name: install
+spec:
+  input:
+    parameters:
+      input-parameters:
+        jsonSchema:
+          value: |-
+            {"type": "object", "properties": {"name": {"type": "string"}, "replicas": {"type": "integer"}}}
+      additional-parameters:
+        typeRef:
+          path: cap.type.app.extra
+          revision: 0.2.0
+    typeInstances:
+      database:
+        typeRef:
+          path: cap.type.db.config
+          revision: 0.1.0
+        verbs: ["get", "update"]
+  output:
+    typeInstances:
+      config:
+        typeRef:
+          path: cap.type.app.config
+          revision: 0.1.0
+`)
+
+	// when
+	res, err := manifest.Diff(oldYAML, newYAML)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []manifest.Change{
+		{Location: "spec.input.parameters.additional-parameters.typeRef", Impact: manifest.CompatibleChange, Message: "Type revision changed from 0.1.0 to 0.2.0"},
+		{Location: "spec.input.parameters.input-parameters.jsonSchema.properties.replicas", Impact: manifest.CompatibleChange, Message: "optional property added"},
+		{Location: "spec.input.typeInstances.database.verbs", Impact: manifest.BreakingChange, Message: `verbs "update" added`},
+		{Location: "spec.output.typeInstances.release", Impact: manifest.BreakingChange, Message: "output TypeInstance removed"},
+	}, res.Changes)
+	assert.Equal(t, manifest.MajorBump, res.RequiredBump())
+	assert.EqualError(t, res.CheckRevisionBump(), "revision bump from 1.0.0 to 1.1.0 is minor, but detected changes require at least major bump")
+}
+
+func TestDiff_Errors(t *testing.T) {
+	tests := map[string]struct {
+		oldYAML     []byte
+		newYAML     []byte
+		expectedErr string
+	}{
+		"Different kinds": {
+			oldYAML:     fixTypeManifest("0.1.0", `{}`),
+			newYAML:     []byte("ocfVersion: 0.0.1\nkind: Interface\nrevision: 0.1.0\nmetadata:\n  name: config\n"),
+			expectedErr: "cannot compare Type with Interface",
+		},
+		"Unsupported kind": {
+			oldYAML:     []byte("ocfVersion: 0.0.1\nkind: Attribute\nrevision: 0.1.0\nmetadata:\n  name: config\n"),
+			newYAML:     []byte("ocfVersion: 0.0.1\nkind: Attribute\nrevision: 0.2.0\nmetadata:\n  name: config\n"),
+			expectedErr: "comparing Attribute manifests is not supported",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// when
+			_, err := manifest.Diff(tc.oldYAML, tc.newYAML)
+
+			// then
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func fixTypeManifest(revision, schema string) []byte {
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, []byte(schema)); err != nil {
+		panic(err)
+	}
+
+	return []byte(fmt.Sprintf(`
+ocfVersion: 0.0.1
+revision: %s
+kind: Type
+metadata:
+  prefix: cap.type.app
+  name: config
+spec:
+  jsonSchema:
+    value: |-
+      %s
+`, revision, compacted.String()))
+}
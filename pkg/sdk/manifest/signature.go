@@ -0,0 +1,264 @@
+package manifest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// SignatureFileSuffix is appended to the manifest file path to get the path of its detached signature.
+	SignatureFileSuffix = ".sig"
+
+	// PublicKeyFileExtension is the extension of the trusted public key files.
+	PublicKeyFileExtension = ".pem"
+
+	privateKeyPEMType = "PRIVATE KEY"
+	publicKeyPEMType  = "PUBLIC KEY"
+)
+
+// ErrMissingSignature indicates that the manifest doesn't have a detached signature.
+var ErrMissingSignature = errors.New("manifest is not signed")
+
+// Signature is a detached signature of a single manifest file.
+type Signature struct {
+	// KeyID is the fingerprint of the public key, which verifies the signature.
+	KeyID string `json:"keyID"`
+	// Path is the canonical manifest path, such as `cap.interface.database.postgresql.install`.
+	// It is signed together with the content, so the signed manifest cannot be published under a different path.
+	Path string `json:"path"`
+	// Value is the base64 encoded ed25519 signature of the manifest path and content.
+	Value string `json:"signature"`
+}
+
+// TrustedKey is a public key of a trusted manifest signer.
+type TrustedKey struct {
+	Signer    string
+	PublicKey ed25519.PublicKey
+}
+
+// TrustedKeys holds trusted public keys indexed by the key ID.
+type TrustedKeys map[string]TrustedKey
+
+// SignatureFilePath returns the path of the detached signature for a given manifest file.
+func SignatureFilePath(manifestPath string) string {
+	return manifestPath + SignatureFileSuffix
+}
+
+// PrefixFromPath returns the manifest prefix computed from the file location in the manifests root directory,
+// the same way as the Hub populator does. For example, `interface/database/postgresql/install.yaml` has the `cap.interface.database.postgresql` prefix.
+func PrefixFromPath(rootDir, manifestPath string) (string, error) {
+	rel, err := filepath.Rel(rootDir, manifestPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "while getting path of %s relative to %s", manifestPath, rootDir)
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("manifest %s is outside of the root directory %s", manifestPath, rootDir)
+	}
+
+	dir := path.Dir(rel)
+	if dir == "." {
+		return "cap", nil
+	}
+	return "cap." + strings.ReplaceAll(dir, "/", "."), nil
+}
+
+// CanonicalPath returns the manifest path, which consists of a given prefix and the manifest name.
+func CanonicalPath(prefix string, manifest []byte) (string, error) {
+	var m struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+	if err := yaml.Unmarshal(manifest, &m); err != nil {
+		return "", errors.Wrap(err, "while unmarshaling manifest metadata")
+	}
+	if m.Metadata.Name == "" {
+		return "", errors.New("manifest name must not be empty")
+	}
+	return prefix + "." + m.Metadata.Name, nil
+}
+
+// GenerateKeyPair generates a new ed25519 key pair for signing manifests.
+// The private key is returned in the PKCS #8 PEM format and the public key in the PKIX PEM format.
+func GenerateKeyPair() ([]byte, []byte, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "while generating key pair")
+	}
+
+	privBytes, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "while marshaling private key")
+	}
+	pubBytes, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "while marshaling public key")
+	}
+
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: privateKeyPEMType, Bytes: privBytes})
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: publicKeyPEMType, Bytes: pubBytes})
+	return privPEM, pubPEM, nil
+}
+
+// ParsePrivateKey parses the ed25519 private key in the PKCS #8 PEM format.
+func ParsePrivateKey(in []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(in)
+	if block == nil || block.Type != privateKeyPEMType {
+		return nil, fmt.Errorf("expected PEM block of type %q", privateKeyPEMType)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing private key")
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T, only ed25519 keys are supported", key)
+	}
+	return priv, nil
+}
+
+// ParsePublicKey parses the ed25519 public key in the PKIX PEM format.
+func ParsePublicKey(in []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(in)
+	if block == nil || block.Type != publicKeyPEMType {
+		return nil, fmt.Errorf("expected PEM block of type %q", publicKeyPEMType)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "while parsing public key")
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T, only ed25519 keys are supported", key)
+	}
+	return pub, nil
+}
+
+// KeyID returns the fingerprint of a given public key.
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Sign signs the canonical manifest path together with the manifest content and returns the detached signature in the YAML format.
+func Sign(manifestPath string, manifest []byte, key ed25519.PrivateKey) ([]byte, error) {
+	pub, ok := key.Public().(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("cannot get public key from the private key")
+	}
+
+	sig := Signature{
+		KeyID: KeyID(pub),
+		Path:  manifestPath,
+		Value: base64.StdEncoding.EncodeToString(ed25519.Sign(key, signedData(manifestPath, manifest))),
+	}
+	out, err := yaml.Marshal(sig)
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshaling signature")
+	}
+	return out, nil
+}
+
+// LoadTrustedKeys loads all public keys from the `*.pem` files in a given directory.
+// The signer identity is the file name without extension, e.g. `capact-maintainers.pem` holds the key of the `capact-maintainers` signer.
+func LoadTrustedKeys(dir string) (TrustedKeys, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+PublicKeyFileExtension))
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing trusted keys")
+	}
+
+	keys := TrustedKeys{}
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, errors.Wrapf(err, "while reading file from path %s", path)
+		}
+		pub, err := ParsePublicKey(content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while loading trusted key from path %s", path)
+		}
+
+		keys[KeyID(pub)] = TrustedKey{
+			Signer:    strings.TrimSuffix(filepath.Base(path), PublicKeyFileExtension),
+			PublicKey: pub,
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no trusted keys found in %s", dir)
+	}
+	return keys, nil
+}
+
+// Verify verifies the detached signature of the canonical manifest path and the manifest content, and returns the signer identity.
+func (k TrustedKeys) Verify(manifestPath string, manifest, signature []byte) (string, error) {
+	var sig Signature
+	if err := yaml.Unmarshal(signature, &sig); err != nil {
+		return "", errors.Wrap(err, "while unmarshaling signature")
+	}
+	if sig.Path != manifestPath {
+		return "", fmt.Errorf("signature was issued for %q, but the manifest path is %q", sig.Path, manifestPath)
+	}
+
+	key, found := k[sig.KeyID]
+	if !found {
+		return "", fmt.Errorf("signature key %q is not trusted", sig.KeyID)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(sig.Value)
+	if err != nil {
+		return "", errors.Wrap(err, "while decoding signature")
+	}
+	if !ed25519.Verify(key.PublicKey, signedData(manifestPath, manifest), value) {
+		return "", fmt.Errorf("invalid signature of %q signer", key.Signer)
+	}
+
+	return key.Signer, nil
+}
+
+// VerifyFile verifies the detached signature of a given manifest file and returns the signer identity.
+// The canonical manifest path is built from a given prefix and the manifest name.
+// It returns ErrMissingSignature if the signature file doesn't exist.
+func (k TrustedKeys) VerifyFile(prefix, manifestPath string) (string, error) {
+	signature, err := ioutil.ReadFile(filepath.Clean(SignatureFilePath(manifestPath)))
+	if os.IsNotExist(err) {
+		return "", ErrMissingSignature
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "while reading signature of %s", manifestPath)
+	}
+
+	manifest, err := ioutil.ReadFile(filepath.Clean(manifestPath))
+	if err != nil {
+		return "", errors.Wrapf(err, "while reading file from path %s", manifestPath)
+	}
+
+	canonicalPath, err := CanonicalPath(prefix, manifest)
+	if err != nil {
+		return "", errors.Wrapf(err, "while getting canonical path of %s", manifestPath)
+	}
+
+	return k.Verify(canonicalPath, manifest, signature)
+}
+
+// signedData returns the data covered by the signature. The path cannot contain a new line, so the data is unambiguous.
+func signedData(manifestPath string, manifest []byte) []byte {
+	return append([]byte(manifestPath+"\n"), manifest...)
+}
@@ -0,0 +1,171 @@
+package manifest_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"capact.io/capact/pkg/sdk/manifest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedKeys_VerifyFile(t *testing.T) {
+	const (
+		content       = "ocfVersion: 0.0.1\nkind: Implementation\nmetadata:\n  name: install\n"
+		prefix        = "cap.implementation.postgresql"
+		canonicalPath = "cap.implementation.postgresql.install"
+	)
+
+	trustedPriv, trustedPub := fixKeyPair(t)
+	untrustedPriv, _ := fixKeyPair(t)
+
+	keysDir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(keysDir, "capact-maintainers.pem"), trustedPub, 0o600))
+
+	keys, err := manifest.LoadTrustedKeys(keysDir)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		signingKey     []byte
+		signedPath     string
+		signedContent  string
+		expectedSigner string
+		expectedErr    string
+	}{
+		"Should return signer for manifest signed with trusted key": {
+			signingKey:     trustedPriv,
+			signedPath:     canonicalPath,
+			signedContent:  content,
+			expectedSigner: "capact-maintainers",
+		},
+		"Should reject manifest modified after signing": {
+			signingKey:    trustedPriv,
+			signedPath:    canonicalPath,
+			signedContent: "ocfVersion: 0.0.1\nkind: Type\nmetadata:\n  name: install\n",
+			expectedErr:   `invalid signature of "capact-maintainers" signer`,
+		},
+		"Should reject manifest signed for other path": {
+			signingKey:    trustedPriv,
+			signedPath:    "cap.implementation.mysql.install",
+			signedContent: content,
+			expectedErr:   `signature was issued for "cap.implementation.mysql.install", but the manifest path is "cap.implementation.postgresql.install"`,
+		},
+		"Should reject manifest signed with untrusted key": {
+			signingKey:    untrustedPriv,
+			signedPath:    canonicalPath,
+			signedContent: content,
+			expectedErr:   "is not trusted",
+		},
+		"Should reject manifest without signature": {
+			expectedErr: manifest.ErrMissingSignature.Error(),
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// given
+			manifestPath := filepath.Join(t.TempDir(), "install.yaml")
+			require.NoError(t, ioutil.WriteFile(manifestPath, []byte(content), 0o600))
+
+			if tc.signingKey != nil {
+				key, err := manifest.ParsePrivateKey(tc.signingKey)
+				require.NoError(t, err)
+				sig, err := manifest.Sign(tc.signedPath, []byte(tc.signedContent), key)
+				require.NoError(t, err)
+				require.NoError(t, ioutil.WriteFile(manifest.SignatureFilePath(manifestPath), sig, 0o600))
+			}
+
+			// when
+			signer, err := keys.VerifyFile(prefix, manifestPath)
+
+			// then
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSigner, signer)
+		})
+	}
+}
+
+func TestTrustedKeys_VerifyPathSubstitution(t *testing.T) {
+	// given
+	priv, pub := fixKeyPair(t)
+	keysDir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(keysDir, "capact-maintainers.pem"), pub, 0o600))
+	keys, err := manifest.LoadTrustedKeys(keysDir)
+	require.NoError(t, err)
+
+	key, err := manifest.ParsePrivateKey(priv)
+	require.NoError(t, err)
+	content := []byte("ocfVersion: 0.0.1\nkind: Implementation\nmetadata:\n  name: install\n")
+	sig, err := manifest.Sign("cap.implementation.mysql.install", content, key)
+	require.NoError(t, err)
+
+	// when
+	_, err = keys.Verify("cap.implementation.postgresql.install", content, []byte(strings.Replace(string(sig), "mysql", "postgresql", 1)))
+
+	// then
+	assert.EqualError(t, err, `invalid signature of "capact-maintainers" signer`)
+}
+
+func TestPrefixFromPath(t *testing.T) {
+	tests := map[string]struct {
+		rootDir        string
+		manifestPath   string
+		expectedPrefix string
+		expectedErr    string
+	}{
+		"Should return prefix from nested directories": {
+			rootDir:        "./manifests",
+			manifestPath:   "manifests/interface/database/postgresql/install.yaml",
+			expectedPrefix: "cap.interface.database.postgresql",
+		},
+		"Should return root prefix": {
+			rootDir:        "manifests",
+			manifestPath:   "manifests/metadata.yaml",
+			expectedPrefix: "cap",
+		},
+		"Should reject manifest outside of root directory": {
+			rootDir:      "manifests",
+			manifestPath: "other/install.yaml",
+			expectedErr:  "manifest other/install.yaml is outside of the root directory manifests",
+		},
+	}
+	for tn, tc := range tests {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			// when
+			prefix, err := manifest.PrefixFromPath(tc.rootDir, tc.manifestPath)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPrefix, prefix)
+		})
+	}
+}
+
+func TestLoadTrustedKeys_Empty(t *testing.T) {
+	// when
+	_, err := manifest.LoadTrustedKeys(t.TempDir())
+
+	// then
+	assert.Error(t, err)
+}
+
+func fixKeyPair(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	priv, pub, err := manifest.GenerateKeyPair()
+	require.NoError(t, err)
+	return priv, pub
+}
@@ -35,6 +35,7 @@ type dedicatedRenderer struct {
 	inputParametersCollection types.ParametersCollection
 	inputTypeInstances        []types.InputTypeInstanceRef
 	ownerID                   *string
+	backendChecker            BackendChecker
 
 	// internal vars
 	currentIteration   int
@@ -157,6 +158,32 @@ func (r *dedicatedRenderer) AddOutputTypeInstancesStep(workflow *Workflow) error
 	return nil
 }
 
+// CheckOutputTypeInstancesBackends checks all distinct storage backends selected for the output TypeInstances.
+// Output TypeInstances without a backend selected by Policy are stored in the default Hub storage, so they are skipped.
+func (r *dedicatedRenderer) CheckOutputTypeInstancesBackends(ctx context.Context) error {
+	if r.backendChecker == nil {
+		return nil
+	}
+
+	checked := map[string]struct{}{}
+	for _, ti := range r.typeInstancesToOutput.typeInstances {
+		backendID := ti.Backend.ID
+		if backendID == "" {
+			continue
+		}
+		if _, ok := checked[backendID]; ok {
+			continue
+		}
+		checked[backendID] = struct{}{}
+
+		if err := r.backendChecker.CheckBackend(ctx, backendID); err != nil {
+			return errors.Wrapf(err, "while checking storage backend for output TypeInstance %q", ptr.StringPtrToString(ti.ArtifactName))
+		}
+	}
+
+	return nil
+}
+
 func (r *dedicatedRenderer) GetTypeInstancesToLock() []string {
 	var typeInstances []string
 	for _, ti := range r.typeInstancesToUpdate {
@@ -52,3 +52,11 @@ func WithOwnerID(ownerID string) RendererOption {
 		r.ownerID = &ownerID
 	}
 }
+
+// WithBackendChecker returns a RendererOption, which checks storage backends selected for the output TypeInstances.
+// It allows rejecting the Action with misconfigured storage backends before it is run.
+func WithBackendChecker(checker BackendChecker) RendererOption {
+	return func(r *dedicatedRenderer) {
+		r.backendChecker = checker
+	}
+}
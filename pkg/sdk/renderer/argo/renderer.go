@@ -36,6 +36,11 @@ type PolicyEnforcedHubClient interface {
 	FindInterfaceRevision(ctx context.Context, ref hubpublicapi.InterfaceReference) (*hubpublicapi.InterfaceRevision, error)
 }
 
+// BackendChecker checks if a given storage backend can be used to store TypeInstances.
+type BackendChecker interface {
+	CheckBackend(ctx context.Context, backendID string) error
+}
+
 type workflowValidator interface {
 	ValidateInterfaceInput(context.Context, renderer.InterfaceInput) error
 	PolicyValidator() hubclient.PolicyIOValidator
@@ -202,10 +207,16 @@ func (r *Renderer) Render(ctx context.Context, input *RenderInput) (*RenderOutpu
 
 	rootWorkflow.Templates = dedicatedRenderer.GetRootTemplates()
 
+	if err := dedicatedRenderer.CheckOutputTypeInstancesBackends(ctxWithTimeout); err != nil {
+		return nil, err
+	}
+
 	if err := dedicatedRenderer.AddOutputTypeInstancesStep(rootWorkflow); err != nil {
 		return nil, err
 	}
 
+	r.typeInstanceHandler.AddGatewayCredentials(rootWorkflow, input.RunnerContextSecretRef.Name)
+
 	out, err := r.toMapStringInterface(rootWorkflow)
 	if err != nil {
 		return nil, err
@@ -2,6 +2,7 @@ package argo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"testing"
@@ -429,6 +430,69 @@ func TestRendererDenyAllPolicy(t *testing.T) {
 	assert.Nil(t, renderOutput)
 }
 
+func TestRendererRejectsMisconfiguredBackend(t *testing.T) {
+	// given
+	fakeCli, err := fake.NewFromLocal("testdata/hub", true)
+	require.NoError(t, err)
+
+	typeInstanceHandler := NewTypeInstanceHandler(hubActionsImage, localHubEndpoint, publicHubEndpoint)
+	typeInstanceHandler.SetGenUUID(genUUIDFn(""))
+
+	interfaceIOValidator := actionvalidation.NewValidator(fakeCli)
+	policyIOValidator := policyvalidation.NewValidator(fakeCli)
+	wfValidator := renderer.NewWorkflowInputValidator(interfaceIOValidator, policyIOValidator)
+
+	argoRenderer := NewRenderer(logger.Noop(), renderer.Config{
+		RenderTimeout: time.Hour,
+		MaxDepth:      50,
+	}, fakeCli, typeInstanceHandler, wfValidator)
+
+	checker := &fakeBackendChecker{
+		errs: map[string]error{
+			"a35a0c04-6153-4fe9-8d49-9ec35a0e86e": errors.New("backend is unhealthy"),
+		},
+	}
+
+	// when
+	renderOutput, err := argoRenderer.Render(
+		context.Background(),
+		&RenderInput{
+			RunnerContextSecretRef: RunnerContextSecretRef{Name: "secret", Key: "key"},
+			InterfaceRef: types.InterfaceRef{
+				Path: "cap.interface.productivity.mattermost.install",
+			},
+			Options: []RendererOption{
+				WithGlobalPolicy(fixHelmStorageGlobalPolicy()),
+				WithSecretUserInput(&UserInputSecretRef{
+					Name: "user-input",
+				}, types.ParametersCollection{
+					"input-parameters": `{"host":"mattermost.local"}`,
+				}),
+				WithBackendChecker(checker),
+			},
+		},
+	)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "backend is unhealthy")
+	assert.Nil(t, renderOutput)
+	assert.Equal(t, 1, checker.calls["a35a0c04-6153-4fe9-8d49-9ec35a0e86e"])
+}
+
+type fakeBackendChecker struct {
+	errs  map[string]error
+	calls map[string]int
+}
+
+func (f *fakeBackendChecker) CheckBackend(_ context.Context, backendID string) error {
+	if f.calls == nil {
+		f.calls = map[string]int{}
+	}
+	f.calls[backendID]++
+	return f.errs[backendID]
+}
+
 func assertYAMLGoldenFile(t *testing.T, actualYAMLData interface{}, filename string, msgAndArgs ...interface{}) {
 	t.Helper()
 
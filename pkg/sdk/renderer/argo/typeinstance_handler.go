@@ -15,18 +15,38 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+const (
+	// GatewayUsernameSecretKey is the runner context Secret key with the Gateway username used by the TypeInstance steps.
+	GatewayUsernameSecretKey = "gateway-username"
+	// GatewayPasswordSecretKey is the runner context Secret key with the Gateway password used by the TypeInstance steps.
+	// #nosec G101
+	GatewayPasswordSecretKey = "gateway-password"
+)
+
 // TypeInstanceHandler provides functionality to handle TypeInstance operations such as
 // injecting download step and upload step.
 type TypeInstanceHandler struct {
 	hubActionsImage   string
 	localHubEndpoint  string
 	publicHubEndpoint string
+	gatewayEndpoint   string
 	genUUID           func() string
 }
 
+// TypeInstanceHandlerOption allows to customize the TypeInstanceHandler.
+type TypeInstanceHandlerOption func(*TypeInstanceHandler)
+
+// WithGatewayEndpoint configures the TypeInstance steps to call the Hubs through the Gateway with a given endpoint.
+// The Gateway credentials are read from the runner context Secret.
+func WithGatewayEndpoint(endpoint string) TypeInstanceHandlerOption {
+	return func(r *TypeInstanceHandler) {
+		r.gatewayEndpoint = endpoint
+	}
+}
+
 // NewTypeInstanceHandler returns a new TypeInstanceHandler instance.
-func NewTypeInstanceHandler(hubActionsImage string, localHubEndpoint string, publicHubEndpoint string) *TypeInstanceHandler {
-	return &TypeInstanceHandler{
+func NewTypeInstanceHandler(hubActionsImage string, localHubEndpoint string, publicHubEndpoint string, opts ...TypeInstanceHandlerOption) *TypeInstanceHandler {
+	r := &TypeInstanceHandler{
 		hubActionsImage:   hubActionsImage,
 		localHubEndpoint:  localHubEndpoint,
 		publicHubEndpoint: publicHubEndpoint,
@@ -34,6 +54,53 @@ func NewTypeInstanceHandler(hubActionsImage string, localHubEndpoint string, pub
 			return uuid.New().String()
 		},
 	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// AddGatewayCredentials configures the TypeInstance steps to call the Hubs through the Gateway,
+// using the credentials issued for the Action and stored in a given Secret.
+// It does nothing if the Gateway endpoint is not configured.
+func (r *TypeInstanceHandler) AddGatewayCredentials(rootWorkflow *Workflow, secretName string) {
+	if r.gatewayEndpoint == "" {
+		return
+	}
+
+	for _, tpl := range rootWorkflow.Templates {
+		if tpl == nil || tpl.Template == nil || tpl.Container == nil || tpl.Container.Image != r.hubActionsImage {
+			continue
+		}
+
+		tpl.Container.Env = append(tpl.Container.Env,
+			apiv1.EnvVar{
+				Name:  "APP_GATEWAY_ENDPOINT",
+				Value: r.gatewayEndpoint,
+			},
+			apiv1.EnvVar{
+				Name:      "APP_GATEWAY_USERNAME",
+				ValueFrom: secretKeySelector(secretName, GatewayUsernameSecretKey),
+			},
+			apiv1.EnvVar{
+				Name:      "APP_GATEWAY_PASSWORD",
+				ValueFrom: secretKeySelector(secretName, GatewayPasswordSecretKey),
+			},
+		)
+	}
+}
+
+func secretKeySelector(name, key string) *apiv1.EnvVarSource {
+	return &apiv1.EnvVarSource{
+		SecretKeyRef: &apiv1.SecretKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{
+				Name: name,
+			},
+			Key: key,
+		},
+	}
 }
 
 // AddInputTypeInstances adds steps to the workflow to download
@@ -0,0 +1,57 @@
+package argo
+
+import (
+	"testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestTypeInstanceHandlerAddGatewayCredentials(t *testing.T) {
+	const gatewayEndpoint = "http://capact-gateway.capact-system/graphql"
+
+	tests := map[string]struct {
+		opts        []TypeInstanceHandlerOption
+		expectedEnv []apiv1.EnvVar
+	}{
+		"Should not change templates if Gateway endpoint is not set": {},
+		"Should add Gateway credentials to Hub actions templates": {
+			opts: []TypeInstanceHandlerOption{WithGatewayEndpoint(gatewayEndpoint)},
+			expectedEnv: []apiv1.EnvVar{
+				{Name: "APP_GATEWAY_ENDPOINT", Value: gatewayEndpoint},
+				{Name: "APP_GATEWAY_USERNAME", ValueFrom: secretKeySelector("runner-ctx", GatewayUsernameSecretKey)},
+				{Name: "APP_GATEWAY_PASSWORD", ValueFrom: secretKeySelector("runner-ctx", GatewayPasswordSecretKey)},
+			},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// given
+			handler := NewTypeInstanceHandler(hubActionsImage, localHubEndpoint, publicHubEndpoint, tc.opts...)
+			hubActionTpl := fixContainerTemplate(hubActionsImage)
+			runnerTpl := fixContainerTemplate("runner:dev")
+			wf := &Workflow{
+				Templates: []*Template{hubActionTpl, runnerTpl},
+			}
+
+			// when
+			handler.AddGatewayCredentials(wf, "runner-ctx")
+
+			// then
+			assert.Equal(t, tc.expectedEnv, hubActionTpl.Container.Env)
+			assert.Empty(t, runnerTpl.Container.Env)
+		})
+	}
+}
+
+func fixContainerTemplate(image string) *Template {
+	return &Template{
+		Template: &wfv1.Template{
+			Container: &apiv1.Container{
+				Image: image,
+			},
+		},
+	}
+}
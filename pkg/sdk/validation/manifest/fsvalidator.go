@@ -61,19 +61,27 @@ func (v *FSValidator) Do(ctx context.Context, path string) (ValidationResult, er
 
 	validators := append(v.commonValidators, v.kindValidators[metadata.Kind]...)
 
-	var validationErrs []error
+	var validationErrs, validationWarnings []error
 	for _, validator := range validators {
 		res, err := validator.Do(ctx, metadata, jsonBytes)
 		if err != nil {
 			validationErrs = append(validationErrs, errors.Wrapf(err, "%s: internal", validator.Name()))
 		}
 
-		var prefixedResErrs []error
-		for _, resErr := range res.Errors {
-			prefixedResErrs = append(prefixedResErrs, errors.Wrap(resErr, validator.Name()))
-		}
-		validationErrs = append(validationErrs, prefixedResErrs...)
+		validationErrs = append(validationErrs, prefixWithValidatorName(validator, res.Errors)...)
+		validationWarnings = append(validationWarnings, prefixWithValidatorName(validator, res.Warnings)...)
 	}
 
-	return newValidationResult(validationErrs...), nil
+	return ValidationResult{
+		Errors:   validationErrs,
+		Warnings: validationWarnings,
+	}, nil
+}
+
+func prefixWithValidatorName(validator JSONValidator, errs []error) []error {
+	var prefixedErrs []error
+	for _, err := range errs {
+		prefixedErrs = append(prefixedErrs, errors.Wrap(err, validator.Name()))
+	}
+	return prefixedErrs
 }
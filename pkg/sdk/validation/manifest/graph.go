@@ -0,0 +1,199 @@
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"capact.io/capact/internal/io"
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"capact.io/capact/pkg/sdk/dbpopulator"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// GraphValidator validates the consistency of all manifests from a given Hub manifests directory.
+// It checks the references between manifests without calling Hub, so it can be run offline.
+type GraphValidator struct{}
+
+// NewGraphValidator creates new GraphValidator.
+func NewGraphValidator() *GraphValidator {
+	return &GraphValidator{}
+}
+
+// graphCheckFn checks a given manifests graph and returns the detected issues, grouped by the manifest file path.
+type graphCheckFn func(graph *manifestGraph) map[string][]error
+
+// Do loads all manifests from the root directory and validates references between them.
+// It returns validation results for all files with detected issues. Results are keyed by the file path.
+func (v *GraphValidator) Do(rootDir string) (map[string]ValidationResult, error) {
+	graph, err := loadManifestGraph(rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []graphCheckFn{
+		checkDuplicatedRevisions,
+		checkReferencesExist,
+		checkImplementsMatchInterfaces,
+		checkRevisionGaps,
+		checkImportCycles,
+	}
+
+	out := map[string]ValidationResult{}
+	for _, check := range checks {
+		for path, errs := range check(graph) {
+			res := out[path]
+			res.Errors = append(res.Errors, errs...)
+			out[path] = res
+		}
+	}
+
+	return out, nil
+}
+
+// Name returns the validator name.
+func (v *GraphValidator) Name() string {
+	return "GraphValidator"
+}
+
+// graphNode represents a single manifest revision.
+type graphNode struct {
+	filePath  string
+	kind      types.ManifestKind
+	path      string
+	revision  string
+	jsonBytes []byte
+}
+
+func (n *graphNode) ref() string {
+	return fmt.Sprintf("%s:%s", n.path, n.revision)
+}
+
+type manifestGraph struct {
+	// nodes holds all manifests sorted by the file path.
+	nodes []*graphNode
+	// revisions holds manifests indexed by path and revision.
+	revisions map[string]map[string]*graphNode
+}
+
+func loadManifestGraph(rootDir string) (*manifestGraph, error) {
+	// dbpopulator computes prefixes by trimming the root directory from file paths,
+	// so the absolute path is used to get consistent results for paths such as `.`.
+	absRootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "while resolving manifests root directory")
+	}
+
+	files, err := io.ListYAMLs(absRootDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing manifests")
+	}
+
+	grouped, err := dbpopulator.Group(files, absRootDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "while grouping manifests")
+	}
+
+	graph := &manifestGraph{
+		revisions: map[string]map[string]*graphNode{},
+	}
+	for kind, manifests := range grouped {
+		for _, manifest := range manifests {
+			relPath, err := filepath.Rel(absRootDir, manifest.Path())
+			if err != nil {
+				return nil, errors.Wrapf(err, "while getting relative path for %s", manifest.Path())
+			}
+
+			node, err := loadGraphNode(manifest.Path(), types.ManifestKind(kind), manifest.Prefix())
+			if err != nil {
+				return nil, err
+			}
+			node.filePath = filepath.Join(rootDir, relPath)
+
+			graph.nodes = append(graph.nodes, node)
+		}
+	}
+
+	sort.SliceStable(graph.nodes, func(i, j int) bool {
+		return graph.nodes[i].filePath < graph.nodes[j].filePath
+	})
+
+	for _, node := range graph.nodes {
+		if graph.revisions[node.path] == nil {
+			graph.revisions[node.path] = map[string]*graphNode{}
+		}
+		// duplicates are reported by a dedicated check
+		if _, found := graph.revisions[node.path][node.revision]; !found {
+			graph.revisions[node.path][node.revision] = node
+		}
+	}
+
+	return graph, nil
+}
+
+func loadGraphNode(path string, kind types.ManifestKind, prefix string) (*graphNode, error) {
+	yamlBytes, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading file from path %s", path)
+	}
+
+	jsonBytes, err := yaml.YAMLToJSON(yamlBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while converting YAML manifest %s to JSON", path)
+	}
+
+	var manifest struct {
+		Revision string `json:"revision"`
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+	if err := yaml.Unmarshal(yamlBytes, &manifest); err != nil {
+		return nil, errors.Wrapf(err, "while unmarshalling manifest %s", path)
+	}
+
+	return &graphNode{
+		kind:      kind,
+		path:      strings.Join([]string{prefix, manifest.Metadata.Name}, "."),
+		revision:  manifest.Revision,
+		jsonBytes: jsonBytes,
+	}, nil
+}
+
+// find returns a manifest with a given path and revision.
+// If the revision is empty, the manifest with the highest revision is returned.
+func (g *manifestGraph) find(path, revision string) *graphNode {
+	revisions := g.revisions[path]
+	if revision != "" {
+		return revisions[revision]
+	}
+
+	var latest *graphNode
+	for _, node := range revisions {
+		if latest == nil || compareRevisions(node.revision, latest.revision) > 0 {
+			latest = node
+		}
+	}
+	return latest
+}
+
+// checkRef returns an error if the referenced manifest of a given kind doesn't exist.
+func (g *manifestGraph) checkRef(kind types.ManifestKind, path, revision string) error {
+	ref := path
+	if revision != "" {
+		ref = fmt.Sprintf("%s:%s", path, revision)
+	}
+
+	node := g.find(path, revision)
+	if node == nil {
+		return fmt.Errorf("%s %q doesn't exist in the manifests directory", kind, ref)
+	}
+	if node.kind != kind {
+		return fmt.Errorf("%q is %s, expected %s", ref, node.kind, kind)
+	}
+	return nil
+}
@@ -0,0 +1,410 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"capact.io/capact/pkg/sdk/renderer/argo"
+	"github.com/Masterminds/semver/v3"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/pkg/errors"
+)
+
+func checkDuplicatedRevisions(graph *manifestGraph) map[string][]error {
+	issues := map[string][]error{}
+	for _, node := range graph.nodes {
+		first := graph.revisions[node.path][node.revision]
+		if first == node {
+			continue
+		}
+		issues[node.filePath] = append(issues[node.filePath], fmt.Errorf("manifest %q is already defined in %q", node.ref(), first.filePath))
+	}
+	return issues
+}
+
+func checkReferencesExist(graph *manifestGraph) map[string][]error {
+	issues := map[string][]error{}
+	for _, node := range graph.nodes {
+		var errs []error
+		switch node.kind {
+		case types.TypeManifestKind:
+			errs = checkTypeReferences(graph, node)
+		case types.InterfaceManifestKind:
+			errs = checkInterfaceReferences(graph, node)
+		case types.ImplementationManifestKind:
+			errs = checkImplementationReferences(graph, node)
+		}
+		if len(errs) > 0 {
+			issues[node.filePath] = append(issues[node.filePath], errs...)
+		}
+	}
+	return issues
+}
+
+func checkTypeReferences(graph *manifestGraph, node *graphNode) []error {
+	var entity types.Type
+	if err := json.Unmarshal(node.jsonBytes, &entity); err != nil {
+		return []error{errors.Wrap(err, "while unmarshalling JSON into Type type")}
+	}
+
+	return checkAttributeReferences(graph, entity.Metadata.Attributes)
+}
+
+func checkInterfaceReferences(graph *manifestGraph, node *graphNode) []error {
+	var entity types.Interface
+	if err := json.Unmarshal(node.jsonBytes, &entity); err != nil {
+		return []error{errors.Wrap(err, "while unmarshalling JSON into Interface type")}
+	}
+
+	var typeRefs []types.TypeRef
+	if entity.Spec.Input.Parameters != nil {
+		for _, param := range entity.Spec.Input.Parameters.ParametersParameterMap {
+			if param.TypeRef != nil {
+				typeRefs = append(typeRefs, *param.TypeRef)
+			}
+		}
+	}
+	for _, ti := range entity.Spec.Input.TypeInstances {
+		typeRefs = append(typeRefs, ti.TypeRef)
+	}
+	for _, ti := range entity.Spec.Output.TypeInstances {
+		if ti.TypeRef != nil {
+			typeRefs = append(typeRefs, *ti.TypeRef)
+		}
+	}
+
+	return checkTypeRefs(graph, typeRefs)
+}
+
+func checkImplementationReferences(graph *manifestGraph, node *graphNode) []error {
+	var entity types.Implementation
+	if err := json.Unmarshal(node.jsonBytes, &entity); err != nil {
+		return []error{errors.Wrap(err, "while unmarshalling JSON into Implementation type")}
+	}
+
+	errs := checkAttributeReferences(graph, entity.Metadata.Attributes)
+
+	var typeRefs []types.TypeRef
+	if entity.Spec.AdditionalInput != nil {
+		for _, param := range entity.Spec.AdditionalInput.Parameters {
+			typeRefs = append(typeRefs, param.TypeRef)
+		}
+		for _, ti := range entity.Spec.AdditionalInput.TypeInstances {
+			typeRefs = append(typeRefs, ti.TypeRef)
+		}
+	}
+	if entity.Spec.AdditionalOutput != nil {
+		for _, ti := range entity.Spec.AdditionalOutput.TypeInstances {
+			if ti.TypeRef != nil {
+				typeRefs = append(typeRefs, *ti.TypeRef)
+			}
+		}
+	}
+	for prefix, req := range entity.Spec.Requires {
+		typeRefs = append(typeRefs, requiredTypeRefs(prefix, req)...)
+	}
+	errs = append(errs, checkTypeRefs(graph, typeRefs)...)
+
+	for _, implementsItem := range entity.Spec.Implements {
+		if err := graph.checkRef(types.InterfaceManifestKind, implementsItem.Path, implementsItem.Revision); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, imp := range entity.Spec.Imports {
+		for _, method := range imp.Methods {
+			if err := graph.checkRef(types.InterfaceManifestKind, importedInterfacePath(imp, method), method.Revision); err != nil {
+				errs = append(errs, errors.Wrap(err, "invalid import"))
+			}
+		}
+	}
+
+	return errs
+}
+
+func checkAttributeReferences(graph *manifestGraph, attributes map[string]types.MetadataAttribute) []error {
+	var paths []string
+	for path := range attributes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var errs []error
+	for _, path := range paths {
+		if err := graph.checkRef(types.AttributeManifestKind, path, attributes[path].Revision); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func checkTypeRefs(graph *manifestGraph, typeRefs []types.TypeRef) []error {
+	sort.Slice(typeRefs, func(i, j int) bool {
+		if typeRefs[i].Path == typeRefs[j].Path {
+			return typeRefs[i].Revision < typeRefs[j].Revision
+		}
+		return typeRefs[i].Path < typeRefs[j].Path
+	})
+
+	var errs []error
+	for _, ref := range typeRefs {
+		if err := graph.checkRef(types.TypeManifestKind, ref.Path, ref.Revision); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// requiredTypeRefs returns references to all Types from a given `requires` entry.
+// The Type name is relative to the prefix, unless it is a full path.
+func requiredTypeRefs(prefix string, req types.Require) []types.TypeRef {
+	var entities []types.RequireEntity
+	entities = append(entities, req.OneOf...)
+	entities = append(entities, req.AllOf...)
+	entities = append(entities, req.AnyOf...)
+
+	var out []types.TypeRef
+	for _, entity := range entities {
+		path := strings.Join([]string{prefix, entity.Name}, ".")
+		if strings.HasPrefix(entity.Name, types.OCFPathPrefix) {
+			path = entity.Name
+		}
+		out = append(out, types.TypeRef{Path: path, Revision: entity.Revision})
+	}
+	return out
+}
+
+func importedInterfacePath(imp types.Import, method types.Method) string {
+	return strings.Join([]string{imp.InterfaceGroupPath, method.Name}, ".")
+}
+
+func checkImplementsMatchInterfaces(graph *manifestGraph) map[string][]error {
+	issues := map[string][]error{}
+	for _, node := range graph.nodes {
+		if node.kind != types.ImplementationManifestKind {
+			continue
+		}
+
+		var impl types.Implementation
+		if err := json.Unmarshal(node.jsonBytes, &impl); err != nil {
+			// already reported by the references check
+			continue
+		}
+
+		errs, err := checkImplementationMatchesInterfaces(graph, impl)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if len(errs) > 0 {
+			issues[node.filePath] = append(issues[node.filePath], errs...)
+		}
+	}
+	return issues
+}
+
+func checkImplementationMatchesInterfaces(graph *manifestGraph, impl types.Implementation) ([]error, error) {
+	var (
+		errs                 []error
+		interfacesInputNames []string
+		implAdditionalInput  []string
+		workflowArtifacts    []wfv1.Artifact
+	)
+
+	for _, implementsItem := range impl.Spec.Implements {
+		ifaceNode := graph.find(implementsItem.Path, implementsItem.Revision)
+		if ifaceNode == nil || ifaceNode.kind != types.InterfaceManifestKind {
+			// already reported by the references check
+			continue
+		}
+
+		var iface types.Interface
+		if err := json.Unmarshal(ifaceNode.jsonBytes, &iface); err != nil {
+			return nil, errors.Wrapf(err, "while unmarshalling Interface %q", ifaceNode.ref())
+		}
+
+		if iface.Spec.Input.Parameters != nil {
+			for name := range iface.Spec.Input.Parameters.ParametersParameterMap {
+				interfacesInputNames = append(interfacesInputNames, name)
+			}
+		}
+		for name := range iface.Spec.Input.TypeInstances {
+			interfacesInputNames = append(interfacesInputNames, name)
+		}
+
+		var outputNames []string
+		for name := range iface.Spec.Output.TypeInstances {
+			outputNames = append(outputNames, name)
+		}
+		sort.Strings(outputNames)
+		for _, name := range outputNames {
+			if _, found := impl.Spec.OutputTypeInstanceRelations[name]; found {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("output TypeInstance %q of Interface %q is not defined in outputTypeInstanceRelations", name, ifaceNode.ref()))
+		}
+	}
+
+	if impl.Spec.AdditionalInput != nil {
+		for name := range impl.Spec.AdditionalInput.Parameters {
+			implAdditionalInput = append(implAdditionalInput, name)
+		}
+		for name := range impl.Spec.AdditionalInput.TypeInstances {
+			implAdditionalInput = append(implAdditionalInput, name)
+		}
+	}
+
+	workflow, err := decodeImplArgsToArgoWorkflow(impl.Spec.Action.Args)
+	if err != nil {
+		return nil, errors.Wrap(err, "while decoding Implementation arguments to Argo workflow")
+	}
+	if workflow != nil && workflow.WorkflowSpec != nil {
+		idx, err := argo.GetEntrypointWorkflowIndex(workflow)
+		if err != nil {
+			return nil, errors.Wrap(err, "while getting entrypoint index from workflow")
+		}
+		workflowArtifacts = workflow.Templates[idx].Inputs.Artifacts
+	}
+
+	errs = append(errs, checkWorkflowInputArtifacts(workflowArtifacts, interfacesInputNames, implAdditionalInput)...)
+	return errs, nil
+}
+
+func checkRevisionGaps(graph *manifestGraph) map[string][]error {
+	var paths []string
+	for path := range graph.revisions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	issues := map[string][]error{}
+	for _, path := range paths {
+		var versions []*semver.Version
+		for revision := range graph.revisions[path] {
+			version, err := semver.StrictNewVersion(revision)
+			if err != nil {
+				// invalid revisions are reported by the OCF schema validation
+				continue
+			}
+			versions = append(versions, version)
+		}
+		sort.Sort(semver.Collection(versions))
+
+		for i := 1; i < len(versions); i++ {
+			prev, current := versions[i-1], versions[i]
+			if isNextRevision(*prev, *current) {
+				continue
+			}
+
+			node := graph.revisions[path][current.Original()]
+			issues[node.filePath] = append(issues[node.filePath], fmt.Errorf("revision gap for %q: %q is followed by %q", path, prev.Original(), current.Original()))
+		}
+	}
+	return issues
+}
+
+// isNextRevision returns true if the next revision bumps exactly one of the major, minor or patch versions of the previous one.
+func isNextRevision(prev, next semver.Version) bool {
+	return next.Equal(ptrVersion(prev.IncPatch())) ||
+		next.Equal(ptrVersion(prev.IncMinor())) ||
+		next.Equal(ptrVersion(prev.IncMajor()))
+}
+
+func ptrVersion(in semver.Version) *semver.Version {
+	return &in
+}
+
+// compareRevisions compares revisions in the SemVer format. Invalid revisions are compared as strings.
+func compareRevisions(a, b string) int {
+	va, errA := semver.StrictNewVersion(a)
+	vb, errB := semver.StrictNewVersion(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return va.Compare(vb)
+}
+
+// checkImportCycles detects cycles between Interfaces. Interface A depends on Interface B,
+// if any Implementation of the Interface A imports the Interface B.
+func checkImportCycles(graph *manifestGraph) map[string][]error {
+	type edge struct {
+		to       string
+		filePath string
+	}
+	deps := map[string][]edge{}
+
+	for _, node := range graph.nodes {
+		if node.kind != types.ImplementationManifestKind {
+			continue
+		}
+
+		var impl types.Implementation
+		if err := json.Unmarshal(node.jsonBytes, &impl); err != nil {
+			continue
+		}
+		for _, implementsItem := range impl.Spec.Implements {
+			for _, imp := range impl.Spec.Imports {
+				for _, method := range imp.Methods {
+					deps[implementsItem.Path] = append(deps[implementsItem.Path], edge{
+						to:       importedInterfacePath(imp, method),
+						filePath: node.filePath,
+					})
+				}
+			}
+		}
+	}
+
+	var ifaces []string
+	for iface := range deps {
+		ifaces = append(ifaces, iface)
+	}
+	sort.Strings(ifaces)
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	var (
+		state  = map[string]int{}
+		stack  []string
+		issues = map[string][]error{}
+		visit  func(iface string)
+	)
+	visit = func(iface string) {
+		state[iface] = visiting
+		stack = append(stack, iface)
+
+		for _, dep := range deps[iface] {
+			switch state[dep.to] {
+			case visiting:
+				cycle := append(cycleFrom(stack, dep.to), dep.to)
+				issues[dep.filePath] = append(issues[dep.filePath], fmt.Errorf("import cycle detected: %s", strings.Join(cycle, " -> ")))
+			case visited:
+			default:
+				visit(dep.to)
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[iface] = visited
+	}
+
+	for _, iface := range ifaces {
+		if state[iface] == 0 {
+			visit(iface)
+		}
+	}
+
+	return issues
+}
+
+// cycleFrom returns the part of the stack, which starts with a given element.
+func cycleFrom(stack []string, start string) []string {
+	for i, elem := range stack {
+		if elem == start {
+			return append([]string{}, stack[i:]...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package manifest_test
+
+import (
+	"testing"
+
+	"capact.io/capact/pkg/sdk/validation/manifest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphValidator_Do(t *testing.T) {
+	tests := map[string]struct {
+		rootDir        string
+		expectedIssues map[string][]string
+	}{
+		"Valid manifests directory": {
+			rootDir:        "testdata/graph/valid",
+			expectedIssues: map[string][]string{},
+		},
+		"Invalid manifests directory": {
+			rootDir: "testdata/graph/invalid",
+			expectedIssues: map[string][]string{
+				"testdata/graph/invalid/type/app/config.yaml": {
+					`manifest "cap.type.app.config:0.1.0" is already defined in "testdata/graph/invalid/type/app/config-dup.yaml"`,
+				},
+				"testdata/graph/invalid/type/app/config-v3.yaml": {
+					`revision gap for "cap.type.app.config": "0.1.0" is followed by "0.3.0"`,
+				},
+				"testdata/graph/invalid/interface/app/install.yaml": {
+					`Type "cap.type.app.missing:0.1.0" doesn't exist in the manifests directory`,
+				},
+				"testdata/graph/invalid/implementation/app/install.yaml": {
+					`Attribute "cap.attribute.app.missing:0.1.0" doesn't exist in the manifests directory`,
+					`invalid import: Interface "cap.interface.app.upgrade:0.2.0" doesn't exist in the manifests directory`,
+					`output TypeInstance "config" of Interface "cap.interface.app.install:0.1.0" is not defined in outputTypeInstanceRelations`,
+					`unknown workflow input artifact "unknown": there is no such input neither in Interface input, nor Implementation additional input`,
+				},
+				"testdata/graph/invalid/implementation/app/upgrade.yaml": {
+					"import cycle detected: cap.interface.app.install -> cap.interface.app.upgrade -> cap.interface.app.install",
+				},
+			},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// given
+			validator := manifest.NewGraphValidator()
+
+			// when
+			results, err := validator.Do(tc.rootDir)
+
+			// then
+			require.NoError(t, err)
+
+			gotIssues := map[string][]string{}
+			for path, res := range results {
+				gotIssues[path] = errorMessages(res.Errors)
+			}
+			assert.Equal(t, tc.expectedIssues, gotIssues)
+		})
+	}
+}
@@ -121,7 +121,6 @@ func (v *RemoteImplementationValidator) checkManifestRevisionsExist(ctx context.
 }
 
 func (v *RemoteImplementationValidator) validateInputArtifactsNames(ctx context.Context, entity types.Implementation) (ValidationResult, error) {
-	var validationErrs []error
 	var interfacesInputNames []string
 	var implAdditionalInput []string
 	var workflowArtifacts []wfv1.Artifact
@@ -154,7 +153,7 @@ func (v *RemoteImplementationValidator) validateInputArtifactsNames(ctx context.
 	}
 
 	//3. get inputs from entrypoint workflow template
-	workflow, err := v.decodeImplArgsToArgoWorkflow(entity.Spec.Action.Args)
+	workflow, err := decodeImplArgsToArgoWorkflow(entity.Spec.Action.Args)
 	if err != nil {
 		return ValidationResult{}, errors.Wrap(err, "while decoding Implementation arguments to Argo workflow")
 	}
@@ -167,6 +166,11 @@ func (v *RemoteImplementationValidator) validateInputArtifactsNames(ctx context.
 	}
 
 	//4. verify if the inputs from Implementation and Interface match with Argo workflow artifacts
+	return ValidationResult{Errors: checkWorkflowInputArtifacts(workflowArtifacts, interfacesInputNames, implAdditionalInput)}, nil
+}
+
+func checkWorkflowInputArtifacts(workflowArtifacts []wfv1.Artifact, interfacesInputNames, implAdditionalInput []string) []error {
+	var validationErrs []error
 	for _, artifact := range workflowArtifacts {
 		existsInInterface := slices.Contains(interfacesInputNames, artifact.Name)
 		existsInAdditionalInput := slices.Contains(implAdditionalInput, artifact.Name)
@@ -182,8 +186,7 @@ func (v *RemoteImplementationValidator) validateInputArtifactsNames(ctx context.
 			validationErrs = append(validationErrs, fmt.Errorf("unknown workflow input artifact %q: there is no such input neither in Interface input, nor Implementation additional input", artifact.Name))
 		}
 	}
-
-	return ValidationResult{Errors: validationErrs}, nil
+	return validationErrs
 }
 
 func (v *RemoteImplementationValidator) fetchInterfaceInput(ctx context.Context, interfaceRef gqlpublicapi.InterfaceReference, hub Hub) (gqlpublicapi.InterfaceInput, error) {
@@ -202,7 +205,7 @@ func (v *RemoteImplementationValidator) fetchInterfaceInput(ctx context.Context,
 	return *iface.Spec.Input, nil
 }
 
-func (v *RemoteImplementationValidator) decodeImplArgsToArgoWorkflow(implArgs map[string]interface{}) (*argo.Workflow, error) {
+func decodeImplArgsToArgoWorkflow(implArgs map[string]interface{}) (*argo.Workflow, error) {
 	var decodedImplArgs = struct {
 		Workflow argo.Workflow `json:"workflow"`
 	}{}
@@ -0,0 +1,205 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultLintConfigFileName is the name of the file with lint rules configuration.
+const DefaultLintConfigFileName = ".capact-lint.yaml"
+
+// LintSeverity defines how a lint rule violation is reported.
+type LintSeverity string
+
+const (
+	// LintSeverityError marks the manifest as invalid.
+	LintSeverityError LintSeverity = "error"
+	// LintSeverityWarning reports the issue without marking the manifest as invalid.
+	LintSeverityWarning LintSeverity = "warning"
+	// LintSeverityOff disables a given rule.
+	LintSeverityOff LintSeverity = "off"
+)
+
+// IsValid returns true if LintSeverity is known.
+func (s LintSeverity) IsValid() bool {
+	switch s {
+	case LintSeverityError, LintSeverityWarning, LintSeverityOff:
+		return true
+	}
+	return false
+}
+
+// UnmarshalJSON unmarshals LintSeverity. It also accepts the unquoted YAML `off` value, which is decoded as a boolean.
+func (s *LintSeverity) UnmarshalJSON(data []byte) error {
+	if string(data) == "false" {
+		*s = LintSeverityOff
+		return nil
+	}
+
+	var severity string
+	if err := json.Unmarshal(data, &severity); err != nil {
+		return errors.Wrap(err, "while unmarshalling lint severity")
+	}
+	*s = LintSeverity(severity)
+	return nil
+}
+
+// LintIssue describes a single lint rule violation.
+type LintIssue struct {
+	RuleID   string
+	Severity LintSeverity
+	Message  string
+}
+
+// Error returns the issue message prefixed with the rule ID.
+func (i LintIssue) Error() string {
+	return fmt.Sprintf("%s: %s", i.RuleID, i.Message)
+}
+
+// LintRule checks if a manifest follows a given convention.
+type LintRule interface {
+	ID() string
+	Description() string
+	// Check returns the messages describing all rule violations found in a given manifest.
+	Check(metadata types.ManifestMetadata, jsonBytes []byte) ([]string, error)
+}
+
+// LintConfig holds the lint rules configuration.
+type LintConfig struct {
+	Rules map[string]LintRuleConfig `json:"rules"`
+}
+
+// LintRuleConfig holds configuration for a single lint rule.
+// Options which are not used by a given rule are ignored.
+type LintRuleConfig struct {
+	Severity LintSeverity `json:"severity,omitempty"`
+	// Pattern is a regular expression, which the manifest name must match.
+	Pattern string `json:"pattern,omitempty"`
+	// MaxDepth is the maximum nesting depth of the Implementation workflow templates.
+	MaxDepth int `json:"maxDepth,omitempty"`
+}
+
+// LoadLintConfig reads the lint rules configuration from a given YAML file.
+func LoadLintConfig(path string) (LintConfig, error) {
+	raw, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return LintConfig{}, errors.Wrap(err, "while reading lint configuration file")
+	}
+
+	var cfg LintConfig
+	if err := yaml.UnmarshalStrict(raw, &cfg); err != nil {
+		return LintConfig{}, errors.Wrapf(err, "while unmarshalling lint configuration from %q", path)
+	}
+
+	return cfg, nil
+}
+
+type lintRuleDefinition struct {
+	defaultSeverity LintSeverity
+	newFn           func(cfg LintRuleConfig) (LintRule, error)
+}
+
+type configuredLintRule struct {
+	LintRule
+	severity LintSeverity
+}
+
+// LintValidator is a validator, which checks manifests against the configured lint rules.
+type LintValidator struct {
+	rules []configuredLintRule
+}
+
+// NewLintValidator creates new LintValidator.
+// Rules which are not present in the configuration are enabled with their default severity.
+func NewLintValidator(cfg LintConfig) (*LintValidator, error) {
+	definitions := lintRuleDefinitions()
+
+	for id := range cfg.Rules {
+		if _, found := definitions[id]; !found {
+			return nil, fmt.Errorf("unknown lint rule %q", id)
+		}
+	}
+
+	var ids []string
+	for id := range definitions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	validator := &LintValidator{}
+	for _, id := range ids {
+		def := definitions[id]
+		ruleCfg := cfg.Rules[id]
+
+		severity := def.defaultSeverity
+		if ruleCfg.Severity != "" {
+			severity = ruleCfg.Severity
+		}
+		if !severity.IsValid() {
+			return nil, fmt.Errorf("invalid severity %q for lint rule %q", severity, id)
+		}
+		if severity == LintSeverityOff {
+			continue
+		}
+
+		rule, err := def.newFn(ruleCfg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while creating lint rule %q", id)
+		}
+
+		validator.rules = append(validator.rules, configuredLintRule{
+			LintRule: rule,
+			severity: severity,
+		})
+	}
+
+	return validator, nil
+}
+
+// Rules returns all enabled lint rules.
+func (v *LintValidator) Rules() []LintRule {
+	var out []LintRule
+	for _, rule := range v.rules {
+		out = append(out, rule.LintRule)
+	}
+	return out
+}
+
+// Do is a method which triggers the validation.
+func (v *LintValidator) Do(_ context.Context, metadata types.ManifestMetadata, jsonBytes []byte) (ValidationResult, error) {
+	var result ValidationResult
+	for _, rule := range v.rules {
+		msgs, err := rule.Check(metadata, jsonBytes)
+		if err != nil {
+			return ValidationResult{}, errors.Wrapf(err, "while checking lint rule %q", rule.ID())
+		}
+
+		for _, msg := range msgs {
+			issue := LintIssue{
+				RuleID:   rule.ID(),
+				Severity: rule.severity,
+				Message:  msg,
+			}
+			if rule.severity == LintSeverityError {
+				result.Errors = append(result.Errors, issue)
+				continue
+			}
+			result.Warnings = append(result.Warnings, issue)
+		}
+	}
+
+	return result, nil
+}
+
+// Name returns the validator name.
+func (v *LintValidator) Name() string {
+	return "LintValidator"
+}
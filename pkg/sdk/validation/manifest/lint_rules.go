@@ -0,0 +1,393 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"capact.io/capact/pkg/sdk/renderer/argo"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultNamingPattern = `^[a-z0-9]+(-[a-z0-9]+)*$`
+	defaultMaxDepth      = 5
+
+	helmRunnerInterfaceGroupPath = "cap.interface.runner.helm"
+)
+
+func lintRuleDefinitions() map[string]lintRuleDefinition {
+	return map[string]lintRuleDefinition{
+		"naming-convention": {
+			defaultSeverity: LintSeverityWarning,
+			newFn: func(cfg LintRuleConfig) (LintRule, error) {
+				pattern := defaultNamingPattern
+				if cfg.Pattern != "" {
+					pattern = cfg.Pattern
+				}
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					return nil, errors.Wrap(err, "while compiling name pattern")
+				}
+				return &namingConventionRule{pattern: re}, nil
+			},
+		},
+		"required-documentation-url": {
+			defaultSeverity: LintSeverityWarning,
+			newFn: func(LintRuleConfig) (LintRule, error) {
+				return &requiredDocumentationURLRule{}, nil
+			},
+		},
+		"required-maintainers": {
+			defaultSeverity: LintSeverityWarning,
+			newFn: func(LintRuleConfig) (LintRule, error) {
+				return &requiredMaintainersRule{}, nil
+			},
+		},
+		"no-latest-helm-chart-version": {
+			defaultSeverity: LintSeverityError,
+			newFn: func(LintRuleConfig) (LintRule, error) {
+				return &noLatestHelmChartVersionRule{}, nil
+			},
+		},
+		"max-nesting-depth": {
+			defaultSeverity: LintSeverityWarning,
+			newFn: func(cfg LintRuleConfig) (LintRule, error) {
+				maxDepth := defaultMaxDepth
+				if cfg.MaxDepth != 0 {
+					maxDepth = cfg.MaxDepth
+				}
+				if maxDepth < 1 {
+					return nil, errors.New("maxDepth cannot be less than 1")
+				}
+				return &maxNestingDepthRule{maxDepth: maxDepth}, nil
+			},
+		},
+		"unused-imports": {
+			defaultSeverity: LintSeverityWarning,
+			newFn: func(LintRuleConfig) (LintRule, error) {
+				return &unusedImportsRule{}, nil
+			},
+		},
+	}
+}
+
+// lintMetadata holds the metadata fields, which are common for all manifest kinds.
+type lintMetadata struct {
+	Metadata struct {
+		Name             string             `json:"name"`
+		DocumentationURL *string            `json:"documentationURL,omitempty"`
+		Maintainers      []types.Maintainer `json:"maintainers"`
+	} `json:"metadata"`
+}
+
+func unmarshalLintMetadata(jsonBytes []byte) (lintMetadata, error) {
+	var out lintMetadata
+	if err := json.Unmarshal(jsonBytes, &out); err != nil {
+		return lintMetadata{}, errors.Wrap(err, "while unmarshalling manifest metadata")
+	}
+	return out, nil
+}
+
+type namingConventionRule struct {
+	pattern *regexp.Regexp
+}
+
+func (r *namingConventionRule) ID() string { return "naming-convention" }
+
+func (r *namingConventionRule) Description() string {
+	return "Manifest name must match the configured naming convention."
+}
+
+func (r *namingConventionRule) Check(_ types.ManifestMetadata, jsonBytes []byte) ([]string, error) {
+	m, err := unmarshalLintMetadata(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.pattern.MatchString(m.Metadata.Name) {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf("name %q doesn't match pattern %q", m.Metadata.Name, r.pattern.String())}, nil
+}
+
+type requiredDocumentationURLRule struct{}
+
+func (r *requiredDocumentationURLRule) ID() string { return "required-documentation-url" }
+
+func (r *requiredDocumentationURLRule) Description() string {
+	return "Manifest must define metadata.documentationURL."
+}
+
+func (r *requiredDocumentationURLRule) Check(_ types.ManifestMetadata, jsonBytes []byte) ([]string, error) {
+	m, err := unmarshalLintMetadata(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Metadata.DocumentationURL != nil && strings.TrimSpace(*m.Metadata.DocumentationURL) != "" {
+		return nil, nil
+	}
+	return []string{"metadata.documentationURL is required"}, nil
+}
+
+type requiredMaintainersRule struct{}
+
+func (r *requiredMaintainersRule) ID() string { return "required-maintainers" }
+
+func (r *requiredMaintainersRule) Description() string {
+	return "Manifest must define at least one maintainer."
+}
+
+func (r *requiredMaintainersRule) Check(_ types.ManifestMetadata, jsonBytes []byte) ([]string, error) {
+	m, err := unmarshalLintMetadata(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.Metadata.Maintainers) > 0 {
+		return nil, nil
+	}
+	return []string{"metadata.maintainers must contain at least one maintainer"}, nil
+}
+
+type noLatestHelmChartVersionRule struct{}
+
+func (r *noLatestHelmChartVersionRule) ID() string { return "no-latest-helm-chart-version" }
+
+func (r *noLatestHelmChartVersionRule) Description() string {
+	return "Helm Implementations must pin the chart version."
+}
+
+// Check verifies the Helm runner input in the Implementation action arguments and in all raw workflow artifacts.
+// Artifacts, which are not a valid YAML, for example Jinja2 templates, are skipped.
+func (r *noLatestHelmChartVersionRule) Check(metadata types.ManifestMetadata, jsonBytes []byte) ([]string, error) {
+	if metadata.Kind != types.ImplementationManifestKind {
+		return nil, nil
+	}
+
+	impl, workflow, err := unmarshalImplementationWithWorkflow(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !importsInterfaceGroup(impl, helmRunnerInterfaceGroupPath) {
+		return nil, nil
+	}
+
+	var msgs []string
+	args, err := json.Marshal(impl.Spec.Action.Args)
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshaling Implementation arguments")
+	}
+	if msg := checkHelmChartVersion(args, "spec.action.args"); msg != "" {
+		msgs = append(msgs, msg)
+	}
+
+	for _, tpl := range workflow.Templates {
+		if tpl == nil || tpl.Template == nil {
+			continue
+		}
+		for _, artifact := range tpl.Inputs.Artifacts {
+			msgs = appendIfNotEmpty(msgs, checkHelmChartArtifact(artifact, fmt.Sprintf("template %q input artifact %q", tpl.Name, artifact.Name)))
+		}
+		for _, parallelSteps := range tpl.Steps {
+			for _, step := range parallelSteps {
+				if step == nil || step.WorkflowStep == nil {
+					continue
+				}
+				for _, artifact := range step.Arguments.Artifacts {
+					msgs = appendIfNotEmpty(msgs, checkHelmChartArtifact(artifact, fmt.Sprintf("step %q artifact %q", step.Name, artifact.Name)))
+				}
+			}
+		}
+	}
+
+	return msgs, nil
+}
+
+func checkHelmChartArtifact(artifact wfv1.Artifact, location string) string {
+	if artifact.Raw == nil {
+		return ""
+	}
+	return checkHelmChartVersion([]byte(artifact.Raw.Data), location)
+}
+
+func checkHelmChartVersion(raw []byte, location string) string {
+	var input struct {
+		Chart *struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"chart"`
+	}
+	if err := yaml.Unmarshal(raw, &input); err != nil || input.Chart == nil || input.Chart.Name == "" {
+		return ""
+	}
+
+	version := strings.TrimSpace(input.Chart.Version)
+	if version != "" && version != "latest" {
+		return ""
+	}
+	return fmt.Sprintf("%s: Helm chart %q must have a pinned version", location, input.Chart.Name)
+}
+
+type maxNestingDepthRule struct {
+	maxDepth int
+}
+
+func (r *maxNestingDepthRule) ID() string { return "max-nesting-depth" }
+
+func (r *maxNestingDepthRule) Description() string {
+	return "Implementation workflow templates must not be nested deeper than the configured maximum."
+}
+
+func (r *maxNestingDepthRule) Check(metadata types.ManifestMetadata, jsonBytes []byte) ([]string, error) {
+	if metadata.Kind != types.ImplementationManifestKind {
+		return nil, nil
+	}
+
+	_, workflow, err := unmarshalImplementationWithWorkflow(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+	if workflow.WorkflowSpec == nil || workflow.Entrypoint == "" {
+		return nil, nil
+	}
+
+	templates := map[string]*argo.Template{}
+	for _, tpl := range workflow.Templates {
+		if tpl == nil || tpl.Template == nil {
+			continue
+		}
+		templates[tpl.Name] = tpl
+	}
+
+	depth := templateDepth(templates, workflow.Entrypoint, map[string]struct{}{})
+	if depth <= r.maxDepth {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf("workflow templates are nested %d levels deep, maximum is %d", depth, r.maxDepth)}, nil
+}
+
+// templateDepth returns the depth of the template call tree. Cycles are ignored.
+func templateDepth(templates map[string]*argo.Template, name string, visiting map[string]struct{}) int {
+	tpl, found := templates[name]
+	if !found {
+		return 0
+	}
+	if _, found := visiting[name]; found {
+		return 0
+	}
+	visiting[name] = struct{}{}
+	defer delete(visiting, name)
+
+	maxChildDepth := 0
+	for _, parallelSteps := range tpl.Steps {
+		for _, step := range parallelSteps {
+			if step == nil || step.WorkflowStep == nil || step.Template == "" {
+				continue
+			}
+			if d := templateDepth(templates, step.Template, visiting); d > maxChildDepth {
+				maxChildDepth = d
+			}
+		}
+	}
+
+	return maxChildDepth + 1
+}
+
+type unusedImportsRule struct{}
+
+func (r *unusedImportsRule) ID() string { return "unused-imports" }
+
+func (r *unusedImportsRule) Description() string {
+	return "Implementation must use all imported Interface methods."
+}
+
+func (r *unusedImportsRule) Check(metadata types.ManifestMetadata, jsonBytes []byte) ([]string, error) {
+	if metadata.Kind != types.ImplementationManifestKind {
+		return nil, nil
+	}
+
+	impl, workflow, err := unmarshalImplementationWithWorkflow(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]struct{}{
+		impl.Spec.Action.RunnerInterface: {},
+	}
+	for _, tpl := range workflow.Templates {
+		if tpl == nil {
+			continue
+		}
+		for _, parallelSteps := range tpl.Steps {
+			for _, step := range parallelSteps {
+				if step == nil {
+					continue
+				}
+				if step.CapactAction != nil {
+					used[*step.CapactAction] = struct{}{}
+				}
+				if step.CapactPolicy == nil {
+					continue
+				}
+				for _, rule := range step.CapactPolicy.Interface.Rules {
+					if rule.Interface.Alias != nil {
+						used[*rule.Interface.Alias] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	var msgs []string
+	for _, imp := range impl.Spec.Imports {
+		// imports without alias cannot be referenced in the workflow
+		if imp.Alias == nil {
+			continue
+		}
+		for _, method := range imp.Methods {
+			ref := fmt.Sprintf("%s.%s", *imp.Alias, method.Name)
+			if _, found := used[ref]; found {
+				continue
+			}
+			msgs = append(msgs, fmt.Sprintf("imported method %q (%s.%s) is not used", ref, imp.InterfaceGroupPath, method.Name))
+		}
+	}
+
+	return msgs, nil
+}
+
+func unmarshalImplementationWithWorkflow(jsonBytes []byte) (types.Implementation, *argo.Workflow, error) {
+	var impl types.Implementation
+	if err := json.Unmarshal(jsonBytes, &impl); err != nil {
+		return types.Implementation{}, nil, errors.Wrap(err, "while unmarshalling JSON into Implementation type")
+	}
+
+	workflow, err := decodeImplArgsToArgoWorkflow(impl.Spec.Action.Args)
+	if err != nil {
+		return types.Implementation{}, nil, errors.Wrap(err, "while decoding Implementation arguments to Argo workflow")
+	}
+
+	return impl, workflow, nil
+}
+
+func importsInterfaceGroup(impl types.Implementation, path string) bool {
+	for _, imp := range impl.Spec.Imports {
+		if imp.InterfaceGroupPath == path {
+			return true
+		}
+	}
+	return false
+}
+
+func appendIfNotEmpty(in []string, msg string) []string {
+	if msg == "" {
+		return in
+	}
+	return append(in, msg)
+}
@@ -0,0 +1,205 @@
+package manifest_test
+
+import (
+	"context"
+	"testing"
+
+	"capact.io/capact/pkg/sdk/apis/0.0.1/types"
+	"capact.io/capact/pkg/sdk/validation/manifest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const lintHelmImplementation = `
+kind: Implementation
+metadata:
+  name: install
+  documentationURL: https://example.com/docs
+  maintainers:
+    - email: dev@example.com
+spec:
+  imports:
+    - interfaceGroupPath: cap.interface.runner.argo
+      alias: argo
+      methods:
+        - name: run
+          revision: 0.1.0
+    - interfaceGroupPath: cap.interface.runner.helm
+      alias: helm
+      methods:
+        - name: install
+          revision: 0.1.0
+        - name: upgrade
+          revision: 0.1.0
+  action:
+    runnerInterface: argo.run
+    args:
+      workflow:
+        entrypoint: main
+        templates:
+          - name: main
+            steps:
+              - - name: install
+                  template: install
+          - name: install
+            steps:
+              - - name: helm-install
+                  capact-action: helm.install
+                  arguments:
+                    artifacts:
+                      - name: input-parameters
+                        raw:
+                          data: |
+                            chart:
+                              name: postgresql
+                              version: latest
+                      - name: templated
+                        raw:
+                          data: "{{ not: [valid yaml"
+`
+
+const lintInterfaceGroup = `
+kind: InterfaceGroup
+metadata:
+  name: PostgreSQL
+  maintainers: []
+`
+
+func TestLintValidator_Do(t *testing.T) {
+	tests := map[string]struct {
+		manifest         string
+		kind             types.ManifestKind
+		cfg              manifest.LintConfig
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		"Default rules for Helm Implementation": {
+			manifest: lintHelmImplementation,
+			kind:     types.ImplementationManifestKind,
+			expectedErrors: []string{
+				`no-latest-helm-chart-version: step "helm-install" artifact "input-parameters": Helm chart "postgresql" must have a pinned version`,
+			},
+			expectedWarnings: []string{
+				`unused-imports: imported method "helm.upgrade" (cap.interface.runner.helm.upgrade) is not used`,
+			},
+		},
+		"Configured severities and options for Helm Implementation": {
+			manifest: lintHelmImplementation,
+			kind:     types.ImplementationManifestKind,
+			cfg: manifest.LintConfig{
+				Rules: map[string]manifest.LintRuleConfig{
+					"no-latest-helm-chart-version": {Severity: manifest.LintSeverityOff},
+					"unused-imports":               {Severity: manifest.LintSeverityError},
+					"max-nesting-depth":            {MaxDepth: 1},
+				},
+			},
+			expectedErrors: []string{
+				`unused-imports: imported method "helm.upgrade" (cap.interface.runner.helm.upgrade) is not used`,
+			},
+			expectedWarnings: []string{
+				"max-nesting-depth: workflow templates are nested 2 levels deep, maximum is 1",
+			},
+		},
+		"Metadata rules for InterfaceGroup": {
+			manifest: lintInterfaceGroup,
+			kind:     types.InterfaceGroupManifestKind,
+			expectedWarnings: []string{
+				`naming-convention: name "PostgreSQL" doesn't match pattern "^[a-z0-9]+(-[a-z0-9]+)*$"`,
+				"required-documentation-url: metadata.documentationURL is required",
+				"required-maintainers: metadata.maintainers must contain at least one maintainer",
+			},
+		},
+		"Custom naming pattern": {
+			manifest: lintInterfaceGroup,
+			kind:     types.InterfaceGroupManifestKind,
+			cfg: manifest.LintConfig{
+				Rules: map[string]manifest.LintRuleConfig{
+					"naming-convention":          {Pattern: "^[A-Za-z]+$"},
+					"required-documentation-url": {Severity: manifest.LintSeverityOff},
+					"required-maintainers":       {Severity: manifest.LintSeverityOff},
+				},
+			},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// given
+			linter, err := manifest.NewLintValidator(tc.cfg)
+			require.NoError(t, err)
+
+			jsonBytes, err := yaml.YAMLToJSON([]byte(tc.manifest))
+			require.NoError(t, err)
+
+			// when
+			res, err := linter.Do(context.Background(), types.ManifestMetadata{Kind: tc.kind}, jsonBytes)
+
+			// then
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.expectedErrors, errorMessages(res.Errors))
+			assert.ElementsMatch(t, tc.expectedWarnings, errorMessages(res.Warnings))
+		})
+	}
+}
+
+func TestNewLintValidator_InvalidConfig(t *testing.T) {
+	tests := map[string]struct {
+		cfg            manifest.LintConfig
+		expectedErrMsg string
+	}{
+		"Unknown rule": {
+			cfg: manifest.LintConfig{
+				Rules: map[string]manifest.LintRuleConfig{"no-tabs": {}},
+			},
+			expectedErrMsg: `unknown lint rule "no-tabs"`,
+		},
+		"Invalid severity": {
+			cfg: manifest.LintConfig{
+				Rules: map[string]manifest.LintRuleConfig{"unused-imports": {Severity: "critical"}},
+			},
+			expectedErrMsg: `invalid severity "critical" for lint rule "unused-imports"`,
+		},
+		"Invalid naming pattern": {
+			cfg: manifest.LintConfig{
+				Rules: map[string]manifest.LintRuleConfig{"naming-convention": {Pattern: "[a-z"}},
+			},
+			expectedErrMsg: "while creating lint rule \"naming-convention\": while compiling name pattern: error parsing regexp: missing closing ]: `[a-z`",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// when
+			_, err := manifest.NewLintValidator(tc.cfg)
+
+			// then
+			assert.EqualError(t, err, tc.expectedErrMsg)
+		})
+	}
+}
+
+func TestLoadLintConfig(t *testing.T) {
+	// when
+	cfg, err := manifest.LoadLintConfig("testdata/lint-config.yaml")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, manifest.LintConfig{
+		Rules: map[string]manifest.LintRuleConfig{
+			"naming-convention":            {Severity: manifest.LintSeverityError},
+			"required-documentation-url":   {Severity: manifest.LintSeverityOff},
+			"no-latest-helm-chart-version": {Severity: manifest.LintSeverityError},
+			"max-nesting-depth":            {Severity: manifest.LintSeverityWarning, MaxDepth: 3},
+		},
+	}, cfg)
+}
+
+func errorMessages(errs []error) []string {
+	var out []string
+	for _, err := range errs {
+		out = append(out, err.Error())
+	}
+	return out
+}
@@ -13,3 +13,10 @@ func WithRemoteChecks(hubCli Hub) ValidatorOption {
 		r.kindValidators[types.ImplementationManifestKind] = append(r.kindValidators[types.ImplementationManifestKind], NewRemoteImplementationValidator(hubCli))
 	}
 }
+
+// WithLinter enables the lint rules checks for all manifests.
+func WithLinter(linter *LintValidator) ValidatorOption {
+	return func(r *FSValidator) {
+		r.commonValidators = append(r.commonValidators, linter)
+	}
+}
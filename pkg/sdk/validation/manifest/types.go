@@ -16,6 +16,8 @@ type FileSystemValidator interface {
 // ValidationResult hold the result of the manifest validation.
 type ValidationResult struct {
 	Errors []error
+	// Warnings holds the issues, which don't make the manifest invalid.
+	Warnings []error
 }
 
 // Valid returns true, if the manifest contains no errors.